@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changesPath   string
+	changesAccept bool
+	changesReject bool
+	changesOutput string
+)
+
+// changesCmd accepts or rejects tracked changes in Word documents
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Accept or reject tracked changes in Word documents",
+	Long: `Accept or reject all tracked changes (revisions) of a Word document.
+
+Accepting keeps inserted text, drops deleted text and keeps the current
+formatting. Rejecting drops inserted text, restores deleted text and restores
+the previous formatting. Resolve pending changes before running
+'dox replace' so that rules only see the text you expect.
+
+Without --accept or --reject, the number of tracked changes is reported.
+
+Examples:
+  # Count tracked changes
+  dox changes --path draft.docx
+
+  # Accept all changes in place
+  dox changes --path draft.docx --accept
+
+  # Save a copy with all changes rejected
+  dox changes -p draft.docx --reject -o original.docx`,
+	RunE: runChanges,
+}
+
+func init() {
+	rootCmd.AddCommand(changesCmd)
+
+	changesCmd.Flags().StringVarP(&changesPath, "path", "p", "", "Word document (.docx) with tracked changes (required)")
+	changesCmd.Flags().BoolVar(&changesAccept, "accept", false, "Accept all tracked changes")
+	changesCmd.Flags().BoolVar(&changesReject, "reject", false, "Reject all tracked changes")
+	changesCmd.Flags().StringVarP(&changesOutput, "output", "o", "", "Save the result to this file instead of updating it in place")
+
+	changesCmd.MarkFlagRequired("path")
+}
+
+func runChanges(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(changesPath); os.IsNotExist(err) {
+		return pkgErrors.LocalizedFileNotFoundError(changesPath)
+	}
+	if !document.IsWordFile(changesPath) {
+		return pkgErrors.NewValidationError("path", changesPath, "tracked changes are only supported in Word documents (.docx, .dotx)")
+	}
+	if changesAccept && changesReject {
+		return pkgErrors.NewValidationError("accept", "true", "--accept and --reject cannot be used together")
+	}
+	if changesOutput != "" && !changesAccept && !changesReject {
+		return pkgErrors.NewValidationError("output", changesOutput, "--output requires --accept or --reject")
+	}
+	if changesOutput != "" && !document.IsWordFile(changesOutput) {
+		return pkgErrors.NewValidationError("output", changesOutput, "output must be a Word document (.docx, .dotx)")
+	}
+
+	doc, err := document.OpenWordDocument(changesPath)
+	if err != nil {
+		return pkgErrors.NewDocumentError(changesPath, "docx", "failed to open document", err)
+	}
+	defer doc.Close()
+
+	count := doc.TrackedChanges()
+	if !changesAccept && !changesReject {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: %d tracked change(s)\n", changesPath, count)
+		return nil
+	}
+
+	if changesOutput != "" && !force {
+		if _, err := os.Stat(changesOutput); err == nil {
+			return pkgErrors.NewFileError(changesOutput, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+		}
+	}
+
+	action := "Accepted"
+	if changesAccept {
+		err = doc.AcceptAllChanges()
+	} else {
+		action = "Rejected"
+		err = doc.RejectAllChanges()
+	}
+	if err != nil {
+		return pkgErrors.NewDocumentError(changesPath, "docx", "failed to resolve tracked changes", err)
+	}
+
+	target := changesPath
+	if changesOutput != "" {
+		err = doc.SaveAs(changesOutput)
+		target = changesOutput
+	} else {
+		err = doc.Save()
+	}
+	if err != nil {
+		return pkgErrors.NewFileError(target, "saving", err)
+	}
+	ui.PrintSuccess("%s %d tracked change(s) in %s", action, count, target)
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestChangesCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "draft.docx")
+	writeZip(t, path, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t xml:space="preserve">Version </w:t></w:r><w:del w:id="1"><w:r><w:delText>1</w:delText></w:r></w:del><w:ins w:id="2"><w:r><w:t>2</w:t></w:r></w:ins></w:p>` +
+			`</w:body></w:document>`,
+	})
+
+	reset := func() {
+		changesPath, changesAccept, changesReject, changesOutput = "", false, false, ""
+	}
+	defer reset()
+
+	t.Run("count", func(t *testing.T) {
+		reset()
+		changesPath = path
+		buf := new(bytes.Buffer)
+		changesCmd.SetOut(buf)
+
+		if err := runChanges(changesCmd, nil); err != nil {
+			t.Fatalf("runChanges failed: %v", err)
+		}
+		if got, want := buf.String(), path+": 2 tracked change(s)\n"; got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	for _, tt := range []struct {
+		name   string
+		accept bool
+		want   string
+	}{
+		{"accept", true, "Version 2"},
+		{"reject", false, "Version 1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			output := filepath.Join(dir, tt.name+".docx")
+			changesPath = path
+			changesAccept = tt.accept
+			changesReject = !tt.accept
+			changesOutput = output
+
+			if err := runChanges(changesCmd, nil); err != nil {
+				t.Fatalf("runChanges failed: %v", err)
+			}
+
+			doc, err := document.OpenWordDocument(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer doc.Close()
+			if text, _ := doc.GetText(); text != tt.want {
+				t.Errorf("text = %q, want %q", text, tt.want)
+			}
+			if doc.TrackedChanges() != 0 {
+				t.Error("tracked changes remain")
+			}
+		})
+	}
+
+	t.Run("output requires an action", func(t *testing.T) {
+		reset()
+		changesPath = path
+		changesOutput = filepath.Join(dir, "other.docx")
+		err := runChanges(changesCmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "--output requires") {
+			t.Errorf("runChanges() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/replace"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkPath      string
+	checkForbidden string
+	checkRecursive bool
+	checkExclude   string
+	checkHidden    bool
+	checkJSON      bool
+)
+
+// errCheckFailed is returned when documents contain forbidden terms or could
+// not be checked, so the command exits non-zero
+var errCheckFailed = errors.New("document check failed")
+
+// checkCmd fails when documents contain forbidden terms
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Fail if documents contain forbidden terms",
+	Long: `Scan Word, PowerPoint and RTF documents for forbidden terms, such as old
+product names or internal codenames, and exit with an error listing every
+file and term found. Documents are never modified, which makes this suitable
+for pre-commit hooks and CI pipelines.
+
+The forbidden terms file is a YAML list. Each entry is plain text or a map:
+  - "Acme Classic"
+  - term: "Project (Falcon|Osprey)"
+    regex: true
+    description: "Internal codename; use the product name"
+  - term: "confidential draft"
+    case_insensitive: true
+
+Matching uses the same extracted text as 'dox find' and 'dox replace --dry-run'.
+Files that cannot be read also fail the check.
+
+Examples:
+  # Check every document under a directory
+  dox check --path ./docs --forbidden forbidden.yml
+
+  # Machine-readable results for CI
+  dox check --path ./docs --forbidden forbidden.yml --json`,
+	SilenceUsage: true,
+	RunE:         runCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVarP(&checkPath, "path", "p", "", "Document or directory to check (required)")
+	checkCmd.Flags().StringVarP(&checkForbidden, "forbidden", "f", "", "YAML file listing forbidden terms (required)")
+	checkCmd.Flags().BoolVar(&checkRecursive, "recursive", true, "Check subdirectories recursively")
+	checkCmd.Flags().StringVar(&checkExclude, "exclude", "", "Glob pattern for files to exclude")
+	checkCmd.Flags().BoolVar(&checkHidden, "include-hidden-text", false, "Also check Word text marked as hidden (skipped by default)")
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "Output results in JSON format")
+
+	checkCmd.MarkFlagRequired("path")
+	checkCmd.MarkFlagRequired("forbidden")
+}
+
+// checkResult is the forbidden terms found in one document
+type checkResult struct {
+	Path       string              `json:"path"`
+	Violations []replace.Violation `json:"violations,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// matchCount returns the number of forbidden term matches in the document
+func (r checkResult) matchCount() int {
+	count := 0
+	for _, violation := range r.Violations {
+		count += len(violation.Matches)
+	}
+	return count
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	terms, err := replace.LoadForbiddenTerms(checkForbidden)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return pkgErrors.NewFileError(checkForbidden, "loading forbidden terms", pkgErrors.ErrFileNotFound)
+		}
+		return pkgErrors.NewFileError(checkForbidden, "loading forbidden terms", err)
+	}
+	if len(terms) == 0 {
+		return pkgErrors.NewValidationError("forbidden", checkForbidden, "no forbidden terms found in the file")
+	}
+
+	info, err := os.Stat(checkPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return pkgErrors.NewFileError(checkPath, "accessing", pkgErrors.ErrFileNotFound)
+		}
+		return pkgErrors.NewFileError(checkPath, "accessing", err)
+	}
+
+	var results []checkResult
+	check := func(path string) error {
+		result := checkResult{Path: path}
+		if text, err := readDocumentText(path, checkHidden); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Violations = replace.CheckForbidden(text, terms)
+		}
+		results = append(results, result)
+		return nil
+	}
+
+	if info.IsDir() {
+		if err := replace.WalkDocumentFilesWithExclude(checkPath, checkRecursive, checkExclude, check); err != nil {
+			return pkgErrors.NewFileError(checkPath, "checking", err)
+		}
+	} else {
+		if !document.IsSupportedFormat(checkPath) {
+			ext := strings.ToLower(filepath.Ext(checkPath))
+			return pkgErrors.NewDocumentError(checkPath, ext,
+				fmt.Sprintf("unsupported format (supported: %s)", strings.Join(document.SupportedExtensions(), ", ")), pkgErrors.ErrUnsupportedFormat)
+		}
+		check(checkPath)
+	}
+
+	return writeCheckResults(cmd.OutOrStdout(), results, checkJSON)
+}
+
+// writeCheckResults prints each file with forbidden terms and their context,
+// or all results as JSON. It returns errCheckFailed when any file has
+// violations or could not be read.
+func writeCheckResults(out io.Writer, results []checkResult, asJSON bool) error {
+	totalMatches, failedFiles, unreadable := 0, 0, 0
+	for _, result := range results {
+		totalMatches += result.matchCount()
+		if len(result.Violations) > 0 {
+			failedFiles++
+		}
+		if result.Error != "" {
+			unreadable++
+		}
+	}
+	passed := failedFiles == 0 && unreadable == 0
+
+	if asJSON {
+		if results == nil {
+			results = []checkResult{}
+		}
+		jsonBytes, err := json.MarshalIndent(map[string]interface{}{
+			"forbidden": checkForbidden,
+			"passed":    passed,
+			"files":     results,
+			"summary": map[string]int{
+				"totalFiles":          len(results),
+				"filesWithViolations": failedFiles,
+				"unreadableFiles":     unreadable,
+				"totalMatches":        totalMatches,
+			},
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+	} else {
+		for _, result := range results {
+			if result.Error != "" {
+				ui.PrintWarning("%s: cannot be checked: %s", result.Path, result.Error)
+				continue
+			}
+			if len(result.Violations) == 0 {
+				continue
+			}
+
+			fmt.Fprintln(out, result.Path)
+			for _, violation := range result.Violations {
+				fmt.Fprintf(out, "  %q: %d match(es)", violation.Term, len(violation.Matches))
+				if violation.Description != "" {
+					fmt.Fprintf(out, " - %s", violation.Description)
+				}
+				fmt.Fprintln(out)
+
+				shown := violation.Matches
+				if !ui.IsVerbose() && len(shown) > maxShownMatches {
+					shown = shown[:maxShownMatches]
+				}
+				for _, match := range shown {
+					fmt.Fprintf(out, "    line %d: %s\n", match.Line, match.Context)
+				}
+				if len(shown) < len(violation.Matches) {
+					fmt.Fprintf(out, "    ... and %d more (use --verbose to list all)\n", len(violation.Matches)-len(shown))
+				}
+			}
+		}
+
+		if passed {
+			fmt.Fprintf(out, "No forbidden terms found in %d file(s)\n", len(results))
+		} else {
+			fmt.Fprintf(out, "Found %d forbidden term match(es) in %d of %d file(s)", totalMatches, failedFiles, len(results))
+			if unreadable > 0 {
+				fmt.Fprintf(out, "; %d file(s) could not be checked", unreadable)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if !passed {
+		return errCheckFailed
+	}
+	return nil
+}
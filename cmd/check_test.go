@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckCommand(t *testing.T) {
+	dir := t.TempDir()
+	paragraphs := func(texts ...string) string {
+		xml := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`
+		for _, text := range texts {
+			xml += `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+		}
+		return xml + `</w:body></w:document>`
+	}
+	docs := filepath.Join(dir, "docs")
+	os.Mkdir(docs, 0755)
+	writeZip(t, filepath.Join(docs, "a.docx"), map[string]string{
+		"word/document.xml": paragraphs("Welcome to Acme Classic", "Codename Project Falcon"),
+	})
+	writeZip(t, filepath.Join(docs, "b.docx"), map[string]string{
+		"word/document.xml": paragraphs("Nothing to see"),
+	})
+	forbidden := filepath.Join(dir, "forbidden.yml")
+	os.WriteFile(forbidden, []byte("- Acme Classic\n- term: \"Project (Falcon|Osprey)\"\n  regex: true\n  description: Internal codename\n"), 0644)
+
+	reset := func() {
+		checkPath, checkForbidden, checkExclude = "", "", ""
+		checkRecursive, checkHidden, checkJSON = true, false, false
+	}
+	defer reset()
+
+	t.Run("violations fail the check", func(t *testing.T) {
+		reset()
+		checkPath, checkForbidden = docs, forbidden
+		buf := new(bytes.Buffer)
+		checkCmd.SetOut(buf)
+
+		if err := runCheck(checkCmd, nil); err != errCheckFailed {
+			t.Fatalf("runCheck() error = %v, want errCheckFailed", err)
+		}
+		want := filepath.Join(docs, "a.docx") + "\n" +
+			"  \"Acme Classic\": 1 match(es)\n" +
+			"    line 1: Welcome to Acme Classic\n" +
+			"  \"Project (Falcon|Osprey)\": 1 match(es) - Internal codename\n" +
+			"    line 2: Codename Project Falcon\n" +
+			"Found 2 forbidden term match(es) in 1 of 2 file(s)\n"
+		if got := buf.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("clean document passes", func(t *testing.T) {
+		reset()
+		checkPath, checkForbidden = filepath.Join(docs, "b.docx"), forbidden
+		buf := new(bytes.Buffer)
+		checkCmd.SetOut(buf)
+
+		if err := runCheck(checkCmd, nil); err != nil {
+			t.Fatalf("runCheck() error = %v", err)
+		}
+		if got := buf.String(); got != "No forbidden terms found in 1 file(s)\n" {
+			t.Errorf("output = %q", got)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		reset()
+		checkPath, checkForbidden, checkJSON = docs, forbidden, true
+		buf := new(bytes.Buffer)
+		checkCmd.SetOut(buf)
+
+		if err := runCheck(checkCmd, nil); err != errCheckFailed {
+			t.Fatalf("runCheck() error = %v, want errCheckFailed", err)
+		}
+		var result struct {
+			Passed  bool          `json:"passed"`
+			Files   []checkResult `json:"files"`
+			Summary struct {
+				FilesWithViolations int `json:"filesWithViolations"`
+				TotalMatches        int `json:"totalMatches"`
+			} `json:"summary"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if result.Passed || len(result.Files) != 2 || result.Summary.FilesWithViolations != 1 || result.Summary.TotalMatches != 2 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if result.Files[0].Violations[1].Description != "Internal codename" {
+			t.Errorf("description missing: %+v", result.Files[0].Violations)
+		}
+	})
+
+	t.Run("documents are not modified", func(t *testing.T) {
+		before, _ := os.ReadFile(filepath.Join(docs, "a.docx"))
+		reset()
+		checkPath, checkForbidden = docs, forbidden
+		checkCmd.SetOut(new(bytes.Buffer))
+		runCheck(checkCmd, nil)
+		after, _ := os.ReadFile(filepath.Join(docs, "a.docx"))
+		if !bytes.Equal(before, after) {
+			t.Error("check modified the document")
+		}
+	})
+
+	t.Run("invalid forbidden file", func(t *testing.T) {
+		reset()
+		bad := filepath.Join(dir, "bad.yml")
+		os.WriteFile(bad, []byte("- term: \"(\"\n  regex: true\n"), 0644)
+		checkPath, checkForbidden = docs, bad
+		if err := runCheck(checkCmd, nil); err == nil || !strings.Contains(err.Error(), "regular expression") {
+			t.Errorf("expected invalid regex error, got %v", err)
+		}
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		reset()
+		checkPath, checkForbidden = filepath.Join(dir, "missing"), forbidden
+		if err := runCheck(checkCmd, nil); err == nil {
+			t.Error("expected an error for a missing path")
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentsPath   string
+	commentsList   bool
+	commentsRemove bool
+	commentsOutput string
+	commentsJSON   bool
+)
+
+// commentsCmd lists and removes reviewer comments in Word documents
+var commentsCmd = &cobra.Command{
+	Use:   "comments",
+	Short: "List and remove reviewer comments in Word documents",
+	Long: `List or remove the reviewer comments of a Word document.
+
+Removing comments deletes the comments part together with the comment
+markers in the body, producing a clean deliverable. The commented text and
+the paragraph structure are kept.
+
+Examples:
+  # List comments with their author and date
+  dox comments --path review.docx --list
+
+  # Remove all comments in place
+  dox comments --path review.docx --remove
+
+  # Save a copy without comments
+  dox comments -p review.docx --remove -o final.docx`,
+	RunE: runComments,
+}
+
+func init() {
+	rootCmd.AddCommand(commentsCmd)
+
+	commentsCmd.Flags().StringVarP(&commentsPath, "path", "p", "", "Word document (.docx) to read comments from (required)")
+	commentsCmd.Flags().BoolVar(&commentsList, "list", false, "List comments (default when --remove is not given)")
+	commentsCmd.Flags().BoolVar(&commentsRemove, "remove", false, "Remove all comments and comment markers")
+	commentsCmd.Flags().StringVarP(&commentsOutput, "output", "o", "", "Save the cleaned document to this file instead of updating it in place")
+	commentsCmd.Flags().BoolVar(&commentsJSON, "json", false, "Output the comment list in JSON format")
+
+	commentsCmd.MarkFlagRequired("path")
+}
+
+func runComments(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(commentsPath); os.IsNotExist(err) {
+		return pkgErrors.LocalizedFileNotFoundError(commentsPath)
+	}
+	if !document.IsWordFile(commentsPath) {
+		return pkgErrors.NewValidationError("path", commentsPath, "comments are only supported in Word documents (.docx, .dotx)")
+	}
+	if commentsOutput != "" && !commentsRemove {
+		return pkgErrors.NewValidationError("output", commentsOutput, "--output requires --remove")
+	}
+	if commentsOutput != "" && !document.IsWordFile(commentsOutput) {
+		return pkgErrors.NewValidationError("output", commentsOutput, "output must be a Word document (.docx, .dotx)")
+	}
+
+	doc, err := document.OpenWordDocument(commentsPath)
+	if err != nil {
+		return pkgErrors.NewDocumentError(commentsPath, "docx", "failed to open document", err)
+	}
+	defer doc.Close()
+
+	comments, err := doc.GetComments()
+	if err != nil {
+		return pkgErrors.NewDocumentError(commentsPath, "docx", "failed to read comments", err)
+	}
+
+	if commentsList || !commentsRemove {
+		if err := writeComments(cmd.OutOrStdout(), commentsPath, comments, commentsJSON); err != nil {
+			return err
+		}
+	}
+
+	if commentsRemove {
+		if commentsOutput != "" && !force {
+			if _, err := os.Stat(commentsOutput); err == nil {
+				return pkgErrors.NewFileError(commentsOutput, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+			}
+		}
+
+		if err := doc.RemoveComments(); err != nil {
+			return pkgErrors.NewDocumentError(commentsPath, "docx", "failed to remove comments", err)
+		}
+
+		target := commentsPath
+		if commentsOutput != "" {
+			err = doc.SaveAs(commentsOutput)
+			target = commentsOutput
+		} else {
+			err = doc.Save()
+		}
+		if err != nil {
+			return pkgErrors.NewFileError(target, "saving", err)
+		}
+		if !commentsJSON {
+			ui.PrintSuccess("Removed %d comment(s) from %s", len(comments), target)
+		}
+	}
+	return nil
+}
+
+// writeComments prints comments as text or JSON
+func writeComments(out io.Writer, path string, comments []document.Comment, asJSON bool) error {
+	if asJSON {
+		if comments == nil {
+			comments = []document.Comment{}
+		}
+		jsonBytes, err := json.MarshalIndent(map[string]interface{}{
+			"path":     path,
+			"comments": comments,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Document: %s\n", path)
+	fmt.Fprintf(out, "Comments found: %d\n", len(comments))
+	for _, comment := range comments {
+		author := comment.Author
+		if author == "" {
+			author = "(unknown)"
+		}
+		if comment.Date != "" {
+			author += ", " + comment.Date
+		}
+		fmt.Fprintf(out, "  [%s] %s\n", comment.ID, author)
+		for _, line := range strings.Split(comment.Text, "\n") {
+			fmt.Fprintf(out, "      %s\n", line)
+		}
+	}
+	return nil
+}
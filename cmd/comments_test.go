@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestCommentsCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "review.docx")
+	writeZip(t, path, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:commentRangeStart w:id="0"/><w:r><w:t>Draft text</w:t></w:r><w:commentRangeEnd w:id="0"/><w:r><w:commentReference w:id="0"/></w:r></w:p>` +
+			`</w:body></w:document>`,
+		"word/comments.xml": `<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+			`<w:comment w:id="0" w:author="Kim" w:date="2024-05-01T09:30:00Z"><w:p><w:r><w:t>Reword this.</w:t></w:r></w:p><w:p><w:r><w:t>Too long.</w:t></w:r></w:p></w:comment>` +
+			`</w:comments>`,
+	})
+
+	reset := func() {
+		commentsPath, commentsList, commentsRemove, commentsOutput, commentsJSON = "", false, false, "", false
+	}
+	defer reset()
+
+	t.Run("list", func(t *testing.T) {
+		reset()
+		commentsPath = path
+		buf := new(bytes.Buffer)
+		commentsCmd.SetOut(buf)
+
+		if err := runComments(commentsCmd, nil); err != nil {
+			t.Fatalf("runComments failed: %v", err)
+		}
+		want := "Document: " + path + "\nComments found: 1\n  [0] Kim, 2024-05-01T09:30:00Z\n      Reword this.\n      Too long.\n"
+		if got := buf.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("remove to output and list as JSON", func(t *testing.T) {
+		reset()
+		output := filepath.Join(dir, "clean.docx")
+		commentsPath = path
+		commentsRemove = true
+		commentsList = true
+		commentsJSON = true
+		commentsOutput = output
+		buf := new(bytes.Buffer)
+		commentsCmd.SetOut(buf)
+
+		if err := runComments(commentsCmd, nil); err != nil {
+			t.Fatalf("runComments failed: %v", err)
+		}
+
+		var result struct {
+			Comments []document.Comment `json:"comments"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		if len(result.Comments) != 1 || result.Comments[0].Author != "Kim" {
+			t.Errorf("comments = %+v", result.Comments)
+		}
+
+		doc, err := document.OpenWordDocument(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer doc.Close()
+		if comments, _ := doc.GetComments(); len(comments) != 0 {
+			t.Errorf("output still has comments: %+v", comments)
+		}
+		if text, _ := doc.GetText(); text != "Draft text" {
+			t.Errorf("text = %q", text)
+		}
+
+		original, err := document.OpenWordDocument(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer original.Close()
+		if comments, _ := original.GetComments(); len(comments) != 1 {
+			t.Error("original document was modified")
+		}
+	})
+
+	t.Run("output requires remove", func(t *testing.T) {
+		reset()
+		commentsPath = path
+		commentsOutput = filepath.Join(dir, "other.docx")
+		err := runComments(commentsCmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "--output requires --remove") {
+			t.Errorf("runComments() error = %v", err)
+		}
+	})
+}
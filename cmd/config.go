@@ -174,12 +174,24 @@ func getConfig(path string, key string) error {
 				fmt.Printf("%s: (not set)\n", key)
 			}
 		}
+	case "openai.api_key_file":
+		fmt.Printf("%s: %s\n", key, cfg.OpenAI.APIKeyFile)
+	case "claude.api_key_file":
+		fmt.Printf("%s: %s\n", key, cfg.Claude.APIKeyFile)
 	case "openai.model":
 		fmt.Printf("%s: %s\n", key, cfg.OpenAI.Model)
 	case "openai.max_tokens":
 		fmt.Printf("%s: %d\n", key, cfg.OpenAI.MaxTokens)
 	case "openai.temperature":
 		fmt.Printf("%s: %.2f\n", key, cfg.OpenAI.Temperature)
+	case "generate.requests_per_minute":
+		fmt.Printf("%s: %d\n", key, cfg.Generate.RequestsPerMinute)
+	case "generate.tokens_per_minute":
+		fmt.Printf("%s: %d\n", key, cfg.Generate.TokensPerMinute)
+	case "generate.prompt_prefix":
+		fmt.Printf("%s: %s\n", key, cfg.Generate.PromptPrefix)
+	case "generate.prompt_suffix":
+		fmt.Printf("%s: %s\n", key, cfg.Generate.PromptSuffix)
 	case "global.verbose":
 		fmt.Printf("%s: %v\n", key, cfg.Global.Verbose)
 	case "global.quiet":
@@ -242,6 +254,8 @@ func setConfig(path string, keyValue string) error {
 		} else {
 			cfg.OpenAI.APIKey = value
 		}
+	case "openai.api_key_file":
+		cfg.OpenAI.APIKeyFile = value
 	case "openai.model":
 		cfg.OpenAI.Model = value
 	case "openai.max_tokens":
@@ -277,6 +291,20 @@ func setConfig(path string, keyValue string) error {
 		} else {
 			cfg.Claude.APIKey = value
 		}
+	case "claude.api_key_file":
+		cfg.Claude.APIKeyFile = value
+	case "generate.requests_per_minute":
+		var rpm int
+		fmt.Sscanf(value, "%d", &rpm)
+		cfg.Generate.RequestsPerMinute = rpm
+	case "generate.tokens_per_minute":
+		var tpm int
+		fmt.Sscanf(value, "%d", &tpm)
+		cfg.Generate.TokensPerMinute = tpm
+	case "generate.prompt_prefix":
+		cfg.Generate.PromptPrefix = value
+	case "generate.prompt_suffix":
+		cfg.Generate.PromptSuffix = value
 	case "global.verbose":
 		cfg.Global.Verbose = (value == "true")
 	case "global.quiet":
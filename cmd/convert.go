@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/export"
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertInput      string
+	convertOutput     string
+	convertFormat     string
+	convertMinQuality float64
+	convertIgnoreQual bool
+	convertHeadingMax int
+	convertLineEnding string
+)
+
+// convertCmd converts a document into another format
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert a document to Markdown, HTML or Word",
+	Long: `Convert a document into another format. The input type is detected from
+its extension and the output format is taken from --format, or else from the
+extension of --output (.md, .html, .docx or .json), defaulting to Markdown.
+JSON keeps the coordinates of every element and table of the PDF.
+
+PDF files are converted with the same extraction as 'dox extract', keeping
+headings, paragraphs and tables. Other inputs are not supported yet; use
+'dox extract' to get the plain text of Word and PowerPoint files.
+
+Examples:
+  # PDF to Markdown
+  dox convert --input report.pdf --output report.md
+
+  # PDF to an editable Word document
+  dox convert --input scan.pdf --output scan.docx
+
+  # PDF to HTML on stdout
+  dox convert --input report.pdf --format html
+
+  # PDF layout with element coordinates
+  dox convert --input report.pdf --output report.json`,
+	SilenceUsage: true,
+	RunE:         runConvert,
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringVarP(&convertInput, "input", "i", "", "Document to convert (required)")
+	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Output file path (default: stdout)")
+	convertCmd.Flags().StringVarP(&convertFormat, "format", "f", "", "Output format (markdown|html|docx|json); default from the --output extension, else markdown")
+	convertCmd.Flags().Float64Var(&convertMinQuality, "min-quality", 0.2, "Minimum PDF quality threshold (0.0-1.0)")
+	convertCmd.Flags().BoolVar(&convertIgnoreQual, "ignore-quality", false, "Ignore PDF quality checks and force conversion")
+	convertCmd.Flags().StringVar(&convertLineEnding, "normalize-line-endings", string(export.LineEndingAuto), "Line endings of Markdown and HTML written to --output: lf, crlf, or auto for the platform's")
+	convertCmd.Flags().IntVar(&convertHeadingMax, "heading-max-len", export.DefaultHeadingMaxLength, "Treat unstructured PDF lines shorter than this many characters as headings (0 = never)")
+
+	convertCmd.MarkFlagRequired("input")
+}
+
+// inputConverter converts a file of one input type to an export format
+type inputConverter func(path string, format export.Format) (string, error)
+
+// inputConverters maps input extensions to their converters. Word and
+// PowerPoint inputs are added here once a document-to-Markdown writer exists.
+var inputConverters = map[string]inputConverter{
+	".pdf": convertPDF,
+}
+
+func runConvert(cmd *cobra.Command, args []string) error {
+	format, err := convertTargetFormat(convertFormat, convertOutput)
+	if err != nil {
+		return err
+	}
+	if format == export.FormatDOCX && convertOutput == "" {
+		return pkgErrors.NewValidationError("output", convertOutput, "--output is required for docx format")
+	}
+	ending, err := outputLineEnding(convertLineEnding, format)
+	if err != nil {
+		return err
+	}
+	if convertHeadingMax < 0 {
+		return pkgErrors.NewValidationError("heading-max-len", convertHeadingMax, "must be 0 or greater")
+	}
+	if convertOutput != "" && filepath.Clean(convertOutput) == filepath.Clean(convertInput) {
+		return pkgErrors.NewValidationError("output", convertOutput, "must differ from --input")
+	}
+
+	if _, err := os.Stat(convertInput); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return pkgErrors.NewFileError(convertInput, "converting", pkgErrors.ErrFileNotFound)
+		}
+		return pkgErrors.NewFileError(convertInput, "converting", err)
+	}
+	ext := strings.ToLower(filepath.Ext(convertInput))
+	convert, ok := inputConverters[ext]
+	if !ok {
+		return pkgErrors.NewDocumentError(convertInput, ext,
+			fmt.Sprintf("conversion from %s is not supported (supported: %s)", ext, strings.Join(convertInputExtensions(), ", ")),
+			pkgErrors.ErrUnsupportedFormat)
+	}
+
+	output, err := convert(convertInput, format)
+	if err != nil {
+		return err
+	}
+	return writeOutputFile(cmd.OutOrStdout(), convertOutput, output, "converted", ending)
+}
+
+// convertTargetFormat returns the --format value, or the format implied by
+// the output extension, or Markdown
+func convertTargetFormat(name, output string) (export.Format, error) {
+	if name != "" {
+		return export.ParseFormat(name)
+	}
+	if format, ok := export.FormatForPath(output); ok {
+		return format, nil
+	}
+	return export.FormatMarkdown, nil
+}
+
+// convertInputExtensions returns the input extensions convert accepts
+func convertInputExtensions() []string {
+	var exts []string
+	for ext := range inputConverters {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// convertPDF extracts a PDF and renders it in the export format
+func convertPDF(path string, format export.Format) (string, error) {
+	result, err := extractPDF(path, pdf.ExtractorOptions{
+		MinQuality:    convertMinQuality,
+		IgnoreQuality: convertIgnoreQual,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	converter := export.NewConverter(result)
+	converter.SetHeadingOptions(export.HeadingOptions{MaxLength: convertHeadingMax})
+	output, err := converter.Convert(format)
+	if err != nil {
+		return "", fmt.Errorf("conversion failed: %w", err)
+	}
+	return output, nil
+}
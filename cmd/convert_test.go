@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/export"
+)
+
+func TestConvertTargetFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		output string
+		want   export.Format
+	}{
+		{"", "", export.FormatMarkdown},
+		{"", "out/report.HTML", export.FormatHTML},
+		{"", "report.docx", export.FormatDOCX},
+		{"", "report.txt", export.FormatMarkdown},
+		{"md", "report.html", export.FormatMarkdown},
+		{"Word", "", export.FormatDOCX},
+	}
+	for _, tt := range tests {
+		got, err := convertTargetFormat(tt.format, tt.output)
+		if err != nil || got != tt.want {
+			t.Errorf("convertTargetFormat(%q, %q) = %q, %v; want %q", tt.format, tt.output, got, err, tt.want)
+		}
+	}
+	if _, err := convertTargetFormat("pdf", ""); err == nil {
+		t.Error("convertTargetFormat(pdf) should fail")
+	}
+}
+
+func TestConvertValidation(t *testing.T) {
+	dir := t.TempDir()
+	docxPath := filepath.Join(dir, "report.docx")
+	if err := os.WriteFile(docxPath, []byte("not read"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { convertInput, convertOutput, convertFormat = "", "", "" }()
+
+	tests := []struct {
+		name   string
+		input  string
+		output string
+		format string
+		want   error // nil expects a validation error
+	}{
+		{"unsupported input", docxPath, filepath.Join(dir, "report.md"), "", pkgErrors.ErrUnsupportedFormat},
+		{"docx needs an output file", filepath.Join(dir, "report.pdf"), "", "docx", nil},
+		{"output overwrites input", docxPath, docxPath, "markdown", nil},
+		{"missing input", filepath.Join(dir, "missing.pdf"), "", "", pkgErrors.ErrFileNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convertInput, convertOutput, convertFormat = tt.input, tt.output, tt.format
+			err := runConvert(convertCmd, nil)
+			var validationErr *pkgErrors.ValidationError
+			if tt.want == nil && !errors.As(err, &validationErr) {
+				t.Errorf("runConvert() error = %v, want a validation error", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("runConvert() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
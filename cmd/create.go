@@ -198,7 +198,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Check if template is specified
 	if templateFile != "" {
-		if verbose {
+		if ui.IsVerbose() {
 			ui.PrintInfo("Using template: %s", templateFile)
 		}
 	}
@@ -208,19 +208,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	switch outputFormat {
 	case "docx":
 		converter = markdown.NewWordConverter()
-		if !quiet {
-			ui.PrintInfo("Converting %s to Word document...", fromFile)
-		}
+		ui.PrintInfo("Converting %s to Word document...", fromFile)
 	case "pptx":
 		converter = markdown.NewPowerPointConverter()
-		if !quiet {
-			ui.PrintInfo("Converting %s to PowerPoint presentation...", fromFile)
-		}
+		ui.PrintInfo("Converting %s to PowerPoint presentation...", fromFile)
 	}
 
 	// Perform conversion with spinner
 	var spinner *ui.ProgressBar
-	if !quiet {
+	if !ui.IsQuiet() {
 		spinner = ui.NewSpinner("Processing...")
 	}
 	
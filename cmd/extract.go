@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 	"github.com/pyhub/pyhub-docs/internal/export"
 	"github.com/pyhub/pyhub-docs/internal/pdf"
 	"github.com/spf13/cobra"
@@ -18,11 +22,19 @@ var (
 	extractStrict     bool
 	extractMinQuality float64
 	extractIgnoreQual bool
+	extractFlatten    bool
+	extractHidden     bool
+	extractSanitize   bool
+	extractNotes      bool
+	extractTablesDir  string
+	extractHeadingMax int
+	extractLineEnding string
+	extractTextOrder  string
 )
 
 var extractCmd = &cobra.Command{
-	Use:   "extract [pdf-file]",
-	Short: "Extract content from PDF documents",
+	Use:   "extract [file]",
+	Short: "Extract content from PDF, Word and PowerPoint documents",
 	Long: `Extract structured content from PDF documents including text, tables, and layout.
 	
 Preserves document structure including:
@@ -31,7 +43,22 @@ Preserves document structure including:
   • Lists and hierarchical content
   • Metadata (title, author, etc.)
 
-Supports export to HTML and Markdown formats.`,
+Supports export to HTML, Markdown, Word (.docx) and JSON formats. JSON keeps
+the bounding box of every text element and table, in points from the top-left
+corner of the page, so tools can rebuild the layout or search by position.
+With --tables-dir,
+each PDF table is also written as a separate CSV file (page{N}_table{M}.csv)
+for use in spreadsheets or data tools.
+
+Word (.docx) and PowerPoint (.pptx) files are extracted as plain text.
+PowerPoint text is grouped under "Slide N:" headers; use --flatten to
+merge all slide text into one block, e.g. to feed a deck into
+'dox generate --type summary'. Speaker notes are left out unless
+--include-notes is given; each slide's notes then follow its text under a
+"Slide N speaker notes:" header ("Speaker notes:" with --flatten), so a
+summary can tell them apart from slide content. Slide text is read title
+first, then top to bottom and left to right; --text-order document keeps the
+order of the slide XML instead, as earlier versions did.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runExtract,
 }
@@ -39,44 +66,101 @@ Supports export to HTML and Markdown formats.`,
 func init() {
 	rootCmd.AddCommand(extractCmd)
 
-	extractCmd.Flags().StringVarP(&extractFormat, "format", "f", "markdown", "Output format (html|markdown)")
+	extractCmd.Flags().StringVarP(&extractFormat, "format", "f", "markdown", "Output format (html|markdown|docx|json); json keeps the coordinates of every element and table")
 	extractCmd.Flags().StringVarP(&extractOutput, "output", "o", "", "Output file path (default: stdout)")
 	extractCmd.Flags().BoolVarP(&extractDebug, "debug", "d", false, "Enable debug output")
 	extractCmd.Flags().BoolVarP(&extractStrict, "strict", "s", false, "Strict quality mode - fail on low quality")
 	extractCmd.Flags().Float64VarP(&extractMinQuality, "min-quality", "m", 0.2, "Minimum quality threshold (0.0-1.0)")
 	extractCmd.Flags().BoolVar(&extractIgnoreQual, "ignore-quality", false, "Ignore quality checks and force extraction")
+	extractCmd.Flags().BoolVar(&extractFlatten, "flatten", false, "Merge Word/PowerPoint text into one block without slide headers")
+	extractCmd.Flags().StringVar(&extractLineEnding, "normalize-line-endings", string(export.LineEndingAuto), "Line endings of text written to --output: lf, crlf, or auto for the platform's")
+	extractCmd.Flags().IntVar(&extractHeadingMax, "heading-max-len", export.DefaultHeadingMaxLength, "Treat unstructured PDF lines shorter than this many characters as headings (0 = never)")
+	extractCmd.Flags().StringVar(&extractTablesDir, "tables-dir", "", "Also write each PDF table to this directory as page{N}_table{M}.csv")
+	extractCmd.Flags().BoolVar(&extractHidden, "include-hidden-text", false, "Include Word text marked as hidden (skipped by default)")
+	extractCmd.Flags().BoolVar(&extractNotes, "include-notes", false, "Add each PowerPoint slide's speaker notes after its text, labeled as notes")
+	extractCmd.Flags().StringVar(&extractTextOrder, "text-order", document.TextOrderNameReading, "Order of PowerPoint slide text: reading (title, then top to bottom, left to right) or document (slide XML order)")
+	extractCmd.Flags().BoolVar(&extractSanitize, "sanitize-utf8", false, "Replace invalid UTF-8 in Word/PowerPoint text with U+FFFD instead of failing")
 }
 
 func runExtract(cmd *cobra.Command, args []string) error {
 	pdfPath := args[0]
 
-	// Verify PDF file exists
-	if _, err := os.Stat(pdfPath); err != nil {
-		return fmt.Errorf("PDF file not found: %s", pdfPath)
+	switch strings.ToLower(filepath.Ext(pdfPath)) {
+	case ".docx", ".pptx":
+		if extractTablesDir != "" {
+			return fmt.Errorf("--tables-dir is only supported for PDF files")
+		}
+		return runExtractText(cmd, pdfPath)
+	}
+
+	if extractHeadingMax < 0 {
+		return pkgErrors.NewValidationError("heading-max-len", extractHeadingMax, "must be 0 or greater")
 	}
 
-	// Create extractor with options
-	options := pdf.ExtractorOptions{
+	result, err := extractPDF(pdfPath, pdf.ExtractorOptions{
 		Debug:         extractDebug,
 		Strict:        extractStrict,
 		MinQuality:    extractMinQuality,
 		IgnoreQuality: extractIgnoreQual,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Convert to desired format
+	converter := export.NewConverter(result)
+	converter.SetHeadingOptions(export.HeadingOptions{MaxLength: extractHeadingMax})
+
+	if extractTablesDir != "" {
+		written, err := converter.WriteTablesCSV(extractTablesDir)
+		if err != nil {
+			return fmt.Errorf("failed to write tables: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d table(s) to: %s\n", len(written), extractTablesDir)
 	}
 	
+	format, err := export.ParseFormat(extractFormat)
+	if err != nil {
+		return err
+	}
+	if format == export.FormatDOCX && extractOutput == "" {
+		return fmt.Errorf("--output is required for docx format")
+	}
+
+	output, err := converter.Convert(format)
+	if err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	ending, err := outputLineEnding(extractLineEnding, format)
+	if err != nil {
+		return err
+	}
+	return writeExtractOutput(cmd.OutOrStdout(), output, ending)
+}
+
+// extractPDF extracts the content of a PDF file, explaining missing
+// dependencies and quality problems on stderr
+func extractPDF(pdfPath string, options pdf.ExtractorOptions) (*pdf.ExtractResult, error) {
+	// Verify PDF file exists
+	if _, err := os.Stat(pdfPath); err != nil {
+		return nil, fmt.Errorf("PDF file not found: %s", pdfPath)
+	}
+
 	extractor, err := pdf.NewExtractor(options)
 	if err != nil {
 		// Check if it's a dependency issue
 		if strings.Contains(err.Error(), "Python not found") {
 			fmt.Fprintln(os.Stderr, "Error: Python 3 is required for PDF extraction")
 			fmt.Fprintln(os.Stderr, "Please install Python 3 from https://www.python.org/")
-			return err
+			return nil, err
 		}
 		if strings.Contains(err.Error(), "script not found") {
 			fmt.Fprintln(os.Stderr, "Error: PDF extraction script not found")
 			fmt.Fprintln(os.Stderr, "Please ensure scripts/pdf_extract.py exists")
-			return err
+			return nil, err
 		}
-		return err
+		return nil, err
 	}
 
 	// Check dependencies
@@ -85,11 +169,11 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr, err.Error())
 		fmt.Fprintln(os.Stderr, "\nTo install required Python libraries:")
 		fmt.Fprintln(os.Stderr, "  pip install pdfplumber")
-		return err
+		return nil, err
 	}
 
 	// Extract PDF content
-	if extractDebug {
+	if options.Debug {
 		fmt.Fprintf(os.Stderr, "Extracting content from: %s\n", pdfPath)
 	}
 
@@ -109,10 +193,10 @@ func runExtract(cmd *cobra.Command, args []string) error {
 			fmt.Fprintln(os.Stderr, "  3. Use OCR tools if the content is in image format")
 			fmt.Fprintln(os.Stderr, "")
 		}
-		return fmt.Errorf("extraction failed: %w", err)
+		return nil, fmt.Errorf("extraction failed: %w", err)
 	}
 
-	if extractDebug {
+	if options.Debug {
 		fmt.Fprintf(os.Stderr, "Extracted %d pages\n", len(result.Pages))
 		for _, page := range result.Pages {
 			if len(page.Tables) > 0 {
@@ -121,42 +205,105 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Convert to desired format
-	converter := export.NewConverter(result)
-	
-	var format export.Format
-	switch strings.ToLower(extractFormat) {
-	case "html":
-		format = export.FormatHTML
-	case "markdown", "md":
-		format = export.FormatMarkdown
-	default:
-		return fmt.Errorf("unsupported format: %s (use 'html' or 'markdown')", extractFormat)
-	}
+	return result, nil
+}
 
-	output, err := converter.Convert(format)
+// writeExtractOutput writes extracted content to --output, or to out when no
+// output file is set
+func writeExtractOutput(out io.Writer, output string, ending export.LineEnding) error {
+	return writeOutputFile(out, extractOutput, output, "extracted", ending)
+}
+
+// outputLineEnding parses a --normalize-line-endings value for output in the
+// given format; binary formats get no line ending so they are written as is
+func outputLineEnding(name string, format export.Format) (export.LineEnding, error) {
+	ending, err := export.ParseLineEnding(name)
 	if err != nil {
-		return fmt.Errorf("conversion failed: %w", err)
+		return "", pkgErrors.NewValidationError("normalize-line-endings", name, "must be one of: lf, crlf, auto")
+	}
+	if format == export.FormatDOCX {
+		return "", nil
 	}
+	return ending, nil
+}
 
-	// Write output
-	if extractOutput == "" {
+// writeOutputFile writes content to path, creating its directory, or to out
+// when path is empty. Text written to a file gets the given line endings;
+// an empty ending writes the content unchanged.
+func writeOutputFile(out io.Writer, path, output, action string, ending export.LineEnding) error {
+	if path == "" {
 		// Write to stdout
-		fmt.Print(output)
+		fmt.Fprint(out, output)
+		return nil
+	}
+	if ending != "" {
+		output = export.NormalizeLineEndings(output, ending)
+	}
+
+	// Ensure output directory exists
+	outputDir := filepath.Dir(path)
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Write to file
+	if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Successfully %s to: %s\n", action, path)
+	return nil
+}
+
+// runExtractText extracts the text of a Word or PowerPoint document
+func runExtractText(cmd *cobra.Command, path string) error {
+	textOrder, err := document.ParseTextOrder(extractTextOrder)
+	if err != nil {
+		return err
+	}
+
+	var doc document.Document
+	if strings.EqualFold(filepath.Ext(path), ".pptx") {
+		var pptDoc *document.PowerPointDocument
+		if pptDoc, err = document.OpenPowerPointDocument(path); err == nil {
+			pptDoc.SetSanitizeUTF8(extractSanitize)
+			pptDoc.SetIncludeNotes(extractNotes)
+			pptDoc.SetTextOrder(textOrder)
+			doc = pptDoc
+		}
 	} else {
-		// Ensure output directory exists
-		outputDir := filepath.Dir(extractOutput)
-		if err := os.MkdirAll(outputDir, 0755); err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+		var wordDoc *document.WordDocument
+		if wordDoc, err = document.OpenWordDocument(path); err == nil {
+			wordDoc.SetIncludeHiddenText(extractHidden)
+			wordDoc.SetSanitizeUTF8(extractSanitize)
+			doc = wordDoc
 		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open document: %w", err)
+	}
+	defer doc.Close()
 
-		// Write to file
-		if err := os.WriteFile(extractOutput, []byte(output), 0644); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
-		}
+	var text string
+	if extractFlatten {
+		text, err = doc.GetPlainText()
+	} else {
+		text, err = doc.GetText()
+	}
+	if errors.Is(err, pkgErrors.ErrInvalidUTF8) {
+		return fmt.Errorf("failed to extract text: %w (use --sanitize-utf8 to replace invalid characters)", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract text: %w", err)
+	}
 
-		fmt.Fprintf(os.Stderr, "✅ Successfully extracted to: %s\n", extractOutput)
+	if extractDebug {
+		fmt.Fprintf(os.Stderr, "Extracted %d characters from: %s\n", len([]rune(text)), path)
 	}
 
-	return nil
-}
\ No newline at end of file
+	ending, err := outputLineEnding(extractLineEnding, export.FormatMarkdown)
+	if err != nil {
+		return err
+	}
+	return writeExtractOutput(cmd.OutOrStdout(), strings.TrimRight(text, "\n")+"\n", ending)
+}
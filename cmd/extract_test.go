@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/export"
+)
+
+func TestExtractOfficeText(t *testing.T) {
+	dir := t.TempDir()
+	slide := func(text string) string {
+		return `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+			`<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`
+	}
+	pptxPath := filepath.Join(dir, "deck.pptx")
+	writeZip(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml": slide("Intro"),
+		"ppt/slides/slide2.xml": slide("Results"),
+	})
+	docxPath := filepath.Join(dir, "report.docx")
+	writeZip(t, docxPath, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>First</w:t></w:r></w:p><w:p><w:r><w:t>Second</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	defer func() { extractFlatten = false }()
+
+	tests := []struct {
+		name    string
+		path    string
+		flatten bool
+		want    string
+	}{
+		{"PowerPoint with slide headers", pptxPath, false, "Slide 1:\nIntro\n\nSlide 2:\nResults\n"},
+		{"PowerPoint flattened", pptxPath, true, "Intro\n\nResults\n"},
+		{"Word flattened", docxPath, true, "First\nSecond\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			extractCmd.SetOut(buf)
+			extractFlatten = tt.flatten
+
+			if err := runExtract(extractCmd, []string{tt.path}); err != nil {
+				t.Fatalf("runExtract failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractHiddenText(t *testing.T) {
+	docxPath := filepath.Join(t.TempDir(), "notes.docx")
+	writeZip(t, docxPath, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t xml:space="preserve">Shown </w:t></w:r><w:r><w:rPr><w:vanish/></w:rPr><w:t>reviewer note</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:rPr><w:vanish/></w:rPr><w:t>Hidden paragraph</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	defer func() { extractHidden = false }()
+
+	for _, tt := range []struct {
+		include bool
+		want    string
+	}{
+		{false, "Shown \n"},
+		{true, "Shown reviewer note\nHidden paragraph\n"},
+	} {
+		buf := new(bytes.Buffer)
+		extractCmd.SetOut(buf)
+		extractHidden = tt.include
+
+		if err := runExtract(extractCmd, []string{docxPath}); err != nil {
+			t.Fatalf("runExtract failed: %v", err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("include hidden %v: output = %q, want %q", tt.include, got, tt.want)
+		}
+	}
+}
+
+func TestExtractTablesDirRequiresPDF(t *testing.T) {
+	docxPath := filepath.Join(t.TempDir(), "report.docx")
+	writeZip(t, docxPath, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body></w:body></w:document>`,
+	})
+
+	extractTablesDir = t.TempDir()
+	defer func() { extractTablesDir = "" }()
+
+	if err := runExtract(extractCmd, []string{docxPath}); err == nil {
+		t.Error("expected --tables-dir to be rejected for a Word document")
+	}
+}
+
+func TestExtractInvalidUTF8(t *testing.T) {
+	pptxPath := filepath.Join(t.TempDir(), "legacy.pptx")
+	writeZip(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml": `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+			"<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Caf\xe9</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>",
+	})
+
+	defer func() { extractSanitize = false }()
+	buf := new(bytes.Buffer)
+	extractCmd.SetOut(buf)
+
+	err := runExtract(extractCmd, []string{pptxPath})
+	if !errors.Is(err, pkgErrors.ErrInvalidUTF8) || !strings.Contains(err.Error(), "--sanitize-utf8") {
+		t.Fatalf("runExtract() error = %v, want an invalid UTF-8 error suggesting --sanitize-utf8", err)
+	}
+
+	extractSanitize = true
+	if err := runExtract(extractCmd, []string{pptxPath}); err != nil {
+		t.Fatalf("runExtract() with --sanitize-utf8 failed: %v", err)
+	}
+	if got := buf.String(); got != "Slide 1:\nCaf�\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestExtractIncludeNotes(t *testing.T) {
+	pptxPath := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZip(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml": `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+			`<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Intro</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`,
+		"ppt/slides/_rels/slide1.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/></Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": `<p:notes xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree>` +
+			`<p:sp><p:nvSpPr><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:r><a:t>Welcome everyone</a:t></a:r></a:p></p:txBody></p:sp>` +
+			`</p:spTree></p:cSld></p:notes>`,
+	})
+
+	defer func() {
+		extractNotes = false
+		extractFlatten = false
+	}()
+
+	for _, tt := range []struct {
+		notes, flatten bool
+		want           string
+	}{
+		{false, false, "Slide 1:\nIntro\n"},
+		{true, false, "Slide 1:\nIntro\n\nSlide 1 speaker notes:\nWelcome everyone\n"},
+		{true, true, "Intro\n\nSpeaker notes:\nWelcome everyone\n"},
+	} {
+		buf := new(bytes.Buffer)
+		extractCmd.SetOut(buf)
+		extractNotes = tt.notes
+		extractFlatten = tt.flatten
+
+		if err := runExtract(extractCmd, []string{pptxPath}); err != nil {
+			t.Fatalf("runExtract failed: %v", err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("notes %v, flatten %v: output = %q, want %q", tt.notes, tt.flatten, got, tt.want)
+		}
+	}
+}
+
+func TestWriteOutputFileLineEndings(t *testing.T) {
+	dir := t.TempDir()
+	mixed := "# Title\r\n\nText\n"
+
+	textPath := filepath.Join(dir, "out.md")
+	if err := writeOutputFile(new(bytes.Buffer), textPath, mixed, "extracted", export.LineEndingCRLF); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(textPath); string(got) != "# Title\r\n\r\nText\r\n" {
+		t.Errorf("text output = %q", got)
+	}
+
+	// Binary formats get no line ending and are written unchanged
+	ending, err := outputLineEnding("crlf", export.FormatDOCX)
+	if err != nil || ending != "" {
+		t.Fatalf("outputLineEnding() = %q, %v", ending, err)
+	}
+	binaryPath := filepath.Join(dir, "out.docx")
+	if err := writeOutputFile(new(bytes.Buffer), binaryPath, mixed, "extracted", ending); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(binaryPath); string(got) != mixed {
+		t.Errorf("binary output = %q, want it unchanged", got)
+	}
+
+	// Standard output is left as is
+	buf := new(bytes.Buffer)
+	writeOutputFile(buf, "", mixed, "extracted", export.LineEndingLF)
+	if buf.String() != mixed {
+		t.Errorf("stdout = %q, want it unchanged", buf.String())
+	}
+
+	var validationErr *pkgErrors.ValidationError
+	if _, err := outputLineEnding("cr", export.FormatMarkdown); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestExtractTextOrder(t *testing.T) {
+	pptxPath := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZip(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml": `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree>` +
+			`<p:sp><p:spPr><a:xfrm><a:off x="0" y="4000000"/></a:xfrm></p:spPr><p:txBody><a:p><a:r><a:t>Closing</a:t></a:r></a:p></p:txBody></p:sp>` +
+			`<p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:spPr/><p:txBody><a:p><a:r><a:t>Title</a:t></a:r></a:p></p:txBody></p:sp>` +
+			`</p:spTree></p:cSld></p:sld>`,
+	})
+
+	defer func() { extractTextOrder = "reading" }()
+
+	for order, want := range map[string]string{
+		"reading":  "Slide 1:\nTitle\nClosing\n",
+		"document": "Slide 1:\nClosing\nTitle\n",
+	} {
+		buf := new(bytes.Buffer)
+		extractCmd.SetOut(buf)
+		extractTextOrder = order
+
+		if err := runExtract(extractCmd, []string{pptxPath}); err != nil {
+			t.Fatalf("runExtract with --text-order %s failed: %v", order, err)
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("--text-order %s: output = %q, want %q", order, got, want)
+		}
+	}
+
+	extractTextOrder = "xml"
+	var validationErr *pkgErrors.ValidationError
+	if err := runExtract(extractCmd, []string{pptxPath}); !errors.As(err, &validationErr) {
+		t.Errorf("--text-order xml error = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/replace"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	findPath            string
+	findQuery           string
+	findRegex           bool
+	findCaseInsensitive bool
+	findRecursive       bool
+	findExclude         string
+	findHidden          bool
+	findJSON            bool
+)
+
+// maxShownMatches is how many matches per file are shown without --verbose
+const maxShownMatches = 5
+
+// findCmd searches documents for text without changing them
+var findCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Search documents for text without replacing it",
+	Long: `Search Word, PowerPoint and RTF documents for a term and report each file
+with the number of matches and the text around them. Documents are never
+modified, so this is a safe way to check what a replacement rule would hit.
+
+Matching uses the same extracted text as 'dox replace --dry-run'.
+
+Examples:
+  # Find a term in all documents under a directory
+  dox find --path ./docs --query "Version 1.0"
+
+  # Ignore case
+  dox find --path ./docs --query "acme corp" --case-insensitive
+
+  # Use a regular expression
+  dox find --path report.docx --query "v[0-9]+\.[0-9]+" --regex
+
+  # Machine-readable results
+  dox find --path ./docs --query "Draft" --json`,
+	RunE: runFind,
+}
+
+func init() {
+	rootCmd.AddCommand(findCmd)
+
+	findCmd.Flags().StringVarP(&findPath, "path", "p", "", "Document or directory to search (required)")
+	findCmd.Flags().StringVar(&findQuery, "query", "", "Text to search for (required)")
+	findCmd.Flags().BoolVar(&findRegex, "regex", false, "Treat the query as a regular expression")
+	findCmd.Flags().BoolVarP(&findCaseInsensitive, "case-insensitive", "i", false, "Ignore letter case")
+	findCmd.Flags().BoolVar(&findRecursive, "recursive", true, "Search subdirectories recursively")
+	findCmd.Flags().StringVar(&findExclude, "exclude", "", "Glob pattern for files to exclude")
+	findCmd.Flags().BoolVar(&findHidden, "include-hidden-text", false, "Also search Word text marked as hidden (skipped by default)")
+	findCmd.Flags().BoolVar(&findJSON, "json", false, "Output results in JSON format")
+
+	findCmd.MarkFlagRequired("path")
+	findCmd.MarkFlagRequired("query")
+}
+
+// findResult is the matches found in one document
+type findResult struct {
+	Path    string          `json:"path"`
+	Count   int             `json:"count"`
+	Matches []replace.Match `json:"matches,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	if findQuery == "" {
+		return pkgErrors.NewValidationError("query", findQuery, "query cannot be empty")
+	}
+	pattern, err := replace.CompileQuery(findQuery, replace.FindOptions{Regex: findRegex, CaseInsensitive: findCaseInsensitive})
+	if err != nil {
+		return pkgErrors.NewValidationError("query", findQuery, fmt.Sprintf("invalid regular expression: %v", err))
+	}
+
+	info, err := os.Stat(findPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return pkgErrors.NewFileError(findPath, "accessing", pkgErrors.ErrFileNotFound)
+		}
+		return pkgErrors.NewFileError(findPath, "accessing", err)
+	}
+
+	var results []findResult
+	search := func(path string) error {
+		results = append(results, findInDocument(path, pattern))
+		return nil
+	}
+
+	if info.IsDir() {
+		if err := replace.WalkDocumentFilesWithExclude(findPath, findRecursive, findExclude, search); err != nil {
+			return pkgErrors.NewFileError(findPath, "searching", err)
+		}
+	} else {
+		if !document.IsSupportedFormat(findPath) {
+			ext := strings.ToLower(filepath.Ext(findPath))
+			return pkgErrors.NewDocumentError(findPath, ext,
+				fmt.Sprintf("unsupported format (supported: %s)", strings.Join(document.SupportedExtensions(), ", ")), pkgErrors.ErrUnsupportedFormat)
+		}
+		search(findPath)
+	}
+
+	return writeFindResults(cmd.OutOrStdout(), results, findJSON)
+}
+
+// findInDocument searches one document's extracted text
+func findInDocument(path string, pattern *regexp.Regexp) findResult {
+	result := findResult{Path: path}
+
+	text, err := readDocumentText(path, findHidden)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Matches = replace.FindMatches(text, pattern)
+	result.Count = len(result.Matches)
+	return result
+}
+
+// readDocumentText returns a document's extracted text, the same text
+// 'dox replace --dry-run' matches against
+func readDocumentText(path string, includeHidden bool) (string, error) {
+	doc, err := document.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer doc.Close()
+	if wordDoc, ok := doc.(*document.WordDocument); ok {
+		wordDoc.SetIncludeHiddenText(includeHidden)
+	}
+	return doc.GetText()
+}
+
+// writeFindResults prints the files with matches and their context, or all
+// results as JSON. Files that could not be read are reported as warnings.
+func writeFindResults(out io.Writer, results []findResult, asJSON bool) error {
+	totalMatches, filesWithMatches := 0, 0
+	for _, result := range results {
+		totalMatches += result.Count
+		if result.Count > 0 {
+			filesWithMatches++
+		}
+	}
+
+	if asJSON {
+		if results == nil {
+			results = []findResult{}
+		}
+		jsonBytes, err := json.MarshalIndent(map[string]interface{}{
+			"query":           findQuery,
+			"regex":           findRegex,
+			"caseInsensitive": findCaseInsensitive,
+			"files":           results,
+			"summary": map[string]int{
+				"totalFiles":       len(results),
+				"filesWithMatches": filesWithMatches,
+				"totalMatches":     totalMatches,
+			},
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			ui.PrintWarning("%s: cannot be read: %s", result.Path, result.Error)
+			continue
+		}
+		if result.Count == 0 {
+			continue
+		}
+
+		fmt.Fprintf(out, "%s: %d match(es)\n", result.Path, result.Count)
+		shown := result.Matches
+		if !ui.IsVerbose() && len(shown) > maxShownMatches {
+			shown = shown[:maxShownMatches]
+		}
+		for _, match := range shown {
+			fmt.Fprintf(out, "  line %d: %s\n", match.Line, match.Context)
+		}
+		if len(shown) < len(result.Matches) {
+			fmt.Fprintf(out, "  ... and %d more (use --verbose to list all)\n", len(result.Matches)-len(shown))
+		}
+	}
+
+	fmt.Fprintf(out, "Found %d match(es) in %d of %d file(s)\n", totalMatches, filesWithMatches, len(results))
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindCommand(t *testing.T) {
+	dir := t.TempDir()
+	paragraphs := func(texts ...string) string {
+		xml := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`
+		for _, text := range texts {
+			xml += `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+		}
+		return xml + `</w:body></w:document>`
+	}
+	writeZip(t, filepath.Join(dir, "a.docx"), map[string]string{
+		"word/document.xml": paragraphs("Version 1.0 released", "Upgrade to version 1.0 today"),
+	})
+	writeZip(t, filepath.Join(dir, "b.docx"), map[string]string{
+		"word/document.xml": paragraphs("Nothing to see"),
+	})
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("Version 1.0"), 0644)
+
+	reset := func() {
+		findPath, findQuery, findRegex, findCaseInsensitive, findJSON = "", "", false, false, false
+		findRecursive, findExclude = true, ""
+	}
+	defer reset()
+
+	t.Run("text", func(t *testing.T) {
+		reset()
+		findPath, findQuery = dir, "Version 1.0"
+		buf := new(bytes.Buffer)
+		findCmd.SetOut(buf)
+
+		if err := runFind(findCmd, nil); err != nil {
+			t.Fatalf("runFind failed: %v", err)
+		}
+		want := filepath.Join(dir, "a.docx") + ": 1 match(es)\n" +
+			"  line 1: Version 1.0 released\n" +
+			"Found 1 match(es) in 1 of 2 file(s)\n"
+		if got := buf.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("case-insensitive regex as JSON", func(t *testing.T) {
+		reset()
+		findPath, findQuery, findRegex, findCaseInsensitive, findJSON = dir, `version \d\.\d`, true, true, true
+		buf := new(bytes.Buffer)
+		findCmd.SetOut(buf)
+
+		if err := runFind(findCmd, nil); err != nil {
+			t.Fatalf("runFind failed: %v", err)
+		}
+		var result struct {
+			Files   []findResult `json:"files"`
+			Summary struct {
+				TotalMatches int `json:"totalMatches"`
+			} `json:"summary"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if result.Summary.TotalMatches != 2 || len(result.Files) != 2 || result.Files[0].Matches[1].Line != 2 {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		reset()
+		findPath, findQuery, findRegex = dir, "(", true
+		if err := runFind(findCmd, nil); err == nil || !strings.Contains(err.Error(), "regular expression") {
+			t.Errorf("expected invalid regex error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	formPath   string
+	formList   bool
+	formSet    []string
+	formOutput string
+	formJSON   bool
+)
+
+// formCmd reads and fills content controls in Word documents
+var formCmd = &cobra.Command{
+	Use:   "form",
+	Short: "List and fill form fields (content controls) in Word documents",
+	Long: `List and fill the form fields of a Word document.
+
+Form fields are Word content controls (Developer > Controls), identified by
+their tag. Unlike 'dox template', which replaces {{placeholder}} text, this
+command reads and writes the native controls, so the document stays a
+fillable form. Setting a field keeps the formatting of its first run.
+
+Examples:
+  # List the fields of a form
+  dox form --path application.docx --list
+
+  # Fill fields in place
+  dox form --path application.docx --set customer_name="Jane Doe" --set order_date=2024-05-01
+
+  # Fill fields into a new file and print them as JSON
+  dox form -p application.docx --set notes="Call on arrival" -o filled.docx --list --json`,
+	RunE: runForm,
+}
+
+func init() {
+	rootCmd.AddCommand(formCmd)
+
+	formCmd.Flags().StringVarP(&formPath, "path", "p", "", "Word document (.docx) containing form fields (required)")
+	formCmd.Flags().BoolVar(&formList, "list", false, "List form fields (default when --set is not given)")
+	formCmd.Flags().StringArrayVar(&formSet, "set", nil, "Set a field as tag=value (can be repeated)")
+	formCmd.Flags().StringVarP(&formOutput, "output", "o", "", "Save the filled form to this file instead of updating it in place")
+	formCmd.Flags().BoolVar(&formJSON, "json", false, "Output the field list in JSON format")
+
+	formCmd.MarkFlagRequired("path")
+}
+
+func runForm(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(formPath); os.IsNotExist(err) {
+		return pkgErrors.LocalizedFileNotFoundError(formPath)
+	}
+	if !document.IsWordFile(formPath) {
+		return pkgErrors.NewValidationError("path", formPath, "form fields are only supported in Word documents (.docx, .dotx)")
+	}
+	if formOutput != "" && !document.IsWordFile(formOutput) {
+		return pkgErrors.NewValidationError("output", formOutput, "output must be a Word document (.docx, .dotx)")
+	}
+
+	values, err := parseFormValues(formSet)
+	if err != nil {
+		return err
+	}
+
+	doc, err := document.OpenWordDocument(formPath)
+	if err != nil {
+		return pkgErrors.NewDocumentError(formPath, "docx", "failed to open document", err)
+	}
+	defer doc.Close()
+
+	if len(values) > 0 {
+		if formOutput != "" && !force {
+			if _, err := os.Stat(formOutput); err == nil {
+				return pkgErrors.NewFileError(formOutput, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+			}
+		}
+
+		for _, v := range values {
+			if err := doc.SetFormField(v.tag, v.value); err != nil {
+				return pkgErrors.NewValidationError("set", v.tag, err.Error())
+			}
+		}
+
+		target := formPath
+		if formOutput != "" {
+			err = doc.SaveAs(formOutput)
+			target = formOutput
+		} else {
+			err = doc.Save()
+		}
+		if err != nil {
+			return pkgErrors.NewFileError(target, "saving", err)
+		}
+		if !formJSON {
+			ui.PrintSuccess("Set %d form field(s) in %s", len(values), target)
+		}
+	}
+
+	if formList || len(values) == 0 {
+		fields, err := doc.GetFormFields()
+		if err != nil {
+			return err
+		}
+		return writeFormFields(cmd.OutOrStdout(), formPath, fields, formJSON)
+	}
+	return nil
+}
+
+// formValue is a parsed --set tag=value pair
+type formValue struct {
+	tag   string
+	value string
+}
+
+// parseFormValues parses --set arguments, keeping their order
+func parseFormValues(pairs []string) ([]formValue, error) {
+	values := make([]formValue, 0, len(pairs))
+	for _, pair := range pairs {
+		tag, value, ok := strings.Cut(pair, "=")
+		tag = strings.TrimSpace(tag)
+		if !ok || tag == "" {
+			return nil, pkgErrors.NewValidationError("set", pair, "expected tag=value")
+		}
+		values = append(values, formValue{tag: tag, value: value})
+	}
+	return values, nil
+}
+
+// writeFormFields prints form fields as text or JSON
+func writeFormFields(out io.Writer, path string, fields []document.FormField, asJSON bool) error {
+	if asJSON {
+		if fields == nil {
+			fields = []document.FormField{}
+		}
+		jsonBytes, err := json.MarshalIndent(map[string]interface{}{
+			"path":   path,
+			"fields": fields,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Form: %s\n", path)
+	fmt.Fprintf(out, "Fields found: %d\n", len(fields))
+	for _, field := range fields {
+		name := field.Tag
+		if name == "" {
+			name = "(untagged)"
+		}
+		if field.Alias != "" {
+			name += fmt.Sprintf(" [%s]", field.Alias)
+		}
+		value := fmt.Sprintf("%q", field.Value)
+		if field.Placeholder {
+			value = "(empty)"
+		}
+		fmt.Fprintf(out, "  %s = %s\n", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestFormCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "form.docx")
+	writeZip(t, path, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:sdt><w:sdtPr><w:alias w:val="Customer name"/><w:tag w:val="customer_name"/></w:sdtPr><w:sdtContent><w:p><w:r><w:t>Jane Doe</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+			`<w:p><w:sdt><w:sdtPr><w:tag w:val="order_date"/><w:showingPlcHdr/></w:sdtPr><w:sdtContent><w:r><w:t>Enter a date.</w:t></w:r></w:sdtContent></w:sdt></w:p>` +
+			`</w:body></w:document>`,
+	})
+
+	reset := func() {
+		formPath, formList, formSet, formOutput, formJSON = "", false, nil, "", false
+	}
+	defer reset()
+
+	t.Run("list", func(t *testing.T) {
+		reset()
+		formPath = path
+		buf := new(bytes.Buffer)
+		formCmd.SetOut(buf)
+
+		if err := runForm(formCmd, nil); err != nil {
+			t.Fatalf("runForm failed: %v", err)
+		}
+		want := "Form: " + path + "\nFields found: 2\n  customer_name [Customer name] = \"Jane Doe\"\n  order_date = (empty)\n"
+		if got := buf.String(); got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("set to output and list as JSON", func(t *testing.T) {
+		reset()
+		output := filepath.Join(dir, "filled.docx")
+		formPath = path
+		formSet = []string{"order_date=2024-05-01", "customer_name=Kim = Lee"}
+		formOutput = output
+		formList = true
+		formJSON = true
+		buf := new(bytes.Buffer)
+		formCmd.SetOut(buf)
+
+		if err := runForm(formCmd, nil); err != nil {
+			t.Fatalf("runForm failed: %v", err)
+		}
+
+		var result struct {
+			Fields []document.FormField `json:"fields"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		if len(result.Fields) != 2 || result.Fields[0].Value != "Kim = Lee" || result.Fields[1].Value != "2024-05-01" {
+			t.Errorf("fields = %+v", result.Fields)
+		}
+
+		// The original form is left untouched
+		doc, err := document.OpenWordDocument(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer doc.Close()
+		fields, _ := doc.GetFormFields()
+		if fields[0].Value != "Jane Doe" {
+			t.Errorf("original form was modified: %+v", fields)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			set  []string
+			want string
+		}{
+			{"unknown tag", []string{"missing=x"}, "form field not found"},
+			{"malformed pair", []string{"no-equals"}, "expected tag=value"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				reset()
+				formPath = path
+				formSet = tt.set
+				err := runForm(formCmd, nil)
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("runForm() error = %v, want %q", err, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/pyhub/pyhub-docs/internal/config"
 	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/export"
 	"github.com/pyhub/pyhub-docs/internal/generate"
+	"github.com/pyhub/pyhub-docs/internal/retry"
+	"github.com/pyhub/pyhub-docs/internal/secrets"
 	"github.com/pyhub/pyhub-docs/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +32,35 @@ var (
 	noCache      bool
 	dryRun       bool
 	jsonOutput   bool
+	fallbackModel string
+	topP          float64
+	batchFile     string
+	apiKeyFile       string
+	claudeAPIKeyFile string
+	requestsPerMinute int
+	tokensPerMinute   int
+	truncatePrompt    bool
+	truncateFrom      string
+	retryPreset       string
+	outputTemplate    string
+	genLanguage       string
+	genTone           string
+	promptPrefix      string
+	promptSuffix      string
+	maxOutputChars    int
+	truncateSentence  bool
+	compareProviders  string
+	showPrompt        bool
+	appendOutput      bool
+	listModels        bool
+	promptEncoding    string
+	cacheCheck        bool
+	frontmatterSpec   string
+	genLineEnding     string
+	genBudget         float64
+	genBudgetState    string
+	repeatCount       int
+	combineRepeats    bool
 )
 
 // generateCmd represents the generate command
@@ -60,7 +95,52 @@ Examples:
   dox generate --type summary --prompt "$(cat long-document.md)" --output summary.md
 
   # Use GPT-4 for complex content
-  dox generate --type blog --prompt "Advanced Go patterns" --model gpt-4 --output article.md`,
+  dox generate --type blog --prompt "Advanced Go patterns" --model gpt-4 --output article.md
+
+  # Fall back to Claude if OpenAI fails
+  dox generate --prompt "Release notes" --model gpt-4 --fallback-model claude-3-sonnet-20240229
+
+  # Read a prompt file saved by a Windows editor in a legacy code page
+  dox generate --prompt @notes.txt --prompt-encoding windows-1252
+
+  # Trim a long prompt file from the middle to fit the context window
+  dox generate --type summary --prompt @transcript.txt --truncate-prompt --truncate-from middle
+
+  # Retry quickly and often on flaky networks
+  dox generate --prompt "Release notes" --retry-preset aggressive
+
+  # Generate several documents from a batch file
+  dox generate --batch campaign.yml --model gpt-4
+
+  # Name batch outputs from each entry's values (values: {topic: ...})
+  dox generate --batch campaign.yml --output-template "posts/{{topic}}_{{date}}.md"
+
+  # Brainstorm three variations of a blog post into ideas_1.md to ideas_3.md
+  dox generate --type blog --prompt "Launch announcement" --repeat 3 --temperature 1.0 --output ideas.md
+
+  # Stop a batch before its estimated spend passes $5, counting earlier runs
+  dox generate --batch campaign.yml --budget 5.00 --budget-state spend.json
+
+  # See exactly what will be sent, including the system message
+  dox generate --type blog --prompt "Go generics" --show-prompt --dry-run
+
+  # Build up a running document across several generations
+  dox generate --prompt "This week's release notes" --output changelog.md --append
+
+  # List supported models with their context window and best use
+  dox generate --list-models --provider claude
+
+  # Compare two models side by side in one Markdown file
+  dox generate --prompt "Release notes" --compare-providers gpt-4o,claude-3-5-sonnet-latest --output compare.md
+
+  # Blog post with front matter for a static site generator
+  dox generate --type blog --prompt "Writing Go CLIs" --frontmatter "tags=go,cli,draft=true" --output post.md
+
+  # Save a generated outline as a PowerPoint deck, one slide per section
+  dox generate --prompt "Outline a kickoff talk: one ## heading per slide with bullets" --output kickoff.pptx
+
+  # Check which batch entries would be answered from the cache, without API calls
+  dox generate --batch campaign.yml --cache-check`,
 	RunE: runGenerate,
 }
 
@@ -68,22 +148,65 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 
 	generateCmd.Flags().StringVarP(&contentType, "type", "t", "custom", "Content type (blog|report|summary|email|proposal|custom)")
-	generateCmd.Flags().StringVarP(&prompt, "prompt", "p", "", "Generation prompt or file containing prompt (required)")
-	generateCmd.Flags().StringVarP(&genOutput, "output", "o", "", "Output file path")
-	generateCmd.Flags().StringVar(&model, "model", "", "AI model to use (auto-detect from name)")
+	generateCmd.Flags().StringVarP(&prompt, "prompt", "p", "", "Generation prompt, @file to read it from a file, or - to read it from standard input (required unless --batch)")
+	generateCmd.Flags().StringVar(&promptEncoding, "prompt-encoding", generate.PromptEncodingAuto, "Encoding of an @file prompt (auto|utf-8|utf-16le|utf-16be|windows-1252); auto detects a byte order mark or UTF-16")
+	generateCmd.Flags().StringVarP(&genOutput, "output", "o", "", "Output file path; a .pptx path saves the generated outline as slides")
+	generateCmd.Flags().StringVar(&model, "model", "", "AI model to use, or an alias such as fast or smart (provider auto-detected from the name)")
 	generateCmd.Flags().IntVar(&maxTokens, "max-tokens", 2000, "Maximum tokens for response")
-	generateCmd.Flags().Float64Var(&temperature, "temperature", 0.7, "Creativity level (0.0-2.0)")
+	generateCmd.Flags().Float64Var(&temperature, "temperature", 0.7, "Creativity level (OpenAI: 0.0-2.0, Claude: 0.0-1.0)")
+	generateCmd.Flags().Float64Var(&topP, "top-p", 0, "Nucleus sampling probability (0.0-1.0, 0 uses the provider default)")
 	generateCmd.Flags().StringVar(&provider, "provider", "", "AI provider (openai|claude, auto-detect if not specified)")
 	generateCmd.Flags().StringVar(&apiKey, "api-key", "", "API key (or use environment variables)")
 	generateCmd.Flags().StringVar(&claudeAPIKey, "claude-api-key", "", "Claude API key (or use ANTHROPIC_API_KEY env var)")
+	generateCmd.Flags().StringVar(&apiKeyFile, "api-key-file", "", "File containing the API key (keeps the key out of shell history)")
+	generateCmd.Flags().StringVar(&claudeAPIKeyFile, "claude-api-key-file", "", "File containing the Claude API key")
 	generateCmd.Flags().BoolVar(&noCache, "no-cache", false, "Disable caching of AI responses")
+	generateCmd.Flags().StringVar(&genLineEnding, "normalize-line-endings", string(export.LineEndingAuto), "Line endings of content written to --output: lf, crlf, or auto for the platform's")
+	generateCmd.Flags().StringVar(&frontmatterSpec, "frontmatter", "", "Prepend YAML front matter to the content: key=value pairs (e.g. \"tags=go,cli,draft=true\") or @file.yml; title and date default to the prompt's first line and today")
+	generateCmd.Flags().BoolVar(&cacheCheck, "cache-check", false, "Report the cache key of each --batch entry and whether it would be a cache hit, without calling the API or filling the cache")
 	generateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview operation without making API calls")
 	generateCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
-
-	generateCmd.MarkFlagRequired("prompt")
+	generateCmd.Flags().StringVar(&fallbackModel, "fallback-model", "", "Model to try if the primary provider fails (provider auto-detected)")
+	generateCmd.Flags().IntVar(&requestsPerMinute, "rpm", 0, "Maximum requests per minute (0 = no limit)")
+	generateCmd.Flags().IntVar(&tokensPerMinute, "tpm", 0, "Maximum tokens per minute, counting prompt and max-tokens (0 = no limit)")
+	generateCmd.Flags().Float64Var(&genBudget, "budget", 0, "Stop before any request whose estimated cost could take the session's spend past this many USD (0 = no limit)")
+	generateCmd.Flags().StringVar(&genBudgetState, "budget-state", "", "JSON file carrying the --budget spend across runs")
+	generateCmd.Flags().StringVar(&retryPreset, "retry-preset", "", "Retry preset (none|conservative|aggressive); retry settings in the config file still override it")
+	generateCmd.Flags().BoolVar(&truncatePrompt, "truncate-prompt", false, "Trim prompts that do not fit the model's context window instead of failing")
+	generateCmd.Flags().StringVar(&truncateFrom, "truncate-from", "tail", "Part of the prompt removed by --truncate-prompt (tail|middle)")
+	generateCmd.Flags().StringVar(&batchFile, "batch", "", "YAML file listing prompts to generate (entries: prompt, type, output, values)")
+	generateCmd.Flags().StringVar(&genLanguage, "language", "", "Language to write in, as a code or name (e.g. ko, Korean); default leaves it to the model")
+	generateCmd.Flags().StringVar(&genTone, "tone", "", "Writing tone (formal|casual|technical); default leaves it to the model")
+	generateCmd.Flags().StringVar(&promptPrefix, "prompt-prefix", "", "Text placed before every prompt, such as standard context (or @file)")
+	generateCmd.Flags().StringVar(&promptSuffix, "prompt-suffix", "", "Text placed after every prompt, such as standard constraints (or @file)")
+	generateCmd.Flags().IntVar(&maxOutputChars, "max-output-chars", 0, "Truncate generated content to at most this many characters before saving (0 = no limit)")
+	generateCmd.Flags().BoolVar(&truncateSentence, "truncate-at-sentence", false, "With --max-output-chars, cut at the end of the last complete sentence")
+	generateCmd.Flags().BoolVar(&showPrompt, "show-prompt", false, "Print the system message and the final prompt, with content-type instructions added, before generating")
+	generateCmd.Flags().BoolVar(&force, "force", false, "Overwrite existing output files")
+	generateCmd.Flags().BoolVar(&appendOutput, "append", false, "Add generated content to the end of existing output files, after a '---' separator")
+	generateCmd.Flags().BoolVar(&listModels, "list-models", false, "List the supported models with context window, max output and best use, then exit (limit with --provider)")
+	generateCmd.Flags().IntVarP(&repeatCount, "repeat", "n", 1, "Generate the prompt this many times, bypassing the cache, and save each variation to a numbered file (e.g. ideas_1.md)")
+	generateCmd.Flags().BoolVar(&combineRepeats, "combine", false, "With --repeat, save all variations to --output as one document, separated by '---'")
+	generateCmd.Flags().StringVar(&compareProviders, "compare-providers", "", "Generate the prompt with two comma-separated models concurrently and write both outputs side by side (e.g. gpt-4o,claude-3-5-sonnet-latest)")
+	generateCmd.Flags().StringVar(&outputTemplate, "output-template", "", "Name batch outputs from a pattern like \"post_{{topic}}_{{date}}.md\" for entries without an output (placeholders: entry values, type, index, date)")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
+	if listModels {
+		if provider != "" && provider != string(generate.ProviderOpenAI) && provider != string(generate.ProviderClaude) {
+			return pkgErrors.NewValidationError("provider", provider, "must be one of: openai, claude")
+		}
+		printModelList(os.Stdout, generate.GetModelInfo(generate.AIProvider(provider)), jsonOutput)
+		return nil
+	}
+
+	// Expand model aliases such as "fast" before detecting the provider
+	var aliases map[string]string
+	if appConfig != nil {
+		aliases = appConfig.Models
+	}
+	model = resolveModel(model, aliases)
+
 	// Auto-detect provider from model name if not specified
 	if provider == "" && model != "" {
 		provider = string(generate.DetectProviderFromModel(model))
@@ -104,21 +227,45 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		}
 	}
 	
+	// API 키 파일 플래그 (직접 지정한 키가 우선)
+	if apiKey == "" && apiKeyFile != "" {
+		key, err := readAPIKeyFile(apiKeyFile)
+		if err != nil {
+			return err
+		}
+		apiKey = key
+	}
+	if claudeAPIKey == "" && claudeAPIKeyFile != "" {
+		key, err := readAPIKeyFile(claudeAPIKeyFile)
+		if err != nil {
+			return err
+		}
+		claudeAPIKey = key
+	}
+	
 	// 설정 파일의 기본값 적용 (CLI 플래그가 우선)
 	if appConfig != nil {
 		// OpenAI API 키
-		if provider == "openai" && apiKey == "" && appConfig.OpenAI.APIKey != "" {
-			apiKey = appConfig.OpenAI.APIKey
+		if provider == "openai" && apiKey == "" {
+			key, err := configAPIKey(appConfig.OpenAI.APIKey, appConfig.OpenAI.APIKeyFile)
+			if err != nil {
+				return err
+			}
+			apiKey = key
 		}
 		
 		// Claude API 키 (설정 파일에서 읽기)
-		if provider == "claude" && claudeAPIKey == "" && appConfig.Claude.APIKey != "" {
-			claudeAPIKey = appConfig.Claude.APIKey
+		if provider == "claude" && claudeAPIKey == "" {
+			key, err := configAPIKey(appConfig.Claude.APIKey, appConfig.Claude.APIKeyFile)
+			if err != nil {
+				return err
+			}
+			claudeAPIKey = key
 		}
 		
 		// 다른 설정들: CLI 플래그가 설정되지 않은 경우 설정 파일 사용
 		if !cmd.Flags().Changed("model") && appConfig.Generate.Model != "" {
-			model = appConfig.Generate.Model
+			model = resolveModel(appConfig.Generate.Model, aliases)
 			// Re-detect provider from configured model
 			if !cmd.Flags().Changed("provider") {
 				provider = string(generate.DetectProviderFromModel(model))
@@ -133,6 +280,25 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		if !cmd.Flags().Changed("type") && appConfig.Generate.ContentType != "" {
 			contentType = appConfig.Generate.ContentType
 		}
+		if !cmd.Flags().Changed("fallback-model") && appConfig.Generate.FallbackModel != "" {
+			fallbackModel = appConfig.Generate.FallbackModel
+		}
+		if !cmd.Flags().Changed("rpm") && appConfig.Generate.RequestsPerMinute > 0 {
+			requestsPerMinute = appConfig.Generate.RequestsPerMinute
+		}
+		if !cmd.Flags().Changed("tpm") && appConfig.Generate.TokensPerMinute > 0 {
+			tokensPerMinute = appConfig.Generate.TokensPerMinute
+		}
+		if !cmd.Flags().Changed("prompt-prefix") && appConfig.Generate.PromptPrefix != "" {
+			promptPrefix = appConfig.Generate.PromptPrefix
+		}
+		if !cmd.Flags().Changed("prompt-suffix") && appConfig.Generate.PromptSuffix != "" {
+			promptSuffix = appConfig.Generate.PromptSuffix
+		}
+		
+		// Provider defaults override the generic settings above, once the
+		// provider is final: flag > provider default > generate > built-in
+		applyProviderDefaults(cmd, appConfig.ProviderDefaults(provider))
 	}
 	
 	// Select appropriate API key based on provider
@@ -147,61 +313,330 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		selectedAPIKey = apiKey
 	}
 	
+	// Load batch entries if a batch file was given
+	var batchEntries []generate.BatchEntry
+	if outputTemplate != "" && batchFile == "" {
+		return pkgErrors.NewValidationError("output-template", outputTemplate, "--output-template requires --batch")
+	}
+	if batchFile != "" {
+		entries, err := generate.LoadBatchFileWithOutputTemplate(batchFile, outputTemplate, contentType)
+		if err != nil {
+			return pkgErrors.NewFileError(batchFile, "loading batch", err)
+		}
+		if len(entries) == 0 {
+			return pkgErrors.NewValidationError("batch", batchFile, "batch file contains no entries")
+		}
+		// Read @file prompts once, so cache checks and generation see the same prompt
+		for i := range entries {
+			resolved, err := generate.ResolvePromptWithEncoding(entries[i].Prompt, promptEncoding)
+			if err != nil {
+				return err
+			}
+			entries[i].Prompt = resolved
+		}
+		batchEntries = entries
+	}
+
 	// Validate inputs
-	if prompt == "" {
+	if prompt == "" && batchFile == "" {
 		return pkgErrors.NewValidationError("prompt", prompt, "prompt is required")
 	}
 
 	// Validate content type
-	validTypes := []string{"blog", "report", "summary", "email", "proposal", "code", "custom"}
-	isValid := false
-	for _, t := range validTypes {
-		if contentType == t {
-			isValid = true
-			break
+	if err := validateContentType(contentType); err != nil {
+		return err
+	}
+	for _, entry := range batchEntries {
+		if entry.ContentType != "" {
+			if err := validateContentType(entry.ContentType); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := generate.ValidateTone(genTone); err != nil {
+		return err
+	}
+	if _, err := generate.ParsePromptEncoding(promptEncoding); err != nil {
+		return err
+	}
+	// Read "--prompt -" from standard input, decoded like an @file prompt
+	if prompt == stdinArg {
+		data, err := readInput(prompt)
+		if err != nil {
+			return pkgErrors.NewFileError(stdinName, "reading prompt", err)
+		}
+		text, err := generate.DecodePrompt(data, promptEncoding)
+		if err != nil {
+			return pkgErrors.NewFileError(stdinName, "reading prompt", err)
+		}
+		if strings.TrimSpace(text) == "" {
+			return pkgErrors.NewValidationError("prompt", stdinArg, "no prompt was given on standard input")
+		}
+		prompt = text
+	}
+	// Read @file prefixes and suffixes once, for every prompt they wrap
+	for _, text := range []*string{&promptPrefix, &promptSuffix} {
+		resolved, err := generate.ResolvePromptWithEncoding(*text, promptEncoding)
+		if err != nil {
+			return err
 		}
+		*text = resolved
+	}
+	if maxOutputChars < 0 {
+		return pkgErrors.NewValidationError("max-output-chars", maxOutputChars, "must be 0 or greater")
 	}
-	if !isValid {
-		return pkgErrors.NewValidationError("type", contentType, "must be one of: blog, report, summary, email, proposal, code, custom")
+
+	// Validate sampling parameters against the provider's accepted range
+	if err := generate.ValidateSamplingOptions(generate.AIProvider(provider), temperature, topP); err != nil {
+		return err
 	}
 
-	// Check if output file exists and force flag is not set
-	if genOutput != "" && !force {
-		if _, err := os.Stat(genOutput); err == nil {
-			return pkgErrors.NewFileError(genOutput, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+	var compareModels []string
+	if compareProviders != "" {
+		models, err := generate.ParseCompareModels(compareProviders)
+		if err != nil {
+			return err
+		}
+		for i := range models {
+			models[i] = resolveModel(models[i], aliases)
+		}
+		if strings.EqualFold(models[0], models[1]) {
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "both models resolve to "+models[0])
+		}
+		switch {
+		case batchFile != "":
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --batch")
+		case fallbackModel != "" && cmd.Flags().Changed("fallback-model"):
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --fallback-model")
+		case dryRun:
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --dry-run")
+		case cacheCheck:
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --cache-check")
+		case frontmatterSpec != "":
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --frontmatter")
+		case genBudget > 0:
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --budget")
+		case repeatCount > 1:
+			return pkgErrors.NewValidationError("compare-providers", compareProviders, "cannot be combined with --repeat")
+		}
+		compareModels = models
+	}
+
+	if frontmatterSpec != "" {
+		if appendOutput {
+			return pkgErrors.NewValidationError("frontmatter", frontmatterSpec, "cannot be combined with --append")
+		}
+		if _, err := generate.ParseFrontmatter(frontmatterSpec); err != nil {
+			return err
+		}
+	}
+
+	if _, err := export.ParseLineEnding(genLineEnding); err != nil {
+		return pkgErrors.NewValidationError("normalize-line-endings", genLineEnding, "must be one of: lf, crlf, auto")
+	}
+
+	if genBudget < 0 {
+		return pkgErrors.NewValidationError("budget", fmt.Sprintf("%.2f", genBudget), "--budget must be 0 (no limit) or more")
+	}
+	if genBudgetState != "" && genBudget == 0 {
+		return pkgErrors.NewValidationError("budget-state", genBudgetState, "--budget-state requires --budget")
+	}
+
+	if repeatCount < 1 {
+		return pkgErrors.NewValidationError("repeat", fmt.Sprint(repeatCount), "--repeat must be 1 or more")
+	}
+	if repeatCount > 1 {
+		switch {
+		case batchFile != "":
+			return pkgErrors.NewValidationError("repeat", fmt.Sprint(repeatCount), "--repeat cannot be combined with --batch")
+		case cacheCheck:
+			return pkgErrors.NewValidationError("repeat", fmt.Sprint(repeatCount), "--repeat cannot be combined with --cache-check")
+		}
+	}
+	if combineRepeats {
+		if repeatCount < 2 {
+			return pkgErrors.NewValidationError("combine", "true", "--combine requires --repeat 2 or more")
+		}
+		if genOutput == "" {
+			return pkgErrors.NewValidationError("combine", "true", "--combine requires --output")
+		}
+	}
+
+	if cacheCheck && noCache {
+		return pkgErrors.NewValidationError("cache-check", "true", "--cache-check cannot be combined with --no-cache")
+	}
+	// Responses are cached for one run only, so only the entries of a batch
+	// can be served from the cache
+	if cacheCheck && batchFile == "" {
+		return pkgErrors.NewValidationError("cache-check", "true", "--cache-check requires --batch; responses are only cached within one run")
+	}
+
+	if appendOutput {
+		if force {
+			return pkgErrors.NewValidationError("append", "true", "--append cannot be combined with --force")
+		}
+		if genOutput == "" && batchFile == "" {
+			return pkgErrors.NewValidationError("append", "true", "--append requires --output or --batch")
+		}
+	}
+
+	// Decks are built from the whole outline, so they cannot be appended
+	// to or carry front matter
+	pptxOutputs := []string{genOutput}
+	for _, entry := range batchEntries {
+		pptxOutputs = append(pptxOutputs, entry.Output)
+	}
+	for _, output := range pptxOutputs {
+		if !generate.IsPowerPointOutput(output) {
+			continue
+		}
+		if appendOutput {
+			return pkgErrors.NewValidationError("append", "true", "--append cannot write to PowerPoint output "+output)
+		}
+		if frontmatterSpec != "" {
+			return pkgErrors.NewValidationError("frontmatter", frontmatterSpec, "cannot be combined with PowerPoint output "+output)
+		}
+	}
+
+	// Check if output file exists and force flag is not set; appending
+	// adds to existing files instead
+	if genOutput != "" && !force && !appendOutput && batchFile == "" {
+		for _, output := range generatedOutputs() {
+			if _, err := os.Stat(output); err == nil {
+				return pkgErrors.NewFileError(output, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+			}
+		}
+	}
+	if !force && !appendOutput {
+		for _, entry := range batchEntries {
+			if _, err := os.Stat(entry.Output); err == nil {
+				return pkgErrors.NewFileError(entry.Output, "creating", fmt.Errorf("%w: use --force to overwrite", pkgErrors.ErrFileAlreadyExists))
+			}
 		}
 	}
 
 	// Create generator with API key and config
-	if verbose {
+	if ui.IsVerbose() {
 		ui.PrintInfo("Initializing %s client...", strings.Title(provider))
 		if !noCache {
 			ui.PrintInfo("Cache enabled for AI responses")
 		}
 	}
 	
-	generator, err := generate.NewGeneratorWithConfig(generate.AIProvider(provider), selectedAPIKey, appConfig)
+	// A retry preset applies to every provider; retry settings written in the
+	// config file still override the preset's values
+	genConfig, err := withRetryPreset(appConfig, retryPreset)
+	if err != nil {
+		return err
+	}
+
+	if compareModels != nil {
+		return runCompareProviders(genConfig, compareModels)
+	}
+
+	generator, err := generate.NewGeneratorWithConfig(generate.AIProvider(provider), selectedAPIKey, genConfig)
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrMissingAPIKey) {
 			// Use new coded error with localized message and solution
 			return pkgErrors.NewAPIKeyNotFoundError(provider)
 		}
-		return fmt.Errorf("failed to initialize generator: %w", err)
+		return fmt.Errorf("failed to initialize generator: %w", ui.RedactError(err))
 	}
 	
-	// Disable cache if requested
-	if noCache {
+	// Disable cache if requested; repeated calls must each reach the model to
+	// produce different variations
+	if noCache || repeatCount > 1 {
 		generator.DisableCache()
 	}
 
+	fallbackModel = resolveModel(fallbackModel, aliases)
+
+	// Throttle requests to stay under provider quotas
+	if err := generator.SetRateLimit(requestsPerMinute, tokensPerMinute); err != nil {
+		return err
+	}
+	if ui.IsVerbose() && (requestsPerMinute > 0 || tokensPerMinute > 0) {
+		ui.PrintInfo("Rate limit: %d requests/min, %d tokens/min (0 = unlimited)", requestsPerMinute, tokensPerMinute)
+	}
+
+	// Stop before spending more than the budget, across runs with a state file
+	if genBudget > 0 && !dryRun && !cacheCheck {
+		budget, err := generate.NewBudget(genBudget, genBudgetState)
+		if err != nil {
+			return err
+		}
+		generator.SetBudget(budget)
+		if ui.IsVerbose() {
+			ui.PrintInfo("Budget: $%.2f ($%.4f spent)", budget.Limit(), budget.Spent())
+			defer func() {
+				ui.PrintInfo("Budget: $%.4f of $%.2f spent", budget.Spent(), budget.Limit())
+			}()
+		}
+	}
+
+	// Configure fallback provider/model
+	if fallbackModel != "" && !dryRun {
+		fallbackProvider := generate.DetectProviderFromModel(fallbackModel)
+		fallbackKey, err := fallbackAPIKey(fallbackProvider)
+		if err != nil {
+			return err
+		}
+		if err := generator.SetFallback(fallbackModel, fallbackKey, genConfig); err != nil {
+			if errors.Is(err, pkgErrors.ErrMissingAPIKey) {
+				return pkgErrors.NewAPIKeyNotFoundError(string(fallbackProvider))
+			}
+			return fmt.Errorf("failed to initialize fallback model: %w", ui.RedactError(err))
+		}
+		if ui.IsVerbose() {
+			ui.PrintInfo("Fallback model: %s (%s)", fallbackModel, fallbackProvider)
+		}
+	}
+
+	if batchFile != "" {
+		return runBatchGenerate(generator, batchEntries)
+	}
+
+	// Read @file prompts up front so their size can be checked before any API call
+	resolvedPrompt, err := generate.ResolvePromptWithEncoding(prompt, promptEncoding)
+	if err != nil {
+		return err
+	}
+
+	// The prompt must leave room for max-tokens in the model's context window
+	estimator := generate.NewTokenEstimator(model)
+	tokenLimit, limitEnforced := generate.PromptLimitFor(model, maxTokens)
+	if truncatePrompt && !limitEnforced {
+		ui.PrintWarning("The context window of %s is not known well enough to truncate the prompt to (limit ~%d tokens); sending it whole", model, tokenLimit)
+	} else if truncatePrompt {
+		mode, err := generate.ParseTruncateMode(truncateFrom)
+		if err != nil {
+			return err
+		}
+		originalTokens := estimator.EstimateTokens(enhancePrompt(resolvedPrompt, contentType))
+		// Only the user prompt is truncated; the prefix, suffix and style
+		// instructions are kept whole
+		wrapperTokens := originalTokens - estimator.EstimateTokens(generate.EnhancePrompt(resolvedPrompt, contentType))
+		fitted, truncated, err := estimator.FitPrompt(resolvedPrompt, contentType, tokenLimit-wrapperTokens, mode)
+		if err != nil {
+			return pkgErrors.NewContextWindowExceededError(model, originalTokens, tokenLimit)
+		}
+		if truncated {
+			ui.PrintWarning("Prompt truncated from the %s to fit the context window (~%d -> ~%d tokens)",
+				mode, originalTokens, estimator.EstimateTokens(enhancePrompt(fitted, contentType)))
+		}
+		resolvedPrompt = fitted
+	} else if !dryRun {
+		if err := checkPromptFits(model, estimator.EstimateTokens(enhancePrompt(resolvedPrompt, contentType))); err != nil {
+			return err
+		}
+	}
+
 	// Enhance prompt based on content type
-	enhancedPrompt := generate.EnhancePrompt(prompt, contentType)
+	enhancedPrompt := enhancePrompt(resolvedPrompt, contentType)
 	
 	// Handle dry-run mode
 	if dryRun {
-		// Create token estimator
-		estimator := generate.NewTokenEstimator(model)
-		
 		// Estimate tokens
 		promptTokens := estimator.EstimateTokens(enhancedPrompt)
 		completionTokens := maxTokens // Use max tokens as estimate for completion
@@ -225,7 +660,12 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 				"provider":  provider,
 				"model":     model,
 				"contentType": contentType,
+				"language":    genLanguage,
+				"tone":        genTone,
+				"promptPrefix": promptPrefix,
+				"promptSuffix": promptSuffix,
 				"temperature": temperature,
+				"topP":        topP,
 				"maxTokens":   maxTokens,
 				"estimatedTokens": map[string]int{
 					"prompt":     promptTokens,
@@ -236,11 +676,16 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 					"amount":   cost,
 					"currency": currency,
 				},
-				"modelInfo": map[string]int{
+				"modelInfo": map[string]interface{}{
 					"contextWindow": modelInfo.ContextWindow,
 					"maxOutput":     modelInfo.MaxOutput,
+					"description":   modelInfo.Description,
+					"bestFor":       modelInfo.BestFor,
 				},
+				"repeat":     repeatCount,
 				"outputFile": genOutput,
+				"systemMessage": generate.SystemMessage(generate.AIProvider(provider), contentType),
+				"prompt":        enhancedPrompt,
 			}
 			
 			jsonBytes, _ := json.MarshalIndent(dryRunInfo, "", "  ")
@@ -253,13 +698,21 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 			ui.PrintInfo("Provider:  %s", provider)
 			ui.PrintInfo("")
 			
+			if showPrompt {
+				printPrompt(os.Stdout, generate.AIProvider(provider), contentType, enhancedPrompt)
+				fmt.Println("")
+			}
+			
 			fmt.Println(generate.FormatModelInfo(modelInfo))
 			fmt.Println("")
 			fmt.Println(generate.FormatCostEstimate(promptTokens, completionTokens, cost, currency))
+			if repeatCount > 1 {
+				ui.PrintInfo("Repeat: %d calls, estimated total $%.4f %s", repeatCount, cost*float64(repeatCount), currency)
+			}
 			
 			if genOutput != "" {
 				ui.PrintInfo("")
-				ui.PrintInfo("Output will be saved to: %s", genOutput)
+				ui.PrintInfo("Output will be saved to: %s", strings.Join(generatedOutputs(), ", "))
 			}
 			
 			ui.PrintInfo("")
@@ -269,9 +722,17 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	
-	if verbose {
+	if ui.IsVerbose() {
 		ui.PrintInfo("Generating %s content with %s model %s...", contentType, provider, model)
 		ui.PrintInfo("Temperature: %.2f, Max tokens: %d", temperature, maxTokens)
+		if topP > 0 {
+			ui.PrintInfo("Top-p: %.2f", topP)
+		}
+		// Prompts may contain sensitive content, so only a redacted preview is shown
+		ui.PrintInfo("Prompt: %s", ui.RedactPrompt(prompt))
+	}
+	if showPrompt {
+		printPrompt(os.Stdout, generate.AIProvider(provider), contentType, enhancedPrompt)
 	}
 
 	// Set generation options (provider-agnostic)
@@ -280,28 +741,39 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		Model:       model,
 		MaxTokens:   maxTokens,
 		Temperature: temperature,
+		TopP:        topP,
+	}
+
+	if repeatCount > 1 {
+		return runRepeatGenerate(generator, enhancedPrompt, resolvedPrompt, options)
 	}
 
 	// Generate content
-	if !quiet {
+	if !ui.IsQuiet() {
 		spinner := ui.NewSpinner(fmt.Sprintf("Generating %s content with %s...", contentType, provider))
 		defer spinner.Finish()
+		// Count down retry waits so a long backoff does not look like a hang
+		generator.SetOnRetry(func(attempt int, err error, delay time.Duration) {
+			spinner.Countdown(fmt.Sprintf("Attempt %d failed, retrying in", attempt), delay)
+		})
+		defer generator.SetOnRetry(nil)
 	}
 	
 	content, err := generator.GenerateContent(enhancedPrompt, options)
+	if generate.IsBudgetExceeded(err) {
+		return err
+	}
 	if err != nil {
-		return fmt.Errorf("failed to generate content: %w", err)
+		return fmt.Errorf("failed to generate content: %w", ui.RedactError(err))
+	}
+	content = limitOutput(content, genOutput)
+	if content, err = addFrontmatter(content, resolvedPrompt); err != nil {
+		return err
 	}
 
 	// Save to file if specified
 	if genOutput != "" {
-		// Check for force flag override for existing files
-		if force {
-			// Delete existing file first
-			os.Remove(genOutput)
-		}
-		
-		err = generate.SaveToFile(content, genOutput)
+		err = saveGenerated(content, genOutput)
 		if err != nil {
 			if !errors.Is(err, pkgErrors.ErrFileAlreadyExists) {
 				return err
@@ -313,9 +785,7 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("output file already exists: %s (use --force to overwrite)", genOutput)
 		}
 		
-		if !quiet {
-			ui.PrintSuccess("Content saved to: %s", genOutput)
-		}
+		ui.PrintSuccess("Content %s to: %s", savedVerb(), genOutput)
 	} else {
 		// Print to stdout if no output file specified
 		fmt.Println("\n--- Generated Content ---")
@@ -323,9 +793,16 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		fmt.Println("--- End of Content ---")
 	}
 
-	if verbose {
+	if ui.IsVerbose() {
 		ui.PrintSuccess("Generation completed successfully!")
 		
+		if servedProvider, servedModel := generator.ServedBy(); servedProvider != "" {
+			ui.PrintInfo("Served by: %s (%s)", servedProvider, servedModel)
+		}
+		if retries := generator.LastRetries(); retries.Retries > 0 {
+			ui.PrintInfo("Succeeded after %s", retries)
+		}
+		
 		// Show cache statistics if cache is enabled
 		if !noCache {
 			if stats := generator.GetCacheStats(); stats != nil {
@@ -336,4 +813,617 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// withRetryPreset returns cfg with the named retry preset selected for every
+// provider, leaving cfg itself unchanged. An empty name returns cfg as is.
+func withRetryPreset(cfg *config.Config, name string) (*config.Config, error) {
+	if name == "" {
+		return cfg, nil
+	}
+	if _, ok := retry.Preset(name); !ok {
+		return nil, pkgErrors.NewValidationError("retry-preset", name,
+			fmt.Sprintf("unknown retry preset (valid: %s)", strings.Join(retry.PresetNames(), ", ")))
+	}
+
+	withPreset := config.DefaultConfig()
+	if cfg != nil {
+		copied := *cfg
+		withPreset = &copied
+	}
+	withPreset.OpenAI.Retry.Preset = name
+	withPreset.Claude.Retry.Preset = name
+	return withPreset, nil
+}
+
+// runBatchGenerate generates every batch entry in order, showing progress with
+// speed and ETA across entries
+func runBatchGenerate(generator *generate.Generator, entries []generate.BatchEntry) error {
+	if cacheCheck {
+		return checkBatchCache(generator, entries)
+	}
+	if dryRun {
+		estimator := generate.NewTokenEstimator(model)
+		ui.PrintInfo("=== DRY-RUN MODE ===")
+		ui.PrintInfo("")
+		ui.PrintInfo("Operation: Generate %d batch entries with %s (%s)", len(entries), model, provider)
+		for i, entry := range entries {
+			entryType := batchEntryType(entry)
+			tokens := estimator.EstimateTokens(enhancePrompt(entry.Prompt, entryType))
+			ui.PrintInfo("  %d. [%s] %s (~%d prompt tokens)", i+1, entryType, entry.Output, tokens)
+			if showPrompt {
+				printPrompt(os.Stdout, generate.AIProvider(provider), entryType, enhancePrompt(entry.Prompt, entryType))
+			}
+		}
+		ui.PrintInfo("")
+		ui.PrintInfo("No API calls were made. Remove --dry-run to execute.")
+		return nil
+	}
+
+	var tracker *ui.ProgressTracker
+	if !ui.IsQuiet() {
+		tracker = ui.NewProgressTracker(len(entries), "Generating batch")
+		tracker.SetupGracefulShutdown()
+	}
+
+	var failed []string
+	var budgetErr error
+	for _, entry := range entries {
+		if tracker != nil && tracker.IsCancelled() {
+			ui.PrintWarning("Operation cancelled by user")
+			break
+		}
+
+		entryPrompt, options := batchRequest(entry)
+
+		var written int64
+		content, err := generator.GenerateContent(entryPrompt, options)
+		if err == nil {
+			content = limitOutput(content, entry.Output)
+			content, err = addFrontmatter(content, entry.Prompt)
+		}
+		if err == nil {
+			err = saveGenerated(content, entry.Output)
+			written = int64(len(content))
+		}
+		// An exhausted budget stops the batch instead of failing every
+		// remaining entry
+		if generate.IsBudgetExceeded(err) {
+			budgetErr = err
+			break
+		}
+		if err != nil {
+			failed = append(failed, entry.Output)
+			ui.PrintError("Failed to generate %s: %v", entry.Output, ui.RedactError(err))
+		} else if ui.IsVerbose() {
+			ui.PrintSuccess("Content %s to: %s", savedVerb(), entry.Output)
+			if retries := generator.LastRetries(); retries.Retries > 0 {
+				ui.PrintInfo("Succeeded after %s", retries)
+			}
+		}
+
+		if tracker != nil {
+			tracker.UpdateProgress(entry.Output, written)
+		}
+	}
+
+	if tracker != nil {
+		tracker.Finish()
+		ui.PrintInfo("%s", tracker.GetStats().String())
+	}
+
+	if budgetErr != nil {
+		return budgetErr
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("batch generation failed for %d of %d entries: %s", len(failed), len(entries), strings.Join(failed, ", "))
+	}
+
+	ui.PrintSuccess("Generated %d batch entries", len(entries))
+	return nil
+}
+
+// generatedOutputs returns the files a single-prompt generation writes:
+// --output, or one numbered file per variation with --repeat
+func generatedOutputs() []string {
+	if repeatCount < 2 || combineRepeats {
+		return []string{genOutput}
+	}
+	outputs := make([]string, repeatCount)
+	for i := range outputs {
+		outputs[i] = generate.VariationPath(genOutput, i+1)
+	}
+	return outputs
+}
+
+// runRepeatGenerate generates the prompt --repeat times and saves the
+// variations to numbered files, to one document with --combine, or prints
+// them, then reports the estimated tokens and cost of all calls
+func runRepeatGenerate(generator *generate.Generator, enhancedPrompt, resolvedPrompt string, options generate.GenerateOptions) error {
+	var tracker *ui.ProgressTracker
+	if !ui.IsQuiet() {
+		tracker = ui.NewProgressTracker(repeatCount, "Generating variations")
+	}
+	variations := generate.GenerateVariations(generator, enhancedPrompt, options, repeatCount, func(v generate.Variation) {
+		if tracker != nil {
+			tracker.UpdateProgress(fmt.Sprintf("variation %d", v.Index), int64(len(v.Content)))
+		}
+	})
+	if tracker != nil {
+		tracker.Finish()
+	}
+
+	var budgetErr error
+	succeeded := 0
+	for i := range variations {
+		v := &variations[i]
+		if v.Err != nil {
+			if generate.IsBudgetExceeded(v.Err) {
+				budgetErr = v.Err
+				continue
+			}
+			ui.PrintError("Variation %d failed: %v", v.Index, ui.RedactError(v.Err))
+			continue
+		}
+		succeeded++
+		path := genOutput
+		if genOutput != "" && !combineRepeats {
+			path = generate.VariationPath(genOutput, v.Index)
+		}
+		// --max-output-chars limits each variation, also when combined
+		v.Content = limitOutput(v.Content, path)
+		if combineRepeats {
+			continue
+		}
+		content, err := addFrontmatter(v.Content, resolvedPrompt)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			fmt.Printf("\n--- Variation %d ---\n", v.Index)
+			fmt.Println(content)
+			continue
+		}
+		if err := saveGenerated(content, path); err != nil {
+			return err
+		}
+		ui.PrintSuccess("Variation %d %s to: %s", v.Index, savedVerb(), path)
+	}
+
+	if combineRepeats && succeeded > 0 {
+		content, err := addFrontmatter(generate.FormatVariations(variations), resolvedPrompt)
+		if err != nil {
+			return err
+		}
+		if err := saveGenerated(content, genOutput); err != nil {
+			return err
+		}
+		ui.PrintSuccess("%d variations %s to: %s", succeeded, savedVerb(), genOutput)
+	}
+
+	promptTokens, completionTokens, cost := generate.SumVariations(variations)
+	_, currency := generate.NewTokenEstimator(options.Model).EstimateCost(0, 0)
+	ui.PrintInfo("%d of %d variations: ~%d prompt + ~%d output tokens, est. $%.4f %s",
+		succeeded, repeatCount, promptTokens, completionTokens, cost, currency)
+
+	if budgetErr != nil {
+		return budgetErr
+	}
+	if succeeded == 0 {
+		return fmt.Errorf("failed to generate content: %w", ui.RedactError(variations[0].Err))
+	}
+	return nil
+}
+
+// cacheCheckResult is the --cache-check outcome for one request
+type cacheCheckResult struct {
+	Output   string `json:"output,omitempty"`
+	Key      string `json:"key"`
+	Hit      bool   `json:"hit"`
+	CachedAt string `json:"cachedAt,omitempty"`
+	// SameAs is the earlier batch entry (1-based) with the same key; its
+	// response would be cached by the time this entry runs
+	SameAs int `json:"sameAsEntry,omitempty"`
+}
+
+// newCacheCheckResult converts a cache lookup for the output file
+func newCacheCheckResult(output string, check generate.CacheCheck) cacheCheckResult {
+	result := cacheCheckResult{Output: output, Key: check.Key, Hit: check.Hit}
+	if !check.CachedAt.IsZero() {
+		result.CachedAt = check.CachedAt.Format(time.RFC3339)
+	}
+	return result
+}
+
+// batchRequest returns the prompt and options a batch entry is generated
+// with, so the cache check looks up the same request the run makes
+func batchRequest(entry generate.BatchEntry) (string, generate.GenerateOptions) {
+	entryType := batchEntryType(entry)
+	return enhancePrompt(entry.Prompt, entryType), generate.GenerateOptions{
+		ContentType: entryType,
+		Model:       model,
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		TopP:        topP,
+	}
+}
+
+// checkBatchCache looks up every batch entry in the cache. A miss whose key
+// an earlier entry shares is reported as served from that entry's response,
+// which a real run would cache first.
+func checkBatchCache(generator *generate.Generator, entries []generate.BatchEntry) error {
+	results := make([]cacheCheckResult, 0, len(entries))
+	firstEntry := make(map[string]int)
+	for i, entry := range entries {
+		check, err := generator.CheckCache(batchRequest(entry))
+		if err != nil {
+			return err
+		}
+		result := newCacheCheckResult(entry.Output, check)
+		if first, seen := firstEntry[check.Key]; seen && !check.Hit {
+			result.SameAs = first
+		} else if !seen {
+			firstEntry[check.Key] = i + 1
+		}
+		results = append(results, result)
+	}
+	return writeCacheChecks(os.Stdout, results, jsonOutput)
+}
+
+// applyProviderDefaults sets --max-tokens and --temperature from the
+// configured defaults of the selected provider, unless given on the command line
+func applyProviderDefaults(cmd *cobra.Command, defaults config.ProviderDefaults) {
+	if !cmd.Flags().Changed("max-tokens") && defaults.MaxTokens > 0 {
+		maxTokens = defaults.MaxTokens
+	}
+	if !cmd.Flags().Changed("temperature") && defaults.Temperature != nil {
+		temperature = *defaults.Temperature
+	}
+}
+
+// writeCacheChecks prints the --cache-check results as text or JSON
+func writeCacheChecks(out io.Writer, results []cacheCheckResult, asJSON bool) error {
+	hits := 0
+	for _, result := range results {
+		if result.Hit || result.SameAs > 0 {
+			hits++
+		}
+	}
+
+	if asJSON {
+		jsonBytes, err := json.MarshalIndent(map[string]interface{}{
+			"operation": "cache-check",
+			"provider":  provider,
+			"model":     model,
+			"results":   results,
+			"summary": map[string]int{
+				"total":  len(results),
+				"hits":   hits,
+				"misses": len(results) - hits,
+			},
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	for i, result := range results {
+		status := "miss"
+		switch {
+		case result.Hit && result.CachedAt != "":
+			status = "hit (cached " + result.CachedAt + ")"
+		case result.Hit:
+			status = "hit"
+		case result.SameAs > 0:
+			status = fmt.Sprintf("hit after entry %d (same request)", result.SameAs)
+		}
+		fmt.Fprintf(out, "%3d. %s: %s\n     key %s\n", i+1, result.Output, status, result.Key)
+	}
+	if len(results) > 1 {
+		fmt.Fprintf(out, "%d of %d request(s) would be served from the cache\n", hits, len(results))
+	}
+	return nil
+}
+
+// runCompareProviders generates the prompt with both models concurrently and
+// writes the outputs as one Markdown document with a section per model.
+// Token counts and costs are estimates; it fails only when every model failed.
+func runCompareProviders(genConfig *config.Config, models []string) error {
+	resolvedPrompt, err := generate.ResolvePromptWithEncoding(prompt, promptEncoding)
+	if err != nil {
+		return err
+	}
+	enhancedPrompt := enhancePrompt(resolvedPrompt, contentType)
+
+	targets := make([]generate.CompareTarget, 0, len(models))
+	for _, compareModel := range models {
+		// The prompt must fit every model's context window
+		estimator := generate.NewTokenEstimator(compareModel)
+		if err := checkPromptFits(compareModel, estimator.EstimateTokens(enhancedPrompt)); err != nil {
+			return err
+		}
+
+		compareProvider := generate.DetectProviderFromModel(compareModel)
+		key, err := fallbackAPIKey(compareProvider)
+		if err != nil {
+			return err
+		}
+		generator, err := generate.NewGeneratorWithConfig(compareProvider, key, genConfig)
+		if err != nil {
+			if errors.Is(err, pkgErrors.ErrMissingAPIKey) {
+				return pkgErrors.NewAPIKeyNotFoundError(string(compareProvider))
+			}
+			return fmt.Errorf("failed to initialize generator for %s: %w", compareModel, ui.RedactError(err))
+		}
+		if noCache {
+			generator.DisableCache()
+		}
+		if err := generator.SetRateLimit(requestsPerMinute, tokensPerMinute); err != nil {
+			return err
+		}
+		targets = append(targets, generate.CompareTarget{Provider: compareProvider, Model: compareModel, Generator: generator})
+	}
+
+	// Both models get the same prompt; the system message differs by provider
+	if showPrompt {
+		for i, target := range targets {
+			if i == 0 || target.Provider != targets[0].Provider {
+				printPrompt(os.Stdout, target.Provider, contentType, enhancedPrompt)
+			}
+		}
+	}
+
+	options := generate.GenerateOptions{
+		ContentType: contentType,
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		TopP:        topP,
+	}
+
+	var spinner *ui.ProgressBar
+	if !ui.IsQuiet() {
+		spinner = ui.NewSpinner(fmt.Sprintf("Generating %s content with %s...", contentType, strings.Join(models, " and ")))
+	}
+	results := generate.CompareModels(enhancedPrompt, options, targets)
+	if spinner != nil {
+		spinner.Finish()
+	}
+
+	failed := 0
+	for i := range results {
+		if results[i].Err != nil {
+			failed++
+			continue
+		}
+		results[i].Content = limitOutput(results[i].Content, results[i].Model)
+	}
+	if failed == len(results) {
+		return fmt.Errorf("failed to generate content with %s: %w", strings.Join(models, " and "), ui.RedactError(results[0].Err))
+	}
+
+	comparison := generate.FormatComparison(results)
+	if genOutput != "" {
+		if err := saveGenerated(comparison, genOutput); err != nil {
+			return err
+		}
+		ui.PrintSuccess("Comparison %s to: %s", savedVerb(), genOutput)
+	} else {
+		fmt.Println(comparison)
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			ui.PrintWarning("%s (%s) failed: %v", r.Model, r.Provider, ui.RedactError(r.Err))
+			continue
+		}
+		ui.PrintInfo("%s (%s): %s, ~%d prompt + ~%d output tokens, est. $%.4f %s",
+			r.Model, r.Provider, ui.FormatDuration(r.Duration), r.PromptTokens, r.CompletionTokens, r.Cost, r.Currency)
+	}
+	return nil
+}
+
+// batchEntryType returns the entry's content type, defaulting to --type
+func batchEntryType(entry generate.BatchEntry) string {
+	if entry.ContentType != "" {
+		return entry.ContentType
+	}
+	return contentType
+}
+
+// enhancePrompt wraps a prompt in --prompt-prefix and --prompt-suffix and
+// enhances it for its content type with the --language and --tone
+// instructions
+func enhancePrompt(prompt string, contentType string) string {
+	return generate.EnhancePromptWithStyle(prompt, contentType, generate.PromptStyle{
+		Language: genLanguage,
+		Tone:     genTone,
+		Prefix:   promptPrefix,
+		Suffix:   promptSuffix,
+	})
+}
+
+// printPrompt writes the system message and the enhanced prompt exactly as
+// they will be sent for --show-prompt
+func printPrompt(w io.Writer, aiProvider generate.AIProvider, contentType string, enhancedPrompt string) {
+	fmt.Fprintln(w, "--- System Message ---")
+	fmt.Fprintln(w, generate.SystemMessage(aiProvider, contentType))
+	fmt.Fprintln(w, "--- Prompt ---")
+	fmt.Fprintln(w, enhancedPrompt)
+	fmt.Fprintln(w, "--- End of Prompt ---")
+}
+
+// printModelList writes the supported models for --list-models as a table
+// or as JSON
+func printModelList(w io.Writer, infos []generate.ModelInfo, asJSON bool) {
+	if asJSON {
+		type modelJSON struct {
+			Model         string `json:"model"`
+			Provider      string `json:"provider"`
+			Description   string `json:"description"`
+			BestFor       string `json:"bestFor"`
+			ContextWindow int    `json:"contextWindow"`
+			MaxOutput     int    `json:"maxOutput"`
+		}
+		models := make([]modelJSON, len(infos))
+		for i, info := range infos {
+			models[i] = modelJSON{
+				Model: info.Model, Provider: string(info.Provider), Description: info.Description,
+				BestFor: info.BestFor, ContextWindow: info.ContextWindow, MaxOutput: info.MaxOutput,
+			}
+		}
+		jsonBytes, _ := json.MarshalIndent(models, "", "  ")
+		fmt.Fprintln(w, string(jsonBytes))
+		return
+	}
+
+	width := len("Model")
+	for _, info := range infos {
+		width = max(width, len(info.Model))
+	}
+	fmt.Fprintf(w, "%-*s  %-8s %9s %10s  %s\n", width, "Model", "Provider", "Context", "Max output", "Best for")
+	for _, info := range infos {
+		fmt.Fprintf(w, "%-*s  %-8s %9d %10d  %s\n", width, info.Model, info.Provider, info.ContextWindow, info.MaxOutput, info.BestFor)
+	}
+}
+
+// resolveModel expands a model alias, reporting the expansion with --verbose
+func resolveModel(name string, aliases map[string]string) string {
+	resolved := generate.ResolveModelAlias(name, aliases)
+	if resolved != name && ui.IsVerbose() {
+		ui.PrintInfo("Model alias %q resolves to %s", name, resolved)
+	}
+	return resolved
+}
+
+// checkPromptFits fails with DOX305 when a prompt of promptTokens leaves less
+// than --max-tokens of model's context window. For models outside the catalog,
+// or when --max-tokens leaves no room at all, the window is not known well
+// enough to refuse the request, so it only warns.
+func checkPromptFits(model string, promptTokens int) error {
+	limit, enforced := generate.PromptLimitFor(model, maxTokens)
+	if promptTokens <= limit {
+		return nil
+	}
+	if enforced {
+		return pkgErrors.NewContextWindowExceededError(model, promptTokens, limit)
+	}
+	ui.PrintWarning("The prompt (~%d tokens) may not fit the context window of %s with --max-tokens %d; sending it anyway",
+		promptTokens, model, maxTokens)
+	return nil
+}
+
+// saveGenerated writes generated content to path with the line endings of
+// --normalize-line-endings, appending with --append and replacing an
+// existing file with --force. A .pptx path gets a deck built from the
+// content's outline instead.
+func saveGenerated(content string, path string) error {
+	if ending, err := export.ParseLineEnding(genLineEnding); err == nil {
+		content = export.NormalizeLineEndings(content, ending)
+	}
+	if appendOutput {
+		return generate.AppendToFile(content, path)
+	}
+	if force {
+		os.Remove(path)
+	}
+	if generate.IsPowerPointOutput(path) {
+		return generate.SaveToPowerPoint(content, path)
+	}
+	return generate.SaveToFile(content, path)
+}
+
+// savedVerb describes how saveGenerated wrote the output
+func savedVerb() string {
+	if appendOutput {
+		return "appended"
+	}
+	return "saved"
+}
+
+// addFrontmatter prepends the --frontmatter block, titled from prompt unless
+// a title is given, to generated content
+func addFrontmatter(content, prompt string) (string, error) {
+	if frontmatterSpec == "" {
+		return content, nil
+	}
+	fm, err := generate.ParseFrontmatter(frontmatterSpec)
+	if err != nil {
+		return "", err
+	}
+	return fm.Prepend(content, prompt, time.Now())
+}
+
+// limitOutput applies --max-output-chars to generated content, warning when
+// the content destined for output was shortened
+func limitOutput(content string, output string) string {
+	limited, truncated := generate.TruncateOutput(content, maxOutputChars, truncateSentence)
+	if truncated {
+		target := output
+		if target == "" {
+			target = "output"
+		}
+		ui.PrintWarning("Generated content for %s truncated from %d to %d characters (--max-output-chars %d)",
+			target, len([]rune(content)), len([]rune(limited)), maxOutputChars)
+	}
+	return limited
+}
+
+// validateContentType checks that a content type is supported
+func validateContentType(value string) error {
+	validTypes := []string{"blog", "report", "summary", "email", "proposal", "code", "custom"}
+	for _, t := range validTypes {
+		if value == t {
+			return nil
+		}
+	}
+	return pkgErrors.NewValidationError("type", value, "must be one of: blog, report, summary, email, proposal, code, custom")
+}
+
+// fallbackAPIKey selects the API key for the fallback provider. The generic
+// --api-key flag belongs to the primary provider, so it is only reused when
+// the fallback uses the same provider.
+func fallbackAPIKey(fallbackProvider generate.AIProvider) (string, error) {
+	switch fallbackProvider {
+	case generate.ProviderClaude:
+		if claudeAPIKey != "" {
+			return claudeAPIKey, nil
+		}
+		if provider == "claude" && apiKey != "" {
+			return apiKey, nil
+		}
+		if appConfig != nil {
+			return configAPIKey(appConfig.Claude.APIKey, appConfig.Claude.APIKeyFile)
+		}
+	default:
+		if provider == "openai" && apiKey != "" {
+			return apiKey, nil
+		}
+		if appConfig != nil {
+			return configAPIKey(appConfig.OpenAI.APIKey, appConfig.OpenAI.APIKeyFile)
+		}
+	}
+	return "", nil
+}
+
+// configAPIKey returns the key from the config file, reading api_key_file
+// when api_key is not set. An empty result falls through to environment variables.
+func configAPIKey(key, keyFile string) (string, error) {
+	if key != "" || keyFile == "" {
+		return key, nil
+	}
+	return readAPIKeyFile(keyFile)
+}
+
+// readAPIKeyFile reads an API key from path. Only the path is ever printed.
+func readAPIKeyFile(path string) (string, error) {
+	key, err := secrets.ReadAPIKeyFile(path)
+	if err != nil {
+		return "", err
+	}
+	if ui.IsVerbose() {
+		ui.PrintInfo("Using API key from file: %s", path)
+	}
+	return key, nil
+}
@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/pyhub/pyhub-docs/internal/config"
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/generate"
 	"github.com/spf13/cobra"
 )
 
@@ -94,6 +100,24 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("Cache Check Requires Batch", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		os.Setenv("OPENAI_API_KEY", "test-key")
+
+		prompt = "test prompt"
+		contentType = "blog"
+		cacheCheck = true
+		defer func() { cacheCheck = false }()
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "--cache-check requires --batch") {
+			t.Errorf("expected --cache-check without --batch to be rejected, got %v", err)
+		}
+	})
+
 	t.Run("Missing API Key Error", func(t *testing.T) {
 		buf := new(bytes.Buffer)
 		cmd := &cobra.Command{}
@@ -196,6 +220,106 @@ func TestGenerateCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("Context Window Overflow", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+
+		os.Setenv("OPENAI_API_KEY", "test-key")
+		promptFile := filepath.Join(t.TempDir(), "prompt.txt")
+		if err := os.WriteFile(promptFile, []byte(strings.Repeat("lorem ipsum dolor sit amet ", 2000)), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		prompt = "@" + promptFile
+		contentType = "custom"
+		model = "gpt-3.5-turbo"
+		provider = "openai"
+		apiKey = ""
+		maxTokens = 2000
+		genOutput = ""
+		dryRun = false
+		truncatePrompt = false
+		defer func() { prompt, model, provider = "", "", "" }()
+
+		// The overflow is reported before any API call is made
+		err := cmd.RunE(cmd, []string{})
+		if pkgErrors.GetErrorCode(err) != pkgErrors.ErrCodeContextWindowExceeded {
+			t.Errorf("expected context window error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Tone", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+
+		os.Setenv("OPENAI_API_KEY", "test-key")
+		prompt = "test prompt"
+		contentType = "blog"
+		provider = "openai"
+		genTone = "friendly"
+		defer func() { prompt, provider, genTone = "", "", "" }()
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "tone") {
+			t.Errorf("expected tone validation error, got %v", err)
+		}
+	})
+
+	t.Run("Compare Providers With Batch", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+
+		os.Setenv("OPENAI_API_KEY", "test-key")
+		prompt = "test prompt"
+		contentType = "blog"
+		provider = "openai"
+		compareProviders = "gpt-4o,claude-3-5-sonnet-latest"
+		dryRun = true
+		defer func() { prompt, provider, compareProviders, dryRun = "", "", "", false }()
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "--dry-run") {
+			t.Errorf("expected compare-providers validation error, got %v", err)
+		}
+	})
+
+	t.Run("Append With Force", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+
+		os.Setenv("OPENAI_API_KEY", "test-key")
+		prompt = "test prompt"
+		contentType = "blog"
+		provider = "openai"
+		genOutput = filepath.Join(t.TempDir(), "notes.md")
+		appendOutput = true
+		force = true
+		defer func() { prompt, provider, genOutput, appendOutput, force = "", "", "", false, false }()
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Errorf("expected append validation error, got %v", err)
+		}
+	})
+
+	t.Run("Append To PowerPoint", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		*cmd = *generateCmd
+
+		os.Setenv("OPENAI_API_KEY", "test-key")
+		prompt = "test prompt"
+		contentType = "blog"
+		provider = "openai"
+		genOutput = filepath.Join(t.TempDir(), "outline.pptx")
+		appendOutput = true
+		defer func() { prompt, provider, genOutput, appendOutput = "", "", "", false }()
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "PowerPoint") {
+			t.Errorf("expected PowerPoint append validation error, got %v", err)
+		}
+	})
+
 	t.Run("Cache Flag", func(t *testing.T) {
 		noCache = true
 		if !noCache {
@@ -209,3 +333,224 @@ func TestGenerateCommand(t *testing.T) {
 	})
 }
 
+
+func TestWithRetryPreset(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	withPreset, err := withRetryPreset(cfg, "none")
+	if err != nil {
+		t.Fatalf("withRetryPreset() error = %v", err)
+	}
+	if withPreset.OpenAI.Retry.Preset != "none" || withPreset.Claude.Retry.Preset != "none" {
+		t.Errorf("preset not applied to every provider: %+v, %+v", withPreset.OpenAI.Retry, withPreset.Claude.Retry)
+	}
+	if cfg.OpenAI.Retry.Preset != "" {
+		t.Error("withRetryPreset should not modify the loaded configuration")
+	}
+
+	if got, _ := withRetryPreset(nil, "aggressive"); got == nil || got.Claude.Retry.Preset != "aggressive" {
+		t.Error("a preset without a loaded configuration should start from the defaults")
+	}
+	if got, _ := withRetryPreset(cfg, ""); got != cfg {
+		t.Error("an empty preset should return the configuration unchanged")
+	}
+	if _, err := withRetryPreset(cfg, "reckless"); err == nil || !strings.Contains(err.Error(), "unknown retry preset") {
+		t.Errorf("expected unknown preset error, got %v", err)
+	}
+}
+
+func TestApplyProviderDefaults(t *testing.T) {
+	oldMaxTokens, oldTemperature := maxTokens, temperature
+	defer func() { maxTokens, temperature = oldMaxTokens, oldTemperature }()
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().IntVar(&maxTokens, "max-tokens", 2000, "")
+		cmd.Flags().Float64Var(&temperature, "temperature", 0.7, "")
+		return cmd
+	}
+	zero := 0.0
+
+	cmd := newCmd()
+	applyProviderDefaults(cmd, config.ProviderDefaults{MaxTokens: 4000, Temperature: &zero})
+	if maxTokens != 4000 || temperature != 0 {
+		t.Errorf("provider defaults not applied: max tokens %d, temperature %v", maxTokens, temperature)
+	}
+
+	cmd = newCmd()
+	applyProviderDefaults(cmd, config.ProviderDefaults{})
+	if maxTokens != 2000 || temperature != 0.7 {
+		t.Errorf("empty defaults changed settings: max tokens %d, temperature %v", maxTokens, temperature)
+	}
+
+	cmd = newCmd()
+	cmd.Flags().Set("max-tokens", "500")
+	cmd.Flags().Set("temperature", "1.2")
+	applyProviderDefaults(cmd, config.ProviderDefaults{MaxTokens: 4000, Temperature: &zero})
+	if maxTokens != 500 || temperature != 1.2 {
+		t.Errorf("flags should win over provider defaults: max tokens %d, temperature %v", maxTokens, temperature)
+	}
+}
+
+func TestEnhancePromptLanguageAndTone(t *testing.T) {
+	defer func() { genLanguage, genTone = "", "" }()
+
+	genLanguage, genTone = "", ""
+	if got := enhancePrompt("Hello", "custom"); got != "Hello" {
+		t.Errorf("enhancePrompt() without style = %q, want prompt unchanged", got)
+	}
+
+	genLanguage, genTone = "ko", "technical"
+	got := enhancePrompt("Hello", "custom")
+	if !strings.Contains(got, "Respond in Korean.") || !strings.Contains(got, "technical tone") {
+		t.Errorf("enhancePrompt() = %q, want language and tone instructions", got)
+	}
+}
+
+func TestEnhancePromptPrefixSuffix(t *testing.T) {
+	defer func() { promptPrefix, promptSuffix = "", "" }()
+
+	promptPrefix, promptSuffix = "Context: we sell tea.", "Keep it short."
+	if got := enhancePrompt("Hello", "custom"); got != "Context: we sell tea.\n\nHello\n\nKeep it short." {
+		t.Errorf("enhancePrompt() = %q, want the prompt wrapped in prefix and suffix", got)
+	}
+}
+
+func TestLimitOutput(t *testing.T) {
+	defer func() { maxOutputChars, truncateSentence = 0, false }()
+
+	maxOutputChars = 0
+	if got := limitOutput("Unlimited output.", "out.md"); got != "Unlimited output." {
+		t.Errorf("limitOutput() without a limit = %q", got)
+	}
+
+	maxOutputChars, truncateSentence = 20, true
+	if got := limitOutput("First sentence. Second sentence.", "out.md"); got != "First sentence." {
+		t.Errorf("limitOutput() = %q, want %q", got, "First sentence.")
+	}
+}
+
+func TestSaveGeneratedPowerPoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outline.pptx")
+	outline := "# Launch Plan\n\n## Goals\n\n- Ship the beta\n\n## Risks\n\n- Hiring\n"
+	if err := saveGenerated(outline, path); err != nil {
+		t.Fatalf("saveGenerated() error = %v", err)
+	}
+
+	doc, err := document.OpenPowerPointDocument(path)
+	if err != nil {
+		t.Fatalf("OpenPowerPointDocument() error = %v", err)
+	}
+	defer doc.Close()
+	text, err := doc.GetText()
+	if err != nil {
+		t.Fatalf("GetText() error = %v", err)
+	}
+	for _, want := range []string{"Launch Plan", "Goals", "Ship the beta", "Risks", "Hiring"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("deck text %q is missing %q", text, want)
+		}
+	}
+
+	if err := saveGenerated(outline, path); !errors.Is(err, pkgErrors.ErrFileAlreadyExists) {
+		t.Errorf("saveGenerated() over an existing deck error = %v, want ErrFileAlreadyExists", err)
+	}
+}
+
+func TestPrintPrompt(t *testing.T) {
+	defer func() { genLanguage = "" }()
+	genLanguage = "ko"
+
+	var buf bytes.Buffer
+	printPrompt(&buf, generate.ProviderClaude, "blog", enhancePrompt("Go generics", "blog"))
+	out := buf.String()
+
+	for _, want := range []string{
+		"--- System Message ---\n" + generate.SystemMessage(generate.ProviderClaude, "blog") + "\n",
+		"--- Prompt ---\n",
+		"Go generics",
+		"Respond in Korean.",
+		"--- End of Prompt ---\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("printPrompt() missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintModelList(t *testing.T) {
+	infos := []generate.ModelInfo{
+		{Model: "gpt-4", Provider: generate.ProviderOpenAI, BestFor: "Complex reasoning", ContextWindow: 8192, MaxOutput: 4096},
+		{Model: "claude-2.1", Provider: generate.ProviderClaude, BestFor: "Long documents", ContextWindow: 200000, MaxOutput: 4096},
+	}
+
+	buf := new(bytes.Buffer)
+	printModelList(buf, infos, false)
+	want := "Model       Provider   Context Max output  Best for\n" +
+		"gpt-4       openai        8192       4096  Complex reasoning\n" +
+		"claude-2.1  claude      200000       4096  Long documents\n"
+	if got := buf.String(); got != want {
+		t.Errorf("table = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	printModelList(buf, infos, true)
+	var models []struct {
+		Model         string `json:"model"`
+		Provider      string `json:"provider"`
+		ContextWindow int    `json:"contextWindow"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &models); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(models) != 2 || models[1].Provider != "claude" || models[1].ContextWindow != 200000 {
+		t.Errorf("models = %+v", models)
+	}
+}
+
+func TestWriteCacheChecks(t *testing.T) {
+	results := []cacheCheckResult{
+		{Output: "a.md", Key: "ai:openai:1", Hit: true, CachedAt: "2025-01-02T03:04:05Z"},
+		{Output: "b.md", Key: "ai:openai:2"},
+		{Output: "c.md", Key: "ai:openai:2", SameAs: 2},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := writeCacheChecks(buf, results, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"a.md: hit (cached 2025-01-02T03:04:05Z)",
+		"b.md: miss",
+		"c.md: hit after entry 2 (same request)",
+		"2 of 3 request(s) would be served from the cache",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	single := []cacheCheckResult{{Output: "d.md", Key: "ai:openai:3"}}
+	if err := writeCacheChecks(buf, single, false); err != nil {
+		t.Fatal(err)
+	}
+	if want := "  1. d.md: miss\n     key ai:openai:3\n"; buf.String() != want {
+		t.Errorf("single entry output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeCacheChecks(buf, results, true); err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Results []cacheCheckResult `json:"results"`
+		Summary map[string]int     `json:"summary"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(report.Results) != 3 || report.Summary["hits"] != 2 || report.Results[2].SameAs != 2 {
+		t.Errorf("report = %+v", report)
+	}
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectPath string
+	inspectJSON bool
+)
+
+// inspectCmd lists what a Word or PowerPoint package contains
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "List the parts, media and embedded objects of Word and PowerPoint files",
+	Long: `List what a Word or PowerPoint file bundles: its XML parts, media such as
+images and video, and embedded objects such as spreadsheets, OLE objects and
+fonts, each with its size. Use it to audit documents before sharing them or
+to find out why a file is large.
+
+Binary parts that are neither media nor embedded objects, such as thumbnails
+and macro projects, are listed with the embedded objects. Files are only read.
+
+Examples:
+  # What does a document contain?
+  dox inspect --path report.docx
+
+  # List the parts of a presentation as JSON
+  dox inspect --path deck.pptx --json`,
+	SilenceUsage: true,
+	RunE:         runInspect,
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+
+	inspectCmd.Flags().StringVarP(&inspectPath, "path", "p", "", "Word or PowerPoint file to inspect (required)")
+	inspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "Output the parts in JSON format")
+
+	inspectCmd.MarkFlagRequired("path")
+}
+
+// partLister is implemented by documents stored as zip packages
+type partLister interface {
+	ListParts() ([]document.PackagePart, error)
+}
+
+// packageContents is the inspection result of one file
+type packageContents struct {
+	Path  string                 `json:"path"`
+	Size  int64                  `json:"size"`
+	Parts []document.PackagePart `json:"parts"`
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	contents, err := inspectPackage(inspectPath)
+	if err != nil {
+		return err
+	}
+	return writePackageContents(cmd.OutOrStdout(), contents, inspectJSON)
+}
+
+// inspectPackage opens a Word or PowerPoint file and lists its parts
+func inspectPackage(path string) (packageContents, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return packageContents{}, pkgErrors.LocalizedFileNotFoundError(path)
+	}
+	if err != nil {
+		return packageContents{}, pkgErrors.NewFileError(path, "inspecting", err)
+	}
+	if !document.IsWordFile(path) && !document.IsPowerPointFile(path) {
+		return packageContents{}, pkgErrors.NewDocumentError(path, ext,
+			"inspection is only available for Word and PowerPoint files", pkgErrors.ErrUnsupportedFormat)
+	}
+
+	doc, err := document.Open(path)
+	if err != nil {
+		return packageContents{}, pkgErrors.NewDocumentError(path, ext, "failed to open document", err)
+	}
+	defer doc.Close()
+
+	lister, ok := doc.(partLister)
+	if !ok {
+		return packageContents{}, pkgErrors.NewDocumentError(path, ext,
+			"inspection is not supported for this format", pkgErrors.ErrUnsupportedFormat)
+	}
+	parts, err := lister.ListParts()
+	if err != nil {
+		return packageContents{}, pkgErrors.NewDocumentError(path, ext, "failed to list parts", err)
+	}
+	return packageContents{Path: path, Size: info.Size(), Parts: parts}, nil
+}
+
+// writePackageContents prints the parts grouped into XML parts, media and
+// embedded objects, or all of them as JSON
+func writePackageContents(out io.Writer, contents packageContents, asJSON bool) error {
+	if asJSON {
+		if contents.Parts == nil {
+			contents.Parts = []document.PackagePart{}
+		}
+		jsonBytes, err := json.MarshalIndent(contents, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s (%s)\n", contents.Path, ui.FormatFileSize(contents.Size))
+
+	var xmlParts, media, embedded []document.PackagePart
+	for _, part := range contents.Parts {
+		switch part.Kind {
+		case document.PartKindXML:
+			xmlParts = append(xmlParts, part)
+		case document.PartKindMedia:
+			media = append(media, part)
+		default:
+			embedded = append(embedded, part)
+		}
+	}
+
+	for _, group := range []struct {
+		title    string
+		parts    []document.PackagePart
+		showKind bool
+	}{
+		{"Parts", xmlParts, false},
+		{"Media", media, false},
+		{"Embedded objects", embedded, true},
+	} {
+		var total int64
+		width := 0
+		for _, part := range group.parts {
+			total += part.Size
+			width = max(width, len(part.Name))
+		}
+
+		fmt.Fprintln(out)
+		if len(group.parts) == 0 {
+			fmt.Fprintf(out, "%s: none\n", group.title)
+			continue
+		}
+		fmt.Fprintf(out, "%s (%d, %s):\n", group.title, len(group.parts), ui.FormatFileSize(total))
+		for _, part := range group.parts {
+			line := fmt.Sprintf("  %-*s  %10s", width, part.Name, ui.FormatFileSize(part.Size))
+			if group.showKind {
+				line += "  " + part.Kind
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestRunInspect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.docx")
+	writeZip(t, path, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>Budget</w:t></w:r></w:p></w:body></w:document>`,
+		"word/media/image1.png":                          strings.Repeat("p", 2048),
+		"word/embeddings/Microsoft_Excel_Worksheet.xlsx": strings.Repeat("x", 100),
+	})
+
+	defer func() { inspectPath, inspectJSON = "", false }()
+	inspectPath = path
+
+	buf := new(bytes.Buffer)
+	inspectCmd.SetOut(buf)
+	if err := runInspect(inspectCmd, nil); err != nil {
+		t.Fatalf("runInspect() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Parts (1, ",
+		"Media (1, 2.0 KB):\n  word/media/image1.png  ",
+		"Embedded objects (1, 100 B):\n  word/embeddings/Microsoft_Excel_Worksheet.xlsx       100 B  embedded\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	inspectJSON = true
+	if err := runInspect(inspectCmd, nil); err != nil {
+		t.Fatalf("runInspect() error = %v", err)
+	}
+	var contents packageContents
+	if err := json.Unmarshal(buf.Bytes(), &contents); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if contents.Path != path || len(contents.Parts) != 3 || contents.Parts[0].Kind != document.PartKindXML {
+		t.Errorf("contents = %+v", contents)
+	}
+}
+
+func TestRunInspectRejectsOtherFormats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	writeZip(t, path, map[string]string{"a.txt": "a"})
+
+	defer func() { inspectPath = "" }()
+	inspectPath = path
+	if err := runInspect(inspectCmd, nil); err == nil || !strings.Contains(err.Error(), "only available for Word and PowerPoint") {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+var metaJSON bool
+
+// metaCmd shows the document properties of Office files
+var metaCmd = &cobra.Command{
+	Use:   "meta <file>...",
+	Short: "Show authorship, revision and statistics of Word and PowerPoint files",
+	Long: `Show the document properties stored in Word and PowerPoint files: title,
+author, who saved the file last and when, the revision count and when it was
+last printed, together with the statistics written by the authoring
+application, such as total editing time, word count, and for presentations
+the slide count and slide titles.
+
+Files are only read. Properties the file does not record are not shown.
+
+Examples:
+  # Who wrote and last changed a document
+  dox meta report.docx
+
+  # Audit several files as JSON
+  dox meta *.docx *.pptx --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runMeta,
+}
+
+func init() {
+	rootCmd.AddCommand(metaCmd)
+
+	metaCmd.Flags().BoolVar(&metaJSON, "json", false, "Output the properties in JSON format")
+}
+
+// metadataReader is implemented by documents with docProps parts
+type metadataReader interface {
+	GetMetadata() (document.Metadata, error)
+}
+
+// fileMetadata is the metadata of one file
+type fileMetadata struct {
+	Path string `json:"path"`
+	document.Metadata
+}
+
+func runMeta(cmd *cobra.Command, args []string) error {
+	var files []fileMetadata
+	for _, path := range args {
+		meta, err := readFileMetadata(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, fileMetadata{Path: path, Metadata: meta})
+	}
+	return writeMetadata(cmd.OutOrStdout(), files, metaJSON)
+}
+
+// readFileMetadata opens a Word or PowerPoint file and reads its properties
+func readFileMetadata(path string) (document.Metadata, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return document.Metadata{}, pkgErrors.LocalizedFileNotFoundError(path)
+	}
+	if !document.IsWordFile(path) && !document.IsPowerPointFile(path) {
+		return document.Metadata{}, pkgErrors.NewDocumentError(path, ext,
+			"document properties are only available for Word and PowerPoint files", pkgErrors.ErrUnsupportedFormat)
+	}
+
+	doc, err := document.Open(path)
+	if err != nil {
+		return document.Metadata{}, pkgErrors.NewDocumentError(path, ext, "failed to open document", err)
+	}
+	defer doc.Close()
+
+	reader, ok := doc.(metadataReader)
+	if !ok {
+		return document.Metadata{}, pkgErrors.NewDocumentError(path, ext,
+			"document properties are not supported for this format", pkgErrors.ErrUnsupportedFormat)
+	}
+	meta, err := reader.GetMetadata()
+	if err != nil {
+		return document.Metadata{}, pkgErrors.NewDocumentError(path, ext, "failed to read document properties", err)
+	}
+	return meta, nil
+}
+
+// writeMetadata prints each file's properties as aligned text, or all of
+// them as a JSON array
+func writeMetadata(out io.Writer, files []fileMetadata, asJSON bool) error {
+	if asJSON {
+		if files == nil {
+			files = []fileMetadata{}
+		}
+		jsonBytes, err := json.MarshalIndent(files, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	for i, file := range files {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintln(out, file.Path)
+
+		meta := file.Metadata
+		fields := [][2]string{
+			{"Title", meta.Title},
+			{"Subject", meta.Subject},
+			{"Author", meta.Creator},
+			{"Last modified by", meta.LastModifiedBy},
+			{"Created", meta.Created},
+			{"Modified", meta.Modified},
+			{"Revision", meta.Revision},
+			{"Last printed", meta.LastPrinted},
+			{"Keywords", meta.Keywords},
+			{"Category", meta.Category},
+			{"Description", meta.Description},
+			{"Company", meta.Company},
+			{"Manager", meta.Manager},
+			{"Application", meta.Application},
+		}
+		for _, count := range []struct {
+			label string
+			value int
+		}{
+			{"Editing time (min)", meta.TotalTime},
+			{"Pages", meta.Pages},
+			{"Words", meta.Words},
+			{"Slides", meta.Slides},
+			{"Notes", meta.Notes},
+			{"Hidden slides", meta.HiddenSlides},
+		} {
+			if count.value > 0 {
+				fields = append(fields, [2]string{count.label, fmt.Sprint(count.value)})
+			}
+		}
+
+		shown := 0
+		for _, field := range fields {
+			if field[1] != "" {
+				fmt.Fprintf(out, "  %-19s %s\n", field[0]+":", field[1])
+				shown++
+			}
+		}
+		if len(meta.SlideTitles) > 0 {
+			fmt.Fprintln(out, "  Slide titles:")
+			for n, title := range meta.SlideTitles {
+				fmt.Fprintf(out, "    %d. %s\n", n+1, title)
+			}
+			shown++
+		}
+		if shown == 0 {
+			fmt.Fprintln(out, "  No document properties recorded")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetaCommand(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.docx")
+	writeZip(t, path, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>Hi</w:t></w:r></w:p></w:body></w:document>`,
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">` +
+			`<dc:title>Annual Report</dc:title><dc:creator>Jane Doe</dc:creator><cp:lastModifiedBy>John Roe</cp:lastModifiedBy>` +
+			`<cp:revision>12</cp:revision><dcterms:modified>2024-02-03T04:05:06Z</dcterms:modified></cp:coreProperties>`,
+		"docProps/app.xml": `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties"><Application>Microsoft Office Word</Application><TotalTime>95</TotalTime><Pages>3</Pages></Properties>`,
+	})
+	bare := filepath.Join(dir, "bare.docx")
+	writeZip(t, bare, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body/></w:document>`,
+	})
+	defer func() { metaJSON = false }()
+
+	t.Run("text", func(t *testing.T) {
+		metaJSON = false
+		buf := new(bytes.Buffer)
+		metaCmd.SetOut(buf)
+
+		if err := runMeta(metaCmd, []string{path, bare}); err != nil {
+			t.Fatalf("runMeta failed: %v", err)
+		}
+		want := path + "\n" +
+			"  Title:              Annual Report\n" +
+			"  Author:             Jane Doe\n" +
+			"  Last modified by:   John Roe\n" +
+			"  Modified:           2024-02-03T04:05:06Z\n" +
+			"  Revision:           12\n" +
+			"  Application:        Microsoft Office Word\n" +
+			"  Editing time (min): 95\n" +
+			"  Pages:              3\n" +
+			"\n" + bare + "\n" +
+			"  No document properties recorded\n"
+		if got := buf.String(); got != want {
+			t.Errorf("output =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		metaJSON = true
+		buf := new(bytes.Buffer)
+		metaCmd.SetOut(buf)
+
+		if err := runMeta(metaCmd, []string{path}); err != nil {
+			t.Fatalf("runMeta failed: %v", err)
+		}
+		var files []map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &files); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+		}
+		if len(files) != 1 || files[0]["path"] != path || files[0]["lastModifiedBy"] != "John Roe" || files[0]["revision"] != "12" {
+			t.Errorf("unexpected JSON: %v", files)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		rtf := filepath.Join(dir, "notes.rtf")
+		writeZip(t, rtf, nil)
+		if err := runMeta(metaCmd, []string{rtf}); err == nil || !strings.Contains(err.Error(), "Word and PowerPoint") {
+			t.Errorf("expected unsupported format error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/generate"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pingProvider   string
+	pingAPIKey     string
+	pingAPIKeyFile string
+	pingRetries    int
+	pingTimeout    time.Duration
+)
+
+// maxListedModels is how many model names are shown without --verbose
+const maxListedModels = 5
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check connectivity and API key for an AI provider",
+	Long: `Check that an AI provider is reachable and accepts your API key before
+starting a long generation run.
+
+The check lists the models available to the key, which consumes no tokens.
+It reports the latency and the number of available models, and exits with a
+non-zero status if the key is rejected or the service cannot be reached.
+
+Examples:
+  dox ping
+  dox ping --provider claude
+  dox ping --provider openai --api-key-file ~/.pyhub/openai.key`,
+	Args: cobra.NoArgs,
+	RunE: runPing,
+}
+
+func init() {
+	rootCmd.AddCommand(pingCmd)
+
+	pingCmd.Flags().StringVar(&pingProvider, "provider", "openai", "AI provider to check (openai|claude)")
+	pingCmd.Flags().StringVar(&pingAPIKey, "api-key", "", "API key (or use config file / environment variables)")
+	pingCmd.Flags().StringVar(&pingAPIKeyFile, "api-key-file", "", "File containing the API key")
+	pingCmd.Flags().IntVar(&pingRetries, "retries", 1, "Maximum number of retries")
+	pingCmd.Flags().DurationVar(&pingTimeout, "timeout", 30*time.Second, "Overall time limit for the check")
+}
+
+func runPing(cmd *cobra.Command, args []string) error {
+	provider := generate.AIProvider(strings.ToLower(pingProvider))
+	if provider != generate.ProviderOpenAI && provider != generate.ProviderClaude {
+		return pkgErrors.NewValidationError("provider", pingProvider, "must be one of: openai, claude")
+	}
+
+	key, err := pingKey(provider)
+	if err != nil {
+		return err
+	}
+
+	generator, err := generate.NewGeneratorWithConfig(provider, key, appConfig)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrMissingAPIKey) {
+			return pkgErrors.NewAPIKeyNotFoundError(string(provider))
+		}
+		return fmt.Errorf("failed to initialize %s client: %w", provider, ui.RedactError(err))
+	}
+
+	if ui.IsVerbose() {
+		ui.PrintInfo("Checking %s...", provider)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	result, err := generator.Ping(ctx, pingRetries)
+	if err != nil {
+		return ui.RedactError(err)
+	}
+
+	ui.PrintSuccess("%s is reachable (latency %s)", provider, result.Latency.Round(time.Millisecond))
+	if ui.IsQuiet() {
+		return nil
+	}
+
+	if len(result.Models) == 0 {
+		ui.PrintWarning("No models are available to this API key")
+		return nil
+	}
+	ui.PrintInfo("%d model(s) available", len(result.Models))
+
+	shown := result.Models
+	if !ui.IsVerbose() && len(shown) > maxListedModels {
+		shown = shown[:maxListedModels]
+	}
+	for _, model := range shown {
+		fmt.Printf("  - %s\n", model)
+	}
+	if len(shown) < len(result.Models) {
+		fmt.Printf("  ... and %d more (use --verbose to list all)\n", len(result.Models)-len(shown))
+	}
+
+	return nil
+}
+
+// pingKey resolves the API key like generate does: flag, key file, config
+// file, then environment variables (the latter are read by the generator)
+func pingKey(provider generate.AIProvider) (string, error) {
+	if pingAPIKey != "" {
+		return pingAPIKey, nil
+	}
+	if pingAPIKeyFile != "" {
+		return readAPIKeyFile(pingAPIKeyFile)
+	}
+	if appConfig == nil {
+		return "", nil
+	}
+	if provider == generate.ProviderClaude {
+		return configAPIKey(appConfig.Claude.APIKey, appConfig.Claude.APIKeyFile)
+	}
+	return configAPIKey(appConfig.OpenAI.APIKey, appConfig.OpenAI.APIKeyFile)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPingCommand(t *testing.T) {
+	found := false
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "ping" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("ping command not registered with root command")
+	}
+
+	for _, name := range []string{"provider", "api-key", "api-key-file", "retries", "timeout"} {
+		if pingCmd.Flags().Lookup(name) == nil {
+			t.Errorf("--%s flag not defined", name)
+		}
+	}
+
+	pingProvider = "gemini"
+	defer func() { pingProvider = "openai" }()
+	if err := runPing(pingCmd, nil); err == nil {
+		t.Error("expected error for unsupported provider")
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pyhub/pyhub-docs/internal/document"
@@ -17,7 +22,7 @@ import (
 )
 
 var (
-	rulesFile       string
+	rulesFiles      []string
 	targetPath      string
 	replaceDryRun   bool
 	backup          bool
@@ -27,15 +32,38 @@ var (
 	maxWorkers      int
 	replaceJsonOutput bool
 	showDiff        bool
+	diffContext     int
+	diffFormat      string
+	diffOutput      string
 	enableStreaming bool
 	memoryMonitor   bool
+	slideRange      string
+	strictRules     bool
+	dedupeRules     bool
+	strictXML       bool
+	maxRuleReplace  int
+	stripMetadata   bool
+	stateFile       string
+	manifestFile    string
+	normalizeRules  bool
+	replaceWatch    bool
+	includeGlobs    string
+	measurePhases   bool
+	replaceHidden   bool
+	replaceParts    string
+	failFast        bool
+	dumpXMLDir      string
+	showSkipped     bool
+	keepBackups     int
+	allParts        bool
+	timeoutPerFile  time.Duration
 )
 
 // replaceCmd represents the replace command
 var replaceCmd = &cobra.Command{
 	Use:   "replace",
 	Short: "Replace text in documents using rules from a YAML file",
-	Long: `Replace text in Word and PowerPoint documents based on rules defined in a YAML file.
+	Long: `Replace text in Word, PowerPoint and RTF documents based on rules defined in a YAML file.
 
 The rules file should contain replacement pairs:
   - old: "old text"
@@ -43,6 +71,19 @@ The rules file should contain replacement pairs:
   - old: "v1.0.0"
     new: "v2.0.0"
 
+Rules may also carry a description, and can be turned off without deleting them:
+  - old: "ACME Corp"
+    new: "ACME Inc."
+    description: "Company renamed in 2024"
+    enabled: false
+
+Word turns straight quotes into curly quotes and may use non-breaking spaces
+or ligatures. Set "normalize: true" on a rule, or pass --normalize, to match
+such text as if it were typed plainly; only the matched text is replaced:
+  - old: '"v1.0"'
+    new: '"v2.0"'
+    normalize: true
+
 Examples:
   # Replace text in a single file
   dox replace --rules rules.yml --path document.docx
@@ -53,36 +94,229 @@ Examples:
   # Dry run to preview changes
   dox replace --rules rules.yml --path ./docs --dry-run
 
+  # Write proposed changes to a Markdown report for review
+  dox replace --rules rules.yml --path ./docs --diff-output changes.md
+
+  # Merge rule sets; brand.yml overrides terms.yml where both replace the same text
+  dox replace --rules terms.yml --rules brand.yml --path ./docs
+
   # Create backups before modifying
-  dox replace --rules rules.yml --path ./docs --backup`,
+  dox replace --rules rules.yml --path ./docs --backup
+
+  # Keep only the 5 most recent backups
+  dox replace --rules rules.yml --path ./docs --backup --keep-backups 5
+
+  # Apply rules produced by another tool
+  generate-rules | dox replace --rules - --path ./docs
+
+  # Keep going past documents that take more than a minute each
+  dox replace --rules rules.yml --path ./docs --timeout-per-file 1m
+
+  # Only replace on the title slide and slides 3 to 5 of a presentation
+  dox replace --rules rules.yml --path deck.pptx --slides 1,3-5
+
+  # Resume an interrupted run over a large tree
+  dox replace --rules rules.yml --path ./docs --state-file progress.json
+
+  # Skip documents unchanged since the last run with the same rules
+  dox replace --rules rules.yml --path ./docs --manifest .dox-manifest.json
+
+  # Confirm which files were left out and why
+  dox replace --rules rules.yml --path ./docs --exclude "draft*" --show-skipped
+
+  # Remove author and company properties before sharing
+  dox replace --rules rules.yml --path ./docs --strip-metadata
+
+  # See where time goes: opening, matching or saving
+  dox replace --rules rules.yml --path ./docs --measure
+
+  # Keep documents normalized while editing them (Ctrl+C to stop)
+  dox replace --rules rules.yml --path ./docs --watch --include "*.docx"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate inputs
-		if rulesFile == "" {
-			return pkgErrors.NewValidationError("rules", rulesFile, "rules file is required")
+		if len(rulesFiles) == 0 {
+			return pkgErrors.NewValidationError("rules", "", "rules file is required")
 		}
 		if targetPath == "" {
 			return pkgErrors.NewValidationError("path", targetPath, "target path is required")
 		}
 
-		// Load rules from YAML file
-		rules, err := replace.LoadRulesFromFile(rulesFile)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return pkgErrors.NewFileError(rulesFile, "loading rules", pkgErrors.ErrFileNotFound)
+		if err := checkSingleStdin("rules", rulesFiles...); err != nil {
+			return err
+		}
+
+		// Load rules from each YAML file, or standard input for "-"; later
+		// files override earlier rules with the same old text
+		var sourced []replace.SourcedRule
+		for _, rulesFile := range rulesFiles {
+			fileRules, err := loadRules(rulesFile)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return pkgErrors.NewFileError(rulesFile, "loading rules", pkgErrors.ErrFileNotFound)
+				}
+				return pkgErrors.NewFileError(rulesFile, "loading rules", err)
+			}
+			source := rulesFile
+			if rulesFile == stdinArg {
+				source = stdinName
+			}
+			sourced = replace.MergeRules(sourced, source, fileRules)
+		}
+
+		// Drop repeated rules and report contradictory ones; merged rule
+		// files are always cleaned this way
+		if dedupeRules || len(rulesFiles) > 1 {
+			deduped, conflicts := replace.DedupeRules(sourced)
+			if removed := len(sourced) - len(deduped); removed > 0 && ui.IsVerbose() {
+				ui.PrintInfo("Removed %d duplicate rule(s)", removed)
+			}
+			if err := replace.CheckRuleConflicts(conflicts, strictRules); err != nil {
+				return err
 			}
-			return pkgErrors.NewFileError(rulesFile, "loading rules", err)
+			sourced = deduped
 		}
+		rules := replace.RulesOf(sourced)
 
 		if len(rules) == 0 {
-			ui.PrintWarning("No replacement rules found in the file")
+			ui.PrintWarning("No replacement rules found in the rules file(s)")
 			return nil
 		}
+		if disabled := len(rules) - len(replace.EnabledRules(rules)); disabled > 0 {
+			if disabled == len(rules) {
+				ui.PrintWarning("All %d replacement rules are disabled", disabled)
+				return nil
+			}
+			if ui.IsVerbose() || replaceDryRun {
+				ui.PrintInfo("Skipping %d disabled rule(s)", disabled)
+			}
+		}
 
-		// Print rules if in dry-run mode
-		if replaceDryRun {
+		// Match every rule ignoring smart quotes, non-breaking spaces and ligatures
+		if normalizeRules {
+			rules = replace.WithNormalization(rules)
+		}
+
+		// Watch mode applies rules as files change, so it cannot preview or resume
+		if replaceWatch {
+			if replaceDryRun || diffOutput != "" {
+				return pkgErrors.NewValidationError("watch", "true", "--watch cannot be combined with --dry-run or --diff-output")
+			}
+			if stateFile != "" {
+				return pkgErrors.NewValidationError("watch", "true", "--watch cannot be combined with --state-file")
+			}
+			if manifestFile != "" {
+				return pkgErrors.NewValidationError("watch", "true", "--watch cannot be combined with --manifest")
+			}
+		}
+
+		switch diffFormat {
+		case diffFormatColor:
+		case diffFormatUnified:
+			// Choosing a diff format asks for the diff
+			showDiff = true
+		default:
+			return pkgErrors.NewValidationError("diff-format", diffFormat, "must be one of: color, unified")
+		}
+
+		if keepBackups < 0 {
+			return pkgErrors.NewValidationError("keep-backups", fmt.Sprint(keepBackups), "--keep-backups must be 0 (keep all) or more")
+		}
+		if keepBackups > 0 && !backup {
+			return pkgErrors.NewValidationError("keep-backups", fmt.Sprint(keepBackups), "--keep-backups requires --backup")
+		}
+
+		// Replacing in every part has no part or slide selection
+		if allParts {
+			if replaceParts != "" {
+				return pkgErrors.NewValidationError("all-parts", "true", "--all-parts cannot be combined with --parts")
+			}
+			if slideRange != "" {
+				return pkgErrors.NewValidationError("all-parts", "true", "--all-parts cannot be combined with --slides")
+			}
+		}
+
+		if timeoutPerFile < 0 {
+			return pkgErrors.NewValidationError("timeout-per-file", timeoutPerFile.String(), "--timeout-per-file must be 0 (no limit) or more")
+		}
+		// All-parts replacement rewrites documents as it goes, so it cannot be given up on
+		if timeoutPerFile > 0 && allParts {
+			return pkgErrors.NewValidationError("timeout-per-file", timeoutPerFile.String(), "--timeout-per-file cannot be combined with --all-parts")
+		}
+
+		// Writing a change report only previews the replacements
+		if diffOutput != "" {
+			replaceDryRun = true
+		}
+
+		// Parse slide selection for PowerPoint files
+		replaceOpts := replace.ReplaceOptions{Strict: strictRules, StripMetadata: stripMetadata, IncludeHiddenText: replaceHidden, FailFast: failFast, StrictXML: strictXML, MaxRuleReplacements: maxRuleReplace, AllParts: allParts, FileTimeout: timeoutPerFile}
+
+		// Debugging aid for bug reports: keep the XML each replacement saw and produced
+		if dumpXMLDir != "" && !replaceDryRun {
+			replaceOpts.DumpXMLDir = dumpXMLDir
+			ui.PrintWarning("Debug: writing document XML before and after replacement to %s", dumpXMLDir)
+		}
+		// List the files a directory run leaves out and why
+		if showSkipped {
+			replaceOpts.Skipped = replace.NewSkipLog()
+		}
+		if slideRange != "" {
+			slides, err := document.ParseSlideRange(slideRange)
+			if err != nil {
+				return err
+			}
+			replaceOpts.Slides = slides
+		}
+		if replaceParts != "" {
+			parts, err := document.ParseWordParts(replaceParts)
+			if err != nil {
+				return err
+			}
+			replaceOpts.Parts = parts
+		}
+
+		// Time the open, replace and save phases of every document
+		if measurePhases {
+			replaceOpts.Timings = replace.NewTimings()
+			started := time.Now()
+			defer func() {
+				printTimings(os.Stdout, replaceOpts.Timings, time.Since(started), replaceJsonOutput)
+			}()
+		}
+
+		// Resume from a previous run's state file
+		if stateFile != "" && !replaceDryRun {
+			state, err := replace.LoadState(stateFile, rules, replaceOpts)
+			if err != nil {
+				return pkgErrors.NewFileError(stateFile, "loading state", err)
+			}
+			replaceOpts.State = state
+		}
+
+		// Skip documents unchanged since the run that wrote the manifest
+		if manifestFile != "" && !replaceDryRun {
+			manifest, err := replace.LoadManifest(manifestFile, rules, replaceOpts)
+			if err != nil {
+				return pkgErrors.NewFileError(manifestFile, "loading manifest", err)
+			}
+			replaceOpts.Manifest = manifest
+		}
+
+		// Print rules if in dry-run mode; a unified diff is printed alone
+		if replaceDryRun && diffFormat != diffFormatUnified {
 			ui.PrintHeader("Replacement Rules to Apply")
 			for i, rule := range rules {
-				ui.PrintStep(i+1, len(rules), fmt.Sprintf("Replace '%s' with '%s'", rule.Old, rule.New))
+				text := describeRule(rule)
+				if len(rulesFiles) > 1 {
+					text += describeRuleSource(sourced[i])
+				}
+				ui.PrintStep(i+1, len(rules), text)
+			}
+		}
+		if replaceDryRun {
+			// Surface rule conflicts in the preview
+			if err := replace.CheckCollisions(rules, strictRules); err != nil {
+				return err
 			}
 		}
 
@@ -100,22 +334,36 @@ Examples:
 
 		// Create backup if requested
 		if backup && !replaceDryRun {
-			if !quiet {
-				ui.PrintInfo("Creating backup of %s...", targetPath)
-			}
+			ui.PrintInfo("Creating backup of %s...", targetPath)
 			if err := createBackup(targetPath, info.IsDir()); err != nil {
 				return pkgErrors.NewFileError(targetPath, "creating backup", err)
 			}
-			if !quiet {
-				ui.PrintSuccess("Backup created successfully")
+			ui.PrintSuccess("Backup created successfully")
+
+			// Pruning is housekeeping; the new backup exists either way
+			removed, err := pruneBackups(targetPath, info.IsDir(), keepBackups)
+			if err != nil {
+				ui.PrintWarning("Failed to remove old backups: %v", err)
+			} else if len(removed) > 0 {
+				ui.PrintInfo("Removed %d old backup(s), keeping the %d most recent", len(removed), keepBackups)
 			}
 		}
 
+		if replaceWatch {
+			return watchReplacements(targetPath, rules, replaceOpts)
+		}
+
 		// Process based on target type
 		if info.IsDir() {
+			// A mixed directory gets different rules per format
+			if ui.IsVerbose() {
+				for _, format := range replace.RuleFormats(rules) {
+					ui.PrintInfo("%d rule(s) apply to .%s documents", len(replace.RulesForFormat(rules, format)), format)
+				}
+			}
 			// Process directory
 			if replaceDryRun {
-				return previewDirectoryReplacements(targetPath, rules, recursive)
+				return previewDirectoryReplacements(targetPath, rules, recursive, replaceOpts.Skipped)
 			}
 			
 			var results []replace.ReplaceResult
@@ -127,16 +375,26 @@ Examples:
 				if maxWorkers > 0 {
 					opts.MaxWorkers = maxWorkers
 				}
-				opts.ShowProgress = !quiet && !verbose
-				opts.Verbose = verbose
+				opts.ShowProgress = !ui.IsQuiet() && !ui.IsVerbose()
+				opts.Verbose = ui.IsVerbose()
+				opts.Replace = replaceOpts
 				
-				if verbose {
+				if ui.IsVerbose() {
 					ui.PrintInfo("Processing directory with %d workers...", opts.MaxWorkers)
 				}
 				
 				results, err = replace.ReplaceInDirectoryConcurrent(targetPath, rules, recursive, excludeGlob, opts)
 			} else {
-				results, err = replace.ReplaceInDirectoryWithResultsAndExclude(targetPath, rules, recursive, excludeGlob)
+				results, err = replace.ReplaceInDirectoryWithOptions(targetPath, rules, recursive, excludeGlob, replaceOpts)
+			}
+			var stopped *replace.FailFastError
+			if errors.As(err, &stopped) {
+				printResults(results, rules)
+				if showSkipped {
+					printSkipped(os.Stdout, replaceOpts.Skipped.Files(), replaceJsonOutput)
+				}
+				return pkgErrors.NewDocumentError(stopped.Path, filepath.Ext(stopped.Path),
+					"processing failed; stopping because of --fail-fast", stopped.Err)
 			}
 			if err != nil {
 				return pkgErrors.NewError(pkgErrors.ErrCodeFileNotFound, "Failed to process directory").
@@ -148,21 +406,40 @@ Examples:
 					Build()
 			}
 
+			if replaceOpts.State != nil && replaceOpts.State.Skipped() > 0 {
+				ui.PrintInfo("Skipped %d file(s) already completed according to %s", replaceOpts.State.Skipped(), stateFile)
+			}
+			if replaceOpts.Manifest != nil && replaceOpts.Manifest.Skipped() > 0 {
+				ui.PrintInfo("Skipped %d unchanged file(s) according to %s", replaceOpts.Manifest.Skipped(), manifestFile)
+			}
+
 			// Print results
-			printResults(results)
+			printResults(results, rules)
+			if showSkipped {
+				printSkipped(os.Stdout, replaceOpts.Skipped.Files(), replaceJsonOutput)
+			}
 		} else {
 			// Process single file
 			ext := strings.ToLower(filepath.Ext(targetPath))
-			if ext != ".docx" && ext != ".pptx" {
-				return pkgErrors.NewDocumentError(targetPath, ext, "unsupported format (only .docx and .pptx are supported)", pkgErrors.ErrUnsupportedFormat)
+			if !document.IsSupportedFormat(targetPath) {
+				return pkgErrors.NewDocumentError(targetPath, ext,
+					fmt.Sprintf("unsupported format (supported: %s)", strings.Join(document.SupportedExtensions(), ", ")), pkgErrors.ErrUnsupportedFormat)
 			}
 
 			if replaceDryRun {
-				ui.PrintInfo("Would process file: %s", targetPath)
+				if diffFormat != diffFormatUnified {
+					ui.PrintInfo("Would process file: %s", targetPath)
+				}
+				if showDiff && !replaceJsonOutput {
+					previewFileDiff(targetPath, rules)
+				}
+				if diffOutput != "" {
+					return writeDiffReport([]replace.FileChanges{previewFileChanges(targetPath, rules)}, rules)
+				}
 				return nil
 			}
 
-			if verbose {
+			if ui.IsVerbose() {
 				ui.PrintInfo("Processing file: %s", targetPath)
 			}
 			
@@ -172,7 +449,17 @@ Examples:
 				opts := replace.DefaultLargeFileOptions()
 				opts.EnableStreaming = enableStreaming
 				opts.EnableMemoryMonitor = memoryMonitor
-				opts.ShowMemoryUsage = verbose
+				opts.ShowMemoryUsage = ui.IsVerbose()
+				opts.Slides = replaceOpts.Slides
+				opts.Strict = replaceOpts.Strict
+				opts.StripMetadata = replaceOpts.StripMetadata
+				opts.Timings = replaceOpts.Timings
+				opts.IncludeHiddenText = replaceOpts.IncludeHiddenText
+				opts.Parts = replaceOpts.Parts
+				opts.StrictXML = replaceOpts.StrictXML
+				opts.DumpXMLDir = replaceOpts.DumpXMLDir
+				opts.AllParts = replaceOpts.AllParts
+				opts.MaxRuleReplacements = replaceOpts.MaxRuleReplacements
 				
 				result, err := replace.ProcessLargeFile(targetPath, rules, opts)
 				if err != nil {
@@ -182,12 +469,12 @@ Examples:
 					return pkgErrors.NewDocumentError(targetPath, ext, "processing failed", err)
 				}
 				
-				if verbose {
+				if ui.IsVerbose() {
 					ui.PrintInfo("Made %d replacements in %s", result.Replacements, targetPath)
 				}
 			} else {
 				// Use standard processing for small files
-				count, err := replace.ReplaceInDocumentWithCount(targetPath, rules)
+				count, err := replace.ReplaceInDocumentWithOptions(targetPath, rules, replaceOpts)
 				if err != nil {
 					if errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
 						return pkgErrors.NewDocumentError(targetPath, ext, "document appears to be corrupted", err)
@@ -195,7 +482,7 @@ Examples:
 					return pkgErrors.NewDocumentError(targetPath, ext, "processing failed", err)
 				}
 				
-				if verbose {
+				if ui.IsVerbose() {
 					ui.PrintInfo("Made %d replacements in %s", count, targetPath)
 				}
 			}
@@ -209,7 +496,185 @@ Examples:
 
 // Helper functions
 
+// loadRules loads replacement rules from a YAML file, or from standard input
+// for "-". JSON is read too, as YAML accepts it.
+func loadRules(path string) ([]replace.Rule, error) {
+	if path != stdinArg {
+		return replace.LoadRulesFromFile(path)
+	}
+	data, err := readInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules from %s: %w", stdinName, err)
+	}
+	rules, err := replace.ParseYAMLRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules from %s: %w", stdinName, err)
+	}
+	return rules, nil
+}
+
+// describeRule formats a rule for the dry-run listing, including its
+// description and whether it is disabled
+func describeRule(rule replace.Rule) string {
+	text := fmt.Sprintf("Replace '%s' with '%s'", rule.Old, rule.New)
+	if rule.Occurrence != 0 {
+		text += fmt.Sprintf(" (occurrence %d only)", rule.Occurrence)
+	}
+	if rule.Format != "" {
+		text += fmt.Sprintf(" (.%s only)", rule.Format)
+	}
+	if rule.Description != "" {
+		text += " - " + rule.Description
+	}
+	if !rule.IsEnabled() {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// describeRuleSource names the rules file a merged rule came from for the
+// dry-run listing, and the file whose rule it overrides
+func describeRuleSource(rule replace.SourcedRule) string {
+	if rule.Overrides != "" {
+		return fmt.Sprintf(" [%s, overrides %s]", rule.Source, rule.Overrides)
+	}
+	return fmt.Sprintf(" [%s]", rule.Source)
+}
+
+// previewFileChanges reads a document and computes the changes the rules would make
+func previewFileChanges(path string, rules []replace.Rule) replace.FileChanges {
+	changes := replace.FileChanges{Path: path}
+	rules = replace.RulesForFile(rules, path)
+
+	doc, err := openPreviewDocument(path)
+	if err != nil {
+		changes.Error = err.Error()
+		return changes
+	}
+	defer doc.Close()
+
+	text, err := previewText(doc)
+	if err != nil {
+		changes.Error = err.Error()
+		return changes
+	}
+
+	changes.Rules = replace.PreviewChanges(text, rules)
+	warnBroadRules(path, changes.Rules)
+	return changes
+}
+
+// warnBroadRules warns about rules whose previewed changes exceed
+// --max-rule-replacements; a preview never fails for it
+func warnBroadRules(path string, changes []replace.RuleChange) {
+	replace.CheckReplacementLimit(path, changes, maxRuleReplace, false)
+}
+
+// Formats of the --diff preview
+const (
+	// diffFormatColor is the colored summary and line preview for terminals
+	diffFormatColor = "color"
+	// diffFormatUnified is a plain unified diff for piping to other tools
+	diffFormatUnified = "unified"
+)
+
+// replacementMap converts the enabled rules to a map of old to new text
+func replacementMap(rules []replace.Rule) map[string]string {
+	replacements := make(map[string]string)
+	for _, rule := range replace.EnabledRules(rules) {
+		replacements[rule.Old] = rule.New
+	}
+	return replacements
+}
+
+// previewReplacements converts the enabled rules, in order, to the
+// replacements of a diff preview
+func previewReplacements(rules []replace.Rule) []ui.Replacement {
+	var replacements []ui.Replacement
+	for _, rule := range replace.EnabledRules(rules) {
+		replacements = append(replacements, ui.Replacement{Old: rule.Old, New: rule.New})
+	}
+	return replacements
+}
+
+// showFileDiff prints the --diff preview of one file in the --diff-format
+func showFileDiff(text string, replacements []ui.Replacement, path string) {
+	if diffFormat == diffFormatUnified {
+		fmt.Print(ui.FormatUnifiedDiff(text, replacements, path, diffContext))
+		return
+	}
+	ui.ShowReplacementPreviewWithContext(text, replacements, path, diffContext)
+}
+
+// previewFileDiff prints the --diff preview of a single file
+func previewFileDiff(path string, rules []replace.Rule) {
+	rules = replace.RulesForFile(rules, path)
+	doc, err := openPreviewDocument(path)
+	if err != nil {
+		ui.PrintWarning("Cannot preview %s: %v", path, err)
+		return
+	}
+	defer doc.Close()
+
+	text, err := previewText(doc)
+	if err != nil {
+		ui.PrintWarning("Cannot preview %s: %v", path, err)
+		return
+	}
+	warnBroadRules(path, replace.PreviewChanges(text, rules))
+	showFileDiff(text, previewReplacements(rules), path)
+}
+
+// openPreviewDocument opens a document for previewing changes, seeing hidden
+// Word text only when --include-hidden-text is set and only the Word parts
+// selected by --parts, and validating its XML with --strict-xml, as
+// replacement does
+func openPreviewDocument(path string) (document.Document, error) {
+	if strictXML {
+		if err := document.ValidateXML(path); err != nil {
+			return nil, err
+		}
+	}
+	doc, err := document.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if wordDoc, ok := doc.(*document.WordDocument); ok {
+		wordDoc.SetIncludeHiddenText(replaceHidden)
+		if replaceParts != "" {
+			// Already validated when the command started
+			parts, _ := document.ParseWordParts(replaceParts)
+			wordDoc.SetParts(parts)
+		}
+	}
+	return doc, nil
+}
+
+// previewText returns the text a preview matches rules against: for Word
+// documents the text of every selected part, headers and footers included
+func previewText(doc document.Document) (string, error) {
+	if wordDoc, ok := doc.(*document.WordDocument); ok {
+		return wordDoc.SelectedText()
+	}
+	return doc.GetText()
+}
+
+// writeDiffReport writes the Markdown change report to --diff-output
+func writeDiffReport(files []replace.FileChanges, rules []replace.Rule) error {
+	report := replace.FormatMarkdownReport(files, rules)
+	if err := os.WriteFile(diffOutput, []byte(report), 0644); err != nil {
+		return pkgErrors.NewFileError(diffOutput, "writing change report", err)
+	}
+	if !replaceJsonOutput {
+		ui.PrintSuccess("Change report written to: %s", diffOutput)
+	}
+	return nil
+}
+
 func createBackup(path string, isDir bool) error {
+	// A trailing separator would put a directory's backup inside it
+	path = filepath.Clean(path)
+
 	// Use time-based timestamp for uniqueness
 	timestamp := time.Now().Format("20060102_150405")
 	
@@ -234,6 +699,68 @@ func createBackup(path string, isDir bool) error {
 	return os.WriteFile(backupPath, input, 0644)
 }
 
+// backupTimestampPattern matches the timestamp createBackup puts in backup names
+const backupTimestampPattern = `_backup_\d{8}_\d{6}`
+
+// backupNamePattern matches exactly the names createBackup gives backups of
+// path: <name>_backup_<timestamp><ext> for files and <name>_backup_<timestamp>
+// for directories
+func backupNamePattern(path string, isDir bool) *regexp.Regexp {
+	name := filepath.Base(filepath.Clean(path))
+	if isDir {
+		return regexp.MustCompile("^" + regexp.QuoteMeta(name) + backupTimestampPattern + "$")
+	}
+	ext := filepath.Ext(name)
+	return regexp.MustCompile("^" + regexp.QuoteMeta(strings.TrimSuffix(name, ext)) + backupTimestampPattern + regexp.QuoteMeta(ext) + "$")
+}
+
+// pruneBackups deletes the oldest backups of path beyond the keep most recent
+// and returns the deleted paths; keep 0 keeps every backup. Only regular
+// files (or directories, for a directory) next to path whose names match the
+// backup pattern exactly are considered, so unrelated files are never removed.
+func pruneBackups(path string, isDir bool, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	dir := filepath.Dir(filepath.Clean(path))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := backupNamePattern(path, isDir)
+	var backups []string
+	for _, entry := range entries {
+		if (isDir && !entry.IsDir()) || (!isDir && !entry.Type().IsRegular()) {
+			continue
+		}
+		if pattern.MatchString(entry.Name()) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	if len(backups) <= keep {
+		return nil, nil
+	}
+
+	// Names differ only in their fixed-width timestamp, so they sort oldest first
+	sort.Strings(backups)
+	var removed []string
+	for _, name := range backups[:len(backups)-keep] {
+		backupPath := filepath.Join(dir, name)
+		if isDir {
+			err = os.RemoveAll(backupPath)
+		} else {
+			err = os.Remove(backupPath)
+		}
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, backupPath)
+	}
+	return removed, nil
+}
+
 func copyDir(src, dst string) error {
 	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -262,7 +789,7 @@ func copyDir(src, dst string) error {
 	})
 }
 
-func previewDirectoryReplacements(dirPath string, rules []replace.Rule, recursive bool) error {
+func previewDirectoryReplacements(dirPath string, rules []replace.Rule, recursive bool, skipped *replace.SkipLog) error {
 	type filePreview struct {
 		Path         string            `json:"path"`
 		Type         string            `json:"type"`
@@ -271,72 +798,84 @@ func previewDirectoryReplacements(dirPath string, rules []replace.Rule, recursiv
 	}
 	
 	var previews []filePreview
+	var reportFiles []replace.FileChanges
 	
-	if !replaceJsonOutput {
+	// A unified diff is meant for other tools, so it is printed alone
+	unified := diffFormat == diffFormatUnified
+	if !replaceJsonOutput && !unified {
 		ui.PrintHeader("Files to Process")
 	}
 	
-	// Convert rules to replacement map
-	replacements := make(map[string]string)
-	for _, rule := range rules {
-		replacements[rule.Old] = rule.New
-	}
-	
 	// Use the new walk function with exclude support
-	err := replace.WalkDocumentFilesWithExclude(dirPath, recursive, excludeGlob, func(path string) error {
+	var onSkip func(path, reason string)
+	if skipped != nil {
+		onSkip = skipped.Record
+	}
+	err := replace.WalkDocumentFilesWithSkips(dirPath, recursive, excludeGlob, func(path string) error {
 		ext := strings.ToLower(filepath.Ext(path))
 		
 		preview := filePreview{
 			Path: path,
 			Type: ext,
 		}
+		// Rules limited to other file names do not apply
+		fileRules := replace.RulesForFile(rules, path)
+		replacements := previewReplacements(fileRules)
 		
-		// If diff mode is enabled, try to read the file and show what would change
-		if showDiff && !replaceJsonOutput {
+		// With --diff or --json, read the file to count and show what would change
+		if showDiff || replaceJsonOutput {
 			// Try to read the document content
-			var doc document.Document
-			switch ext {
-			case ".docx":
-				d, err := document.OpenWordDocument(path)
-				if err == nil {
-					doc = d
-					defer d.Close()
-				}
-			case ".pptx":
-				d, err := document.OpenPowerPointDocument(path)
-				if err == nil {
-					doc = d
-					defer d.Close()
-				}
-			}
-			
-			if doc != nil {
-				text, err := doc.GetText()
+			doc, err := openPreviewDocument(path)
+			if err == nil {
+				defer doc.Close()
+				text, err := previewText(doc)
 				if err == nil {
 					// Count replacements
-					for old := range replacements {
-						preview.Count += strings.Count(text, old)
+					changes := replace.PreviewChanges(text, fileRules)
+					for _, change := range changes {
+						preview.Count += change.Count
 					}
+					warnBroadRules(path, changes)
 					
 					// Show diff preview
-					if preview.Count > 0 {
-						ui.ShowReplacementPreview(text, replacements, path)
+					if preview.Count > 0 && !replaceJsonOutput {
+						showFileDiff(text, replacements, path)
 					}
 				}
+			} else {
+				skipped.Record(path, fmt.Sprintf("cannot be read: %v", err))
 			}
 		} else if !replaceJsonOutput {
 			ui.PrintFileOperation("Preview", path, ext)
 		}
 		
-		preview.Replacements = replacements
+		preview.Replacements = replacementMap(fileRules)
 		previews = append(previews, preview)
+		
+		if diffOutput != "" {
+			reportFiles = append(reportFiles, previewFileChanges(path, rules))
+		}
 		return nil
-	})
+	}, onSkip)
 	
 	if err != nil {
 		return err
 	}
 	
+	if diffOutput != "" {
+		if err := writeDiffReport(reportFiles, rules); err != nil {
+			return err
+		}
+	}
+	
+	// Files in which no rule would match
+	unchanged := 0
+	for _, preview := range previews {
+		if preview.Count == 0 {
+			unchanged++
+		}
+	}
+	
 	if replaceJsonOutput {
 		// JSON output
 		output := map[string]interface{}{
@@ -346,67 +885,281 @@ func previewDirectoryReplacements(dirPath string, rules []replace.Rule, recursiv
 			"files":     previews,
 			"summary": map[string]interface{}{
 				"totalFiles": len(previews),
+				"unchanged":  unchanged,
 				"recursive":  recursive,
 				"exclude":    excludeGlob,
 			},
 		}
+		if skipped != nil {
+			output["skipped"] = skipped.Files()
+		}
 		
 		jsonBytes, _ := json.MarshalIndent(output, "", "  ")
 		fmt.Println(string(jsonBytes))
-	} else {
+	} else if !unified {
 		ui.PrintInfo("Total files to process: %d", len(previews))
 		if showDiff {
+			ui.PrintInfo("Unchanged: %d file(s) where no rule matched", unchanged)
+		} else {
 			ui.PrintInfo("Use --diff to see detailed changes for each file")
 		}
+		if showSkipped {
+			printSkipped(os.Stdout, skipped.Files(), false)
+		}
 	}
 	
 	return nil
 }
 
-func printResults(results []replace.ReplaceResult) {
-	successCount := 0
-	failureCount := 0
-	totalReplacements := 0
-	
-	ui.PrintHeader("Processing Results")
+// watchReplacements applies the rules to each document under path whenever
+// it changes, until interrupted
+func watchReplacements(path string, rules []replace.Rule, opts replace.ReplaceOptions) error {
+	watcher, err := replace.NewWatcher(path, replace.WatchOptions{
+		Recursive: recursive,
+		Exclude:   excludeGlob,
+		Include:   parseIncludeGlobs(includeGlobs),
+	})
+	if err != nil {
+		return pkgErrors.NewFileError(path, "watching", err)
+	}
+	defer watcher.Close()
+
+	// Stop cleanly on Ctrl+C
+	stop := make(chan struct{})
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	go func() {
+		<-sigChan
+		close(stop)
+	}()
+
+	ui.PrintInfo("Watching %s for changes (press Ctrl+C to stop)", path)
+
+	err = watcher.Run(stop, func(file string) {
+		count, err := replace.ReplaceInDocumentWithOptions(file, rules, opts)
+		if err != nil {
+			ui.PrintError("%s - %v", file, err)
+			return
+		}
+		ui.PrintSuccess("%s (%d replacements)", file, count)
+	})
+	if err != nil {
+		return pkgErrors.NewFileError(path, "watching", err)
+	}
+
+	ui.PrintInfo("Stopped watching %s", path)
+	return nil
+}
+
+// parseIncludeGlobs splits the comma-separated --include patterns
+func parseIncludeGlobs(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// printTimings prints the per-phase durations recorded by --measure as a
+// table or JSON. Concurrent runs overlap phases, so their phase totals can
+// exceed the elapsed wall time.
+func printTimings(out io.Writer, timings *replace.Timings, elapsed time.Duration, asJSON bool) {
+	if timings.Files() == 0 {
+		return
+	}
+	phases := timings.Phases()
+	total := timings.Total()
+
+	if asJSON {
+		type phaseJSON struct {
+			Phase   string  `json:"phase"`
+			Count   int     `json:"count"`
+			TotalMs float64 `json:"totalMs"`
+			AvgMs   float64 `json:"avgMs"`
+			MaxMs   float64 `json:"maxMs"`
+		}
+		ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+
+		report := struct {
+			Files     int         `json:"files"`
+			ElapsedMs float64     `json:"elapsedMs"`
+			TotalMs   float64     `json:"totalMs"`
+			Phases    []phaseJSON `json:"phases"`
+		}{Files: timings.Files(), ElapsedMs: ms(elapsed), TotalMs: ms(total)}
+		for _, p := range phases {
+			report.Phases = append(report.Phases, phaseJSON{
+				Phase: p.Phase, Count: p.Count, TotalMs: ms(p.Total), AvgMs: ms(p.Average()), MaxMs: ms(p.Max),
+			})
+		}
+		jsonBytes, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Fprintln(out, string(jsonBytes))
+		return
+	}
+
+	fmt.Fprintf(out, "\nTimings (%d file(s), %s elapsed)\n", timings.Files(), ui.FormatDuration(elapsed))
+	fmt.Fprintf(out, "  %-10s %8s %10s %10s %10s %7s\n", "Phase", "Count", "Total", "Average", "Max", "Share")
+	for _, p := range phases {
+		share := 0.0
+		if total > 0 {
+			share = float64(p.Total) / float64(total) * 100
+		}
+		fmt.Fprintf(out, "  %-10s %8d %10s %10s %10s %6.1f%%\n",
+			p.Phase, p.Count, ui.FormatDuration(p.Total), ui.FormatDuration(p.Average()), ui.FormatDuration(p.Max), share)
+	}
+}
+
+// printSkipped lists the files a directory run left out for --show-skipped,
+// as text or as JSON with a "skipped" array
+func printSkipped(out io.Writer, files []replace.SkippedFile, asJSON bool) {
+	if asJSON {
+		jsonBytes, _ := json.MarshalIndent(map[string]interface{}{"skipped": files}, "", "  ")
+		fmt.Fprintln(out, string(jsonBytes))
+		return
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintln(out, "\nNo files were skipped")
+		return
+	}
+	fmt.Fprintf(out, "\nSkipped %d file(s)\n", len(files))
+	for _, file := range files {
+		fmt.Fprintf(out, "  %s - %s\n", file.Path, file.Reason)
+	}
+}
+
+// printAppliedRules lists the enabled rules of a run by their description, or
+// by their old and new text when they have none
+func printAppliedRules(out io.Writer, rules []replace.Rule) {
+	enabled := replace.EnabledRules(rules)
+	if len(enabled) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "Rules applied (%d)\n", len(enabled))
+	for i, rule := range enabled {
+		label := rule.Description
+		if label == "" {
+			label = fmt.Sprintf("'%s' → '%s'", rule.Old, rule.New)
+		}
+		fmt.Fprintf(out, "  %d. %s\n", i+1, label)
+	}
+}
+
+// allSucceeded reports whether every file was processed successfully
+func allSucceeded(results []replace.ReplaceResult) bool {
+	for _, result := range results {
+		if !result.Success {
+			return false
+		}
+	}
+	return true
+}
+
+// resultSummary counts the outcomes of a replacement run
+type resultSummary struct {
+	Successful   int
+	Failed       int
+	Unchanged    int // successful files in which no rule matched
+	Replacements int
+}
+
+// summarizeResults counts successes, failures and unchanged files
+func summarizeResults(results []replace.ReplaceResult) resultSummary {
+	var summary resultSummary
+	for _, result := range results {
+		if !result.Success {
+			summary.Failed++
+			continue
+		}
+		summary.Successful++
+		summary.Replacements += result.Replacements
+		if result.Replacements == 0 {
+			summary.Unchanged++
+		}
+	}
+	return summary
+}
+
+func printResults(results []replace.ReplaceResult, rules []replace.Rule) {
+	// With --quiet-errors the header only introduces failures
+	if !ui.IsQuiet() || !allSucceeded(results) {
+		ui.PrintHeader("Processing Results")
+	}
+	if !ui.IsQuiet() {
+		printAppliedRules(os.Stdout, rules)
+	}
 	
 	for _, result := range results {
-		if result.Success {
-			ui.PrintSuccess("%s (%d replacements)", result.FilePath, result.Replacements)
-			successCount++
-			totalReplacements += result.Replacements
-		} else {
+		if !result.Success {
 			ui.PrintError("%s - %v", result.FilePath, result.Error)
-			failureCount++
+		} else if result.Replacements == 0 {
+			// Stand out from real changes so rules that match nothing are noticed
+			ui.PrintInfo("%s (unchanged, no rules matched)", result.FilePath)
+		} else {
+			ui.PrintSuccess("%s (%d replacements)", result.FilePath, result.Replacements)
 		}
 	}
 	
+	summary := summarizeResults(results)
+	
 	// Create summary statistics
 	stats := map[string]interface{}{
-		"Successful":          successCount,
-		"Failed":             failureCount,
+		"Successful":          summary.Successful,
+		"Failed":             summary.Failed,
+		"Unchanged":          summary.Unchanged,
 		"Total Files":        len(results),
-		"Total Replacements": totalReplacements,
+		"Total Replacements": summary.Replacements,
 	}
 	
 	ui.PrintSummary("Summary", stats)
+	if summary.Unchanged > 0 && summary.Unchanged == summary.Successful {
+		ui.PrintWarning("No rules matched in any file; check the 'old' text of your rules with 'dox find'")
+	}
 }
 
 func init() {
 	rootCmd.AddCommand(replaceCmd)
 
-	replaceCmd.Flags().StringVarP(&rulesFile, "rules", "r", "", "YAML file containing replacement rules (required)")
+	replaceCmd.Flags().StringSliceVarP(&rulesFiles, "rules", "r", nil, "YAML file containing replacement rules, or - to read them from standard input; repeat or separate with commas to merge several, later files overriding earlier rules with the same old text (required)")
 	replaceCmd.Flags().StringVarP(&targetPath, "path", "p", "", "Target file or directory (required)")
 	replaceCmd.Flags().BoolVar(&replaceDryRun, "dry-run", false, "Preview changes without applying them")
 	replaceCmd.Flags().BoolVar(&backup, "backup", false, "Create backup files before modification")
+	replaceCmd.Flags().IntVar(&keepBackups, "keep-backups", 0, "With --backup, delete the oldest backups of the target beyond this many (0 keeps all)")
 	replaceCmd.Flags().BoolVar(&recursive, "recursive", true, "Process subdirectories recursively")
 	replaceCmd.Flags().StringVar(&excludeGlob, "exclude", "", "Glob pattern for files to exclude")
 	replaceCmd.Flags().BoolVar(&concurrent, "concurrent", false, "Process files concurrently for better performance")
 	replaceCmd.Flags().IntVar(&maxWorkers, "max-workers", 0, "Maximum number of concurrent workers (default: number of CPUs)")
 	replaceCmd.Flags().BoolVar(&replaceJsonOutput, "json", false, "Output in JSON format")
 	replaceCmd.Flags().BoolVar(&showDiff, "diff", false, "Show diff-style preview in dry-run mode")
+	replaceCmd.Flags().StringVar(&diffOutput, "diff-output", "", "Write a Markdown report of proposed changes to this file (implies --dry-run)")
+	replaceCmd.Flags().IntVar(&diffContext, "context", 3, "Number of context lines around each change in --diff output (-1 shows everything)")
+	replaceCmd.Flags().StringVar(&diffFormat, "diff-format", diffFormatColor, "Format of the --diff preview: color, or unified for a plain unified diff to pipe to other tools (implies --diff)")
 	replaceCmd.Flags().BoolVar(&enableStreaming, "streaming", false, "Enable streaming mode for large files (>10MB) to reduce memory usage")
 	replaceCmd.Flags().BoolVar(&memoryMonitor, "memory-monitor", true, "Enable memory usage monitoring and warnings")
+	replaceCmd.Flags().StringVar(&slideRange, "slides", "", "Limit PowerPoint replacement to these slides (e.g. 1,3-5)")
+	replaceCmd.Flags().BoolVar(&strictRules, "strict", false, "Fail instead of warning when rules conflict (duplicate or overlapping 'old' text)")
+	replaceCmd.Flags().BoolVar(&dedupeRules, "dedupe-rules", false, "Remove repeated rules and warn about rules replacing the same text differently (always on when merging several rules files)")
+	replaceCmd.Flags().IntVar(&maxRuleReplace, "max-rule-replacements", replace.DefaultMaxRuleReplacements, "Warn when one rule would make more replacements than this in a file; with --strict the file is left unchanged (0 disables)")
+	replaceCmd.Flags().BoolVar(&strictXML, "strict-xml", false, "Validate every XML part of each document when it is opened and fail on malformed XML instead of risking a broken save")
+	replaceCmd.Flags().StringVar(&stateFile, "state-file", "", "Record completed files in this JSON file and skip them when re-run")
+	replaceCmd.Flags().StringVar(&manifestFile, "manifest", "", "Record content hashes of processed files in this JSON file and skip files unchanged since the last run with the same rules")
+	replaceCmd.Flags().BoolVar(&normalizeRules, "normalize", false, "Match rules ignoring smart quotes, non-breaking spaces and ligatures")
+	replaceCmd.Flags().BoolVar(&stripMetadata, "strip-metadata", false, "Remove author, company and other document properties when saving")
+	replaceCmd.Flags().BoolVar(&replaceWatch, "watch", false, "Keep running and reprocess documents whenever they change")
+	replaceCmd.Flags().StringVar(&includeGlobs, "include", "", "Comma-separated glob patterns of files to reprocess in --watch mode (default: all supported formats)")
+	replaceCmd.Flags().BoolVar(&replaceHidden, "include-hidden-text", false, "Also replace text that Word marks as hidden (skipped by default)")
+	replaceCmd.Flags().StringVar(&replaceParts, "parts", "", "Limit Word replacement to these parts: body, headers, footers, footnotes (default: all)")
+	replaceCmd.Flags().BoolVar(&allParts, "all-parts", false, "Replace text runs in every XML part of Word and PowerPoint files, not only the body, headers, footers, notes and slides (slower)")
+	replaceCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop processing a directory at the first file that fails")
+	replaceCmd.Flags().DurationVar(&timeoutPerFile, "timeout-per-file", 0, "Give up on a file of a directory run that takes longer than this (e.g. 30s), leaving it unchanged and recording it as failed (0 = no limit)")
+	replaceCmd.Flags().BoolVar(&showSkipped, "show-skipped", false, "List files left out of a directory run and why (included under \"skipped\" with --json)")
+	replaceCmd.Flags().BoolVar(&measurePhases, "measure", false, "Report time spent opening, replacing and saving documents (table, or JSON with --json)")
+
+	// Debugging aid for bug reports, not shown in help
+	replaceCmd.Flags().StringVar(&dumpXMLDir, "dump-xml", "", "Debug: write each document's XML parts before and after replacement to this directory")
+	replaceCmd.Flags().MarkHidden("dump-xml")
 
 	replaceCmd.MarkFlagRequired("rules")
 	replaceCmd.MarkFlagRequired("path")
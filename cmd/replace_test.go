@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pyhub/pyhub-docs/internal/replace"
 	"gopkg.in/yaml.v3"
@@ -78,7 +83,22 @@ func TestReplaceCommand(t *testing.T) {
 		}
 		
 		// This won't panic if printResults works correctly
-		printResults(results)
+		printResults(results, []replace.Rule{{Old: "a", New: "b"}})
+	})
+
+	t.Run("SummarizeResults", func(t *testing.T) {
+		results := []replace.ReplaceResult{
+			{FilePath: "doc1.docx", Success: true, Replacements: 5},
+			{FilePath: "doc2.docx", Success: false, Error: os.ErrNotExist},
+			{FilePath: "doc3.docx", Success: true},
+			{FilePath: "doc4.pptx", Success: true},
+		}
+
+		got := summarizeResults(results)
+		want := resultSummary{Successful: 3, Failed: 1, Unchanged: 2, Replacements: 5}
+		if got != want {
+			t.Errorf("summarizeResults() = %+v, want %+v", got, want)
+		}
 	})
 }
 
@@ -112,7 +132,7 @@ func TestPreviewDirectoryReplacements(t *testing.T) {
 	// Test non-recursive preview
 	t.Run("NonRecursivePreview", func(t *testing.T) {
 		excludeGlob = "" // Reset global variable
-		if err := previewDirectoryReplacements(tempDir, rules, false); err != nil {
+		if err := previewDirectoryReplacements(tempDir, rules, false, nil); err != nil {
 			t.Errorf("previewDirectoryReplacements failed: %v", err)
 		}
 	})
@@ -120,7 +140,7 @@ func TestPreviewDirectoryReplacements(t *testing.T) {
 	// Test recursive preview
 	t.Run("RecursivePreview", func(t *testing.T) {
 		excludeGlob = "" // Reset global variable
-		if err := previewDirectoryReplacements(tempDir, rules, true); err != nil {
+		if err := previewDirectoryReplacements(tempDir, rules, true, nil); err != nil {
 			t.Errorf("previewDirectoryReplacements failed: %v", err)
 		}
 	})
@@ -128,9 +148,220 @@ func TestPreviewDirectoryReplacements(t *testing.T) {
 	// Test with exclude pattern
 	t.Run("PreviewWithExclude", func(t *testing.T) {
 		excludeGlob = "doc1*"
-		if err := previewDirectoryReplacements(tempDir, rules, false); err != nil {
+		if err := previewDirectoryReplacements(tempDir, rules, false, nil); err != nil {
 			t.Errorf("previewDirectoryReplacements with exclude failed: %v", err)
 		}
 		excludeGlob = "" // Reset
 	})
-}
\ No newline at end of file
+}
+
+func TestParseIncludeGlobs(t *testing.T) {
+	got := parseIncludeGlobs(" *.docx, ,report_*.pptx ")
+	if len(got) != 2 || got[0] != "*.docx" || got[1] != "report_*.pptx" {
+		t.Errorf("parseIncludeGlobs() = %q", got)
+	}
+	if got := parseIncludeGlobs(""); got != nil {
+		t.Errorf("parseIncludeGlobs(\"\") = %q, want nil", got)
+	}
+}
+
+func TestPrintSkipped(t *testing.T) {
+	files := []replace.SkippedFile{
+		{Path: "docs/notes.txt", Reason: replace.SkipUnsupported},
+		{Path: "docs/old.docx", Reason: replace.SkipUnchanged},
+	}
+
+	buf := new(bytes.Buffer)
+	printSkipped(buf, files, false)
+	want := "\nSkipped 2 file(s)\n" +
+		"  docs/notes.txt - unsupported file type\n" +
+		"  docs/old.docx - unchanged since the last run according to the manifest\n"
+	if got := buf.String(); got != want {
+		t.Errorf("list = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	printSkipped(buf, files, true)
+	var report struct {
+		Skipped []replace.SkippedFile `json:"skipped"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(report.Skipped) != 2 || report.Skipped[1] != files[1] {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestPrintAppliedRules(t *testing.T) {
+	disabled := false
+	rules := []replace.Rule{
+		{Old: "ACME Corp", New: "ACME Inc.", Description: "Company renamed in 2024"},
+		{Old: "v1.0", New: "v2.0"},
+		{Old: "draft", New: "final", Description: "Not used", Enabled: &disabled},
+	}
+
+	buf := new(bytes.Buffer)
+	printAppliedRules(buf, rules)
+	want := "Rules applied (2)\n" +
+		"  1. Company renamed in 2024\n" +
+		"  2. 'v1.0' → 'v2.0'\n"
+	if got := buf.String(); got != want {
+		t.Errorf("list = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	printAppliedRules(buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("no rules printed %q", buf.String())
+	}
+}
+
+func TestPrintTimings(t *testing.T) {
+	timings := replace.NewTimings()
+	timings.AddFile()
+	timings.Record(replace.PhaseOpen, 30*time.Millisecond)
+	timings.Record(replace.PhaseReplace, 10*time.Millisecond)
+
+	buf := new(bytes.Buffer)
+	printTimings(buf, timings, 50*time.Millisecond, false)
+	want := "\nTimings (1 file(s), 50ms elapsed)\n" +
+		"  Phase         Count      Total    Average        Max   Share\n" +
+		"  open              1       30ms       30ms       30ms   75.0%\n" +
+		"  replace           1       10ms       10ms       10ms   25.0%\n"
+	if got := buf.String(); got != want {
+		t.Errorf("table = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	printTimings(buf, timings, 50*time.Millisecond, true)
+	var report struct {
+		Files  int `json:"files"`
+		Phases []struct {
+			Phase   string  `json:"phase"`
+			TotalMs float64 `json:"totalMs"`
+		} `json:"phases"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if report.Files != 1 || len(report.Phases) != 2 || report.Phases[0].TotalMs != 30 {
+		t.Errorf("report = %+v", report)
+	}
+
+	// Nothing is printed when no document was processed
+	buf.Reset()
+	printTimings(buf, replace.NewTimings(), time.Second, false)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
+
+func TestDescribeRuleSource(t *testing.T) {
+	rule := replace.SourcedRule{Rule: replace.Rule{Old: "Acme", New: "ACME"}, Source: "brand.yml"}
+	if got := describeRuleSource(rule); got != " [brand.yml]" {
+		t.Errorf("describeRuleSource() = %q", got)
+	}
+
+	rule.Overrides = "terms.yml"
+	if got := describeRuleSource(rule); got != " [brand.yml, overrides terms.yml]" {
+		t.Errorf("describeRuleSource() = %q", got)
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "report.docx")
+	write := func(name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("report.docx")
+	for _, name := range []string{
+		"report_backup_20240101_090000.docx",
+		"report_backup_20240301_090000.docx",
+		"report_backup_20240201_090000.docx",
+		"report_backup_20240401_090000.docx",
+		// Look alike, but are not backups of report.docx
+		"report_backup_notes.docx",
+		"report_backup_20240101_090000.docx.bak",
+		"old_report_backup_20230101_090000.docx",
+		"report_backup_20230101_090000.pptx",
+		"report2_backup_20230101_090000.docx",
+	} {
+		write(name)
+	}
+	// A directory with a backup-like name is not a file backup
+	if err := os.Mkdir(filepath.Join(dir, "report_backup_20220101_090000.docx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("keep zero keeps everything", func(t *testing.T) {
+		removed, err := pruneBackups(target, false, 0)
+		if err != nil || len(removed) != 0 {
+			t.Errorf("pruneBackups(0) = %v, %v", removed, err)
+		}
+	})
+
+	t.Run("oldest backups beyond keep are removed", func(t *testing.T) {
+		removed, err := pruneBackups(target, false, 2)
+		if err != nil {
+			t.Fatalf("pruneBackups() error = %v", err)
+		}
+		want := []string{
+			filepath.Join(dir, "report_backup_20240101_090000.docx"),
+			filepath.Join(dir, "report_backup_20240201_090000.docx"),
+		}
+		if strings.Join(removed, "|") != strings.Join(want, "|") {
+			t.Errorf("removed = %v, want %v", removed, want)
+		}
+
+		entries, _ := os.ReadDir(dir)
+		var remaining []string
+		for _, entry := range entries {
+			remaining = append(remaining, entry.Name())
+		}
+		for _, name := range []string{
+			"report.docx",
+			"report_backup_20240301_090000.docx",
+			"report_backup_20240401_090000.docx",
+			"report_backup_notes.docx",
+			"report_backup_20240101_090000.docx.bak",
+			"old_report_backup_20230101_090000.docx",
+			"report_backup_20230101_090000.pptx",
+			"report2_backup_20230101_090000.docx",
+			"report_backup_20220101_090000.docx",
+		} {
+			if !slices.Contains(remaining, name) {
+				t.Errorf("%s should not have been removed; remaining %v", name, remaining)
+			}
+		}
+	})
+
+	t.Run("directory backups", func(t *testing.T) {
+		docs := filepath.Join(dir, "docs")
+		for _, name := range []string{"docs", "docs_backup_20240101_090000", "docs_backup_20240201_090000", "docs_backup_20240301_090000"} {
+			if err := os.MkdirAll(filepath.Join(dir, name, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// A file with a directory backup name is left alone
+		write("docs_backup_20200101_090000")
+
+		removed, err := pruneBackups(docs+string(filepath.Separator), true, 1)
+		if err != nil {
+			t.Fatalf("pruneBackups() error = %v", err)
+		}
+		if len(removed) != 2 {
+			t.Fatalf("removed = %v, want the two oldest directories", removed)
+		}
+		for _, name := range []string{"docs", "docs_backup_20240301_090000", "docs_backup_20200101_090000"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("%s should remain: %v", name, err)
+			}
+		}
+	})
+}
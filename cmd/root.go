@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/pyhub/pyhub-docs/internal/config"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 	"github.com/pyhub/pyhub-docs/internal/i18n"
 	"github.com/pyhub/pyhub-docs/internal/ui"
 	"github.com/spf13/cobra"
@@ -13,12 +15,15 @@ import (
 
 var (
 	// Configuration flags
-	cfgFile  string
-	verbose  bool
-	quiet    bool
-	langFlag string
-	noColor  bool
-	logLevel string
+	cfgFile     string
+	verboseFlag bool
+	quietFlag   bool
+	quietErrors bool
+	langFlag    string
+	noColor    bool
+	forceColor bool
+	logLevel   string
+	explainErrors bool
 	
 	// Global configuration instance
 	appConfig *config.Config
@@ -51,21 +56,52 @@ Examples:
 // Execute adds all child commands to the root command and sets flags appropriately
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if explainErrors {
+			fmt.Fprint(os.Stderr, "\n", pkgErrors.Explain(err))
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(exitCode(err))
 	}
 }
 
+// usageError marks an error parsing flags, which exits with ExitUsage
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// exitCode returns the exit code for an error returned by a command (see
+// pkgErrors.ExitCode for the contract)
+func exitCode(err error) int {
+	var usage usageError
+	switch {
+	case errors.As(err, &usage):
+		return pkgErrors.ExitUsage
+	case errors.Is(err, errCheckFailed):
+		return pkgErrors.ExitCheckFailed
+	}
+	return pkgErrors.ExitCode(err)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig, initI18n, initUI, initLogLevel)
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError{err: err}
+	})
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.pyhub/config.yml)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress non-error output")
+	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "suppress non-error output")
+	rootCmd.PersistentFlags().BoolVar(&quietErrors, "quiet-errors", false, "show only warnings and errors (on stderr), suppressing success and progress messages")
 	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", i18n.T(i18n.MsgFlagLang))
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&forceColor, "color", false, "force colored output even when NO_COLOR is set or output is not a terminal")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug|info|warn|error)")
+	rootCmd.PersistentFlags().BoolVar(&explainErrors, "explain", false, "on failure, explain the error: its code, context, solution, cause chain and documentation link")
 
 	// Version template
 	rootCmd.SetVersionTemplate(fmt.Sprintf(`{{with .Name}}{{printf "%%s version information:\n" .}}{{end}}
@@ -99,14 +135,16 @@ func initConfig() {
 		// CLI 플래그가 우선
 	} else {
 		// 설정 파일의 값 사용
-		verbose = cfg.Global.Verbose
+		verboseFlag = cfg.Global.Verbose
 	}
 	
 	if rootCmd.PersistentFlags().Changed("quiet") {
 		// CLI 플래그가 우선
 	} else {
-		quiet = cfg.Global.Quiet
+		quietFlag = cfg.Global.Quiet
 	}
+
+	ui.SetVerbosity(ui.ResolveVerbosity(quietFlag, quietErrors, verboseFlag))
 	
 	if rootCmd.PersistentFlags().Changed("lang") {
 		// CLI 플래그가 우선
@@ -136,10 +174,11 @@ func initI18n() {
 
 // initUI initializes the UI settings
 func initUI() {
-	// Handle color output settings
-	if noColor || os.Getenv("NO_COLOR") != "" {
+	// Handle color output settings. The ui package already turned color off
+	// for NO_COLOR and non-terminal output; explicit flags take precedence.
+	if noColor {
 		ui.DisableColor()
-	} else if os.Getenv("FORCE_COLOR") != "" {
+	} else if forceColor || os.Getenv("FORCE_COLOR") != "" {
 		ui.EnableColor()
 	}
 }
@@ -155,13 +194,13 @@ func initLogLevel() {
 		level = "info" // default
 	}
 	
-	// If verbose flag is set, override to debug
-	if verbose {
+	// Verbosity flags override the level
+	switch ui.GetVerbosity() {
+	case ui.VerbosityVerbose:
 		level = "debug"
-	}
-	
-	// If quiet flag is set, override to error
-	if quiet {
+	case ui.VerbosityErrors:
+		level = "warn"
+	case ui.VerbosityQuiet:
 		level = "error"
 	}
 	
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 	"github.com/pyhub/pyhub-docs/internal/i18n"
 	. "github.com/pyhub/pyhub-docs/internal/ui"
 )
@@ -112,8 +113,8 @@ func TestInitI18n(t *testing.T) {
 
 func TestInitUI(t *testing.T) {
 	t.Run("QuietMode", func(t *testing.T) {
-		quiet = true
-		defer func() { quiet = false }()
+		quietFlag = true
+		defer func() { quietFlag = false }()
 		
 		initUI()
 		// Color should be disabled in quiet mode
@@ -123,10 +124,29 @@ func TestInitUI(t *testing.T) {
 	})
 	
 	t.Run("NormalMode", func(t *testing.T) {
-		quiet = false
+		quietFlag = false
 		initUI()
 		// Should initialize UI without issues
 	})
+
+	t.Run("ColorFlagOverridesNoColor", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		forceColor = true
+		defer func() { forceColor = false }()
+
+		DetectColor()
+		initUI()
+		if !IsColorEnabled() {
+			t.Error("--color should force color on when NO_COLOR is set")
+		}
+
+		noColor = true
+		defer func() { noColor = false }()
+		initUI()
+		if IsColorEnabled() {
+			t.Error("--no-color should take precedence over --color")
+		}
+	})
 }
 
 func TestExecute(t *testing.T) {
@@ -151,4 +171,24 @@ func TestExecute(t *testing.T) {
 			t.Error("No subcommands registered")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(errCheckFailed); got != pkgErrors.ExitCheckFailed {
+		t.Errorf("exitCode(check failed) = %d, want %d", got, pkgErrors.ExitCheckFailed)
+	}
+	if got := exitCode(pkgErrors.NewFileError("rules.yml", "loading rules", pkgErrors.ErrFileNotFound)); got != pkgErrors.ExitFile {
+		t.Errorf("exitCode(missing rules file) = %d, want %d", got, pkgErrors.ExitFile)
+	}
+
+	// Unknown flags are usage errors
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"check", "--no-such-flag"})
+	defer rootCmd.SetArgs(nil)
+	err := rootCmd.Execute()
+	if got := exitCode(err); got != pkgErrors.ExitUsage {
+		t.Errorf("exitCode(%v) = %d, want %d", err, got, pkgErrors.ExitUsage)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// stdinArg is the file name that reads standard input instead, as in
+// "--rules -"
+const stdinArg = "-"
+
+// stdinName names standard input in messages and rule sources
+const stdinName = "<stdin>"
+
+// stdin is read for stdinArg; tests replace it
+var stdin io.Reader = os.Stdin
+
+// readInput reads the named file, or standard input for stdinArg
+func readInput(path string) ([]byte, error) {
+	if path == stdinArg {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// checkSingleStdin fails when more than one of the values reads standard
+// input, which can only be consumed once per invocation
+func checkSingleStdin(flag string, values ...string) error {
+	readers := 0
+	for _, value := range values {
+		if value == stdinArg {
+			readers++
+		}
+	}
+	if readers > 1 {
+		return pkgErrors.NewValidationError(flag, stdinArg, "standard input (-) can only be read once per command")
+	}
+	return nil
+}
+
+// looksLikeJSON reports whether data, which has no file extension to go by,
+// is a JSON object or array rather than YAML
+func looksLikeJSON(data []byte) bool {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
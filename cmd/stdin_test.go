@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// withStdin makes "-" read input for the rest of the test
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	previous := stdin
+	stdin = strings.NewReader(input)
+	t.Cleanup(func() { stdin = previous })
+}
+
+func TestLoadRulesFromStdin(t *testing.T) {
+	withStdin(t, "- old: \"Draft\"\n  new: \"Final\"\n")
+	rules, err := loadRules("-")
+	if err != nil || len(rules) != 1 || rules[0].New != "Final" {
+		t.Fatalf("loadRules(-) = %+v, %v", rules, err)
+	}
+
+	withStdin(t, `[{"old": "2023", "new": "2024", "occurrence": 1}]`)
+	rules, err = loadRules("-")
+	if err != nil || len(rules) != 1 || rules[0].Occurrence != 1 {
+		t.Fatalf("loadRules(-) of JSON = %+v, %v", rules, err)
+	}
+
+	withStdin(t, "- new: \"missing old\"\n")
+	if _, err := loadRules("-"); err == nil || !strings.Contains(err.Error(), stdinName) {
+		t.Errorf("loadRules(-) error = %v, want it to name %s", err, stdinName)
+	}
+}
+
+func TestLoadValuesFromStdin(t *testing.T) {
+	withStdin(t, `{"name": "Kim", "count": 3}`)
+	values, err := loadValuesFromFile("-")
+	if err != nil || values["name"] != "Kim" {
+		t.Fatalf("loadValuesFromFile(-) of JSON = %v, %v", values, err)
+	}
+
+	withStdin(t, "name: Lee\ntitle: Report\n")
+	values, err = loadValuesFromFile("-")
+	if err != nil || values["name"] != "Lee" || values["title"] != "Report" {
+		t.Fatalf("loadValuesFromFile(-) of YAML = %v, %v", values, err)
+	}
+}
+
+func TestCheckSingleStdin(t *testing.T) {
+	if err := checkSingleStdin("rules", "base.yml", "-"); err != nil {
+		t.Errorf("one reader of stdin should pass, got %v", err)
+	}
+	if err := checkSingleStdin("rules", "-", "base.yml", "-"); err == nil {
+		t.Error("two readers of stdin should fail")
+	}
+}
+
+func TestLooksLikeJSON(t *testing.T) {
+	for input, want := range map[string]bool{
+		`{"a": 1}`:           true,
+		"\n  [1, 2]":         true,
+		"\xef\xbb\xbf{}":     true,
+		"a: 1":               false,
+		"- old: x\n  new: y": false,
+		"":                   false,
+	} {
+		if got := looksLikeJSON([]byte(input)); got != want {
+			t.Errorf("looksLikeJSON(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
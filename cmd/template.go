@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/document"
 	"github.com/pyhub/pyhub-docs/internal/i18n"
 	"github.com/pyhub/pyhub-docs/internal/template"
+	"github.com/pyhub/pyhub-docs/internal/ui"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -22,6 +24,9 @@ var (
 	templateForce bool
 	templateDryRun bool
 	templateJsonOutput bool
+	templateStripMetadata bool
+	templateOutTemplate   string
+	templateEngine        string
 )
 
 // templateCmd represents the template command
@@ -40,16 +45,39 @@ Examples:
   # Process template with values from YAML file
   dox template --template presentation.pptx --values data.yaml --output final.pptx
 
+  # Fill a native Word template (.dotx) into a regular document
+  dox template --template letter.dotx --values values.yaml --output letter.docx
+
   # Force overwrite existing file
   dox template --template template.docx --values values.yaml --output output.docx --force
 
+  # Remove author and company properties from the output
+  dox template --template template.docx --values values.yaml --output output.docx --strip-metadata
+
+  # Name the output from the values
+  dox template --template letter.docx --values kim.yaml --output-template "letter_{{name}}_{{date}}.docx"
+
+  # Use Go template syntax for conditionals and ranges
+  dox template --template report.docx --values data.yaml --output final.docx --engine gotemplate
+
 Values file format (YAML):
   title: "Annual Report"
   author: "John Doe"
   year: 2024
   items:
     - "Achievement 1"
-    - "Achievement 2"`,
+    - "Achievement 2"
+  steps:
+    style: numbered
+    items: ["Plan", "Build"]
+
+A list placeholder that stands alone in a paragraph is rendered as one bulleted
+(or numbered) paragraph per item; inside other text, items are joined with commas.
+
+With --engine gotemplate the document text is a Go text/template: use {{.title}},
+{{if .draft}}...{{end}} and {{range .items}}...{{end}}. A range repeats every
+paragraph between {{range}} and {{end}}. Paragraphs whose text changes keep only
+the formatting of their first run.`,
 	RunE: runTemplate,
 }
 
@@ -57,15 +85,21 @@ func init() {
 	rootCmd.AddCommand(templateCmd)
 
 	templateCmd.Flags().StringVarP(&templatePath, "template", "t", "", "Template file path (required)")
-	templateCmd.Flags().StringVar(&valuesFile, "values", "", "Values file (YAML or JSON)")
+	templateCmd.Flags().StringVar(&valuesFile, "values", "", "Values file (YAML or JSON), or - to read it from standard input")
 	templateCmd.Flags().StringArrayVar(&setValues, "set", []string{}, "Set individual values (format: key=value)")
-	templateCmd.Flags().StringVarP(&templateOut, "output", "o", "", "Output file path (required)")
+	templateCmd.Flags().StringVarP(&templateOut, "output", "o", "", "Output file path (required unless --output-template is given)")
+	templateCmd.Flags().StringVar(&templateOutTemplate, "output-template", "", "Name the output from the values, e.g. \"letter_{{name}}_{{date}}.docx\"")
 	templateCmd.Flags().BoolVar(&templateForce, "force", false, "Overwrite existing output file")
 	templateCmd.Flags().BoolVar(&templateDryRun, "dry-run", false, "Preview operation without creating files")
 	templateCmd.Flags().BoolVar(&templateJsonOutput, "json", false, "Output in JSON format")
+	templateCmd.Flags().BoolVar(&templateStripMetadata, "strip-metadata", false, "Remove author, company and other document properties from the output")
+	templateCmd.Flags().StringVar(&templateEngine, "engine", template.EngineSimple, "Template engine: simple ({{name}} placeholders) or gotemplate (Go text/template)")
 
 	templateCmd.MarkFlagRequired("template")
-	templateCmd.MarkFlagRequired("output")
+
+	templateCmd.AddCommand(templatePlaceholdersCmd)
+	templatePlaceholdersCmd.Flags().StringVarP(&placeholdersTemplate, "template", "t", "", "Template file path")
+	templatePlaceholdersCmd.Flags().BoolVar(&placeholdersJSON, "json", false, "Output in JSON format")
 	
 	// Update descriptions after i18n initialization
 	cobra.OnInitialize(func() {
@@ -74,23 +108,139 @@ func init() {
 	})
 }
 
+// templatePlaceholdersCmd lists the placeholders a template expects
+var templatePlaceholdersCmd = &cobra.Command{
+	Use:   "placeholders [template-file]",
+	Short: "List the placeholders used in a template",
+	Long: `List every {{placeholder}} used in a Word or PowerPoint template, so you
+know which values to provide before writing a values file.
+
+Placeholders may carry a content-type hint, as in {{intro:blog}}, naming the
+'dox generate --type' to use for that section. Values are still keyed by the
+plain name (intro); placeholders without a hint use the global --type.
+
+Examples:
+  dox template placeholders report.docx
+  dox template placeholders --template deck.pptx --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplatePlaceholders,
+}
+
+var (
+	placeholdersTemplate string
+	placeholdersJSON     bool
+)
+
+func runTemplatePlaceholders(cmd *cobra.Command, args []string) error {
+	path := placeholdersTemplate
+	if len(args) == 1 {
+		if path != "" && path != args[0] {
+			return pkgErrors.NewValidationError("template", args[0], "give the template either as an argument or with --template, not both")
+		}
+		path = args[0]
+	}
+	if path == "" {
+		return pkgErrors.NewValidationError("template", path, "template file is required")
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return pkgErrors.LocalizedFileNotFoundError(path)
+	}
+
+	placeholders, templateType, err := extractTemplatePlaceholderDetails(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(placeholders))
+	contentTypes := make(map[string]string)
+	for _, placeholder := range placeholders {
+		names = append(names, placeholder.Name)
+		if placeholder.ContentType == "" {
+			continue
+		}
+		contentTypes[placeholder.Name] = placeholder.ContentType
+		if err := validateContentType(placeholder.ContentType); err != nil {
+			ui.PrintWarning("{{%s}}: unknown content type %q; generation falls back to --type", placeholder.Name, placeholder.ContentType)
+		}
+	}
+
+	if placeholdersJSON {
+		result := map[string]interface{}{
+			"template": map[string]interface{}{
+				"path": path,
+				"type": templateType,
+			},
+			"placeholders": names,
+		}
+		if len(contentTypes) > 0 {
+			result["contentTypes"] = contentTypes
+		}
+		jsonBytes, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Template: %s (%s)\n", path, templateType)
+	fmt.Fprintf(out, "Placeholders found: %d\n", len(placeholders))
+	for _, placeholder := range placeholders {
+		if placeholder.ContentType != "" {
+			fmt.Fprintf(out, "  {{%s}} (content type: %s)\n", placeholder.Name, placeholder.ContentType)
+		} else {
+			fmt.Fprintf(out, "  {{%s}}\n", placeholder.Name)
+		}
+	}
+	return nil
+}
+
+// extractTemplatePlaceholders returns the unique placeholder names of a
+// template in order of appearance, along with a readable document type
+func extractTemplatePlaceholders(path string) ([]string, string, error) {
+	placeholders, templateType, err := extractTemplatePlaceholderDetails(path)
+	if err != nil {
+		return nil, "", err
+	}
+	names := make([]string, 0, len(placeholders))
+	for _, placeholder := range placeholders {
+		names = append(names, placeholder.Name)
+	}
+	return names, templateType, nil
+}
+
+// extractTemplatePlaceholderDetails is extractTemplatePlaceholders with each
+// placeholder's content-type hint
+func extractTemplatePlaceholderDetails(path string) ([]template.Placeholder, string, error) {
+	var placeholders []template.Placeholder
+	var templateType string
+	var err error
+
+	switch {
+	case document.IsWordFile(path):
+		placeholders, err = template.NewWordProcessor().ExtractPlaceholderDetails(path)
+		templateType = "Word Document"
+	case document.IsPowerPointFile(path):
+		placeholders, err = template.NewPowerPointProcessor().ExtractPlaceholderDetails(path)
+		templateType = "PowerPoint Presentation"
+	default:
+		return nil, "", fmt.Errorf("unsupported template format: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to extract placeholders: %w", err)
+	}
+
+	return placeholders, templateType, nil
+}
+
 func runTemplate(cmd *cobra.Command, args []string) error {
+	if err := template.ValidateEngine(templateEngine); err != nil {
+		return pkgErrors.NewValidationError("engine", templateEngine, err.Error())
+	}
+
 	// Check if template file exists
 	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
 		return pkgErrors.LocalizedFileNotFoundError(templatePath)
 	}
 
-	// Check if output file exists and force flag is not set
-	if !templateForce {
-		if _, err := os.Stat(templateOut); err == nil {
-			return pkgErrors.NewError(pkgErrors.ErrCodeFileAlreadyExists, fmt.Sprintf("Output file already exists: %s", templateOut)).
-				WithContext("path", templateOut).
-				WithSuggestion("Use --force to overwrite the existing file").
-				WithSuggestion("Or choose a different output filename").
-				Build()
-		}
-	}
-
 	// Load values
 	values := make(map[string]interface{})
 
@@ -127,34 +277,54 @@ func runTemplate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Name the output from the values
+	if templateOutTemplate != "" {
+		if templateOut != "" {
+			return pkgErrors.NewValidationError("output-template", templateOutTemplate, "--output and --output-template cannot be used together")
+		}
+		names, err := template.NewFilenameTemplate(templateOutTemplate)
+		if err != nil {
+			return pkgErrors.NewValidationError("output-template", templateOutTemplate, err.Error())
+		}
+		if templateOut, err = names.Resolve(1, values); err != nil {
+			return pkgErrors.NewValidationError("output-template", templateOutTemplate, err.Error())
+		}
+	}
+	if templateOut == "" {
+		return pkgErrors.NewValidationError("output", templateOut, "output file is required (--output or --output-template)")
+	}
+
+	// Check if output file exists and force flag is not set
+	if !templateForce {
+		if _, err := os.Stat(templateOut); err == nil {
+			return pkgErrors.NewError(pkgErrors.ErrCodeFileAlreadyExists, fmt.Sprintf("Output file already exists: %s", templateOut)).
+				WithContext("path", templateOut).
+				WithSuggestion("Use --force to overwrite the existing file").
+				WithSuggestion("Or choose a different output filename").
+				Build()
+		}
+	}
+
 	// Determine document type from template extension
 	ext := strings.ToLower(filepath.Ext(templatePath))
+
+	// A .dotx/.potx template may produce a regular document, but the output
+	// must stay in the template's application format
+	if (document.IsWordFile(templatePath) && !document.IsWordFile(templateOut)) ||
+		(document.IsPowerPointFile(templatePath) && !document.IsPowerPointFile(templateOut)) {
+		return pkgErrors.NewValidationError("output", templateOut,
+			fmt.Sprintf("output file must have the same document format as the %s template", ext))
+	}
 	
 	// Handle dry-run mode
+	if templateDryRun && templateEngine == template.EngineGoTemplate {
+		return dryRunGoTemplate(cmd, values)
+	}
 	if templateDryRun {
 		// Get template information
-		var placeholders []string
-		var templateType string
-		
-		switch ext {
-		case ".docx":
-			processor := template.NewWordProcessor()
-			foundPlaceholders, err := processor.ExtractPlaceholders(templatePath)
-			if err != nil {
-				return fmt.Errorf("failed to extract placeholders: %w", err)
-			}
-			placeholders = foundPlaceholders
-			templateType = "Word Document"
-		case ".pptx":
-			processor := template.NewPowerPointProcessor()
-			foundPlaceholders, err := processor.ExtractPlaceholders(templatePath)
-			if err != nil {
-				return fmt.Errorf("failed to extract placeholders: %w", err)
-			}
-			placeholders = foundPlaceholders
-			templateType = "PowerPoint Presentation"
-		default:
-			return fmt.Errorf("unsupported template format: %s", ext)
+		placeholders, templateType, err := extractTemplatePlaceholders(templatePath)
+		if err != nil {
+			return err
 		}
 		
 		// Check which placeholders will be replaced
@@ -222,9 +392,11 @@ func runTemplate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	
-	switch ext {
-	case ".docx":
+	switch {
+	case document.IsWordFile(templatePath):
 		processor := template.NewWordProcessor()
+		processor.StripMetadata = templateStripMetadata
+		processor.Engine = templateEngine
 		
 		// Validate template
 		missing, err := processor.ValidateTemplate(templatePath, values)
@@ -252,8 +424,10 @@ func runTemplate(cmd *cobra.Command, args []string) error {
 			}))
 		}
 		
-	case ".pptx":
+	case document.IsPowerPointFile(templatePath):
 		processor := template.NewPowerPointProcessor()
+		processor.StripMetadata = templateStripMetadata
+		processor.Engine = templateEngine
 		
 		// Validate template
 		missing, err := processor.ValidateTemplate(templatePath, values)
@@ -285,7 +459,7 @@ func runTemplate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%s", i18n.T(i18n.MsgErrorUnsupported, map[string]interface{}{
 			"Type":      "template format",
 			"Value":     ext,
-			"Supported": ".docx, .pptx",
+			"Supported": ".docx, .dotx, .pptx, .potx",
 		}))
 	}
 
@@ -295,17 +469,78 @@ func runTemplate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// dryRunGoTemplate checks that the template renders with the Go template
+// engine and reports what would be created. Go templates have no fixed
+// placeholder list, so rendering is the only meaningful check.
+func dryRunGoTemplate(cmd *cobra.Command, values map[string]interface{}) error {
+	var templateType string
+	var err error
+	switch {
+	case document.IsWordFile(templatePath):
+		templateType = "Word Document"
+		processor := template.NewWordProcessor()
+		processor.Engine = template.EngineGoTemplate
+		_, err = processor.ValidateTemplate(templatePath, values)
+	case document.IsPowerPointFile(templatePath):
+		templateType = "PowerPoint Presentation"
+		processor := template.NewPowerPointProcessor()
+		processor.Engine = template.EngineGoTemplate
+		_, err = processor.ValidateTemplate(templatePath, values)
+	default:
+		return fmt.Errorf("unsupported template format: %s", filepath.Ext(templatePath))
+	}
+	if err != nil {
+		return fmt.Errorf("%s", i18n.T(i18n.MsgErrorValidate, map[string]interface{}{
+			"Type":  "template",
+			"Error": err.Error(),
+		}))
+	}
+
+	out := cmd.OutOrStdout()
+	if templateJsonOutput {
+		jsonBytes, _ := json.MarshalIndent(map[string]interface{}{
+			"operation": "template",
+			"engine":    template.EngineGoTemplate,
+			"template": map[string]interface{}{
+				"path": templatePath,
+				"type": templateType,
+			},
+			"values": values,
+			"output": templateOut,
+		}, "", "  ")
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintln(out, "=== DRY-RUN MODE ===")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Template: %s (%s)\n", templatePath, templateType)
+	fmt.Fprintf(out, "Output:   %s\n", templateOut)
+	fmt.Fprintf(out, "Engine:   %s\n", template.EngineGoTemplate)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "The template renders with the given values.")
+	fmt.Fprintln(out, "No files were created. Remove --dry-run to execute.")
+	return nil
+}
+
 // loadValuesFromFile loads values from a YAML or JSON file
 func loadValuesFromFile(path string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(path)
+	data, err := readInput(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	values := make(map[string]interface{})
 
-	// Try to determine format from extension
+	// Try to determine format from extension; standard input has none, so
+	// its content decides
 	ext := strings.ToLower(filepath.Ext(path))
+	if path == stdinArg {
+		ext = ".yaml"
+		if looksLikeJSON(data) {
+			ext = ".json"
+		}
+	}
 	
 	switch ext {
 	case ".yaml", ".yml":
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"archive/zip"
 	"bytes"
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/pyhub/pyhub-docs/internal/document"
+	"github.com/pyhub/pyhub-docs/internal/template"
 	"github.com/spf13/cobra"
 )
 
@@ -223,4 +228,348 @@ func TestTemplateCommand(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestTemplatePlaceholdersCommand(t *testing.T) {
+	dir := t.TempDir()
+	docxPath := filepath.Join(dir, "report.docx")
+	writeZip(t, docxPath, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>{{title}} by {{author}}</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>Year: {{year}}, again {{title}}</w:t></w:r></w:p></w:body></w:document>`,
+	})
+	pptxPath := filepath.Join(dir, "deck.pptx")
+	writeZip(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml": `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+			`<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>{{company}}</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`,
+	})
+
+	defer func() {
+		placeholdersTemplate = ""
+		placeholdersJSON = false
+	}()
+
+	t.Run("Word text output", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		templatePlaceholdersCmd.SetOut(buf)
+		placeholdersJSON = false
+
+		if err := runTemplatePlaceholders(templatePlaceholdersCmd, []string{docxPath}); err != nil {
+			t.Fatalf("runTemplatePlaceholders failed: %v", err)
+		}
+		output := buf.String()
+		for _, expected := range []string{"Placeholders found: 3", "{{title}}", "{{author}}", "{{year}}"} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("output missing %q:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("PowerPoint JSON output", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		templatePlaceholdersCmd.SetOut(buf)
+		placeholdersTemplate = pptxPath
+		placeholdersJSON = true
+
+		if err := runTemplatePlaceholders(templatePlaceholdersCmd, nil); err != nil {
+			t.Fatalf("runTemplatePlaceholders failed: %v", err)
+		}
+		var result struct {
+			Placeholders []string `json:"placeholders"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		if len(result.Placeholders) != 1 || result.Placeholders[0] != "company" {
+			t.Errorf("placeholders = %v, want [company]", result.Placeholders)
+		}
+	})
+
+	t.Run("Content-type hints", func(t *testing.T) {
+		hintedPath := filepath.Join(dir, "sections.docx")
+		writeZip(t, hintedPath, map[string]string{
+			"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+				`<w:p><w:r><w:t>{{title}}</w:t></w:r></w:p>` +
+				`<w:p><w:r><w:t>{{intro:blog}}</w:t></w:r></w:p>` +
+				`<w:p><w:r><w:t>{{closing:email}}</w:t></w:r></w:p></w:body></w:document>`,
+		})
+
+		buf := new(bytes.Buffer)
+		templatePlaceholdersCmd.SetOut(buf)
+		placeholdersTemplate = ""
+		placeholdersJSON = false
+		if err := runTemplatePlaceholders(templatePlaceholdersCmd, []string{hintedPath}); err != nil {
+			t.Fatalf("runTemplatePlaceholders failed: %v", err)
+		}
+		for _, expected := range []string{"  {{title}}\n", "{{intro}} (content type: blog)", "{{closing}} (content type: email)"} {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("output missing %q:\n%s", expected, buf.String())
+			}
+		}
+
+		buf.Reset()
+		placeholdersJSON = true
+		if err := runTemplatePlaceholders(templatePlaceholdersCmd, []string{hintedPath}); err != nil {
+			t.Fatalf("runTemplatePlaceholders failed: %v", err)
+		}
+		var result struct {
+			Placeholders []string          `json:"placeholders"`
+			ContentTypes map[string]string `json:"contentTypes"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+		}
+		if strings.Join(result.Placeholders, ",") != "title,intro,closing" {
+			t.Errorf("placeholders = %v, want [title intro closing]", result.Placeholders)
+		}
+		if len(result.ContentTypes) != 2 || result.ContentTypes["intro"] != "blog" || result.ContentTypes["closing"] != "email" {
+			t.Errorf("contentTypes = %v", result.ContentTypes)
+		}
+	})
+
+	t.Run("Missing template", func(t *testing.T) {
+		placeholdersTemplate = ""
+		if err := runTemplatePlaceholders(templatePlaceholdersCmd, nil); err == nil {
+			t.Error("expected error without a template")
+		}
+	})
+}
+
+// writeZip creates a zip archive with the given entries
+func writeZip(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, content := range entries {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTemplateCommandDotx(t *testing.T) {
+	dir := t.TempDir()
+	dotxPath := filepath.Join(dir, "letter.dotx")
+	writeZip(t, dotxPath, map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"/></Types>`,
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>Dear {{name}}</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	defer func() {
+		templatePath = ""
+		templateOut = ""
+		setValues = nil
+	}()
+
+	cmd := &cobra.Command{}
+	*cmd = *templateCmd
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	t.Run("Output in another format is rejected", func(t *testing.T) {
+		templatePath = dotxPath
+		templateOut = filepath.Join(dir, "letter.pptx")
+		if err := cmd.RunE(cmd, []string{}); err == nil {
+			t.Error("expected an error for a .pptx output from a Word template")
+		}
+	})
+
+	t.Run("Word template produces a document", func(t *testing.T) {
+		templatePath = dotxPath
+		templateOut = filepath.Join(dir, "letter.docx")
+		setValues = []string{"name=Jane"}
+
+		if err := cmd.RunE(cmd, []string{}); err != nil {
+			t.Fatalf("template command failed: %v", err)
+		}
+
+		doc, err := document.OpenWordDocument(templateOut)
+		if err != nil {
+			t.Fatalf("failed to open output: %v", err)
+		}
+		defer doc.Close()
+		if text, _ := doc.GetText(); text != "Dear Jane" {
+			t.Errorf("output text = %q", text)
+		}
+
+		reader, err := zip.OpenReader(templateOut)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		for _, f := range reader.File {
+			if f.Name != "[Content_Types].xml" {
+				continue
+			}
+			rc, _ := f.Open()
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			if !strings.Contains(string(data), "wordprocessingml.document.main+xml") {
+				t.Errorf("output should be declared as a document:\n%s", data)
+			}
+		}
+	})
+}
+
+func TestTemplateCommandOutputTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templateFile := filepath.Join(dir, "letter.docx")
+	writeZip(t, templateFile, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>Dear {{name}}</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	defer func() {
+		templatePath = ""
+		templateOut = ""
+		templateOutTemplate = ""
+		setValues = nil
+	}()
+
+	cmd := &cobra.Command{}
+	*cmd = *templateCmd
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	t.Run("Output is named from the values", func(t *testing.T) {
+		templatePath = templateFile
+		templateOut = ""
+		templateOutTemplate = filepath.Join(dir, "letter_{{name}}.docx")
+		setValues = []string{"name=Kim/Lee"}
+
+		if err := cmd.RunE(cmd, []string{}); err != nil {
+			t.Fatalf("template command failed: %v", err)
+		}
+		want := filepath.Join(dir, "letter_Kim_Lee.docx")
+		if templateOut != want {
+			t.Errorf("output = %q, want %q", templateOut, want)
+		}
+		doc, err := document.OpenWordDocument(want)
+		if err != nil {
+			t.Fatalf("failed to open output: %v", err)
+		}
+		defer doc.Close()
+		if text, _ := doc.GetText(); text != "Dear Kim/Lee" {
+			t.Errorf("output text = %q", text)
+		}
+	})
+
+	t.Run("Missing value is an error", func(t *testing.T) {
+		templatePath = templateFile
+		templateOut = ""
+		templateOutTemplate = filepath.Join(dir, "letter_{{company}}.docx")
+		setValues = []string{"name=Kim"}
+
+		err := cmd.RunE(cmd, []string{})
+		if err == nil || !strings.Contains(err.Error(), "{{company}}") {
+			t.Errorf("expected missing value error, got %v", err)
+		}
+	})
+
+	t.Run("Output and output template together", func(t *testing.T) {
+		templatePath = templateFile
+		templateOut = filepath.Join(dir, "x.docx")
+		templateOutTemplate = "letter_{{name}}.docx"
+
+		if err := cmd.RunE(cmd, []string{}); err == nil {
+			t.Error("expected an error when both --output and --output-template are given")
+		}
+	})
+}
+
+func TestTemplateCommandGoTemplateEngine(t *testing.T) {
+	dir := t.TempDir()
+	templateFile := filepath.Join(dir, "report.docx")
+	writeZip(t, templateFile, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>{{.title}}</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>{{range .items}}</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:rPr><w:b/></w:rPr><w:t>- {{.}}</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>{{end}}</w:t></w:r></w:p>` +
+			`</w:body></w:document>`,
+	})
+	valuesPath := filepath.Join(dir, "values.yaml")
+	if err := os.WriteFile(valuesPath, []byte("title: Report\nitems:\n  - Alpha\n  - Beta\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		templatePath = ""
+		templateOut = ""
+		valuesFile = ""
+		templateEngine = template.EngineSimple
+		templateDryRun = false
+	}()
+
+	cmd := &cobra.Command{}
+	*cmd = *templateCmd
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+
+	t.Run("Range repeats paragraphs", func(t *testing.T) {
+		templatePath = templateFile
+		templateOut = filepath.Join(dir, "report_out.docx")
+		valuesFile = valuesPath
+		templateEngine = template.EngineGoTemplate
+
+		if err := cmd.RunE(cmd, []string{}); err != nil {
+			t.Fatalf("template command failed: %v", err)
+		}
+		doc, err := document.OpenWordDocument(templateOut)
+		if err != nil {
+			t.Fatalf("failed to open output: %v", err)
+		}
+		defer doc.Close()
+		paragraphs, _ := doc.ParagraphTexts()
+		if got := strings.Join(paragraphs, "|"); got != "Report|- Alpha|- Beta" {
+			t.Errorf("output paragraphs = %q", got)
+		}
+	})
+
+	t.Run("Dry run reports undefined values", func(t *testing.T) {
+		templatePath = templateFile
+		templateOut = filepath.Join(dir, "dry.docx")
+		valuesFile = ""
+		templateEngine = template.EngineGoTemplate
+		templateDryRun = true
+
+		if err := cmd.RunE(cmd, []string{}); err == nil {
+			t.Error("expected an error for undefined values")
+		}
+
+		valuesFile = valuesPath
+		out.Reset()
+		if err := cmd.RunE(cmd, []string{}); err != nil {
+			t.Fatalf("dry run failed: %v", err)
+		}
+		if !strings.Contains(out.String(), "renders with the given values") {
+			t.Errorf("unexpected dry-run output:\n%s", out.String())
+		}
+		if _, err := os.Stat(templateOut); !os.IsNotExist(err) {
+			t.Error("dry run should not create the output")
+		}
+	})
+
+	t.Run("Unknown engine", func(t *testing.T) {
+		templateEngine = "jinja"
+		templateDryRun = false
+		if err := cmd.RunE(cmd, []string{}); err == nil {
+			t.Error("expected an error for an unknown engine")
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/export"
+	"github.com/pyhub/pyhub-docs/internal/generate"
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokensModel string
+	tokensText  string
+	tokensFile  string
+	tokensPath  string
+	tokensJSON  bool
+)
+
+// tokensCmd estimates the token count of a prompt or document
+var tokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Estimate how many tokens a text or document uses",
+	Long: `Estimate the number of tokens a text takes up for a model, and how much of
+the model's context window that is, without calling the API. Use it to budget
+prompts and to check that a document fits before passing it to 'dox generate'.
+
+The text is given with exactly one of --text, --file (a plain text file) or
+--path (a Word, PowerPoint or PDF document, whose extracted text is counted).
+The count is the same estimate 'dox generate --dry-run' shows; actual
+tokenization differs by model and may vary by about 10-20%.
+
+Examples:
+  # Count the tokens of a prompt
+  dox tokens --model gpt-4 --text "Summarize the quarterly report"
+
+  # Check whether a prompt file fits Claude's context window
+  dox tokens --model sonnet --file prompt.txt
+
+  # Count the text of a document as JSON
+  dox tokens --path report.docx --json`,
+	SilenceUsage: true,
+	RunE:         runTokens,
+}
+
+func init() {
+	rootCmd.AddCommand(tokensCmd)
+
+	tokensCmd.Flags().StringVarP(&tokensModel, "model", "m", "", "Model to count for, or a model alias (default: generate.model from config, else gpt-3.5-turbo)")
+	tokensCmd.Flags().StringVarP(&tokensText, "text", "t", "", "Text to count")
+	tokensCmd.Flags().StringVarP(&tokensFile, "file", "f", "", "Text file to count")
+	tokensCmd.Flags().StringVarP(&tokensPath, "path", "p", "", "Word, PowerPoint or PDF document whose text to count")
+	tokensCmd.Flags().BoolVar(&tokensJSON, "json", false, "Output the estimate in JSON format")
+}
+
+// tokenCount is the estimate for one text
+type tokenCount struct {
+	Model         string `json:"model"`
+	Source        string `json:"source"`
+	Characters    int    `json:"characters"`
+	Words         int    `json:"words"`
+	Tokens        int    `json:"tokens"`
+	ContextWindow int    `json:"contextWindow"`
+	FitsContext   bool   `json:"fitsContext"`
+}
+
+func runTokens(cmd *cobra.Command, args []string) error {
+	sources := 0
+	for _, value := range []string{tokensText, tokensFile, tokensPath} {
+		if value != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return pkgErrors.NewValidationError("text", tokensText, "give exactly one of --text, --file or --path")
+	}
+
+	model := tokensModel
+	var aliases map[string]string
+	if appConfig != nil {
+		aliases = appConfig.Models
+		if model == "" {
+			model = appConfig.Generate.Model
+		}
+	}
+	if model == "" {
+		model = "gpt-3.5-turbo"
+	}
+	model = resolveModel(model, aliases)
+
+	text, source, err := readTokensInput()
+	if err != nil {
+		return err
+	}
+	return writeTokenCount(cmd.OutOrStdout(), countTokens(model, source, text), tokensJSON)
+}
+
+// readTokensInput returns the text to count and a description of where it
+// came from
+func readTokensInput() (string, string, error) {
+	switch {
+	case tokensFile != "":
+		data, err := os.ReadFile(tokensFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return "", "", pkgErrors.NewFileError(tokensFile, "counting tokens", pkgErrors.ErrFileNotFound)
+			}
+			return "", "", pkgErrors.NewFileError(tokensFile, "counting tokens", err)
+		}
+		return string(data), tokensFile, nil
+	case tokensPath != "":
+		text, err := documentText(tokensPath)
+		return text, tokensPath, err
+	}
+	return tokensText, "text", nil
+}
+
+// documentText extracts the text of a Word, PowerPoint or PDF document, as
+// it would be given to a model
+func documentText(path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", pkgErrors.NewFileError(path, "counting tokens", pkgErrors.ErrFileNotFound)
+		}
+		return "", pkgErrors.NewFileError(path, "counting tokens", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".pdf" {
+		result, err := extractPDF(path, pdf.ExtractorOptions{MinQuality: 0.2})
+		if err != nil {
+			return "", err
+		}
+		text, err := export.NewConverter(result).Convert(export.FormatMarkdown)
+		if err != nil {
+			return "", fmt.Errorf("conversion failed: %w", err)
+		}
+		return text, nil
+	}
+	if !document.IsWordFile(path) && !document.IsPowerPointFile(path) {
+		return "", pkgErrors.NewDocumentError(path, ext,
+			"token counts are only available for text, Word, PowerPoint and PDF files", pkgErrors.ErrUnsupportedFormat)
+	}
+
+	doc, err := document.Open(path)
+	if err != nil {
+		return "", pkgErrors.NewDocumentError(path, ext, "failed to open document", err)
+	}
+	defer doc.Close()
+
+	text, err := doc.GetPlainText()
+	if err != nil {
+		return "", pkgErrors.NewDocumentError(path, ext, "failed to extract text", err)
+	}
+	return text, nil
+}
+
+// countTokens estimates the tokens of text for a model
+func countTokens(model, source, text string) tokenCount {
+	estimator := generate.NewTokenEstimator(model)
+	tokens := estimator.EstimateTokens(text)
+	contextWindow := estimator.GetModelInfo().ContextWindow
+	return tokenCount{
+		Model:         model,
+		Source:        source,
+		Characters:    len([]rune(text)),
+		Words:         len(strings.Fields(text)),
+		Tokens:        tokens,
+		ContextWindow: contextWindow,
+		FitsContext:   tokens <= contextWindow,
+	}
+}
+
+// writeTokenCount prints the estimate as aligned text or as JSON
+func writeTokenCount(out io.Writer, count tokenCount, asJSON bool) error {
+	if asJSON {
+		jsonBytes, err := json.MarshalIndent(count, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Model:       %s\n", count.Model)
+	fmt.Fprintf(out, "Source:      %s\n", count.Source)
+	fmt.Fprintf(out, "Characters:  %d\n", count.Characters)
+	fmt.Fprintf(out, "Words:       %d\n", count.Words)
+	fmt.Fprintf(out, "Tokens:      ~%d\n", count.Tokens)
+	if count.ContextWindow > 0 {
+		fmt.Fprintf(out, "Context:     %.1f%% of %d tokens\n",
+			float64(count.Tokens)*100/float64(count.ContextWindow), count.ContextWindow)
+	}
+	if !count.FitsContext {
+		fmt.Fprintf(out, "\n⚠️  The text does not fit the context window of %s\n", count.Model)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestRunTokens(t *testing.T) {
+	dir := t.TempDir()
+	promptPath := filepath.Join(dir, "prompt.txt")
+	if err := os.WriteFile(promptPath, []byte("Summarize the quarterly report in three bullet points"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	docxPath := filepath.Join(dir, "report.docx")
+	writeZip(t, docxPath, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:r><w:t>Revenue grew in every region</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	defer func() { tokensModel, tokensText, tokensFile, tokensPath, tokensJSON = "", "", "", "", false }()
+
+	tests := []struct {
+		name       string
+		text       string
+		file       string
+		path       string
+		wantSource string
+		wantWords  int
+	}{
+		{"text", "Hello world", "", "", "text", 2},
+		{"text file", "", promptPath, "", promptPath, 8},
+		{"Word document", "", "", docxPath, docxPath, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokensModel, tokensText, tokensFile, tokensPath, tokensJSON = "gpt-4", tt.text, tt.file, tt.path, true
+			buf := new(bytes.Buffer)
+			tokensCmd.SetOut(buf)
+
+			if err := runTokens(tokensCmd, nil); err != nil {
+				t.Fatalf("runTokens() error = %v", err)
+			}
+			var got tokenCount
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+			if got.Model != "gpt-4" || got.Source != tt.wantSource || got.Words != tt.wantWords {
+				t.Errorf("count = %+v", got)
+			}
+			if got.Tokens <= 0 || got.ContextWindow != 8192 || !got.FitsContext {
+				t.Errorf("count = %+v, want a positive estimate within gpt-4's context", got)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		tokensText, tokensFile, tokensPath = "", filepath.Join(dir, "missing.txt"), ""
+		if err := runTokens(tokensCmd, nil); !errors.Is(err, pkgErrors.ErrFileNotFound) {
+			t.Errorf("expected file not found, got %v", err)
+		}
+	})
+
+	t.Run("more than one source", func(t *testing.T) {
+		tokensText, tokensFile, tokensPath = "Hello", promptPath, ""
+		var validationErr *pkgErrors.ValidationError
+		if err := runTokens(tokensCmd, nil); !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestWriteTokenCount(t *testing.T) {
+	buf := new(bytes.Buffer)
+	count := tokenCount{Model: "gpt-4", Source: "text", Characters: 40000, Words: 8000, Tokens: 10000, ContextWindow: 8192}
+	if err := writeTokenCount(buf, count, false); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Tokens:      ~10000", "122.1% of 8192 tokens", "does not fit the context window of gpt-4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -15,9 +15,11 @@ type AIRequest struct {
 	Provider    string  `json:"provider"`    // "openai" or "claude"
 	Model       string  `json:"model"`
 	Prompt      string  `json:"prompt"`
+	System      string  `json:"system,omitempty"` // System message sent with the prompt
 	ContentType string  `json:"content_type"`
 	MaxTokens   int     `json:"max_tokens"`
 	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p,omitempty"`
 }
 
 // Hash generates a unique hash for the request
@@ -27,9 +29,11 @@ func (r *AIRequest) Hash() string {
 		Provider:    strings.ToLower(r.Provider),
 		Model:       strings.ToLower(r.Model),
 		Prompt:      strings.TrimSpace(r.Prompt),
+		System:      strings.TrimSpace(r.System),
 		ContentType: strings.ToLower(r.ContentType),
 		MaxTokens:   r.MaxTokens,
 		Temperature: r.Temperature,
+		TopP:        r.TopP,
 	}
 	
 	// Create JSON representation
@@ -86,6 +90,38 @@ func (c *AICache) Get(ctx context.Context, request *AIRequest) (*AIResponse, boo
 	return response, true
 }
 
+// Peek returns the cached response for a request without affecting the
+// cache: hits and misses are not counted and nothing is added, removed or
+// reordered. Caches that do not implement Peeker are read with Get.
+func (c *AICache) Peek(ctx context.Context, request *AIRequest) (*AIResponse, bool) {
+	key := c.buildKey(request)
+	
+	var value interface{}
+	var found bool
+	if peeker, ok := c.cache.(Peeker); ok {
+		value, found = peeker.Peek(ctx, key)
+	} else {
+		value, found = c.cache.Get(ctx, key)
+	}
+	if !found {
+		return nil, false
+	}
+	response, ok := value.(*AIResponse)
+	return response, ok
+}
+
+// Has reports whether a response for the request is cached, without
+// affecting the cache (see Peek)
+func (c *AICache) Has(ctx context.Context, request *AIRequest) bool {
+	_, found := c.Peek(ctx, request)
+	return found
+}
+
+// Key returns the cache key a request is stored under
+func (c *AICache) Key(request *AIRequest) string {
+	return c.buildKey(request)
+}
+
 // Set stores an AI response in the cache
 func (c *AICache) Set(ctx context.Context, request *AIRequest, response *AIResponse) error {
 	key := c.buildKey(request)
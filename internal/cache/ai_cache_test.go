@@ -142,6 +142,99 @@ func TestAICache_CacheMiss(t *testing.T) {
 	}
 }
 
+func TestAICache_Peek(t *testing.T) {
+	ctx := context.Background()
+	lruCache := NewLRUCache(DefaultOptions())
+	defer lruCache.Close()
+
+	aiCache := NewAICache(lruCache, 1*time.Hour)
+
+	request := &AIRequest{
+		Provider:    "openai",
+		Model:       "gpt-4",
+		Prompt:      "Peeked prompt",
+		ContentType: "custom",
+		MaxTokens:   100,
+	}
+
+	// A miss neither counts nor populates the cache
+	if aiCache.Has(ctx, request) {
+		t.Fatal("Has() = true for uncached request")
+	}
+	if stats := aiCache.Stats(); stats.Misses != 0 || stats.Size != 0 {
+		t.Errorf("Peek changed the cache on miss: misses=%d size=%d", stats.Misses, stats.Size)
+	}
+
+	if err := aiCache.Set(ctx, request, &AIResponse{Content: "cached"}); err != nil {
+		t.Fatalf("Failed to set response: %v", err)
+	}
+	response, found := aiCache.Peek(ctx, request)
+	if !found || response.Content != "cached" || response.Timestamp.IsZero() {
+		t.Errorf("Peek() = %+v, %v, want the cached response with its timestamp", response, found)
+	}
+	if stats := aiCache.Stats(); stats.Hits != 0 {
+		t.Errorf("Peek counted %d hit(s)", stats.Hits)
+	}
+
+	if key := aiCache.Key(request); key != "ai:openai:"+request.Hash() {
+		t.Errorf("Key() = %q, want the key Set stores under", key)
+	}
+}
+
+func TestAICache_SystemPromptInKey(t *testing.T) {
+	ctx := context.Background()
+	lruCache := NewLRUCache(DefaultOptions())
+	defer lruCache.Close()
+
+	aiCache := NewAICache(lruCache, 1*time.Hour)
+
+	blogRequest := &AIRequest{
+		Provider:    "openai",
+		Model:       "gpt-3.5-turbo",
+		Prompt:      "Same user prompt",
+		System:      "You are a professional blog writer.",
+		ContentType: "custom",
+		MaxTokens:   100,
+		Temperature: 0.7,
+	}
+	reportRequest := *blogRequest
+	reportRequest.System = "You are a business analyst."
+
+	if blogRequest.Hash() == reportRequest.Hash() {
+		t.Fatal("Requests with different system prompts have the same hash")
+	}
+
+	if err := aiCache.Set(ctx, blogRequest, &AIResponse{Content: "blog response"}); err != nil {
+		t.Fatalf("Failed to set response: %v", err)
+	}
+	if err := aiCache.Set(ctx, &reportRequest, &AIResponse{Content: "report response"}); err != nil {
+		t.Fatalf("Failed to set response: %v", err)
+	}
+
+	for _, tc := range []struct {
+		request *AIRequest
+		want    string
+	}{
+		{blogRequest, "blog response"},
+		{&reportRequest, "report response"},
+	} {
+		cached, found := aiCache.Get(ctx, tc.request)
+		if !found {
+			t.Fatalf("Expected cached response for system prompt %q", tc.request.System)
+		}
+		if cached.Content != tc.want {
+			t.Errorf("System prompt %q: got %q, want %q", tc.request.System, cached.Content, tc.want)
+		}
+	}
+
+	// A request without a system prompt does not share either entry
+	noSystem := *blogRequest
+	noSystem.System = ""
+	if _, found := aiCache.Get(ctx, &noSystem); found {
+		t.Error("Request without system prompt should miss the cache")
+	}
+}
+
 func TestAICache_Delete(t *testing.T) {
 	ctx := context.Background()
 	lruCache := NewLRUCache(DefaultOptions())
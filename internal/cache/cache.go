@@ -26,6 +26,12 @@ type Cache interface {
 	Stats() *Statistics
 }
 
+// Peeker is implemented by caches that can look up a value without side
+// effects: no statistics, no recency update and no removal
+type Peeker interface {
+	Peek(ctx context.Context, key string) (interface{}, bool)
+}
+
 // Statistics holds cache performance metrics
 type Statistics struct {
 	Hits       int64     // Number of cache hits
@@ -82,6 +82,23 @@ func (c *LRUCache) Get(ctx context.Context, key string) (interface{}, bool) {
 	return item.value, true
 }
 
+// Peek returns a value without counting a hit or miss and without marking it
+// as recently used. Expired items are reported as missing but left for cleanup.
+func (c *LRUCache) Peek(ctx context.Context, key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	
+	element, exists := c.items[key]
+	if !exists {
+		return nil, false
+	}
+	item := element.Value.(*item)
+	if item.isExpired() {
+		return nil, false
+	}
+	return item.value, true
+}
+
 // Set stores a value in the cache with expiration
 func (c *LRUCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	c.mu.Lock()
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StreamRecorder assembles a streamed AI response chunk by chunk and caches
+// it only once the stream completes. Interrupted streams are never cached,
+// so a later request cannot be served partial content. The response is
+// stored under the same key as a non-streamed request, so a later
+// non-streamed call for the same request hits the cache.
+//
+// A nil *StreamRecorder is valid and records nothing, so callers can stream
+// the same way whether or not caching is enabled.
+type StreamRecorder struct {
+	mu       sync.Mutex
+	cache    *AICache
+	request  *AIRequest
+	content  strings.Builder
+	finished bool
+}
+
+// NewStreamRecorder starts recording a streamed response to request. It
+// returns nil when the cache is nil.
+func (c *AICache) NewStreamRecorder(request *AIRequest) *StreamRecorder {
+	if c == nil {
+		return nil
+	}
+	return &StreamRecorder{cache: c, request: request}
+}
+
+// Write appends a chunk of the streamed response. Chunks written after the
+// stream completed or was aborted are ignored.
+func (s *StreamRecorder) Write(chunk string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.finished {
+		s.content.WriteString(chunk)
+	}
+}
+
+// Content returns the response assembled so far
+func (s *StreamRecorder) Content() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.content.String()
+}
+
+// Complete caches the assembled response. Call it only after the stream
+// finished successfully; empty responses are not cached. Completing twice,
+// or after Abort, does nothing.
+func (s *StreamRecorder) Complete(ctx context.Context, tokensUsed int) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return nil
+	}
+	s.finished = true
+
+	content := s.content.String()
+	if content == "" {
+		return nil
+	}
+	return s.cache.Set(ctx, s.request, &AIResponse{
+		Content:    content,
+		Provider:   s.request.Provider,
+		Model:      s.request.Model,
+		Timestamp:  time.Now(),
+		TokensUsed: tokensUsed,
+	})
+}
+
+// Abort discards the assembled response, e.g. when the stream failed or
+// was cancelled. Nothing is cached.
+func (s *StreamRecorder) Abort() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished = true
+	s.content.Reset()
+}
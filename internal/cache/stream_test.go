@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStreamRecorder(t *testing.T) {
+	ctx := context.Background()
+	newRequest := func() *AIRequest {
+		return &AIRequest{
+			Provider:    "claude",
+			Model:       "claude-3-haiku-20240307",
+			Prompt:      "Write a haiku",
+			ContentType: "custom",
+			MaxTokens:   100,
+		}
+	}
+
+	t.Run("completed stream is cached for non-streamed requests", func(t *testing.T) {
+		lruCache := NewLRUCache(DefaultOptions())
+		defer lruCache.Close()
+		aiCache := NewAICache(lruCache, time.Hour)
+
+		recorder := aiCache.NewStreamRecorder(newRequest())
+		for _, chunk := range []string{"Autumn ", "moonlight", " -"} {
+			recorder.Write(chunk)
+		}
+		if err := recorder.Complete(ctx, 12); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+
+		// A separately built, identical request hits the streamed entry
+		cached, found := aiCache.Get(ctx, newRequest())
+		if !found {
+			t.Fatal("completed stream was not cached")
+		}
+		if cached.Content != "Autumn moonlight -" {
+			t.Errorf("cached content = %q", cached.Content)
+		}
+		if cached.Provider != "claude" || cached.TokensUsed != 12 {
+			t.Errorf("cached response = %+v", cached)
+		}
+
+		// Chunks arriving after completion are ignored
+		recorder.Write(" extra")
+		if got := recorder.Content(); got != "Autumn moonlight -" {
+			t.Errorf("Content() after Complete = %q", got)
+		}
+	})
+
+	t.Run("interrupted stream is not cached", func(t *testing.T) {
+		lruCache := NewLRUCache(DefaultOptions())
+		defer lruCache.Close()
+		aiCache := NewAICache(lruCache, time.Hour)
+
+		// The stream fails after the first chunk
+		recorder := aiCache.NewStreamRecorder(newRequest())
+		recorder.Write("Autumn ")
+		recorder.Abort()
+
+		// A late Complete must not store the partial content either
+		if err := recorder.Complete(ctx, 0); err != nil {
+			t.Fatalf("Complete() after Abort error = %v", err)
+		}
+		if _, found := aiCache.Get(ctx, newRequest()); found {
+			t.Error("interrupted stream poisoned the cache")
+		}
+		if got := recorder.Content(); got != "" {
+			t.Errorf("Content() after Abort = %q, want empty", got)
+		}
+	})
+
+	t.Run("empty stream is not cached", func(t *testing.T) {
+		lruCache := NewLRUCache(DefaultOptions())
+		defer lruCache.Close()
+		aiCache := NewAICache(lruCache, time.Hour)
+
+		if err := aiCache.NewStreamRecorder(newRequest()).Complete(ctx, 0); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		if _, found := aiCache.Get(ctx, newRequest()); found {
+			t.Error("empty stream was cached")
+		}
+	})
+
+	t.Run("nil cache records nothing", func(t *testing.T) {
+		var aiCache *AICache
+		recorder := aiCache.NewStreamRecorder(newRequest())
+		if recorder != nil {
+			t.Fatal("NewStreamRecorder() on a nil cache should return nil")
+		}
+		recorder.Write("chunk")
+		recorder.Abort()
+		if err := recorder.Complete(ctx, 0); err != nil {
+			t.Errorf("Complete() on nil recorder error = %v", err)
+		}
+	})
+}
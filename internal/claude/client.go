@@ -11,11 +11,14 @@ import (
 	"time"
 
 	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/httpclient"
 	"github.com/pyhub/pyhub-docs/internal/retry"
+	"github.com/pyhub/pyhub-docs/internal/ui"
 )
 
 const (
 	defaultAPIURL = "https://api.anthropic.com/v1/messages"
+	modelsAPIURL  = "https://api.anthropic.com/v1/models"
 	defaultModel  = "claude-3-sonnet-20240229"
 	apiVersion    = "2023-06-01"
 )
@@ -24,8 +27,14 @@ const (
 type Client struct {
 	apiKey      string
 	apiURL      string
+	modelsURL   string
 	httpClient  *http.Client
 	retryConfig retry.Config
+
+	// lastInputTokens and lastOutputTokens are the usage the API reported
+	// for the last successful request
+	lastInputTokens  int
+	lastOutputTokens int
 }
 
 // NewClient creates a new Claude API client
@@ -43,10 +52,9 @@ func NewClient(apiKey string) (*Client, error) {
 
 	return &Client{
 		apiKey: apiKey,
-		apiURL: defaultAPIURL,
-		httpClient: &http.Client{
-			Timeout: 60 * time.Second, // Claude may take longer for complex requests
-		},
+		apiURL:    defaultAPIURL,
+		modelsURL: modelsAPIURL,
+		httpClient: httpclient.New(60 * time.Second), // Claude may take longer for complex requests
 		retryConfig: retryConfig,
 	}, nil
 }
@@ -63,6 +71,7 @@ type MessagesRequest struct {
 	Messages    []Message `json:"messages"`
 	MaxTokens   int       `json:"max_tokens"`
 	Temperature float64   `json:"temperature,omitempty"`
+	TopP        float64   `json:"top_p,omitempty"`
 	System      string    `json:"system,omitempty"`
 }
 
@@ -102,6 +111,13 @@ func (c *Client) GenerateContent(prompt string, options GenerateOptions) (string
 func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string, options GenerateOptions) (string, error) {
 	// Build system message based on content type
 	systemMessage := c.buildSystemMessage(options.ContentType)
+
+	// Requests over the model's output limit are rejected with HTTP 400
+	if capped, ok := CapMaxTokens(options.Model, options.MaxTokens); ok {
+		ui.PrintWarning("--max-tokens %d exceeds the %d output tokens %s supports; using %d",
+			options.MaxTokens, capped, options.Model, capped)
+		options.MaxTokens = capped
+	}
 	
 	// Create the request
 	req := MessagesRequest{
@@ -111,6 +127,7 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 		},
 		MaxTokens:   options.MaxTokens,
 		Temperature: options.Temperature,
+		TopP:        options.TopP,
 		System:      systemMessage,
 	}
 
@@ -120,6 +137,8 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	c.lastInputTokens, c.lastOutputTokens = 0, 0
+
 	// Execute with retry logic
 	return retry.DoWithResult(ctx, c.retryConfig, func() (string, error) {
 		// Create HTTP request
@@ -193,12 +212,25 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 			return "", fmt.Errorf("no text content in response")
 		}
 
+		c.lastInputTokens = msgResp.Usage.InputTokens
+		c.lastOutputTokens = msgResp.Usage.OutputTokens
 		return result, nil
 	})
 }
 
+// LastUsage returns the input and output tokens the API reported for the
+// last successful request, or zeros if it reported none
+func (c *Client) LastUsage() (int, int) {
+	return c.lastInputTokens, c.lastOutputTokens
+}
+
 // buildSystemMessage creates appropriate system message based on content type
 func (c *Client) buildSystemMessage(contentType string) string {
+	return SystemMessage(contentType)
+}
+
+// SystemMessage returns the system message sent for a content type
+func SystemMessage(contentType string) string {
 	switch contentType {
 	case "blog":
 		return "You are a professional blog writer. Create engaging, well-structured blog posts with clear sections, compelling introductions, and actionable conclusions. Use markdown formatting."
@@ -225,6 +257,7 @@ type GenerateOptions struct {
 	Model       string
 	MaxTokens   int
 	Temperature float64
+	TopP        float64 // Nucleus sampling; 0 leaves the provider default
 }
 
 // DefaultGenerateOptions returns default generation options
@@ -237,62 +270,6 @@ func DefaultGenerateOptions() GenerateOptions {
 	}
 }
 
-// AvailableModels returns a list of available Claude models
-func AvailableModels() []string {
-	return []string{
-		"claude-3-opus-20240229",    // Most capable, slower
-		"claude-3-sonnet-20240229",  // Balanced (default)
-		"claude-3-haiku-20240307",   // Fastest, most compact
-		"claude-2.1",                // Previous generation
-		"claude-2.0",                // Legacy
-		"claude-instant-1.2",        // Fast, older model
-	}
-}
-
-// ModelInfo provides information about Claude models
-type ModelInfo struct {
-	Name        string
-	Description string
-	MaxTokens   int
-	Best4       string // Best for what use case
-}
-
-// GetModelInfo returns information about available models
-func GetModelInfo() []ModelInfo {
-	return []ModelInfo{
-		{
-			Name:        "claude-3-opus-20240229",
-			Description: "Most capable Claude 3 model",
-			MaxTokens:   4096,
-			Best4:       "Complex tasks, nuanced content, creative writing",
-		},
-		{
-			Name:        "claude-3-sonnet-20240229",
-			Description: "Balanced performance and speed",
-			MaxTokens:   4096,
-			Best4:       "General purpose, good balance of quality and speed",
-		},
-		{
-			Name:        "claude-3-haiku-20240307",
-			Description: "Fastest Claude 3 model",
-			MaxTokens:   4096,
-			Best4:       "Quick responses, simple tasks, high volume",
-		},
-		{
-			Name:        "claude-2.1",
-			Description: "Previous generation, 200K context",
-			MaxTokens:   4096,
-			Best4:       "Long documents, extended conversations",
-		},
-		{
-			Name:        "claude-instant-1.2",
-			Description: "Fast, lightweight model",
-			MaxTokens:   4096,
-			Best4:       "Quick drafts, simple content",
-		},
-	}
-}
-
 // ClaudeError represents an error from the Claude API with additional metadata
 type ClaudeError struct {
 	StatusCode int
@@ -339,4 +316,68 @@ func isRetryableClaudeError(err error) bool {
 // SetRetryConfig allows customizing the retry configuration
 func (c *Client) SetRetryConfig(config retry.Config) {
 	c.retryConfig = config
+}
+
+// RetryConfig returns the current retry configuration
+func (c *Client) RetryConfig() retry.Config {
+	return c.retryConfig
+}
+
+// SetAPIURL sends generation requests to url instead of the provider's API,
+// such as a proxy or a test server
+func (c *Client) SetAPIURL(url string) {
+	c.apiURL = url
+}
+
+// ListModels returns the IDs of the models available to the API key. It is a
+// cheap request that does not consume tokens, useful for connectivity checks.
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	return retry.DoWithResult(ctx, c.retryConfig, func() ([]string, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "GET", c.modelsURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		httpReq.Header.Set("x-api-key", c.apiKey)
+		httpReq.Header.Set("anthropic-version", apiVersion)
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			var apiError struct {
+				Error APIError `json:"error"`
+			}
+			if err := json.Unmarshal(body, &apiError); err == nil && apiError.Error.Message != "" {
+				return nil, &ClaudeError{
+					StatusCode: resp.StatusCode,
+					Message:    apiError.Error.Message,
+					Type:       apiError.Error.Type,
+				}
+			}
+			return nil, retry.NewHTTPError(resp.StatusCode, string(body))
+		}
+
+		var listResp struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &listResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		models := make([]string, 0, len(listResp.Data))
+		for _, m := range listResp.Data {
+			models = append(models, m.ID)
+		}
+		return models, nil
+	})
 }
\ No newline at end of file
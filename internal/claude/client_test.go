@@ -2,6 +2,7 @@ package claude
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -565,4 +566,98 @@ func TestIsRetryableClaudeError(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("anthropic-version") == "" {
+			t.Error("anthropic-version header missing")
+		}
+		if r.Header.Get("x-api-key") != "valid-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"claude-3-haiku-20240307","type":"model"}],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("valid-key")
+	client.modelsURL = server.URL
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 1 || models[0] != "claude-3-haiku-20240307" {
+		t.Errorf("ListModels() = %v", models)
+	}
+
+	client, _ = NewClient("wrong-key")
+	client.modelsURL = server.URL
+	_, err = client.ListModels(context.Background())
+	var claudeErr *ClaudeError
+	if !errors.As(err, &claudeErr) || claudeErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 ClaudeError, got %v", err)
+	}
+}
+
+func TestLookupModel(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      string
+		maxTokens int
+	}{
+		{"claude-sonnet-4-5-20250929", "claude-sonnet-4-5-20250929", 64000},
+		{"claude-sonnet-4-5", "claude-sonnet-4-5-20250929", 64000},
+		{"Claude-3-5-Sonnet-Latest", "claude-3-5-sonnet-20241022", 8192},
+		{"claude-opus-4-1-20260101", "claude-opus-4-1-20250805", 32000},
+		{"claude-3-haiku-20240307", "claude-3-haiku-20240307", 4096},
+	}
+	for _, tt := range tests {
+		info, ok := LookupModel(tt.name)
+		if !ok || info.Name != tt.want || info.MaxTokens != tt.maxTokens {
+			t.Errorf("LookupModel(%q) = %s (%d), %v; want %s (%d)", tt.name, info.Name, info.MaxTokens, ok, tt.want, tt.maxTokens)
+		}
+	}
+	if _, ok := LookupModel("claude-unknown-20250101"); ok {
+		t.Error("LookupModel() found an unknown model")
+	}
+}
+
+func TestCapMaxTokens(t *testing.T) {
+	if got, capped := CapMaxTokens("claude-3-opus-20240229", 8000); !capped || got != 4096 {
+		t.Errorf("CapMaxTokens(claude-3-opus, 8000) = %d, %v; want 4096, true", got, capped)
+	}
+	if got, capped := CapMaxTokens("claude-sonnet-4-5", 8000); capped || got != 8000 {
+		t.Errorf("CapMaxTokens(claude-sonnet-4-5, 8000) = %d, %v; want 8000, false", got, capped)
+	}
+	if got, capped := CapMaxTokens("claude-next", 100000); capped || got != 100000 {
+		t.Errorf("CapMaxTokens(unknown) = %d, %v; want the request unchanged", got, capped)
+	}
+}
+
+func TestGenerateContentCapsMaxTokens(t *testing.T) {
+	var sent MessagesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"content": [{"type": "text", "text": "ok"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.apiURL = server.URL
+
+	if _, err := client.GenerateContent("test prompt", GenerateOptions{
+		ContentType: "custom",
+		Model:       "claude-3-5-haiku-latest",
+		MaxTokens:   20000,
+	}); err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if sent.MaxTokens != 8192 {
+		t.Errorf("max_tokens = %d, want the model limit 8192", sent.MaxTokens)
+	}
+}
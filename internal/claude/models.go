@@ -0,0 +1,225 @@
+package claude
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ModelInfo provides information about Claude models
+type ModelInfo struct {
+	Name          string
+	Aliases       []string // Other names the API accepts for the model
+	Description   string
+	ContextWindow int     // Maximum input tokens
+	MaxTokens     int     // Maximum output tokens
+	Best4         string  // Best for what use case
+	InputPrice    float64 // USD per 1M input tokens
+	OutputPrice   float64 // USD per 1M output tokens
+}
+
+// models is the registry of supported Claude models, newest first
+var models = []ModelInfo{
+	{
+		Name:          "claude-opus-4-5-20251101",
+		Aliases:       []string{"claude-opus-4-5"},
+		Description:   "Most capable Claude 4.5 model",
+		ContextWindow: 200000,
+		MaxTokens:     64000,
+		Best4:         "Complex reasoning, long-form writing, demanding analysis",
+		InputPrice:    5.00,
+		OutputPrice:   25.00,
+	},
+	{
+		Name:          "claude-sonnet-4-5-20250929",
+		Aliases:       []string{"claude-sonnet-4-5"},
+		Description:   "Balanced Claude 4.5 model",
+		ContextWindow: 200000,
+		MaxTokens:     64000,
+		Best4:         "General purpose, long documents, code",
+		InputPrice:    3.00,
+		OutputPrice:   15.00,
+	},
+	{
+		Name:          "claude-haiku-4-5-20251001",
+		Aliases:       []string{"claude-haiku-4-5"},
+		Description:   "Fastest Claude 4.5 model",
+		ContextWindow: 200000,
+		MaxTokens:     64000,
+		Best4:         "Quick responses, high volume, summaries",
+		InputPrice:    1.00,
+		OutputPrice:   5.00,
+	},
+	{
+		Name:          "claude-opus-4-1-20250805",
+		Aliases:       []string{"claude-opus-4-1"},
+		Description:   "Claude Opus 4.1",
+		ContextWindow: 200000,
+		MaxTokens:     32000,
+		Best4:         "Complex tasks, nuanced content",
+		InputPrice:    15.00,
+		OutputPrice:   75.00,
+	},
+	{
+		Name:          "claude-opus-4-20250514",
+		Aliases:       []string{"claude-opus-4-0"},
+		Description:   "Claude Opus 4",
+		ContextWindow: 200000,
+		MaxTokens:     32000,
+		Best4:         "Complex tasks, nuanced content",
+		InputPrice:    15.00,
+		OutputPrice:   75.00,
+	},
+	{
+		Name:          "claude-sonnet-4-20250514",
+		Aliases:       []string{"claude-sonnet-4-0"},
+		Description:   "Claude Sonnet 4",
+		ContextWindow: 200000,
+		MaxTokens:     64000,
+		Best4:         "General purpose, good balance of quality and speed",
+		InputPrice:    3.00,
+		OutputPrice:   15.00,
+	},
+	{
+		Name:          "claude-3-7-sonnet-20250219",
+		Aliases:       []string{"claude-3-7-sonnet-latest"},
+		Description:   "Claude 3.7 Sonnet",
+		ContextWindow: 200000,
+		MaxTokens:     64000,
+		Best4:         "Long outputs, reports, code",
+		InputPrice:    3.00,
+		OutputPrice:   15.00,
+	},
+	{
+		Name:          "claude-3-5-sonnet-20241022",
+		Aliases:       []string{"claude-3-5-sonnet-latest", "claude-3-5-sonnet-20240620"},
+		Description:   "Claude 3.5 Sonnet",
+		ContextWindow: 200000,
+		MaxTokens:     8192,
+		Best4:         "General purpose writing and analysis",
+		InputPrice:    3.00,
+		OutputPrice:   15.00,
+	},
+	{
+		Name:          "claude-3-5-haiku-20241022",
+		Aliases:       []string{"claude-3-5-haiku-latest"},
+		Description:   "Claude 3.5 Haiku",
+		ContextWindow: 200000,
+		MaxTokens:     8192,
+		Best4:         "Quick drafts, simple content, high volume",
+		InputPrice:    0.80,
+		OutputPrice:   4.00,
+	},
+	{
+		Name:          "claude-3-opus-20240229",
+		Aliases:       []string{"claude-3-opus-latest"},
+		Description:   "Most capable Claude 3 model",
+		ContextWindow: 200000,
+		MaxTokens:     4096,
+		Best4:         "Complex tasks, nuanced content, creative writing",
+		InputPrice:    15.00,
+		OutputPrice:   75.00,
+	},
+	{
+		Name:          "claude-3-sonnet-20240229",
+		Description:   "Balanced performance and speed",
+		ContextWindow: 200000,
+		MaxTokens:     4096,
+		Best4:         "General purpose, good balance of quality and speed",
+		InputPrice:    3.00,
+		OutputPrice:   15.00,
+	},
+	{
+		Name:          "claude-3-haiku-20240307",
+		Description:   "Fastest Claude 3 model",
+		ContextWindow: 200000,
+		MaxTokens:     4096,
+		Best4:         "Quick responses, simple tasks, high volume",
+		InputPrice:    0.25,
+		OutputPrice:   1.25,
+	},
+	{
+		Name:          "claude-2.1",
+		Description:   "Previous generation, 200K context",
+		ContextWindow: 200000,
+		MaxTokens:     4096,
+		Best4:         "Long documents, extended conversations",
+		InputPrice:    8.00,
+		OutputPrice:   24.00,
+	},
+	{
+		Name:          "claude-2.0",
+		Description:   "Legacy model, 100K context",
+		ContextWindow: 100000,
+		MaxTokens:     4096,
+		Best4:         "Existing workflows tuned for Claude 2",
+		InputPrice:    8.00,
+		OutputPrice:   24.00,
+	},
+	{
+		Name:          "claude-instant-1.2",
+		Description:   "Fast, lightweight model",
+		ContextWindow: 100000,
+		MaxTokens:     4096,
+		Best4:         "Quick drafts, simple content",
+		InputPrice:    0.80,
+		OutputPrice:   2.40,
+	},
+}
+
+// snapshotSuffix matches the date that pins a model alias to a snapshot
+var snapshotSuffix = regexp.MustCompile(`-\d{8}$`)
+
+// GetModelInfo returns information about available models
+func GetModelInfo() []ModelInfo {
+	infos := make([]ModelInfo, len(models))
+	copy(infos, models)
+	return infos
+}
+
+// AvailableModels returns a list of available Claude models
+func AvailableModels() []string {
+	names := make([]string, len(models))
+	for i, info := range models {
+		names[i] = info.Name
+	}
+	return names
+}
+
+// LookupModel returns the registry entry of a model given by name or alias.
+// A dated snapshot of a known alias, such as "claude-sonnet-4-5-20260101",
+// resolves to that alias's entry.
+func LookupModel(name string) (ModelInfo, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if info, ok := lookupModelName(name); ok {
+		return info, true
+	}
+	if base := snapshotSuffix.ReplaceAllString(name, ""); base != name {
+		return lookupModelName(base)
+	}
+	return ModelInfo{}, false
+}
+
+// lookupModelName finds the entry whose name or alias is name
+func lookupModelName(name string) (ModelInfo, bool) {
+	for _, info := range models {
+		if info.Name == name {
+			return info, true
+		}
+		for _, alias := range info.Aliases {
+			if alias == name {
+				return info, true
+			}
+		}
+	}
+	return ModelInfo{}, false
+}
+
+// CapMaxTokens returns the output limit of a known model and true when
+// maxTokens exceeds it. Unknown models are never capped.
+func CapMaxTokens(model string, maxTokens int) (int, bool) {
+	info, ok := LookupModel(model)
+	if !ok || maxTokens <= info.MaxTokens {
+		return maxTokens, false
+	}
+	return info.MaxTokens, true
+}
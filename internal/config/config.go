@@ -4,17 +4,46 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/pyhub/pyhub-docs/internal/retry"
 	"gopkg.in/yaml.v3"
 )
 
 // RetryConfig contains retry settings for API calls
 type RetryConfig struct {
+	// Preset names a retry preset (none, conservative, aggressive) that
+	// supplies every value; settings given in the file override it
+	Preset       string `yaml:"preset,omitempty"`
 	MaxRetries   int  `yaml:"max_retries"`
 	InitialDelay int  `yaml:"initial_delay_ms"`
 	MaxDelay     int  `yaml:"max_delay_ms"`
 	Multiplier   float64 `yaml:"multiplier"`
 	Jitter       bool `yaml:"jitter"`
+
+	explicit map[string]bool // keys set in the configuration file
+}
+
+// UnmarshalYAML decodes the retry settings and records which keys the file
+// sets, so that they can override the values of a preset
+func (r *RetryConfig) UnmarshalYAML(node *yaml.Node) error {
+	type plain RetryConfig
+	if err := node.Decode((*plain)(r)); err != nil {
+		return err
+	}
+	if node.Kind == yaml.MappingNode {
+		r.explicit = make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			r.explicit[node.Content[i].Value] = true
+		}
+	}
+	return nil
+}
+
+// IsSet reports whether a retry setting, given by its YAML key such as
+// "max_retries", was set in the configuration file
+func (r RetryConfig) IsSet(key string) bool {
+	return r.explicit[key]
 }
 
 // Config represents the application configuration
@@ -31,6 +60,9 @@ type Config struct {
 	Generate GenerateConfig `yaml:"generate"`
 	Template TemplateConfig `yaml:"template"`
 	
+	// Models maps short model aliases to full model names, e.g. fast: claude-3-haiku-20240307
+	Models map[string]string `yaml:"models,omitempty"`
+	
 	// Global options
 	Global GlobalConfig `yaml:"global"`
 }
@@ -38,19 +70,47 @@ type Config struct {
 // OpenAIConfig contains OpenAI API settings
 type OpenAIConfig struct {
 	APIKey      string       `yaml:"api_key"`
+	// APIKeyFile is a file holding the API key, used when APIKey is not set
+	APIKeyFile  string       `yaml:"api_key_file,omitempty"`
 	Model       string       `yaml:"model"`
 	MaxTokens   int          `yaml:"max_tokens"`
 	Temperature float64      `yaml:"temperature"`
 	Retry       RetryConfig  `yaml:"retry"`
+	// Defaults are generate settings used for this provider's models
+	Defaults    ProviderDefaults `yaml:"defaults,omitempty"`
 }
 
 // ClaudeConfig contains Claude API settings
 type ClaudeConfig struct {
 	APIKey      string       `yaml:"api_key"`
+	// APIKeyFile is a file holding the API key, used when APIKey is not set
+	APIKeyFile  string       `yaml:"api_key_file,omitempty"`
 	Model       string       `yaml:"model"`
 	MaxTokens   int          `yaml:"max_tokens"`
 	Temperature float64      `yaml:"temperature"`
 	Retry       RetryConfig  `yaml:"retry"`
+	// Defaults are generate settings used for this provider's models
+	Defaults    ProviderDefaults `yaml:"defaults,omitempty"`
+}
+
+// ProviderDefaults contains generate settings for one provider. They take
+// precedence over the generate section and are overridden by command flags.
+type ProviderDefaults struct {
+	MaxTokens int `yaml:"max_tokens,omitempty"`
+	// Temperature is a pointer so that 0 can be configured explicitly
+	Temperature *float64 `yaml:"temperature,omitempty"`
+}
+
+// ProviderDefaults returns the generate defaults configured for a provider
+// ("openai" or "claude"), or empty defaults for any other provider
+func (c *Config) ProviderDefaults(provider string) ProviderDefaults {
+	switch provider {
+	case "openai":
+		return c.OpenAI.Defaults
+	case "claude":
+		return c.Claude.Defaults
+	}
+	return ProviderDefaults{}
 }
 
 // ReplaceConfig contains default settings for replace command
@@ -75,6 +135,14 @@ type GenerateConfig struct {
 	Model       string  `yaml:"model"`
 	MaxTokens   int     `yaml:"max_tokens"`
 	Temperature float64 `yaml:"temperature"`
+	// FallbackModel is tried when the primary model's provider fails
+	FallbackModel string `yaml:"fallback_model,omitempty"`
+	// RequestsPerMinute and TokensPerMinute throttle requests client-side (0 = no limit)
+	RequestsPerMinute int `yaml:"requests_per_minute,omitempty"`
+	TokensPerMinute   int `yaml:"tokens_per_minute,omitempty"`
+	// PromptPrefix and PromptSuffix wrap every prompt, as text or @file
+	PromptPrefix string `yaml:"prompt_prefix,omitempty"`
+	PromptSuffix string `yaml:"prompt_suffix,omitempty"`
 }
 
 // TemplateConfig contains default settings for template command
@@ -242,6 +310,42 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("max_tokens must be positive")
 	}
 	
+	// Validate provider defaults against each provider's temperature range
+	for _, d := range []struct {
+		name           string
+		defaults       ProviderDefaults
+		maxTemperature float64
+	}{
+		{"openai", c.OpenAI.Defaults, 2},
+		{"claude", c.Claude.Defaults, 1},
+	} {
+		if d.defaults.MaxTokens < 0 {
+			return fmt.Errorf("%s.defaults.max_tokens must be positive", d.name)
+		}
+		if t := d.defaults.Temperature; t != nil && (*t < 0 || *t > d.maxTemperature) {
+			return fmt.Errorf("%s.defaults.temperature must be between 0 and %g", d.name, d.maxTemperature)
+		}
+	}
+	
+	// Validate retry presets
+	for _, preset := range []string{c.OpenAI.Retry.Preset, c.Claude.Retry.Preset} {
+		if _, ok := retry.Preset(preset); preset != "" && !ok {
+			return fmt.Errorf("invalid retry preset: %s (valid: %s)", preset, strings.Join(retry.PresetNames(), ", "))
+		}
+	}
+	
+	// Validate rate limits
+	if c.Generate.RequestsPerMinute < 0 || c.Generate.TokensPerMinute < 0 {
+		return fmt.Errorf("requests_per_minute and tokens_per_minute cannot be negative")
+	}
+	
+	// Validate model aliases
+	for alias, model := range c.Models {
+		if strings.TrimSpace(alias) == "" || strings.TrimSpace(model) == "" {
+			return fmt.Errorf("invalid model alias %q: alias and model name cannot be empty", alias)
+		}
+	}
+	
 	// Validate global settings
 	if c.Global.Verbose && c.Global.Quiet {
 		return fmt.Errorf("verbose and quiet cannot both be true")
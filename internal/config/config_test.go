@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -165,6 +166,16 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Invalid retry preset",
+			config: &Config{
+				Claude: ClaudeConfig{
+					Retry: RetryConfig{Preset: "reckless"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid retry preset",
+		},
 		{
 			name: "Invalid model",
 			config: &Config{
@@ -219,6 +230,14 @@ func TestValidateConfig(t *testing.T) {
 			wantErr: true,
 			errMsg:  "verbose and quiet cannot both be true",
 		},
+		{
+			name: "Model alias without a model",
+			config: &Config{
+				Models: map[string]string{"fast": ""},
+			},
+			wantErr: true,
+			errMsg:  "invalid model alias",
+		},
 		{
 			name: "Invalid language",
 			config: &Config{
@@ -253,6 +272,75 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestRetryConfigExplicitKeys(t *testing.T) {
+	data := []byte(`
+openai:
+  retry:
+    preset: aggressive
+    max_retries: 2
+`)
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	retry := cfg.OpenAI.Retry
+	if retry.Preset != "aggressive" || retry.MaxRetries != 2 {
+		t.Errorf("retry = %+v", retry)
+	}
+	if !retry.IsSet("preset") || !retry.IsSet("max_retries") {
+		t.Error("keys in the file should be reported as set")
+	}
+	if retry.IsSet("initial_delay_ms") || retry.IsSet("jitter") {
+		t.Error("keys missing from the file should not be reported as set")
+	}
+	if cfg.Claude.Retry.IsSet("max_retries") {
+		t.Error("defaults should not be reported as set")
+	}
+}
+
+func TestProviderDefaults(t *testing.T) {
+	data := []byte(`
+openai:
+  defaults:
+    max_tokens: 4000
+    temperature: 1.2
+claude:
+  defaults:
+    temperature: 0
+`)
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	openai := cfg.ProviderDefaults("openai")
+	if openai.MaxTokens != 4000 || openai.Temperature == nil || *openai.Temperature != 1.2 {
+		t.Errorf("openai defaults = %+v", openai)
+	}
+	claude := cfg.ProviderDefaults("claude")
+	if claude.MaxTokens != 0 || claude.Temperature == nil || *claude.Temperature != 0 {
+		t.Errorf("claude defaults = %+v, an explicit 0 temperature should be kept", claude)
+	}
+	if d := cfg.ProviderDefaults("other"); d.MaxTokens != 0 || d.Temperature != nil {
+		t.Errorf("unknown provider defaults = %+v", d)
+	}
+
+	// Claude accepts temperatures up to 1 only
+	high := 1.5
+	cfg.Claude.Defaults.Temperature = &high
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "claude.defaults.temperature") {
+		t.Errorf("expected claude temperature error, got %v", err)
+	}
+	cfg.Claude.Defaults = ProviderDefaults{MaxTokens: -1}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for negative max_tokens")
+	}
+}
+
 func TestGetConfigPath(t *testing.T) {
 	// 환경 변수 백업
 	originalEnv := os.Getenv("PYHUB_CONFIG")
@@ -0,0 +1,25 @@
+package document
+
+import "strings"
+
+// isTextPart reports whether a package entry is an XML part that may hold
+// text runs. Relationship parts and the content types part never do.
+func isTextPart(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".xml") && name != "[Content_Types].xml"
+}
+
+// SetAllParts makes streaming replacement cover every XML part of the
+// package instead of the body, header, footer and note parts, so text in
+// parts a producer placed elsewhere, such as glossaries or custom parts, is
+// found as well. Only the text of <w:t> and <a:t> elements is replaced.
+// It is slower and broader than the default, and overrides SetParts.
+func (d *StreamingWordDocument) SetAllParts(all bool) {
+	d.allParts = all
+}
+
+// SetAllParts makes streaming replacement cover every XML part of the
+// presentation instead of the slides and their charts, such as layouts,
+// masters and notes. Only the text of <a:t> elements is replaced.
+func (d *StreamingPowerPointDocument) SetAllParts(all bool) {
+	d.allParts = all
+}
@@ -0,0 +1,108 @@
+package document
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const wordNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`
+
+func TestStreamingWordAllParts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "glossary.docx")
+	writeZipParts(t, path, map[string]string{
+		"[Content_Types].xml": `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"/>`,
+		"word/document.xml": `<w:document ` + wordNS + `><w:body><w:p>` +
+			`<w:r><w:instrText> DOCPROPERTY ACME </w:instrText></w:r>` +
+			`<w:r><w:t>Body ACME</w:t></w:r></w:p></w:body></w:document>`,
+		"word/glossary/document.xml": `<w:glossaryDocument ` + wordNS + `><w:docParts><w:docPart><w:docPartBody>` +
+			`<w:p><w:r><w:t>Glossary ACME</w:t></w:r></w:p></w:docPartBody></w:docPart></w:docParts></w:glossaryDocument>`,
+		"customXml/item1.xml": `<company>ACME</company>`,
+	})
+
+	doc, err := OpenWordDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := doc.ReplaceTextStreaming("ACME", "Initech")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Field codes in the body are text nodes too and change by default
+	if count != 2 {
+		t.Errorf("default replacement count = %d, want 2", count)
+	}
+	if glossary := readZipText(t, path, "word/glossary/document.xml"); !strings.Contains(glossary, "Glossary ACME") {
+		t.Errorf("glossary changed without all parts: %s", glossary)
+	}
+
+	doc.SetAllParts(true)
+	count, err = doc.ReplaceTextStreaming("ACME", "Initech")
+	doc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("all parts replacement count = %d, want 1", count)
+	}
+	if glossary := readZipText(t, path, "word/glossary/document.xml"); !strings.Contains(glossary, "Glossary Initech") {
+		t.Errorf("glossary not replaced: %s", glossary)
+	}
+	// Text outside text elements is not a text run
+	if custom := readZipText(t, path, "customXml/item1.xml"); custom != `<company>ACME</company>` {
+		t.Errorf("custom XML changed: %s", custom)
+	}
+}
+
+func TestStreamingPowerPointAllParts(t *testing.T) {
+	shape := func(root, text string) string {
+		return `<p:` + root + ` xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" ` +
+			`xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><p:cSld><p:spTree><p:sp><p:txBody>` +
+			`<a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:` + root + `>`
+	}
+	path := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZipParts(t, path, map[string]string{
+		"ppt/slides/slide1.xml":             shape("sld", "Slide ACME"),
+		"ppt/slideLayouts/slideLayout1.xml": shape("sldLayout", "Layout ACME"),
+		"ppt/notesSlides/notesSlide1.xml":   shape("notes", "Notes ACME"),
+	})
+
+	doc, err := OpenPowerPointDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+	doc.SetAllParts(true)
+
+	count, err := doc.ReplaceTextInSlidesStreaming("ACME", "Initech")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("replacement count = %d, want 3", count)
+	}
+	for _, name := range []string{"ppt/slides/slide1.xml", "ppt/slideLayouts/slideLayout1.xml", "ppt/notesSlides/notesSlide1.xml"} {
+		if text := readZipText(t, path, name); strings.Contains(text, "ACME") {
+			t.Errorf("%s not replaced: %s", name, text)
+		}
+	}
+}
+
+func TestStreamReplaceTextElements(t *testing.T) {
+	input := `<w:p ` + wordNS + `><w:t/>ACME<w:r><w:t xml:space="preserve">ACME &amp; co</w:t></w:r>` +
+		`<w:r><w:rPr><w:vanish/></w:rPr><w:t>ACME</w:t></w:r></w:p>`
+	var out bytes.Buffer
+	err := streamReplaceTextElements(strings.NewReader(input), &out, func(text string) string {
+		return strings.ReplaceAll(text, "ACME", "Initech")
+	}, &hiddenRunFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<w:p ` + wordNS + `><w:t/>ACME<w:r><w:t xml:space="preserve">Initech &amp; co</w:t></w:r>` +
+		`<w:r><w:rPr><w:vanish/></w:rPr><w:t>ACME</w:t></w:r></w:p>`
+	if out.String() != want {
+		t.Errorf("got  %s\nwant %s", out.String(), want)
+	}
+}
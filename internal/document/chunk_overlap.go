@@ -0,0 +1,77 @@
+package document
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ChunkOverlapFor returns the ChunkOverlap needed to find every pattern in
+// chunked text: one byte less than the longest pattern
+func ChunkOverlapFor(patterns ...string) int {
+	longest := 0
+	for _, pattern := range patterns {
+		longest = max(longest, len(pattern))
+	}
+	return max(longest-1, 0)
+}
+
+// chunkBuffer accumulates the text of a text node and hands it to a
+// processor in chunks of about size bytes. When a chunk is flushed because it
+// is full, its last overlap bytes are kept as the start of the next chunk, so
+// a match of up to overlap+1 bytes across the boundary is still found.
+type chunkBuffer struct {
+	size    int
+	overlap int
+	text    strings.Builder
+	carried int // bytes at the start of text repeated from the previous chunk
+}
+
+// newChunkBuffer returns a buffer for the chunk size of opts, with an overlap
+// of ChunkOverlap or, if larger, the overlap needed to find patterns. The
+// overlap is limited to half a chunk so every chunk has new text.
+func newChunkBuffer(opts *StreamingOptions, patterns ...string) *chunkBuffer {
+	overlap := max(opts.ChunkOverlap, ChunkOverlapFor(patterns...), 0)
+	if overlap > opts.ChunkSize/2 {
+		overlap = opts.ChunkSize / 2
+	}
+	return &chunkBuffer{size: opts.ChunkSize, overlap: overlap}
+}
+
+// write adds text of the current text node
+func (b *chunkBuffer) write(data []byte) {
+	b.text.Write(data)
+}
+
+// full reports whether the buffer holds more than a chunk
+func (b *chunkBuffer) full() bool {
+	return b.text.Len() > b.size
+}
+
+// flush passes the buffered text and the length of its carried prefix to
+// emit. With keepTail, used when a chunk is full, the end of the text is
+// carried into the next chunk; at the end of a text node nothing is carried.
+// Text that consists only of a carried prefix was already processed and is
+// not passed again.
+func (b *chunkBuffer) flush(keepTail bool, emit func(chunk string, carried int) error) error {
+	text := b.text.String()
+	if len(text) > b.carried {
+		if err := emit(text, b.carried); err != nil {
+			return err
+		}
+	}
+
+	b.text.Reset()
+	b.carried = 0
+	if !keepTail || b.overlap == 0 {
+		return nil
+	}
+
+	// Start the tail on a character boundary so both chunks stay valid UTF-8
+	start := max(len(text)-b.overlap, 0)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	b.text.WriteString(text[start:])
+	b.carried = len(text) - start
+	return nil
+}
@@ -0,0 +1,131 @@
+package document
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestChunkOverlapFor(t *testing.T) {
+	if got := ChunkOverlapFor("Draft", "Version 1.0", ""); got != 10 {
+		t.Errorf("ChunkOverlapFor() = %d, want 10", got)
+	}
+	if got := ChunkOverlapFor(); got != 0 {
+		t.Errorf("ChunkOverlapFor() = %d, want 0", got)
+	}
+
+	// Patterns raise the overlap of the default options, never lower it
+	if b := newChunkBuffer(DefaultStreamingOptions(), "Version"); b.overlap != 6 {
+		t.Errorf("overlap for a pattern = %d, want 6", b.overlap)
+	}
+	if b := newChunkBuffer(&StreamingOptions{ChunkSize: 64, ChunkOverlap: 20}, "Version"); b.overlap != 20 {
+		t.Errorf("overlap with ChunkOverlap set = %d, want 20", b.overlap)
+	}
+}
+
+func TestProcessTextChunkedFindsMatchAcrossChunks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chunks.docx")
+	// CDATA sections make the decoder return the text of one node in pieces
+	writeDocxWithDocumentXML(t, "testdata/sample.docx", path,
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r>`+
+			`<w:t>0123456789abVer<![CDATA[sion 1.0 is out]]></w:t>`+
+			`</w:r></w:p></w:body></w:document>`)
+
+	search := func(overlap int, patterns []string) (found int, chunks []string) {
+		opts := DefaultStreamingOptions()
+		opts.ChunkSize = 14
+		opts.ChunkOverlap = overlap
+		doc, err := OpenWordDocumentStreaming(path, opts)
+		if err != nil {
+			t.Fatalf("OpenWordDocumentStreaming() error = %v", err)
+		}
+		defer doc.Close()
+
+		err = doc.ProcessTextChunkedOverlapped(patterns, func(chunk string, carried int) error {
+			chunks = append(chunks, chunk)
+			// Count only matches that end in the new part of the chunk
+			for i := 0; ; {
+				index := strings.Index(chunk[i:], "Version")
+				if index < 0 {
+					break
+				}
+				if end := i + index + len("Version"); end > carried {
+					found++
+				}
+				i += index + 1
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ProcessTextChunkedOverlapped() error = %v", err)
+		}
+		return found, chunks
+	}
+
+	// Without overlap the chunk boundary splits the match
+	if found, chunks := search(0, nil); found != 0 {
+		t.Errorf("expected the split match to be missed without overlap, chunks %q", chunks)
+	}
+
+	// Passing the pattern sizes the overlap with the default options, the
+	// same as setting ChunkOverlap
+	for _, tt := range []struct {
+		overlap  int
+		patterns []string
+	}{
+		{0, []string{"Version"}},
+		{ChunkOverlapFor("Version"), nil},
+	} {
+		found, chunks := search(tt.overlap, tt.patterns)
+		if found != 1 {
+			t.Errorf("overlap %d, patterns %q: found %d matches, want 1; chunks %q", tt.overlap, tt.patterns, found, chunks)
+		}
+		if len(chunks) != 2 || chunks[0] != "0123456789abVer" || chunks[1] != "9abVersion 1.0 is out" {
+			t.Errorf("overlap %d, patterns %q: chunks = %q", tt.overlap, tt.patterns, chunks)
+		}
+	}
+}
+
+func TestChunkBufferKeepsCharacters(t *testing.T) {
+	buffer := newChunkBuffer(&StreamingOptions{ChunkSize: 8, ChunkOverlap: 4})
+	buffer.write([]byte("abcdef한글"))
+
+	var chunks []string
+	var carried []int
+	emit := func(chunk string, n int) error {
+		chunks = append(chunks, chunk)
+		carried = append(carried, n)
+		return nil
+	}
+	if err := buffer.flush(true, emit); err != nil {
+		t.Fatal(err)
+	}
+	// A carried prefix that is all that is left is not passed again
+	if err := buffer.flush(false, emit); err != nil {
+		t.Fatal(err)
+	}
+	buffer.write([]byte("xyz"))
+	if err := buffer.flush(false, emit); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) != 2 || chunks[1] != "xyz" || carried[1] != 0 {
+		t.Fatalf("chunks = %q, carried = %v", chunks, carried)
+	}
+
+	// The tail moves back to the start of the character it would split
+	buffer.write([]byte("abcdef한글"))
+	buffer.flush(true, emit)
+	buffer.write([]byte("!"))
+	buffer.flush(false, emit)
+	last := chunks[len(chunks)-1]
+	if !utf8.ValidString(last) || last != "한글!" || carried[len(carried)-1] != len("한글") {
+		t.Errorf("chunk = %q, carried = %d", last, carried[len(carried)-1])
+	}
+
+	// The overlap is limited to half a chunk
+	if b := newChunkBuffer(&StreamingOptions{ChunkSize: 8, ChunkOverlap: 100}); b.overlap != 4 {
+		t.Errorf("overlap = %d, want 4", b.overlap)
+	}
+}
@@ -0,0 +1,162 @@
+package document
+
+import (
+	"errors"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Comment is a reviewer comment stored in word/comments.xml
+type Comment struct {
+	ID       string `json:"id"`
+	Author   string `json:"author"`
+	Initials string `json:"initials,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Text     string `json:"text"` // One paragraph per line
+}
+
+// Parts holding comments and their Word 2012+ extensions
+var commentPartNames = []string{
+	"word/comments.xml",
+	"word/commentsExtended.xml",
+	"word/commentsIds.xml",
+	"word/commentsExtensible.xml",
+}
+
+const documentRelsPart = "word/_rels/document.xml.rels"
+
+var (
+	commentPattern      = regexp.MustCompile(`(?s)<w:comment\s([^>]*)>(.*?)</w:comment>`)
+	xmlAttrPattern      = regexp.MustCompile(`([\w:]+)="([^"]*)"`)
+	commentRangePattern = regexp.MustCompile(`<w:commentRange(?:Start|End)\s[^>]*/>`)
+	commentRefPattern   = regexp.MustCompile(`<w:commentReference\s[^>]*/>`)
+	relationshipPattern = regexp.MustCompile(`<Relationship\s[^>]*/>`)
+	overridePattern     = regexp.MustCompile(`<Override\s[^>]*/>`)
+)
+
+// GetComments returns the document's comments in the order they are stored,
+// reflecting a pending RemoveComments
+func (w *WordDocument) GetComments() ([]Comment, error) {
+	if w.closed {
+		return nil, errors.New("document is closed")
+	}
+
+	var data []byte
+	if edited, ok := w.commentParts[commentPartNames[0]]; ok {
+		data = edited
+	} else {
+		var err error
+		if data, err = readZipPart(w.zipFile.File, commentPartNames[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	var comments []Comment
+	for _, match := range commentPattern.FindAllStringSubmatch(string(data), -1) {
+		attrs := xmlAttributes(match[1])
+		comments = append(comments, Comment{
+			ID:       attrs["w:id"],
+			Author:   attrs["w:author"],
+			Initials: attrs["w:initials"],
+			Date:     attrs["w:date"],
+			Text:     formFieldText(match[2]),
+		})
+	}
+	return comments, nil
+}
+
+// RemoveComments deletes all comments: the comment parts, their
+// relationships and content types, and the range markers and references in
+// the body. Paragraphs and the text around the markers are kept. The change
+// is written on the next Save or SaveAs.
+func (w *WordDocument) RemoveComments() error {
+	if w.closed {
+		return errors.New("document is closed")
+	}
+
+	xmlStr := string(w.content.rawXML)
+	cleaned := removeCommentReferences(xmlStr)
+	cleaned = commentRangePattern.ReplaceAllString(cleaned, "")
+	if cleaned != xmlStr {
+		w.content.rawXML = []byte(cleaned)
+		w.modified = true
+	}
+
+	if w.commentParts == nil {
+		w.commentParts = make(map[string][]byte)
+	}
+	for _, name := range commentPartNames {
+		w.commentParts[name] = nil
+	}
+
+	rels, err := readZipPart(w.zipFile.File, documentRelsPart)
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		w.commentParts[documentRelsPart] = []byte(relationshipPattern.ReplaceAllStringFunc(string(rels), func(rel string) string {
+			if isCommentPart(path.Join("word", xmlAttributes(rel)["Target"])) {
+				return ""
+			}
+			return rel
+		}))
+	}
+
+	contentTypes, err := readZipPart(w.zipFile.File, contentTypesPart)
+	if err != nil {
+		return err
+	}
+	if contentTypes != nil {
+		w.commentParts[contentTypesPart] = []byte(overridePattern.ReplaceAllStringFunc(string(contentTypes), func(override string) string {
+			if isCommentPart(strings.TrimPrefix(xmlAttributes(override)["PartName"], "/")) {
+				return ""
+			}
+			return override
+		}))
+	}
+	return nil
+}
+
+// removeCommentReferences removes comment references from xmlStr. A run
+// holding nothing but a reference and its formatting is removed with it.
+func removeCommentReferences(xmlStr string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range commentRefPattern.FindAllStringIndex(xmlStr, -1) {
+		start, end := loc[0], loc[1]
+
+		runStart := max(strings.LastIndex(xmlStr[:start], "<w:r>"), strings.LastIndex(xmlStr[:start], "<w:r "))
+		if runStart >= last && strings.HasPrefix(xmlStr[end:], "</w:r>") {
+			inside := xmlStr[runStart:start]
+			if !strings.Contains(inside, "</w:r>") && !strings.Contains(inside, "<w:t") {
+				start, end = runStart, end+len("</w:r>")
+			}
+		}
+
+		b.WriteString(xmlStr[last:start])
+		last = end
+	}
+	b.WriteString(xmlStr[last:])
+	return b.String()
+}
+
+// isCommentPart reports whether a package part name is a comments part
+func isCommentPart(name string) bool {
+	for _, part := range commentPartNames {
+		if name == part {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlAttributes parses the attributes of an element's start tag
+func xmlAttributes(tag string) map[string]string {
+	attrs := make(map[string]string)
+	for _, match := range xmlAttrPattern.FindAllStringSubmatch(tag, -1) {
+		attrs[match[1]] = html.UnescapeString(match[2])
+	}
+	return attrs
+}
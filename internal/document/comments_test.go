@@ -0,0 +1,124 @@
+package document
+
+import (
+	"archive/zip"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDocument_GetComments(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/comments.docx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	comments, err := doc.GetComments()
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+
+	want := []Comment{
+		{ID: "0", Author: "Kim Reviewer", Initials: "KR", Date: "2024-05-01T09:30:00Z", Text: "Is this still true?"},
+		{ID: "1", Author: "Lee & Partners", Date: "2024-05-02T14:00:00Z", Text: "Check the amount.\nFinance has 12,000."},
+		{ID: "2", Author: "Kim Reviewer", Text: "Add a summary here."},
+	}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("GetComments() = %+v, want %+v", comments, want)
+	}
+}
+
+func TestWordDocument_GetCommentsWithoutComments(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/sample.docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	comments, err := doc.GetComments()
+	if err != nil || len(comments) != 0 {
+		t.Errorf("GetComments() = %+v, %v, want none", comments, err)
+	}
+}
+
+func TestWordDocument_RemoveComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "comments.docx")
+	copyFile(t, "testdata/comments.docx", path)
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, _ := doc.GetText()
+	if err := doc.RemoveComments(); err != nil {
+		t.Fatalf("RemoveComments() error = %v", err)
+	}
+	if comments, _ := doc.GetComments(); len(comments) != 0 {
+		t.Errorf("GetComments() after RemoveComments = %+v", comments)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	reopened, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatalf("reopening cleaned document: %v", err)
+	}
+	defer reopened.Close()
+
+	if after, _ := reopened.GetText(); after != before {
+		t.Errorf("text changed: %q, want %q", after, before)
+	}
+	xml := string(reopened.content.rawXML)
+	if strings.Contains(xml, "comment") {
+		t.Errorf("comment markers remain: %s", xml)
+	}
+	if got := strings.Count(xml, "<w:p>"); got != 3 {
+		t.Errorf("paragraph count = %d, want 3", got)
+	}
+	if !strings.Contains(xml, `<w:r><w:t>on schedule</w:t></w:r><w:r><w:t>.</w:t></w:r>`) {
+		t.Errorf("runs around the comment were not kept: %s", xml)
+	}
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if isCommentPart(file.Name) {
+			t.Errorf("comment part %s was not removed", file.Name)
+		}
+	}
+	for _, name := range []string{documentRelsPart, contentTypesPart} {
+		data, _ := readZipPart(reader.File, name)
+		if strings.Contains(string(data), "comments") {
+			t.Errorf("%s still references comments: %s", name, data)
+		}
+		if !strings.Contains(string(data), "styles.xml") {
+			t.Errorf("%s lost unrelated entries: %s", name, data)
+		}
+	}
+}
+
+func TestRemoveCommentReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		xml  string
+		want string
+	}{
+		{"reference run", `<w:p><w:r><w:rPr><w:rStyle w:val="CommentReference"/></w:rPr><w:commentReference w:id="0"/></w:r></w:p>`, `<w:p></w:p>`},
+		{"reference sharing a run with text", `<w:r><w:t>a</w:t><w:commentReference w:id="0"/></w:r>`, `<w:r><w:t>a</w:t></w:r>`},
+		{"previous run is kept", `<w:r><w:t>a</w:t></w:r><w:r><w:commentReference w:id="0"/></w:r>`, `<w:r><w:t>a</w:t></w:r>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := removeCommentReferences(tt.xml); got != tt.want {
+				t.Errorf("removeCommentReferences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
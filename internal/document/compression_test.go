@@ -0,0 +1,171 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// compressedPart is one entry of a test package with its compression
+type compressedPart struct {
+	name    string
+	content string
+	method  uint16
+	level   int // flate level for Deflate entries
+}
+
+// writeCompressedPackage writes parts with their own compression method and
+// level and a fixed modification time
+func writeCompressedPackage(t *testing.T, path string, parts []compressedPart) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	modified := time.Date(2020, 1, 2, 3, 4, 6, 0, time.UTC)
+	for _, part := range parts {
+		level := part.level
+		w.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: part.name, Method: part.method, Modified: modified})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(part.content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rawEntries returns the headers and compressed bytes of every entry
+func rawEntries(t *testing.T, path string) (map[string]zip.FileHeader, map[string][]byte) {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	headers := make(map[string]zip.FileHeader)
+	raw := make(map[string][]byte)
+	for _, file := range reader.File {
+		rc, err := file.OpenRaw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		headers[file.Name] = file.FileHeader
+		raw[file.Name] = data
+	}
+	return headers, raw
+}
+
+func TestSavePreservesCompression(t *testing.T) {
+	image := string(bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 64))
+	styles := `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		string(bytes.Repeat([]byte(`<w:style w:styleId="Normal"/>`), 20)) + `</w:styles>`
+
+	tests := []struct {
+		name     string
+		file     string
+		edited   string
+		parts    []compressedPart
+		replace  func(path string) error
+		unedited []string
+	}{
+		{
+			name:   "Word",
+			file:   "report.docx",
+			edited: "word/document.xml",
+			parts: []compressedPart{
+				{name: "word/document.xml", method: zip.Deflate, level: flate.BestCompression,
+					content: `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>Draft</w:t></w:r></w:p></w:body></w:document>`},
+				{name: "word/styles.xml", method: zip.Deflate, level: flate.BestSpeed, content: styles},
+				{name: "word/media/image1.png", method: zip.Store, content: image},
+			},
+			replace: func(path string) error {
+				doc, err := OpenWordDocument(path)
+				if err != nil {
+					return err
+				}
+				defer doc.Close()
+				if err := doc.ReplaceText("Draft", "Final"); err != nil {
+					return err
+				}
+				return doc.Save()
+			},
+			unedited: []string{"word/styles.xml", "word/media/image1.png"},
+		},
+		{
+			name:   "PowerPoint",
+			file:   "deck.pptx",
+			edited: "ppt/slides/slide1.xml",
+			parts: []compressedPart{
+				{name: "ppt/slides/slide1.xml", method: zip.Deflate, level: flate.BestCompression,
+					content: `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>Draft</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`},
+				{name: "ppt/theme/theme1.xml", method: zip.Deflate, level: flate.BestSpeed, content: styles},
+				{name: "ppt/media/image1.png", method: zip.Store, content: image},
+			},
+			replace: func(path string) error {
+				doc, err := OpenPowerPointDocument(path)
+				if err != nil {
+					return err
+				}
+				defer doc.Close()
+				if err := doc.ReplaceText("Draft", "Final"); err != nil {
+					return err
+				}
+				return doc.Save()
+			},
+			unedited: []string{"ppt/theme/theme1.xml", "ppt/media/image1.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			writeCompressedPackage(t, path, tt.parts)
+			beforeHeaders, beforeRaw := rawEntries(t, path)
+
+			// Save twice so that header fields carried over from the previous
+			// save would show up repeated
+			for i := 0; i < 2; i++ {
+				if err := tt.replace(path); err != nil {
+					t.Fatalf("replace and save failed: %v", err)
+				}
+			}
+			afterHeaders, afterRaw := rawEntries(t, path)
+
+			for _, name := range tt.unedited {
+				if !bytes.Equal(afterRaw[name], beforeRaw[name]) {
+					t.Errorf("%s: compressed bytes changed on save", name)
+				}
+				if afterHeaders[name].Method != beforeHeaders[name].Method {
+					t.Errorf("%s: method %d, want %d", name, afterHeaders[name].Method, beforeHeaders[name].Method)
+				}
+			}
+
+			edited := afterHeaders[tt.edited]
+			if edited.Method != zip.Deflate || !edited.Modified.Equal(beforeHeaders[tt.edited].Modified) {
+				t.Errorf("%s: header %+v should keep the original method and time", tt.edited, edited)
+			}
+			if !bytes.Equal(edited.Extra, beforeHeaders[tt.edited].Extra) {
+				t.Errorf("%s: extra field %x, want %x", tt.edited, edited.Extra, beforeHeaders[tt.edited].Extra)
+			}
+		})
+	}
+}
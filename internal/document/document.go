@@ -4,6 +4,9 @@ package document
 type Document interface {
 	// GetText extracts all text from the document
 	GetText() (string, error)
+
+	// GetPlainText extracts all text without slide or section annotations
+	GetPlainText() (string, error)
 	
 	// ReplaceText replaces all occurrences of old text with new text
 	ReplaceText(old, new string) error
@@ -16,4 +19,12 @@ type Document interface {
 	
 	// Close closes the document and releases resources
 	Close() error
+}
+
+// CountingReplacer is implemented by documents that report how many
+// occurrences a replacement changed
+type CountingReplacer interface {
+	// ReplaceTextCount replaces all occurrences of old text with new text
+	// and returns the number replaced
+	ReplaceTextCount(old, new string) (int, error)
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+package document
+
+import (
+	"regexp"
+	"strings"
+)
+
+// elementTags returns a pattern matching the start, end and empty tags of the
+// element with the given qualified name, such as "w:tr", but not those of
+// elements whose names merely start with it, such as <w:trPr>
+func elementTags(name string) *regexp.Regexp {
+	return regexp.MustCompile(`</?` + regexp.QuoteMeta(name) + `(?:\s[^>]*)?/?>`)
+}
+
+// elementSpan is the byte range [start, end) of an element in XML
+type elementSpan struct {
+	start, end int
+}
+
+// findElements returns the elements whose tags match tagPattern (see
+// elementTags) in the order of their start tags, matching nested elements of
+// the same name by depth. An element nested in another is returned after it;
+// empty elements are included and unclosed ones are not.
+func findElements(xmlStr string, tagPattern *regexp.Regexp) []elementSpan {
+	var spans []elementSpan
+	var open []int // indexes into spans of the elements not yet closed
+	for _, loc := range tagPattern.FindAllStringIndex(xmlStr, -1) {
+		tag := xmlStr[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			if len(open) > 0 {
+				spans[open[len(open)-1]].end = loc[1]
+				open = open[:len(open)-1]
+			}
+		case strings.HasSuffix(tag, "/>"):
+			spans = append(spans, elementSpan{start: loc[0], end: loc[1]})
+		default:
+			open = append(open, len(spans))
+			spans = append(spans, elementSpan{start: loc[0], end: -1})
+		}
+	}
+
+	closed := spans[:0]
+	for _, span := range spans {
+		if span.end >= 0 {
+			closed = append(closed, span)
+		}
+	}
+	return closed
+}
+
+// elementEnd returns the end of the first end tag after offset that closes an
+// element open at offset, skipping elements of the same name opened and
+// closed in between, or -1 if there is none
+func elementEnd(xmlStr string, offset int, tagPattern *regexp.Regexp) int {
+	depth := 0
+	for _, loc := range tagPattern.FindAllStringIndex(xmlStr[offset:], -1) {
+		tag := xmlStr[offset+loc[0] : offset+loc[1]]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			if depth == 0 {
+				return offset + loc[1]
+			}
+			depth--
+		case !strings.HasSuffix(tag, "/>"):
+			depth++
+		}
+	}
+	return -1
+}
+
+// nextElement returns the range of the first element matched by tagPattern
+// that starts at or after offset, or -1, -1 if there is none
+func nextElement(xmlStr string, offset int, tagPattern *regexp.Regexp) (int, int) {
+	for {
+		loc := tagPattern.FindStringIndex(xmlStr[offset:])
+		if loc == nil {
+			return -1, -1
+		}
+		start, tagEnd := offset+loc[0], offset+loc[1]
+		tag := xmlStr[start:tagEnd]
+		switch {
+		case strings.HasPrefix(tag, "</"):
+			offset = tagEnd
+		case strings.HasSuffix(tag, "/>"):
+			return start, tagEnd
+		default:
+			if end := elementEnd(xmlStr, tagEnd, tagPattern); end >= 0 {
+				return start, end
+			}
+			return -1, -1
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package document
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// checkUTF8 validates text extracted from the document at path. Text that
+// is not valid UTF-8, usually from a corrupted part or one written by a tool
+// using a legacy encoding, is an ErrInvalidUTF8 DocumentError, or has each
+// invalid sequence replaced with U+FFFD when sanitize is set.
+func checkUTF8(path, text string, sanitize bool) (string, error) {
+	if utf8.ValidString(text) {
+		return text, nil
+	}
+	if sanitize {
+		return strings.ToValidUTF8(text, "\uFFFD"), nil
+	}
+
+	offset := invalidUTF8Offset(text)
+	before := strings.ToValidUTF8(text[max(0, offset-20):offset], "")
+	reason := fmt.Sprintf("extracted text contains invalid UTF-8 at byte %d (after %q); "+
+		"the file may be corrupted or saved by a tool using a legacy encoding. "+
+		"Open and re-save it in Office to repair it, or sanitize the text to replace invalid characters",
+		offset, before)
+	return "", pkgErrors.NewDocumentError(path, filepath.Ext(path), reason, pkgErrors.ErrInvalidUTF8)
+}
+
+// invalidUTF8Offset returns the byte offset of the first invalid UTF-8
+// sequence in s, or -1 if s is valid
+func invalidUTF8Offset(s string) int {
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// SetSanitizeUTF8 sets whether GetText and GetPlainText replace invalid
+// UTF-8 with U+FFFD instead of failing with an ErrInvalidUTF8 error
+func (w *WordDocument) SetSanitizeUTF8(sanitize bool) {
+	w.sanitizeUTF8 = sanitize
+}
+
+// SetSanitizeUTF8 sets whether GetText and GetPlainText replace invalid
+// UTF-8 with U+FFFD instead of failing with an ErrInvalidUTF8 error
+func (d *PowerPointDocument) SetSanitizeUTF8(sanitize bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sanitizeUTF8 = sanitize
+}
@@ -0,0 +1,82 @@
+package document
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestCheckUTF8(t *testing.T) {
+	t.Run("valid text is unchanged", func(t *testing.T) {
+		got, err := checkUTF8("a.docx", "Grüße 你好", false)
+		if err != nil || got != "Grüße 你好" {
+			t.Errorf("checkUTF8() = %q, %v", got, err)
+		}
+	})
+
+	t.Run("invalid text is an error", func(t *testing.T) {
+		_, err := checkUTF8("a.docx", "Total: \xff\xfe 100", false)
+		if !errors.Is(err, pkgErrors.ErrInvalidUTF8) || !errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
+			t.Fatalf("checkUTF8() error = %v, want ErrInvalidUTF8", err)
+		}
+		var docErr *pkgErrors.DocumentError
+		if !errors.As(err, &docErr) || docErr.Path != "a.docx" {
+			t.Fatalf("checkUTF8() error = %#v, want a DocumentError for a.docx", err)
+		}
+		if !strings.Contains(docErr.Reason, "byte 7") || !strings.Contains(docErr.Reason, `"Total: "`) {
+			t.Errorf("reason should locate the invalid byte: %s", docErr.Reason)
+		}
+	})
+
+	t.Run("sanitizing replaces invalid sequences", func(t *testing.T) {
+		got, err := checkUTF8("a.docx", "Total: \xff\xfe 100", true)
+		if err != nil || got != "Total: � 100" {
+			t.Errorf("checkUTF8() = %q, %v", got, err)
+		}
+	})
+}
+
+func TestInvalidUTF8Offset(t *testing.T) {
+	for _, tt := range []struct {
+		text string
+		want int
+	}{
+		{"valid", -1},
+		{"� is a real replacement character", -1},
+		{"ab\x80", 2},
+		{"é\xc3", 2},
+	} {
+		if got := invalidUTF8Offset(tt.text); got != tt.want {
+			t.Errorf("invalidUTF8Offset(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestGetTextInvalidUTF8(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupted.docx")
+	writeDocxWithDocumentXML(t, "testdata/sample.docx", path,
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`+
+			"<w:p><w:r><w:t>Price \xa3100</w:t></w:r></w:p></w:body></w:document>")
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	if _, err := doc.GetText(); !errors.Is(err, pkgErrors.ErrInvalidUTF8) {
+		t.Errorf("GetText() error = %v, want ErrInvalidUTF8", err)
+	}
+
+	doc.SetSanitizeUTF8(true)
+	text, err := doc.GetPlainText()
+	if err != nil {
+		t.Fatalf("GetPlainText() error = %v", err)
+	}
+	if text != "Price �100" {
+		t.Errorf("GetPlainText() = %q", text)
+	}
+}
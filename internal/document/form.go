@@ -0,0 +1,230 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FormField is a content control (structured document tag, <w:sdt>) in a
+// Word document, such as a plain text or rich text control added from the
+// Developer tab.
+type FormField struct {
+	Tag         string `json:"tag"`
+	Alias       string `json:"alias,omitempty"`       // Title shown in Word
+	Value       string `json:"value"`                 // Empty while the placeholder is shown
+	Placeholder bool   `json:"placeholder,omitempty"` // Control still shows its placeholder text
+}
+
+var (
+	sdtTagPattern      = regexp.MustCompile(`<w:sdt(?:\s[^>]*)?>|</w:sdt>`)
+	sdtValAttrPattern  = regexp.MustCompile(`\bw:val="([^"]*)"`)
+	formTextPattern    = regexp.MustCompile(`(<w:t(?:\s[^>]*)?>)([^<]*)(</w:t>)`)
+	placeholderPattern = regexp.MustCompile(`<w:showingPlcHdr\s*/>`)
+	plcHdrStylePattern = regexp.MustCompile(`<w:rStyle w:val="PlaceholderText"\s*/>`)
+	formParaPattern    = regexp.MustCompile(`<w:p(?:\s[^>]*)?>.*?</w:p>`)
+	formObjectPattern  = regexp.MustCompile(`<w:(?:drawing|pict|object)[\s>]`)
+)
+
+// sdtSpan locates a content control in document.xml
+type sdtSpan struct {
+	start, end               int // whole <w:sdt>...</w:sdt>
+	prStart, prEnd           int // <w:sdtPr> contents
+	contentStart, contentEnd int // <w:sdtContent> contents
+}
+
+// GetFormFields returns the document's content controls in document order.
+// Nested controls are listed separately after the control containing them.
+func (w *WordDocument) GetFormFields() ([]FormField, error) {
+	if w.closed {
+		return nil, errors.New("document is closed")
+	}
+
+	xmlStr := string(w.content.rawXML)
+	var fields []FormField
+	for _, span := range findContentControls(xmlStr) {
+		props := xmlStr[span.prStart:span.prEnd]
+		field := FormField{
+			Tag:         sdtProperty(props, "tag"),
+			Alias:       sdtProperty(props, "alias"),
+			Placeholder: placeholderPattern.MatchString(props),
+		}
+		if !field.Placeholder {
+			field.Value = formFieldText(xmlStr[span.contentStart:span.contentEnd])
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// SetFormField sets the text of every content control with the given tag.
+// The first text run keeps its formatting and receives the value; other
+// runs in the control are emptied. Placeholder state is cleared.
+func (w *WordDocument) SetFormField(tag, value string) error {
+	if w.closed {
+		return errors.New("document is closed")
+	}
+	if tag == "" {
+		return errors.New("form field tag cannot be empty")
+	}
+
+	xmlStr := string(w.content.rawXML)
+
+	// Controls are located again after each change, since rewriting one
+	// moves the offsets of the controls around it
+	for n := 0; ; n++ {
+		var matches []sdtSpan
+		for _, span := range findContentControls(xmlStr) {
+			if sdtProperty(xmlStr[span.prStart:span.prEnd], "tag") == tag {
+				matches = append(matches, span)
+			}
+		}
+		if n == 0 && len(matches) == 0 {
+			return fmt.Errorf("form field not found: %s", tag)
+		}
+		if n >= len(matches) {
+			break
+		}
+
+		span := matches[n]
+		props := placeholderPattern.ReplaceAllString(xmlStr[span.prStart:span.prEnd], "")
+		content := setFormFieldText(xmlStr[span.contentStart:span.contentEnd], value)
+		xmlStr = xmlStr[:span.prStart] + props + xmlStr[span.prEnd:span.contentStart] + content + xmlStr[span.contentEnd:]
+	}
+
+	w.content.rawXML = []byte(xmlStr)
+	w.modified = true
+	return nil
+}
+
+// findContentControls returns the content controls in xmlStr in document
+// order, matching nested <w:sdt> elements by depth
+func findContentControls(xmlStr string) []sdtSpan {
+	var spans []sdtSpan
+	var open []int
+	for _, loc := range sdtTagPattern.FindAllStringIndex(xmlStr, -1) {
+		if strings.HasPrefix(xmlStr[loc[0]:], "</") {
+			if len(open) == 0 {
+				continue
+			}
+			start := open[len(open)-1]
+			open = open[:len(open)-1]
+			if span, ok := newSDTSpan(xmlStr, start, loc[1]); ok {
+				spans = append(spans, span)
+			}
+			continue
+		}
+		if strings.HasSuffix(xmlStr[loc[0]:loc[1]], "/>") {
+			continue
+		}
+		open = append(open, loc[0])
+	}
+
+	// Closing tags are seen innermost first; report outer controls first
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	return spans
+}
+
+// newSDTSpan locates the properties and content of the control at [start, end)
+func newSDTSpan(xmlStr string, start, end int) (sdtSpan, bool) {
+	element := xmlStr[start:end]
+	span := sdtSpan{start: start, end: end}
+
+	// The control's own properties come before any nested control
+	prOpen := strings.Index(element, "<w:sdtPr>")
+	prClose := strings.Index(element, "</w:sdtPr>")
+	if prOpen >= 0 && prClose > prOpen {
+		span.prStart = start + prOpen + len("<w:sdtPr>")
+		span.prEnd = start + prClose
+	} else {
+		span.prStart, span.prEnd = start, start
+	}
+
+	contentOpen := strings.Index(element, "<w:sdtContent>")
+	contentClose := strings.LastIndex(element, "</w:sdtContent>")
+	if contentOpen < 0 || contentClose < contentOpen {
+		return sdtSpan{}, false
+	}
+	span.contentStart = start + contentOpen + len("<w:sdtContent>")
+	span.contentEnd = start + contentClose
+	if span.prEnd > span.contentStart {
+		span.prStart, span.prEnd = start, start
+	}
+	return span, true
+}
+
+// sdtProperty returns the w:val of a property such as <w:tag w:val="..."/>
+func sdtProperty(props, name string) string {
+	idx := strings.Index(props, "<w:"+name+" ")
+	if idx < 0 {
+		return ""
+	}
+	end := strings.Index(props[idx:], ">")
+	if end < 0 {
+		return ""
+	}
+	match := sdtValAttrPattern.FindStringSubmatch(props[idx : idx+end])
+	if match == nil {
+		return ""
+	}
+	return html.UnescapeString(match[1])
+}
+
+// formFieldText returns the text of a control's content, one paragraph per line
+func formFieldText(content string) string {
+	paras := formParaPattern.FindAllString(content, -1)
+	if len(paras) == 0 {
+		paras = []string{content}
+	}
+
+	lines := make([]string, 0, len(paras))
+	for _, para := range paras {
+		var b strings.Builder
+		for _, match := range formTextPattern.FindAllStringSubmatch(para, -1) {
+			b.WriteString(html.UnescapeString(match[2]))
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setFormFieldText puts value into the first text element of content and
+// empties the others, adding a run when the control has no text yet.
+// Paragraphs after the first one that held only text are removed; those
+// with images or other content besides text are kept.
+func setFormFieldText(content, value string) string {
+	content = plcHdrStylePattern.ReplaceAllString(content, "")
+	escaped := escapeXMLString(value)
+
+	first := true
+	content = formTextPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if !first {
+			return "<w:t></w:t>"
+		}
+		first = false
+		return `<w:t xml:space="preserve">` + escaped + "</w:t>"
+	})
+	if !first {
+		seenParagraph := false
+		return formParaPattern.ReplaceAllStringFunc(content, func(para string) string {
+			if !seenParagraph {
+				seenParagraph = true
+				return para
+			}
+			if formFieldText(para) == "" && strings.Contains(para, "<w:t") &&
+				!strings.Contains(para, "<w:sdt") && !formObjectPattern.MatchString(para) {
+				return ""
+			}
+			return para
+		})
+	}
+
+	newRun := `<w:r><w:t xml:space="preserve">` + escaped + `</w:t></w:r>`
+	if idx := strings.Index(content, "</w:p>"); idx >= 0 {
+		return content[:idx] + newRun + content[idx:]
+	}
+	return newRun + content
+}
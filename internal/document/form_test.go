@@ -0,0 +1,149 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDocument_GetFormFields(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/form.docx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	fields, err := doc.GetFormFields()
+	if err != nil {
+		t.Fatalf("GetFormFields() error = %v", err)
+	}
+
+	want := []FormField{
+		{Tag: "customer_name", Alias: "Customer name", Value: "Jane Doe"},
+		{Tag: "order_date", Alias: "Order date", Placeholder: true},
+		{Tag: "notes", Value: "Deliver to the back door.\nCall on arrival."},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("GetFormFields() = %+v, want %+v", fields, want)
+	}
+}
+
+func TestWordDocument_SetFormField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "form.docx")
+	copyFile(t, "testdata/form.docx", path)
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.SetFormField("customer_name", "Kim & Co"); err != nil {
+		t.Fatalf("SetFormField(customer_name) error = %v", err)
+	}
+	if err := doc.SetFormField("order_date", "2024-05-01"); err != nil {
+		t.Fatalf("SetFormField(order_date) error = %v", err)
+	}
+	if err := doc.SetFormField("notes", "Leave at reception"); err != nil {
+		t.Fatalf("SetFormField(notes) error = %v", err)
+	}
+	if err := doc.SetFormField("missing", "x"); err == nil {
+		t.Error("expected error for unknown tag")
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	reopened, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	fields, err := reopened.GetFormFields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]FormField)
+	for _, field := range fields {
+		got[field.Tag] = field
+	}
+	if got["customer_name"].Value != "Kim & Co" {
+		t.Errorf("customer_name = %q", got["customer_name"].Value)
+	}
+	if f := got["order_date"]; f.Value != "2024-05-01" || f.Placeholder {
+		t.Errorf("order_date = %+v, want filled value without placeholder", f)
+	}
+	if got["notes"].Value != "Leave at reception" {
+		t.Errorf("notes = %q", got["notes"].Value)
+	}
+
+	xml := string(reopened.content.rawXML)
+	if !strings.Contains(xml, `<w:rPr><w:b/></w:rPr><w:t xml:space="preserve">Kim &amp; Co</w:t>`) {
+		t.Error("run formatting of customer_name was not kept")
+	}
+	if strings.Contains(xml, "PlaceholderText") || strings.Contains(xml, "showingPlcHdr") {
+		t.Error("placeholder state was not cleared")
+	}
+}
+
+func TestWordDocument_SetFormFieldEmptyControl(t *testing.T) {
+	doc := &WordDocument{content: &documentContent{rawXML: []byte(
+		`<w:body><w:sdt><w:sdtPr><w:tag w:val="city"/></w:sdtPr><w:sdtContent><w:p></w:p></w:sdtContent></w:sdt></w:body>`)}}
+
+	if err := doc.SetFormField("city", "Seoul"); err != nil {
+		t.Fatal(err)
+	}
+	fields, _ := doc.GetFormFields()
+	if len(fields) != 1 || fields[0].Value != "Seoul" {
+		t.Errorf("GetFormFields() = %+v, want city=Seoul", fields)
+	}
+}
+
+func TestWordDocument_SetFormFieldKeepsImages(t *testing.T) {
+	image := `<w:p><w:r><w:drawing><wp:inline><a:graphic/></wp:inline></w:drawing></w:r></w:p>`
+	doc := &WordDocument{content: &documentContent{rawXML: []byte(
+		`<w:body><w:sdt><w:sdtPr><w:tag w:val="notes"/></w:sdtPr><w:sdtContent>` +
+			`<w:p><w:r><w:t>Old</w:t></w:r></w:p>` + image + `<w:p><w:r><w:t>Second</w:t></w:r></w:p>` +
+			`</w:sdtContent></w:sdt></w:body>`)}}
+
+	if err := doc.SetFormField("notes", "New"); err != nil {
+		t.Fatal(err)
+	}
+	xml := string(doc.content.rawXML)
+	if !strings.Contains(xml, image) {
+		t.Errorf("image paragraph was removed: %s", xml)
+	}
+	if strings.Contains(xml, "Second") || strings.Count(xml, "<w:p>") != 2 {
+		t.Errorf("text paragraph was not collapsed: %s", xml)
+	}
+	fields, _ := doc.GetFormFields()
+	if len(fields) != 1 || fields[0].Value != "New\n" {
+		t.Errorf("GetFormFields() = %+v", fields)
+	}
+}
+
+func TestWordDocument_NestedFormFields(t *testing.T) {
+	doc := &WordDocument{content: &documentContent{rawXML: []byte(
+		`<w:body><w:sdt><w:sdtPr><w:tag w:val="address"/></w:sdtPr><w:sdtContent><w:p><w:r><w:t xml:space="preserve">City: </w:t></w:r>` +
+			`<w:sdt><w:sdtPr><w:tag w:val="city"/></w:sdtPr><w:sdtContent><w:r><w:t>Busan</w:t></w:r></w:sdtContent></w:sdt></w:p></w:sdtContent></w:sdt></w:body>`)}}
+
+	fields, err := doc.GetFormFields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || fields[0].Tag != "address" || fields[1].Tag != "city" {
+		t.Fatalf("GetFormFields() = %+v, want address then city", fields)
+	}
+	if fields[0].Value != "City: Busan" || fields[1].Value != "Busan" {
+		t.Errorf("values = %q, %q", fields[0].Value, fields[1].Value)
+	}
+
+	if err := doc.SetFormField("city", "Seoul"); err != nil {
+		t.Fatal(err)
+	}
+	fields, _ = doc.GetFormFields()
+	if fields[0].Value != "City: Seoul" {
+		t.Errorf("address = %q after setting city", fields[0].Value)
+	}
+}
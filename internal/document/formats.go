@@ -0,0 +1,66 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// Content types of the main part of documents and their template variants.
+// Templates (.dotx/.potx) share the document layout and differ only in the
+// main part's content type in [Content_Types].xml.
+const (
+	wordDocumentContentType         = "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"
+	wordTemplateContentType         = "application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"
+	presentationContentType         = "application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"
+	presentationTemplateContentType = "application/vnd.openxmlformats-officedocument.presentationml.template.main+xml"
+)
+
+// contentTypesPart is the package part declaring the content type of every part
+const contentTypesPart = "[Content_Types].xml"
+
+// IsWordFile reports whether path has a Word document or template extension (.docx, .dotx)
+func IsWordFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".docx" || ext == ".dotx"
+}
+
+// IsPowerPointFile reports whether path has a PowerPoint presentation or
+// template extension (.pptx, .potx)
+func IsPowerPointFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".pptx" || ext == ".potx"
+}
+
+// IsTemplateFile reports whether path has an Office template extension (.dotx, .potx)
+func IsTemplateFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".dotx" || ext == ".potx"
+}
+
+// retypedContentTypes returns [Content_Types].xml with the main part declared
+// as a template or a document to match the extension of outputPath, so a
+// .dotx saved as .docx opens as a regular document and vice versa. It
+// reports false when the declared type already matches.
+func retypedContentTypes(files []*zip.File, outputPath, documentType, templateType string) ([]byte, bool, error) {
+	data, err := readZipPart(files, contentTypesPart)
+	if err != nil {
+		return nil, false, err
+	}
+	data, retyped := retypeContentTypes(data, outputPath, documentType, templateType)
+	return data, retyped, nil
+}
+
+// retypeContentTypes rewrites the main part content type in a [Content_Types].xml
+// to match outputPath, reporting whether anything changed
+func retypeContentTypes(data []byte, outputPath, documentType, templateType string) ([]byte, bool) {
+	from, to := templateType, documentType
+	if IsTemplateFile(outputPath) {
+		from, to = documentType, templateType
+	}
+	if !bytes.Contains(data, []byte(from)) {
+		return data, false
+	}
+	return bytes.ReplaceAll(data, []byte(from), []byte(to)), true
+}
@@ -0,0 +1,147 @@
+package document
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOfficeFileExtensions(t *testing.T) {
+	tests := []struct {
+		path       string
+		word       bool
+		powerPoint bool
+		template   bool
+	}{
+		{"report.docx", true, false, false},
+		{"Letter.DOTX", true, false, true},
+		{"deck.pptx", false, true, false},
+		{"brand.potx", false, true, true},
+		{"notes.txt", false, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWordFile(tt.path); got != tt.word {
+			t.Errorf("IsWordFile(%q) = %v, want %v", tt.path, got, tt.word)
+		}
+		if got := IsPowerPointFile(tt.path); got != tt.powerPoint {
+			t.Errorf("IsPowerPointFile(%q) = %v, want %v", tt.path, got, tt.powerPoint)
+		}
+		if got := IsTemplateFile(tt.path); got != tt.template {
+			t.Errorf("IsTemplateFile(%q) = %v, want %v", tt.path, got, tt.template)
+		}
+	}
+}
+
+func TestWordTemplateFile(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/sample.dotx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument(.dotx) failed: %v", err)
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceText("{{name}}", "Jane"); err != nil {
+		t.Fatalf("ReplaceText failed: %v", err)
+	}
+
+	dir := t.TempDir()
+
+	// Saving as .docx declares a regular document
+	docxPath := filepath.Join(dir, "letter.docx")
+	if err := doc.SaveAs(docxPath); err != nil {
+		t.Fatalf("SaveAs(.docx) failed: %v", err)
+	}
+	assertMainContentType(t, docxPath, wordDocumentContentType)
+
+	saved, err := OpenWordDocument(docxPath)
+	if err != nil {
+		t.Fatalf("failed to reopen output: %v", err)
+	}
+	defer saved.Close()
+	if text, _ := saved.GetText(); !strings.Contains(text, "Dear Jane,") {
+		t.Errorf("replacement missing from output: %q", text)
+	}
+
+	// Saving as .dotx keeps the template content type
+	dotxPath := filepath.Join(dir, "letter.dotx")
+	if err := doc.SaveAs(dotxPath); err != nil {
+		t.Fatalf("SaveAs(.dotx) failed: %v", err)
+	}
+	assertMainContentType(t, dotxPath, wordTemplateContentType)
+}
+
+func TestPowerPointTemplateFile(t *testing.T) {
+	dir := t.TempDir()
+	potxPath := filepath.Join(dir, "brand.potx")
+	retypePackage(t, "testdata/table_chart.pptx", potxPath, presentationContentType, presentationTemplateContentType)
+
+	doc, err := OpenPowerPointDocument(potxPath)
+	if err != nil {
+		t.Fatalf("OpenPowerPointDocument(.potx) failed: %v", err)
+	}
+	defer doc.Close()
+
+	if text, _ := doc.GetText(); !strings.Contains(text, "Quarterly Report 2023") {
+		t.Errorf("template text not extracted: %q", text)
+	}
+
+	// An unmodified template is still written when saved as a presentation
+	pptxPath := filepath.Join(dir, "deck.pptx")
+	if err := doc.SaveAs(pptxPath); err != nil {
+		t.Fatalf("SaveAs(.pptx) failed: %v", err)
+	}
+	assertMainContentType(t, pptxPath, presentationContentType)
+}
+
+// assertMainContentType checks the main part content type declared by a saved package
+func assertMainContentType(t *testing.T, path, want string) {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer reader.Close()
+
+	data, err := readZipPart(reader.File, contentTypesPart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), want) {
+		t.Errorf("%s: content types do not declare %s:\n%s", filepath.Base(path), want, data)
+	}
+}
+
+// retypePackage copies a package, replacing one content type with another
+func retypePackage(t *testing.T, src, dst, from, to string) {
+	t.Helper()
+
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	overrides := make(map[string][]byte)
+	data, err := readZipPart(reader.File, contentTypesPart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overrides[contentTypesPart] = []byte(strings.ReplaceAll(string(data), from, to))
+
+	w := zip.NewWriter(f)
+	if err := writePackage(w, reader.File, overrides); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
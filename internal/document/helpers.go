@@ -2,10 +2,44 @@ package document
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 )
 
+// cfbSignature is the magic header of OLE compound binary files. Encrypted
+// OOXML documents are wrapped in this container instead of a plain zip.
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// isCompoundBinary reports whether data starts with the OLE/CFB signature
+func isCompoundBinary(data []byte) bool {
+	return bytes.HasPrefix(data, cfbSignature)
+}
+
+// checkNotEncrypted returns a password-protected error if the file at path is
+// an OLE compound file rather than a zip-based OOXML package
+func checkNotEncrypted(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil // Let the regular open path report the error
+	}
+	defer f.Close()
+
+	header := make([]byte, len(cfbSignature))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return nil
+	}
+	if isCompoundBinary(header) {
+		return pkgErrors.NewPasswordProtectedError(path)
+	}
+	return nil
+}
+
 // CleanupTempFile safely removes a temporary file if it exists
 func CleanupTempFile(path string) {
 	if path == "" {
@@ -16,22 +50,113 @@ func CleanupTempFile(path string) {
 	}
 }
 
-// CopyZipFileWithCompression copies a file from source zip to destination zip with consistent compression
-func CopyZipFileWithCompression(src *zip.File, dst *zip.Writer, bufferPool []byte) error {
-	reader, err := src.Open()
+// writeTempData writes the saved document to the temporary file; replaced in
+// tests to simulate a failure part way through a save
+var writeTempData = func(f *os.File, data []byte) error {
+	_, err := f.Write(data)
+	return err
+}
+
+// writeFileAtomic saves data to path through a temporary file in the same
+// directory that is flushed to disk and then renamed over path, so a failed
+// or interrupted save leaves any existing file untouched. An existing file
+// keeps its permissions; a new one is created with perm.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmpFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create temp file: %w", err)
 	}
-	defer reader.Close()
+	tmpPath := tmpFile.Name()
+	
+	// Ensure temp file is cleaned up
+	defer CleanupTempFile(tmpPath)
+	
+	// Write content to temp file
+	if err := writeTempData(tmpFile, data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	
+	// Ensure data is flushed to disk before it replaces the original
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	
+	// Atomically replace the original file
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	
+	// Persist the rename itself; directories cannot be synced on every
+	// platform, so this is best effort
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	
+	return nil
+}
 
+// createLike adds an entry to dst with the name, timestamps and compression
+// method of src, for content rewritten from src. Methods other than Store and
+// Deflate have no registered compressor and fall back to Deflate.
+func createLike(dst *zip.Writer, src *zip.File) (io.Writer, error) {
 	// Clone the header to avoid modifying the original
 	header := src.FileHeader
-	// Use the original compression method if it's valid, otherwise use Deflate
 	if header.Method != zip.Store && header.Method != zip.Deflate {
 		header.Method = zip.Deflate
 	}
-	
-	writer, err := dst.CreateHeader(&header)
+	header.Extra = withoutWriterExtra(header.Extra)
+	return dst.CreateHeader(&header)
+}
+
+// Extra field IDs that zip.Writer adds itself when it writes an entry
+const (
+	zip64ExtraID   = 0x0001 // Zip64 sizes and offsets
+	extTimeExtraID = 0x5455 // Extended timestamp
+)
+
+// withoutWriterExtra returns extra without the fields zip.Writer adds itself,
+// so that rewriting an entry does not repeat them on every save. Other
+// fields are kept; a malformed tail is dropped.
+func withoutWriterExtra(extra []byte) []byte {
+	var kept []byte
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := 4 + int(binary.LittleEndian.Uint16(extra[2:]))
+		if size > len(extra) {
+			break
+		}
+		if id != zip64ExtraID && id != extTimeExtraID {
+			kept = append(kept, extra[:size]...)
+		}
+		extra = extra[size:]
+	}
+	return kept
+}
+
+// CopyZipFileWithCompression copies a file from source zip to destination zip with consistent compression
+func CopyZipFileWithCompression(src *zip.File, dst *zip.Writer, bufferPool []byte) error {
+	reader, err := src.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := createLike(dst, src)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+package document
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// hiddenRunFilter follows the runs of a Word XML token stream and reports
+// whether the current text belongs to a run hidden with <w:vanish/>. Only
+// the run's own properties count; a vanish inside <w:rPrChange> describes
+// formatting before a tracked change and is ignored.
+type hiddenRunFilter struct {
+	elements []string // local names of the open elements
+	runs     []bool   // hidden state of each open run, innermost last
+	hidden   int      // number of open runs that are hidden
+}
+
+// observe updates the filter with the next token of the stream
+func (f *hiddenRunFilter) observe(token xml.Token) {
+	switch t := token.(type) {
+	case xml.StartElement:
+		if t.Name.Local == "r" {
+			f.runs = append(f.runs, false)
+		} else if t.Name.Local == "vanish" && f.inRunProperties() && isOnOff(t) {
+			if !f.runs[len(f.runs)-1] {
+				f.runs[len(f.runs)-1] = true
+				f.hidden++
+			}
+		}
+		f.elements = append(f.elements, t.Name.Local)
+
+	case xml.EndElement:
+		if len(f.elements) > 0 {
+			f.elements = f.elements[:len(f.elements)-1]
+		}
+		if t.Name.Local == "r" && len(f.runs) > 0 {
+			if f.runs[len(f.runs)-1] {
+				f.hidden--
+			}
+			f.runs = f.runs[:len(f.runs)-1]
+		}
+	}
+}
+
+// inRunProperties reports whether the innermost open element is the <w:rPr>
+// of a run
+func (f *hiddenRunFilter) inRunProperties() bool {
+	n := len(f.elements)
+	return len(f.runs) > 0 && n >= 2 && f.elements[n-1] == "rPr" && f.elements[n-2] == "r"
+}
+
+// inHiddenRun reports whether the stream is inside a hidden run
+func (f *hiddenRunFilter) inHiddenRun() bool {
+	return f != nil && f.hidden > 0
+}
+
+// isOnOff reports whether an on/off property such as <w:vanish/> is turned
+// on. The element alone means on; w:val="false", "0" or "off" turns it off.
+func isOnOff(el xml.StartElement) bool {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == "val" {
+			switch attr.Value {
+			case "false", "0", "off":
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hiddenRunSpans returns the byte ranges [start, end) of the outermost hidden
+// runs in Word XML. Malformed XML yields the runs found before the error.
+func hiddenRunSpans(data []byte) [][2]int {
+	if !bytes.Contains(data, []byte("vanish")) {
+		return nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	filter := &hiddenRunFilter{}
+	var starts []int64
+	var spans [][2]int
+
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err != nil {
+			break // io.EOF, or malformed XML
+		}
+
+		wasHidden := filter.inHiddenRun()
+		filter.observe(token)
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "r" {
+				starts = append(starts, start)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "r" && len(starts) > 0 {
+				runStart := starts[len(starts)-1]
+				starts = starts[:len(starts)-1]
+				// The outermost hidden run ends when no hidden run remains open
+				if wasHidden && !filter.inHiddenRun() {
+					spans = append(spans, [2]int{int(runStart), int(decoder.InputOffset())})
+				}
+			}
+		}
+	}
+
+	return spans
+}
+
+// visibleXML returns Word XML with its hidden runs removed
+func visibleXML(data []byte) []byte {
+	spans := hiddenRunSpans(data)
+	if len(spans) == 0 {
+		return data
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, span := range spans {
+		buf.Write(data[last:span[0]])
+		last = span[1]
+	}
+	buf.Write(data[last:])
+	return buf.Bytes()
+}
+
+// mapVisibleXML applies fn to the parts of Word XML outside hidden runs and
+// copies the hidden runs unchanged
+func mapVisibleXML(data string, fn func(string) string) string {
+	spans := hiddenRunSpans([]byte(data))
+	if len(spans) == 0 {
+		return fn(data)
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, span := range spans {
+		buf.WriteString(fn(data[last:span[0]]))
+		buf.WriteString(data[span[0]:span[1]])
+		last = span[1]
+	}
+	buf.WriteString(fn(data[last:]))
+	return buf.String()
+}
+
+// SetIncludeHiddenText sets whether GetText and ReplaceText see text in runs
+// hidden with Word's hidden font effect. Hidden text is skipped by default,
+// so extracted text matches what the document shows.
+func (w *WordDocument) SetIncludeHiddenText(include bool) {
+	w.includeHidden = include
+}
+
+// SetIncludeHiddenText sets whether ReplaceTextStreaming replaces text in
+// hidden runs. Hidden text is skipped by default.
+func (d *StreamingWordDocument) SetIncludeHiddenText(include bool) {
+	d.includeHidden = include
+}
@@ -0,0 +1,126 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	visibleParagraphs = []string{"Status: Draft end.", "Shown Draft text", "Formerly hidden", "Visible with hidden mark"}
+	allParagraphs     = []string{"Status: Draft Draft note for reviewers end.", "Hidden Draft paragraph", "Shown Draft text", "Formerly hidden", "Visible with hidden mark"}
+)
+
+func TestWordDocument_GetTextHiddenText(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/hidden.docx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	if got := doc.GetTextParagraphs(); !reflect.DeepEqual(got, visibleParagraphs) {
+		t.Errorf("default paragraphs = %q, want %q", got, visibleParagraphs)
+	}
+
+	doc.SetIncludeHiddenText(true)
+	if got := doc.GetTextParagraphs(); !reflect.DeepEqual(got, allParagraphs) {
+		t.Errorf("paragraphs with hidden text = %q, want %q", got, allParagraphs)
+	}
+}
+
+func TestWordDocument_ReplaceTextHiddenText(t *testing.T) {
+	tests := []struct {
+		name          string
+		includeHidden bool
+		want          []string
+	}{
+		{
+			name: "skips hidden runs",
+			want: []string{"Status: Final Draft note for reviewers end.", "Hidden Draft paragraph", "Shown Final text", "Formerly hidden", "Visible with hidden mark"},
+		},
+		{
+			name:          "include hidden text",
+			includeHidden: true,
+			want:          []string{"Status: Final Final note for reviewers end.", "Hidden Final paragraph", "Shown Final text", "Formerly hidden", "Visible with hidden mark"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hidden.docx")
+			copyFile(t, "testdata/hidden.docx", path)
+
+			doc, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc.SetIncludeHiddenText(tt.includeHidden)
+			if err := doc.ReplaceText("Draft", "Final"); err != nil {
+				t.Fatalf("ReplaceText() error = %v", err)
+			}
+			if err := doc.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			doc.Close()
+
+			reopened, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+			reopened.SetIncludeHiddenText(true)
+
+			if got := reopened.GetTextParagraphs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paragraphs = %q, want %q", got, tt.want)
+			}
+			if xml := string(reopened.content.rawXML); strings.Count(xml, "<w:vanish/>") != 4 {
+				t.Errorf("hidden formatting changed:\n%s", xml)
+			}
+		})
+	}
+}
+
+func TestStreamingWordDocument_ReplaceHiddenText(t *testing.T) {
+	for _, includeHidden := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "hidden.docx")
+		copyFile(t, "testdata/hidden.docx", path)
+
+		doc, err := OpenWordDocumentStreaming(path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc.SetIncludeHiddenText(includeHidden)
+		count, err := doc.ReplaceTextStreaming("Draft", "Final")
+		doc.Close()
+		if err != nil {
+			t.Fatalf("ReplaceTextStreaming() error = %v", err)
+		}
+
+		want := 2
+		if includeHidden {
+			want = 4
+		}
+		if count != want {
+			t.Errorf("includeHidden=%v: replacement count = %d, want %d", includeHidden, count, want)
+		}
+	}
+}
+
+func TestHiddenRunSpans(t *testing.T) {
+	// A hidden run holding a text box with its own runs is removed as one span
+	xml := `<w:p><w:r><w:t>a</w:t></w:r><w:r><w:rPr><w:vanish w:val="1"/></w:rPr><w:drawing><w:txbxContent><w:p><w:r><w:t>b</w:t></w:r></w:p></w:txbxContent></w:drawing></w:r><w:r><w:t>c</w:t></w:r></w:p>`
+
+	spans := hiddenRunSpans([]byte(xml))
+	if len(spans) != 1 {
+		t.Fatalf("hiddenRunSpans() = %v, want one span", spans)
+	}
+	want := `<w:p><w:r><w:t>a</w:t></w:r><w:r><w:t>c</w:t></w:r></w:p>`
+	if got := string(visibleXML([]byte(xml))); got != want {
+		t.Errorf("visibleXML() = %s, want %s", got, want)
+	}
+
+	if spans := hiddenRunSpans([]byte(`<w:p><w:r><w:t>vanish</w:t></w:r></w:p>`)); spans != nil {
+		t.Errorf("text mentioning vanish produced spans %v", spans)
+	}
+}
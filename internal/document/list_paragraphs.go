@@ -0,0 +1,104 @@
+package document
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Paragraph and text patterns used to expand list placeholders. The paragraph
+// tag patterns (see elementTags) do not match property elements such as
+// <w:pPr> or <a:pPr>, and paragraphs are matched by depth, since a Word
+// paragraph holds the paragraphs of the text boxes it anchors.
+var (
+	wordParagraphTags = elementTags("w:p")
+	wordTextRegex     = regexp.MustCompile(`(<w:t(?:\s[^>]*)?>)([^<]*)(</w:t>)`)
+	pptParagraphTags  = elementTags("a:p")
+)
+
+// expandListParagraphs replaces every paragraph whose whole text is the given
+// placeholder with one copy of the paragraph per line. Each copy keeps the
+// original paragraph and run formatting. A paragraph anchoring a text box is
+// never expanded, but the paragraphs inside the text box are. It reports
+// whether any paragraph was expanded.
+func expandListParagraphs(xmlContent string, paragraphTags, textRegex *regexp.Regexp, placeholder string, lines []string) (string, bool) {
+	var out strings.Builder
+	last := 0
+
+	spans := findElements(xmlContent, paragraphTags)
+	for i, span := range spans {
+		if i+1 < len(spans) && spans[i+1].start < span.end {
+			continue // Holds nested paragraphs
+		}
+		para := xmlContent[span.start:span.end]
+
+		var text strings.Builder
+		for _, match := range textRegex.FindAllStringSubmatch(para, -1) {
+			text.WriteString(html.UnescapeString(match[2]))
+		}
+		if strings.TrimSpace(text.String()) != placeholder {
+			continue
+		}
+
+		// The placeholder must sit in a single text node to be substituted
+		if !strings.Contains(para, placeholder) {
+			continue
+		}
+
+		out.WriteString(xmlContent[last:span.start])
+		for _, line := range lines {
+			out.WriteString(strings.Replace(para, placeholder, escapeXMLString(line), 1))
+		}
+		last = span.end
+	}
+
+	if last == 0 {
+		return xmlContent, false
+	}
+	out.WriteString(xmlContent[last:])
+	return out.String(), true
+}
+
+// ReplaceWithList replaces paragraphs consisting only of placeholder with one
+// paragraph per line, keeping the paragraph's formatting. Occurrences of the
+// placeholder inside longer paragraphs are left for ReplaceText.
+func (w *WordDocument) ReplaceWithList(placeholder string, lines []string) (bool, error) {
+	if w.closed {
+		return false, errors.New("document is closed")
+	}
+	if placeholder == "" {
+		return false, errors.New("placeholder cannot be empty")
+	}
+
+	xmlStr, expanded := expandListParagraphs(string(w.content.rawXML), wordParagraphTags, wordTextRegex, placeholder, lines)
+	if expanded {
+		w.content.rawXML = []byte(xmlStr)
+		w.modified = true
+	}
+	return expanded, nil
+}
+
+// ReplaceWithList replaces text-box paragraphs consisting only of placeholder
+// with one paragraph per line on every slide, keeping the paragraph's formatting.
+// Occurrences of the placeholder inside longer paragraphs are left for ReplaceText.
+func (d *PowerPointDocument) ReplaceWithList(placeholder string, lines []string) (bool, error) {
+	if placeholder == "" {
+		return false, errors.New("placeholder cannot be empty")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	expandedAny := false
+	for _, slide := range d.slides {
+		xmlStr, expanded := expandListParagraphs(slide.xmlDoc, pptParagraphTags, pptTextReplaceRegex, placeholder, lines)
+		if expanded {
+			slide.xmlDoc = xmlStr
+			expandedAny = true
+		}
+	}
+	if expandedAny {
+		d.modified = true
+	}
+	return expandedAny, nil
+}
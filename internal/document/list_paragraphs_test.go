@@ -0,0 +1,85 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandListParagraphs(t *testing.T) {
+	xml := `<w:body>` +
+		`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>{{items}}</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Items: {{items}}</w:t></w:r></w:p>` +
+		`</w:body>`
+
+	result, expanded := expandListParagraphs(xml, wordParagraphTags, wordTextRegex, "{{items}}", []string{"• a & b", "• c"})
+	if !expanded {
+		t.Fatal("expected the standalone placeholder paragraph to be expanded")
+	}
+
+	for _, expected := range []string{
+		`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>• a &amp; b</w:t></w:r></w:p>`,
+		`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>• c</w:t></w:r></w:p>`,
+		`<w:t>Items: {{items}}</w:t>`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("result missing %q:\n%s", expected, result)
+		}
+	}
+}
+
+func TestWordReplaceWithListInTextBox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "textbox.docx")
+	copyFile(t, "testdata/textbox.docx", path)
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The text box paragraph is nested in the paragraph anchoring the box
+	expanded, err := doc.ReplaceWithList("Box says Draft", []string{"• First", "• Second"})
+	if err != nil {
+		t.Fatalf("ReplaceWithList() error = %v", err)
+	}
+	if !expanded {
+		t.Fatal("expected the text box paragraph to be expanded")
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	reopened, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	want := []string{"Intro outro.", "• First", "• Second", "Second line", "Body Draft"}
+	if got := reopened.GetTextParagraphs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("paragraphs = %q, want %q", got, want)
+	}
+}
+
+func TestPowerPointReplaceWithList(t *testing.T) {
+	doc, err := OpenPowerPointDocument("testdata/table_chart.pptx")
+	if err != nil {
+		t.Fatalf("failed to open presentation: %v", err)
+	}
+	defer doc.Close()
+
+	// The title paragraph holds only this text, so it is expanded into lines
+	expanded, err := doc.ReplaceWithList("Quarterly Report 2023", []string{"• First", "• Second"})
+	if err != nil {
+		t.Fatalf("ReplaceWithList failed: %v", err)
+	}
+	if !expanded {
+		t.Fatal("expected the title paragraph to be expanded")
+	}
+
+	text, _ := doc.GetText()
+	if !strings.Contains(text, "• First") || !strings.Contains(text, "• Second") || strings.Contains(text, "Quarterly Report 2023") {
+		t.Errorf("unexpected text after expansion:\n%s", text)
+	}
+}
@@ -0,0 +1,331 @@
+package document
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Document property parts shared by Word and PowerPoint packages
+const (
+	corePropsPart = "docProps/core.xml"
+	appPropsPart  = "docProps/app.xml"
+)
+
+// Metadata holds the document properties stored in docProps
+type Metadata struct {
+	Title          string `json:"title,omitempty"`
+	Subject        string `json:"subject,omitempty"`
+	Creator        string `json:"creator,omitempty"`
+	Keywords       string `json:"keywords,omitempty"`
+	Description    string `json:"description,omitempty"`
+	LastModifiedBy string `json:"lastModifiedBy,omitempty"`
+	Category       string `json:"category,omitempty"`
+	Created        string `json:"created,omitempty"`
+	Modified       string `json:"modified,omitempty"`
+	Company        string `json:"company,omitempty"`
+	Manager        string `json:"manager,omitempty"`
+
+	// Revision history from core.xml
+	Revision    string `json:"revision,omitempty"`
+	LastPrinted string `json:"lastPrinted,omitempty"`
+
+	// Statistics from app.xml, as last saved by the authoring application.
+	// They do not identify anyone and are kept by StripMetadata.
+	Application  string `json:"application,omitempty"`
+	TotalTime    int    `json:"totalEditingMinutes,omitempty"`
+	Pages        int    `json:"pages,omitempty"`
+	Words        int    `json:"words,omitempty"`
+	Slides       int    `json:"slides,omitempty"`
+	Notes        int    `json:"notes,omitempty"`
+	HiddenSlides int    `json:"hiddenSlides,omitempty"`
+	// SlideTitles lists the title of each slide, in order
+	SlideTitles []string `json:"slideTitles,omitempty"`
+}
+
+// IsEmpty reports whether no identifying, date or revision values are set.
+// Application statistics are not considered.
+func (m Metadata) IsEmpty() bool {
+	for _, value := range []string{
+		m.Title, m.Subject, m.Creator, m.Keywords, m.Description, m.LastModifiedBy,
+		m.Category, m.Created, m.Modified, m.Company, m.Manager, m.Revision, m.LastPrinted,
+	} {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Text properties whose values are blanked when stripping. The elements
+// themselves are kept so the parts stay schema-valid.
+var (
+	coreTextProperties = []string{
+		"dc:title", "dc:subject", "dc:creator", "cp:keywords",
+		"dc:description", "cp:lastModifiedBy", "cp:category", "cp:contentStatus",
+	}
+	appTextProperties = []string{"Company", "Manager", "HyperlinkBase"}
+
+	// Date and revision properties cannot be blank (an empty W3CDTF date is
+	// invalid), so these optional elements are removed instead
+	coreRemovedProperties = []string{"dcterms:created", "dcterms:modified", "cp:lastPrinted", "cp:revision"}
+
+	// Application statistics, which are only read
+	appStatisticProperties = []string{
+		"Application", "TotalTime", "Pages", "Words", "Slides", "Notes", "HiddenSlides",
+		"HeadingPairs", "TitlesOfParts",
+	}
+)
+
+// Elements of the vectors in HeadingPairs and TitlesOfParts
+var (
+	vectorStringRegex = regexp.MustCompile(`(?s)<vt:lpstr>(.*?)</vt:lpstr>|<vt:lpstr\s*/>`)
+	headingPairRegex  = regexp.MustCompile(`(?s)<vt:lpstr>(.*?)</vt:lpstr>\s*</vt:variant>\s*<vt:variant>\s*<vt:i4>(\d+)</vt:i4>`)
+)
+
+// propertyRegexes caches the element regex for each property name
+var propertyRegexes = make(map[string]*regexp.Regexp)
+
+func init() {
+	for _, names := range [][]string{coreTextProperties, appTextProperties, coreRemovedProperties, appStatisticProperties} {
+		for _, name := range names {
+			propertyRegexes[name] = regexp.MustCompile(`(?s)(<` + regexp.QuoteMeta(name) + `(?:\s[^>]*)?>)(.*?)(</` + regexp.QuoteMeta(name) + `>)`)
+		}
+	}
+}
+
+// propertyValueRaw returns the XML content of a property element
+func propertyValueRaw(xmlContent, name string) string {
+	match := propertyRegexes[name].FindStringSubmatch(xmlContent)
+	if match == nil {
+		return ""
+	}
+	return match[2]
+}
+
+// propertyValue returns the text value of a property element
+func propertyValue(xmlContent, name string) string {
+	return html.UnescapeString(strings.TrimSpace(propertyValueRaw(xmlContent, name)))
+}
+
+// propertyInt returns the integer value of a property element, or 0
+func propertyInt(xmlContent, name string) int {
+	value, err := strconv.Atoi(propertyValue(xmlContent, name))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// slideTitles returns the slide titles listed in app.xml. TitlesOfParts
+// lists the parts of every group named in HeadingPairs, in order; PowerPoint
+// writes the slide titles as the last group, whose name is localized, so it
+// is recognized as "Slide Titles" or as a last group of one part per slide.
+func slideTitles(app string, slides int) []string {
+	pairs := headingPairRegex.FindAllStringSubmatch(propertyValueRaw(app, "HeadingPairs"), -1)
+	var titles []string
+	for _, match := range vectorStringRegex.FindAllStringSubmatch(propertyValueRaw(app, "TitlesOfParts"), -1) {
+		titles = append(titles, html.UnescapeString(match[1]))
+	}
+
+	start := 0
+	for i, pair := range pairs {
+		count, _ := strconv.Atoi(pair[2])
+		isLast := i == len(pairs)-1
+		if html.UnescapeString(pair[1]) == "Slide Titles" || (isLast && slides > 0 && count == slides) {
+			if start+count > len(titles) {
+				return nil
+			}
+			return titles[start : start+count]
+		}
+		start += count
+	}
+	return nil
+}
+
+// parseMetadata reads metadata values from core and app property parts
+func parseMetadata(core, app string) Metadata {
+	slides := propertyInt(app, "Slides")
+	return Metadata{
+		Title:          propertyValue(core, "dc:title"),
+		Subject:        propertyValue(core, "dc:subject"),
+		Creator:        propertyValue(core, "dc:creator"),
+		Keywords:       propertyValue(core, "cp:keywords"),
+		Description:    propertyValue(core, "dc:description"),
+		LastModifiedBy: propertyValue(core, "cp:lastModifiedBy"),
+		Category:       propertyValue(core, "cp:category"),
+		Created:        propertyValue(core, "dcterms:created"),
+		Modified:       propertyValue(core, "dcterms:modified"),
+		Company:        propertyValue(app, "Company"),
+		Manager:        propertyValue(app, "Manager"),
+		Revision:       propertyValue(core, "cp:revision"),
+		LastPrinted:    propertyValue(core, "cp:lastPrinted"),
+		Application:    propertyValue(app, "Application"),
+		TotalTime:      propertyInt(app, "TotalTime"),
+		Pages:          propertyInt(app, "Pages"),
+		Words:          propertyInt(app, "Words"),
+		Slides:         slides,
+		Notes:          propertyInt(app, "Notes"),
+		HiddenSlides:   propertyInt(app, "HiddenSlides"),
+		SlideTitles:    slideTitles(app, slides),
+	}
+}
+
+// stripCoreProperties blanks the text properties of core.xml and drops its dates
+func stripCoreProperties(xmlContent string) string {
+	for _, name := range coreTextProperties {
+		xmlContent = propertyRegexes[name].ReplaceAllString(xmlContent, "${1}${3}")
+	}
+	for _, name := range coreRemovedProperties {
+		xmlContent = propertyRegexes[name].ReplaceAllString(xmlContent, "")
+	}
+	return xmlContent
+}
+
+// stripAppProperties blanks the identifying properties of app.xml
+func stripAppProperties(xmlContent string) string {
+	for _, name := range appTextProperties {
+		xmlContent = propertyRegexes[name].ReplaceAllString(xmlContent, "${1}${3}")
+	}
+	return xmlContent
+}
+
+// readZipPart returns the content of a named entry, or nil if the package has no such entry
+func readZipPart(files []*zip.File, name string) ([]byte, error) {
+	for _, file := range files {
+		if file.Name != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
+// readMetadata reads metadata from a package, preferring pending overrides
+func readMetadata(files []*zip.File, overrides map[string][]byte) (Metadata, error) {
+	parts := make(map[string]string, 2)
+	for _, name := range []string{corePropsPart, appPropsPart} {
+		if data, ok := overrides[name]; ok {
+			parts[name] = string(data)
+			continue
+		}
+		data, err := readZipPart(files, name)
+		if err != nil {
+			return Metadata{}, err
+		}
+		parts[name] = string(data)
+	}
+	return parseMetadata(parts[corePropsPart], parts[appPropsPart]), nil
+}
+
+// strippedMetadataParts returns the stripped content of the property parts present in the package
+func strippedMetadataParts(files []*zip.File) (map[string][]byte, error) {
+	stripped := make(map[string][]byte, 2)
+
+	core, err := readZipPart(files, corePropsPart)
+	if err != nil {
+		return nil, err
+	}
+	if core != nil {
+		stripped[corePropsPart] = []byte(stripCoreProperties(string(core)))
+	}
+
+	app, err := readZipPart(files, appPropsPart)
+	if err != nil {
+		return nil, err
+	}
+	if app != nil {
+		stripped[appPropsPart] = []byte(stripAppProperties(string(app)))
+	}
+
+	return stripped, nil
+}
+
+// StripFileMetadata blanks the metadata of an Office file in place. Entries
+// other than the property parts are copied without recompression, so this is
+// also suitable for files processed in streaming mode.
+func StripFileMetadata(path string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	stripped, err := strippedMetadataParts(reader.File)
+	if err != nil {
+		return err
+	}
+	if len(stripped) == 0 {
+		return nil // No property parts to strip
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "strip_*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer CleanupTempFile(tmpPath)
+
+	w := zip.NewWriter(tmpFile)
+	if err := writePackage(w, reader.File, stripped); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	// Release the source before replacing it
+	reader.Close()
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+// writePackage copies every entry to w, substituting the content of
+// overridden parts and leaving out parts overridden with nil. Unchanged
+// entries keep their compressed bytes, so they round-trip byte-identically.
+func writePackage(w *zip.Writer, files []*zip.File, overrides map[string][]byte) error {
+	for _, file := range files {
+		data, ok := overrides[file.Name]
+		if !ok {
+			if err := w.Copy(file); err != nil {
+				return fmt.Errorf("failed to copy %s: %w", file.Name, err)
+			}
+			continue
+		}
+		if data == nil {
+			continue // Removed part
+		}
+
+		writer, err := createLike(w, file)
+		if err != nil {
+			return fmt.Errorf("failed to create %s in zip: %w", file.Name, err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Name, err)
+		}
+	}
+	return nil
+}
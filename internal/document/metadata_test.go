@@ -0,0 +1,232 @@
+package document
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCoreProps = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<dc:title>Budget &amp; Plan</dc:title>
+<dc:creator>Jane Doe</dc:creator>
+<cp:lastModifiedBy>John Roe</cp:lastModifiedBy>
+<cp:revision>7</cp:revision>
+<dcterms:created xsi:type="dcterms:W3CDTF">2024-01-02T03:04:05Z</dcterms:created>
+<dcterms:modified xsi:type="dcterms:W3CDTF">2024-02-03T04:05:06Z</dcterms:modified>
+</cp:coreProperties>`
+
+const testAppProps = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">
+<Application>Microsoft Office Word</Application>
+<Company>Acme Corp</Company>
+<Manager>Big Boss</Manager>
+</Properties>`
+
+// copyWithMetadata copies an Office file and adds docProps parts to it
+func copyWithMetadata(t *testing.T, src, dst string) {
+	t.Helper()
+
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", src, err)
+	}
+	defer reader.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	w := zip.NewWriter(out)
+	for _, file := range reader.File {
+		if err := w.Copy(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range map[string]string{corePropsPart: testCoreProps, appPropsPart: testAppProps} {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseMetadata(t *testing.T) {
+	meta := parseMetadata(testCoreProps, testAppProps)
+
+	if meta.Title != "Budget & Plan" {
+		t.Errorf("Title = %q, want %q", meta.Title, "Budget & Plan")
+	}
+	if meta.Creator != "Jane Doe" || meta.LastModifiedBy != "John Roe" {
+		t.Errorf("unexpected authors: %+v", meta)
+	}
+	if meta.Created != "2024-01-02T03:04:05Z" {
+		t.Errorf("Created = %q", meta.Created)
+	}
+	if meta.Company != "Acme Corp" || meta.Manager != "Big Boss" {
+		t.Errorf("unexpected app properties: %+v", meta)
+	}
+}
+
+func TestParseMetadataRevisionAndStatistics(t *testing.T) {
+	core := strings.Replace(testCoreProps, "</cp:coreProperties>",
+		"<cp:lastPrinted>2024-01-15T09:00:00Z</cp:lastPrinted></cp:coreProperties>", 1)
+	app := `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">
+<TotalTime>42</TotalTime><Words>120</Words>
+<Application>Microsoft Office PowerPoint</Application>
+<Slides>2</Slides><Notes>1</Notes><HiddenSlides>0</HiddenSlides>
+<HeadingPairs><vt:vector size="4" baseType="variant">
+<vt:variant><vt:lpstr>Fonts Used</vt:lpstr></vt:variant><vt:variant><vt:i4>1</vt:i4></vt:variant>
+<vt:variant><vt:lpstr>Slide Titles</vt:lpstr></vt:variant><vt:variant><vt:i4>2</vt:i4></vt:variant>
+</vt:vector></HeadingPairs>
+<TitlesOfParts><vt:vector size="3" baseType="lpstr">
+<vt:lpstr>Arial</vt:lpstr><vt:lpstr>Q3 &amp; Q4 Review</vt:lpstr><vt:lpstr>Next Steps</vt:lpstr>
+</vt:vector></TitlesOfParts>
+</Properties>`
+
+	meta := parseMetadata(core, app)
+	if meta.Revision != "7" || meta.LastPrinted != "2024-01-15T09:00:00Z" {
+		t.Errorf("revision history = %q, %q", meta.Revision, meta.LastPrinted)
+	}
+	if meta.Application != "Microsoft Office PowerPoint" || meta.TotalTime != 42 || meta.Words != 120 {
+		t.Errorf("unexpected statistics: %+v", meta)
+	}
+	if meta.Slides != 2 || meta.Notes != 1 {
+		t.Errorf("Slides = %d, Notes = %d", meta.Slides, meta.Notes)
+	}
+	if strings.Join(meta.SlideTitles, "|") != "Q3 & Q4 Review|Next Steps" {
+		t.Errorf("SlideTitles = %q", meta.SlideTitles)
+	}
+
+	// Localized group names are recognized by position and count
+	localized := strings.Replace(app, "Slide Titles", "슬라이드 제목", 1)
+	if titles := parseMetadata(core, localized).SlideTitles; len(titles) != 2 {
+		t.Errorf("localized SlideTitles = %q", titles)
+	}
+
+	// Statistics alone do not count as metadata
+	if !parseMetadata("", app).IsEmpty() {
+		t.Error("IsEmpty() should ignore application statistics")
+	}
+}
+
+func TestStripCoreProperties(t *testing.T) {
+	stripped := stripCoreProperties(testCoreProps)
+
+	for _, kept := range []string{"<dc:title></dc:title>", "<dc:creator></dc:creator>", "<cp:lastModifiedBy></cp:lastModifiedBy>", "</cp:coreProperties>"} {
+		if !strings.Contains(stripped, kept) {
+			t.Errorf("stripped core.xml missing %q:\n%s", kept, stripped)
+		}
+	}
+	for _, removed := range []string{"Jane Doe", "dcterms:created", "cp:revision"} {
+		if strings.Contains(stripped, removed) {
+			t.Errorf("stripped core.xml should not contain %q:\n%s", removed, stripped)
+		}
+	}
+}
+
+func TestWordDocument_StripMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.docx")
+	copyWithMetadata(t, "testdata/sample.docx", path)
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatalf("failed to open document: %v", err)
+	}
+
+	meta, err := doc.GetMetadata()
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if meta.Creator != "Jane Doe" {
+		t.Fatalf("expected creator before stripping, got %+v", meta)
+	}
+
+	if err := doc.StripMetadata(); err != nil {
+		t.Fatalf("StripMetadata failed: %v", err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	doc.Close()
+
+	reopened, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatalf("failed to reopen stripped document: %v", err)
+	}
+	defer reopened.Close()
+
+	meta, err = reopened.GetMetadata()
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if !meta.IsEmpty() {
+		t.Errorf("expected empty metadata after stripping, got %+v", meta)
+	}
+	if _, err := reopened.GetText(); err != nil {
+		t.Errorf("stripped document should remain readable: %v", err)
+	}
+}
+
+func TestPowerPointDocument_StripMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.pptx")
+	copyWithMetadata(t, "testdata/table_chart.pptx", path)
+
+	doc, err := OpenPowerPointDocument(path)
+	if err != nil {
+		t.Fatalf("failed to open presentation: %v", err)
+	}
+
+	if err := doc.StripMetadata(); err != nil {
+		t.Fatalf("StripMetadata failed: %v", err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	doc.Close()
+
+	reopened, err := OpenPowerPointDocument(path)
+	if err != nil {
+		t.Fatalf("failed to reopen stripped presentation: %v", err)
+	}
+	defer reopened.Close()
+
+	meta, err := reopened.GetMetadata()
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if !meta.IsEmpty() {
+		t.Errorf("expected empty metadata after stripping, got %+v", meta)
+	}
+}
+
+func TestStripFileMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.docx")
+	copyWithMetadata(t, "testdata/sample.docx", path)
+
+	if err := StripFileMetadata(path); err != nil {
+		t.Fatalf("StripFileMetadata failed: %v", err)
+	}
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatalf("failed to reopen stripped document: %v", err)
+	}
+	defer doc.Close()
+
+	meta, err := doc.GetMetadata()
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if !meta.IsEmpty() {
+		t.Errorf("expected empty metadata after stripping, got %+v", meta)
+	}
+}
@@ -0,0 +1,63 @@
+package document
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Speaker notes live in notes slide parts, linked from each slide's .rels
+// part. The notes text is in the body placeholder; the other placeholders
+// hold the slide image and the slide number.
+var (
+	notesTargetRegex          = regexp.MustCompile(`Target="([^"]*notesSlides/notesSlide\d+\.xml)"`)
+	pptShapeRegex             = regexp.MustCompile(`(?s)<p:sp[ >].*?</p:sp>`)
+	notesBodyPlaceholderRegex = regexp.MustCompile(`<p:ph\s[^>]*type="body"`)
+)
+
+// SetIncludeNotes sets whether GetText and GetPlainText add each slide's
+// speaker notes after its text, in a block labeled as notes. Notes are left
+// out by default.
+func (d *PowerPointDocument) SetIncludeNotes(include bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.includeNotes = include
+}
+
+// SlideNotes returns the speaker notes of a slide, one paragraph per line,
+// or an empty string when the slide has none
+func (d *PowerPointDocument) SlideNotes(number int) (string, error) {
+	rels, err := readZipPart(d.zipFile.File, fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", number))
+	if err != nil || rels == nil {
+		return "", err
+	}
+	match := notesTargetRegex.FindSubmatch(rels)
+	if match == nil {
+		return "", nil
+	}
+
+	// Targets are relative to ppt/slides/
+	notes, err := readZipPart(d.zipFile.File, path.Clean(path.Join("ppt/slides", string(match[1]))))
+	if err != nil || notes == nil {
+		return "", err
+	}
+	return extractNotesText(string(notes)), nil
+}
+
+// extractNotesText returns the non-empty paragraphs of the body placeholder
+// of a notes slide, one per line
+func extractNotesText(xmlContent string) string {
+	var lines []string
+	for _, shape := range pptShapeRegex.FindAllString(xmlContent, -1) {
+		if !notesBodyPlaceholderRegex.MatchString(shape) {
+			continue
+		}
+		for _, text := range paragraphTexts(shape, pptParagraphTags, pptTextReplaceRegex) {
+			if strings.TrimSpace(text) != "" {
+				lines = append(lines, text)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+package document
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// notesSlideXML is a notes slide with the slide image, the notes body and
+// the slide number placeholders
+const notesSlideXML = `<p:notes xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree>` +
+	`<p:sp><p:nvSpPr><p:nvPr><p:ph type="sldImg"/></p:nvPr></p:nvSpPr></p:sp>` +
+	`<p:sp><p:nvSpPr><p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr><p:txBody>` +
+	`<a:p><a:r><a:t>Mention the </a:t></a:r><a:r><a:t>Q3 dip</a:t></a:r></a:p><a:p></a:p><a:p><a:r><a:t>Ask for questions</a:t></a:r></a:p>` +
+	`</p:txBody></p:sp>` +
+	`<p:sp><p:nvSpPr><p:nvPr><p:ph type="sldNum" idx="5"/></p:nvPr></p:nvSpPr><p:txBody><a:p><a:fld type="slidenum"><a:t>1</a:t></a:fld></a:p></p:txBody></p:sp>` +
+	`</p:spTree></p:cSld></p:notes>`
+
+// writeDeckWithNotes writes a two-slide presentation where only slide 1 has notes
+func writeDeckWithNotes(t *testing.T) string {
+	t.Helper()
+	slide := func(text string) string {
+		return `<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">` +
+			`<p:cSld><p:spTree><p:sp><p:txBody><a:p><a:r><a:t>` + text + `</a:t></a:r></a:p></p:txBody></p:sp></p:spTree></p:cSld></p:sld>`
+	}
+	parts := map[string]string{
+		"ppt/slides/slide1.xml": slide("Results"),
+		"ppt/slides/slide2.xml": slide("Next steps"),
+		"ppt/slides/_rels/slide1.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide" Target="../notesSlides/notesSlide1.xml"/></Relationships>`,
+		"ppt/notesSlides/notesSlide1.xml": notesSlideXML,
+	}
+
+	path := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZipParts(t, path, parts)
+	return path
+}
+
+func TestExtractNotesText(t *testing.T) {
+	if got := extractNotesText(notesSlideXML); got != "Mention the Q3 dip\nAsk for questions" {
+		t.Errorf("extractNotesText() = %q", got)
+	}
+}
+
+func TestPowerPointSlideNotes(t *testing.T) {
+	doc, err := OpenPowerPointDocument(writeDeckWithNotes(t))
+	if err != nil {
+		t.Fatalf("OpenPowerPointDocument failed: %v", err)
+	}
+	defer doc.Close()
+
+	if notes, err := doc.SlideNotes(1); err != nil || notes != "Mention the Q3 dip\nAsk for questions" {
+		t.Errorf("SlideNotes(1) = %q, %v", notes, err)
+	}
+	if notes, err := doc.SlideNotes(2); err != nil || notes != "" {
+		t.Errorf("SlideNotes(2) = %q, %v; want no notes", notes, err)
+	}
+
+	// Notes are left out by default
+	if text, _ := doc.GetText(); text != "Slide 1:\nResults\n\nSlide 2:\nNext steps\n\n" {
+		t.Errorf("GetText() without notes = %q", text)
+	}
+
+	doc.SetIncludeNotes(true)
+	text, err := doc.GetText()
+	if err != nil {
+		t.Fatalf("GetText() error = %v", err)
+	}
+	want := "Slide 1:\nResults\n\nSlide 1 speaker notes:\nMention the Q3 dip\nAsk for questions\n\nSlide 2:\nNext steps\n\n"
+	if text != want {
+		t.Errorf("GetText() with notes = %q, want %q", text, want)
+	}
+
+	plain, err := doc.GetPlainText()
+	if err != nil {
+		t.Fatalf("GetPlainText() error = %v", err)
+	}
+	want = "Results\n\nSpeaker notes:\nMention the Q3 dip\nAsk for questions\n\nNext steps"
+	if plain != want {
+		t.Errorf("GetPlainText() with notes = %q, want %q", plain, want)
+	}
+}
@@ -0,0 +1,217 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// wordTextNodePattern matches a Word text node; the second group is its content
+var wordTextNodePattern = regexp.MustCompile(`(<w:t[^>]*>)([^<]*)(</w:t>)`)
+
+// textSpan is a byte range [start, end) of text content in XML
+type textSpan struct {
+	start, end int
+}
+
+// textMatch is one occurrence of the search text. Word and PowerPoint split
+// text into runs, so an occurrence may cover several text nodes; spans lists
+// the covered part of each node in document order.
+type textMatch struct {
+	spans []textSpan
+}
+
+// findTextOccurrences finds every occurrence of old, already XML-escaped, in
+// the text nodes matched by nodePattern, whose second group is the node's
+// content. Consecutive nodes of one paragraph are searched as a single string
+// so an occurrence split across runs is found; occurrences never cross a
+// paragraphEnd tag. Nodes for which skip returns true are ignored.
+func findTextOccurrences(xmlContent string, nodePattern *regexp.Regexp, paragraphEnd string, skip func(pos int) bool, old string) []textMatch {
+	var matches []textMatch
+	var text strings.Builder
+	var nodes []textSpan // content span of each node in the current paragraph
+	lastEnd := 0
+
+	flush := func() {
+		joined := text.String()
+		for offset := 0; ; {
+			i := strings.Index(joined[offset:], old)
+			if i < 0 {
+				break
+			}
+			matchStart := offset + i
+			matchEnd := matchStart + len(old)
+			matches = append(matches, textMatch{spans: coveredSpans(nodes, matchStart, matchEnd)})
+			offset = matchEnd
+		}
+		text.Reset()
+		nodes = nodes[:0]
+	}
+
+	for _, loc := range nodePattern.FindAllStringSubmatchIndex(xmlContent, -1) {
+		if skip != nil && skip(loc[0]) {
+			continue
+		}
+		if strings.Contains(xmlContent[lastEnd:loc[0]], paragraphEnd) {
+			flush()
+		}
+		text.WriteString(xmlContent[loc[4]:loc[5]])
+		nodes = append(nodes, textSpan{loc[4], loc[5]})
+		lastEnd = loc[1]
+	}
+	flush()
+
+	return matches
+}
+
+// coveredSpans maps the range [start, end) of a paragraph's joined text back
+// to the parts of its nodes' content spans in the XML
+func coveredSpans(nodes []textSpan, start, end int) []textSpan {
+	var spans []textSpan
+	offset := 0
+	for _, node := range nodes {
+		nodeLen := node.end - node.start
+		from, to := max(start, offset), min(end, offset+nodeLen)
+		if from < to {
+			spans = append(spans, textSpan{node.start + from - offset, node.start + to - offset})
+		}
+		offset += nodeLen
+	}
+	return spans
+}
+
+// applyTextMatch replaces one occurrence with newEscaped. The replacement
+// goes into the first node of the occurrence, keeping that run's formatting,
+// and the rest of the occurrence is removed from the following nodes.
+func applyTextMatch(xmlContent string, match textMatch, newEscaped string) string {
+	for i := len(match.spans) - 1; i >= 0; i-- {
+		span := match.spans[i]
+		replacement := ""
+		if i == 0 {
+			replacement = newEscaped
+		}
+		xmlContent = xmlContent[:span.start] + replacement + xmlContent[span.end:]
+	}
+	return xmlContent
+}
+
+// occurrenceIndex converts an occurrence number to a 0-based index among
+// total occurrences: 1 is the first, -1 the last, -2 the one before it.
+// It returns false when there is no such occurrence.
+func occurrenceIndex(occurrence, total int) (int, bool) {
+	index := occurrence - 1
+	if occurrence < 0 {
+		index = total + occurrence
+	}
+	return index, index >= 0 && index < total
+}
+
+// ReplaceTextOccurrence replaces only the given occurrence of old text in the
+// document body: 1 is the first, -1 the last, and 0 replaces every occurrence
+// like ReplaceText. Occurrences are counted across the body's text in reading
+// order, including occurrences split across formatting runs. It reports
+// whether an occurrence was replaced.
+func (w *WordDocument) ReplaceTextOccurrence(old, new string, occurrence int) (bool, error) {
+	if w.closed {
+		return false, errors.New("document is closed")
+	}
+	if old == "" {
+		return false, errors.New("old text cannot be empty")
+	}
+	if occurrence == 0 {
+		count, err := w.ReplaceTextCount(old, new)
+		return count > 0, err
+	}
+
+	xmlStr := string(w.content.rawXML)
+	var skip func(int) bool
+	if !w.includeHidden {
+		skip = inSpans(hiddenRunSpans(w.content.rawXML))
+	}
+
+	matches := findTextOccurrences(xmlStr, wordTextNodePattern, "</w:p>", skip, escapeXMLString(old))
+	index, ok := occurrenceIndex(occurrence, len(matches))
+	if !ok {
+		return false, nil
+	}
+
+	w.content.rawXML = []byte(applyTextMatch(xmlStr, matches[index], escapeXMLString(new)))
+	w.modified = true
+	return true, nil
+}
+
+// inSpans returns a function reporting whether a position lies in one of the spans
+func inSpans(spans [][2]int) func(int) bool {
+	if len(spans) == 0 {
+		return nil
+	}
+	return func(pos int) bool {
+		for _, span := range spans {
+			if pos >= span[0] && pos < span[1] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ReplaceTextOccurrenceInSlides replaces only the given occurrence of old
+// text on the selected slides (nil selects all): 1 is the first, -1 the
+// last, and 0 replaces every occurrence like ReplaceTextInSlides.
+// Occurrences are counted across slide text in slide order, including
+// occurrences split across runs; chart text is not counted or replaced. It
+// reports whether an occurrence was replaced.
+func (d *PowerPointDocument) ReplaceTextOccurrenceInSlides(old, new string, occurrence int, slides map[int]bool) (bool, error) {
+	if old == "" {
+		return false, fmt.Errorf("search text cannot be empty")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if occurrence == 0 {
+		count, err := d.replaceTextInSlides(old, new, slides)
+		return count > 0, err
+	}
+
+	selected, err := selectedSlideParts(d.zipFile.File, slides)
+	if err != nil {
+		return false, err
+	}
+
+	// Count occurrences slide by slide in slide order
+	type slideMatch struct {
+		slide *slideContent
+		match textMatch
+	}
+	var paths []string
+	for path := range d.slides {
+		if selected == nil || selected[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		a, _ := partNumber(paths[i], "ppt/slides/slide")
+		b, _ := partNumber(paths[j], "ppt/slides/slide")
+		return a < b
+	})
+
+	oldEscaped := escapeXMLStringPPT(old)
+	var matches []slideMatch
+	for _, path := range paths {
+		slide := d.slides[path]
+		for _, match := range findTextOccurrences(slide.xmlDoc, pptTextReplaceRegex, "</a:p>", nil, oldEscaped) {
+			matches = append(matches, slideMatch{slide: slide, match: match})
+		}
+	}
+
+	index, ok := occurrenceIndex(occurrence, len(matches))
+	if !ok {
+		return false, nil
+	}
+
+	target := matches[index]
+	target.slide.xmlDoc = applyTextMatch(target.slide.xmlDoc, target.match, escapeXMLStringPPT(new))
+	d.modified = true
+	return true, nil
+}
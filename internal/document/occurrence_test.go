@@ -0,0 +1,138 @@
+package document
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// occurrenceXML has "Draft" three times; the second is split across two runs
+const occurrenceXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+	`<w:p><w:r><w:t>Draft one</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:t>Dr</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>aft two</w:t></w:r></w:p>` +
+	`<w:p><w:r><w:rPr><w:vanish/></w:rPr><w:t>Draft hidden</w:t></w:r><w:r><w:t>Draft three</w:t></w:r></w:p>` +
+	`</w:body></w:document>`
+
+func TestWordDocument_ReplaceTextOccurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		occurrence int
+		want       []string
+	}{
+		{"first", 1, []string{"Final one", "Draft two", "Draft three"}},
+		{"split across runs", 2, []string{"Draft one", "Final two", "Draft three"}},
+		{"last", -1, []string{"Draft one", "Draft two", "Final three"}},
+		{"all", 0, []string{"Final one", "Draft two", "Final three"}}, // ReplaceText does not join runs
+		{"missing", 4, []string{"Draft one", "Draft two", "Draft three"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "occurrence.docx")
+			writeDocxWithDocumentXML(t, "testdata/sample.docx", path, occurrenceXML)
+
+			doc, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			replaced, err := doc.ReplaceTextOccurrence("Draft", "Final", tt.occurrence)
+			if err != nil {
+				t.Fatalf("ReplaceTextOccurrence() error = %v", err)
+			}
+			if replaced != (tt.name != "missing") {
+				t.Errorf("ReplaceTextOccurrence() replaced = %v", replaced)
+			}
+			if err := doc.Save(); err != nil {
+				t.Fatal(err)
+			}
+			doc.Close()
+
+			text := documentText(t, path)
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("text %q does not contain %q", text, want)
+				}
+			}
+			// Hidden text is neither counted nor replaced
+			if xml := readZipEntry(t, path, "word/document.xml"); !strings.Contains(xml, "Draft hidden") {
+				t.Error("hidden run was changed")
+			}
+		})
+	}
+}
+
+func TestWordDocument_ReplaceTextOccurrenceKeepsRunFormatting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "occurrence.docx")
+	writeDocxWithDocumentXML(t, "testdata/sample.docx", path, occurrenceXML)
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+	if _, err := doc.ReplaceTextOccurrence("Draft", "Final", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// The replacement goes into the first run; the rest is removed from the second
+	want := `<w:p><w:r><w:t>Final</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t> two</w:t></w:r></w:p>`
+	if xml := string(doc.content.rawXML); !strings.Contains(xml, want) {
+		t.Errorf("document.xml does not contain %s:\n%s", want, xml)
+	}
+}
+
+func TestPowerPointDocument_ReplaceTextOccurrence(t *testing.T) {
+	tests := []struct {
+		occurrence int
+		slides     map[int]bool
+		want       []string
+	}{
+		{1, nil, []string{"Year: 2024", "Copyright 2023"}},
+		{-1, nil, []string{"Year: 2023", "Copyright 2024"}},
+		{1, map[int]bool{1: true}, []string{"Year: 2023", "Copyright 2023"}}, // not on slide 1
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "test.pptx")
+		if err := createTestPowerPoint(path); err != nil {
+			t.Fatal(err)
+		}
+		doc, err := OpenPowerPointDocument(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := doc.ReplaceTextOccurrenceInSlides("2023", "2024", tt.occurrence, tt.slides); err != nil {
+			t.Fatalf("ReplaceTextOccurrenceInSlides() error = %v", err)
+		}
+		text, _ := doc.GetText()
+		doc.Close()
+
+		for _, want := range tt.want {
+			if !strings.Contains(text, want) {
+				t.Errorf("occurrence %d, slides %v: text %q does not contain %q", tt.occurrence, tt.slides, text, want)
+			}
+		}
+	}
+}
+
+func TestOccurrenceIndex(t *testing.T) {
+	tests := []struct {
+		occurrence, total, want int
+		ok                      bool
+	}{
+		{1, 3, 0, true},
+		{3, 3, 2, true},
+		{4, 3, 3, false},
+		{-1, 3, 2, true},
+		{-3, 3, 0, true},
+		{-4, 3, -1, false},
+		{1, 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := occurrenceIndex(tt.occurrence, tt.total)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("occurrenceIndex(%d, %d) = %d, %v, want %d, %v", tt.occurrence, tt.total, got, ok, tt.want, tt.ok)
+		}
+	}
+}
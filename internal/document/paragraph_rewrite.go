@@ -0,0 +1,218 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// RenderedParagraph is one output paragraph for RewriteParagraphs: its text
+// and the index of the source paragraph it is built from. A source paragraph
+// may be rendered several times, or not at all.
+type RenderedParagraph struct {
+	Source int
+	Text   string
+}
+
+// paragraphSpan is an outermost paragraph together with the paragraphs
+// nested in it, such as those of a Word text box it anchors. The nested
+// paragraphs are part of its XML but not of its text.
+type paragraphSpan struct {
+	elementSpan
+	nested []elementSpan
+}
+
+// paragraphSpans returns the outermost paragraphs of xmlContent in order,
+// matching their tags with paragraphTags (see elementTags)
+func paragraphSpans(xmlContent string, paragraphTags *regexp.Regexp) []paragraphSpan {
+	var paras []paragraphSpan
+	for _, span := range findElements(xmlContent, paragraphTags) {
+		if n := len(paras); n > 0 && span.start < paras[n-1].end {
+			paras[n-1].nested = append(paras[n-1].nested, span)
+			continue
+		}
+		paras = append(paras, paragraphSpan{elementSpan: span})
+	}
+	return paras
+}
+
+// textNodes returns the submatch indexes in xmlContent of the paragraph's
+// own text nodes, leaving out those of nested paragraphs
+func (p paragraphSpan) textNodes(xmlContent string, textRegex *regexp.Regexp) [][]int {
+	var nodes [][]int
+	for _, loc := range textRegex.FindAllStringSubmatchIndex(xmlContent[p.start:p.end], -1) {
+		for i := range loc {
+			loc[i] += p.start
+		}
+		if !p.inNested(loc[0]) {
+			nodes = append(nodes, loc)
+		}
+	}
+	return nodes
+}
+
+// inNested reports whether a position lies in a nested paragraph
+func (p paragraphSpan) inNested(pos int) bool {
+	for _, span := range p.nested {
+		if pos >= span.start && pos < span.end {
+			return true
+		}
+	}
+	return false
+}
+
+// paragraphTexts returns the unescaped text of every outermost paragraph in
+// xmlContent, without the text of paragraphs nested in it
+func paragraphTexts(xmlContent string, paragraphTags, textRegex *regexp.Regexp) []string {
+	paragraphs := paragraphSpans(xmlContent, paragraphTags)
+	texts := make([]string, len(paragraphs))
+	for i, para := range paragraphs {
+		var text strings.Builder
+		for _, loc := range para.textNodes(xmlContent, textRegex) {
+			text.WriteString(html.UnescapeString(xmlContent[loc[4]:loc[5]]))
+		}
+		texts[i] = text.String()
+	}
+	return texts
+}
+
+// rewriteParagraphs rebuilds xmlContent from rendered paragraphs, in order.
+// A paragraph whose text is unchanged keeps its XML exactly; a changed one
+// keeps its paragraph properties and the formatting of its first run, which
+// receives all of the text. The XML between paragraphs, such as table
+// markup, is kept once in its original position, and paragraphs nested in a
+// paragraph, such as those of a text box, are kept as they are.
+func rewriteParagraphs(xmlContent string, paragraphTags, textRegex *regexp.Regexp, rendered []RenderedParagraph) (string, error) {
+	locs := paragraphSpans(xmlContent, paragraphTags)
+	texts := paragraphTexts(xmlContent, paragraphTags, textRegex)
+
+	var out strings.Builder
+	next := 0 // index of the next source paragraph whose preceding XML is not yet written
+	writeGapsTo := func(index int) {
+		for ; next <= index && next < len(locs); next++ {
+			start := 0
+			if next > 0 {
+				start = locs[next-1].end
+			}
+			out.WriteString(xmlContent[start:locs[next].start])
+		}
+	}
+
+	for _, para := range rendered {
+		if para.Source < 0 || para.Source >= len(locs) {
+			return "", fmt.Errorf("paragraph %d does not exist", para.Source)
+		}
+		writeGapsTo(para.Source)
+
+		loc := locs[para.Source]
+		if para.Text == texts[para.Source] {
+			out.WriteString(xmlContent[loc.start:loc.end])
+		} else {
+			out.WriteString(setParagraphText(xmlContent, loc, textRegex, para.Text))
+		}
+	}
+
+	writeGapsTo(len(locs) - 1)
+	if len(locs) > 0 {
+		out.WriteString(xmlContent[locs[len(locs)-1].end:])
+	} else {
+		out.WriteString(xmlContent)
+	}
+	return out.String(), nil
+}
+
+// setParagraphText returns the XML of a paragraph with text put into its
+// first text node and its other text nodes emptied; the text of nested
+// paragraphs is left alone. Paragraphs without text nodes are returned unchanged.
+func setParagraphText(xmlContent string, para paragraphSpan, textRegex *regexp.Regexp, text string) string {
+	var out strings.Builder
+	last := para.start
+	for i, loc := range para.textNodes(xmlContent, textRegex) {
+		openTag, closeTag := xmlContent[loc[2]:loc[3]], xmlContent[loc[6]:loc[7]]
+		content := ""
+		if i == 0 {
+			// Word drops leading and trailing spaces unless they are preserved
+			if strings.HasPrefix(openTag, "<w:t") && !strings.Contains(openTag, "xml:space") {
+				openTag = `<w:t xml:space="preserve">`
+			}
+			content = escapeXMLString(text)
+		}
+		out.WriteString(xmlContent[last:loc[0]])
+		out.WriteString(openTag + content + closeTag)
+		last = loc[1]
+	}
+	out.WriteString(xmlContent[last:para.end])
+	return out.String()
+}
+
+// ParagraphTexts returns the text of each paragraph in the document body, in
+// order, including paragraphs inside tables. Indexes into it are the source
+// indexes of RewriteParagraphs.
+func (w *WordDocument) ParagraphTexts() ([]string, error) {
+	if w.closed {
+		return nil, errors.New("document is closed")
+	}
+	return paragraphTexts(string(w.content.rawXML), wordParagraphTags, wordTextRegex), nil
+}
+
+// RewriteParagraphs replaces the body paragraphs with rendered paragraphs,
+// each built from a source paragraph of ParagraphTexts. Changed paragraphs
+// keep the formatting of their first run only.
+func (w *WordDocument) RewriteParagraphs(rendered []RenderedParagraph) error {
+	if w.closed {
+		return errors.New("document is closed")
+	}
+	xmlStr, err := rewriteParagraphs(string(w.content.rawXML), wordParagraphTags, wordTextRegex, rendered)
+	if err != nil {
+		return err
+	}
+	if xmlStr != string(w.content.rawXML) {
+		w.content.rawXML = []byte(xmlStr)
+		w.modified = true
+	}
+	return nil
+}
+
+// slideByNumber returns the content of the given slide. d.mu must be held.
+func (d *PowerPointDocument) slideByNumber(number int) (*slideContent, error) {
+	slide, ok := d.slides[fmt.Sprintf("ppt/slides/slide%d.xml", number)]
+	if !ok {
+		return nil, fmt.Errorf("slide %d does not exist", number)
+	}
+	return slide, nil
+}
+
+// SlideParagraphTexts returns the text of each paragraph on a slide, in
+// order. Indexes into it are the source indexes of RewriteSlideParagraphs.
+func (d *PowerPointDocument) SlideParagraphTexts(number int) ([]string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	slide, err := d.slideByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return paragraphTexts(slide.xmlDoc, pptParagraphTags, pptTextReplaceRegex), nil
+}
+
+// RewriteSlideParagraphs replaces the paragraphs of a slide with rendered
+// paragraphs, each built from a source paragraph of SlideParagraphTexts.
+// Changed paragraphs keep the formatting of their first run only.
+func (d *PowerPointDocument) RewriteSlideParagraphs(number int, rendered []RenderedParagraph) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	slide, err := d.slideByNumber(number)
+	if err != nil {
+		return err
+	}
+	xmlStr, err := rewriteParagraphs(slide.xmlDoc, pptParagraphTags, pptTextReplaceRegex, rendered)
+	if err != nil {
+		return err
+	}
+	if xmlStr != slide.xmlDoc {
+		slide.xmlDoc = xmlStr
+		d.modified = true
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package document
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewriteParagraphs(t *testing.T) {
+	xml := `<w:body>` +
+		`<w:p><w:r><w:t>Title</w:t></w:r></w:p>` +
+		`<w:tbl><w:tc>` +
+		`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>Item</w:t></w:r><w:r><w:t> one</w:t></w:r></w:p>` +
+		`</w:tc></w:tbl>` +
+		`<w:p><w:r><w:t>End</w:t></w:r></w:p>` +
+		`</w:body>`
+
+	texts := paragraphTexts(xml, wordParagraphTags, wordTextRegex)
+	if strings.Join(texts, "|") != "Title|Item one|End" {
+		t.Fatalf("paragraphTexts() = %q", texts)
+	}
+
+	t.Run("repeats and drops paragraphs", func(t *testing.T) {
+		result, err := rewriteParagraphs(xml, wordParagraphTags, wordTextRegex, []RenderedParagraph{
+			{Source: 1, Text: " a & b"},
+			{Source: 1, Text: "Item one"},
+			{Source: 2, Text: "End"},
+		})
+		if err != nil {
+			t.Fatalf("rewriteParagraphs() error = %v", err)
+		}
+
+		want := `<w:body>` +
+			`<w:tbl><w:tc>` +
+			`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve"> a &amp; b</w:t></w:r><w:r><w:t></w:t></w:r></w:p>` +
+			`<w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>Item</w:t></w:r><w:r><w:t> one</w:t></w:r></w:p>` +
+			`</w:tc></w:tbl>` +
+			`<w:p><w:r><w:t>End</w:t></w:r></w:p>` +
+			`</w:body>`
+		if result != want {
+			t.Errorf("rewriteParagraphs() =\n%s\nwant\n%s", result, want)
+		}
+	})
+
+	t.Run("unchanged paragraphs keep their XML", func(t *testing.T) {
+		var rendered []RenderedParagraph
+		for i, text := range texts {
+			rendered = append(rendered, RenderedParagraph{Source: i, Text: text})
+		}
+		result, err := rewriteParagraphs(xml, wordParagraphTags, wordTextRegex, rendered)
+		if err != nil {
+			t.Fatalf("rewriteParagraphs() error = %v", err)
+		}
+		if result != xml {
+			t.Errorf("rewriteParagraphs() changed the XML:\n%s", result)
+		}
+	})
+
+	t.Run("unknown source paragraph", func(t *testing.T) {
+		if _, err := rewriteParagraphs(xml, wordParagraphTags, wordTextRegex, []RenderedParagraph{{Source: 3}}); err == nil {
+			t.Error("expected an error for a paragraph that does not exist")
+		}
+	})
+}
+
+func TestRewriteParagraphsWithTextBox(t *testing.T) {
+	box := `<w:r><w:drawing><w:txbxContent><w:p><w:r><w:t>In the box</w:t></w:r></w:p></w:txbxContent></w:drawing></w:r>`
+	xml := `<w:body>` +
+		`<w:p><w:r><w:t>Before </w:t></w:r>` + box + `<w:r><w:t>after</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>End</w:t></w:r></w:p>` +
+		`</w:body>`
+
+	// The text box paragraph belongs to the paragraph anchoring the box
+	texts := paragraphTexts(xml, wordParagraphTags, wordTextRegex)
+	if strings.Join(texts, "|") != "Before after|End" {
+		t.Fatalf("paragraphTexts() = %q", texts)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		result, err := rewriteParagraphs(xml, wordParagraphTags, wordTextRegex, []RenderedParagraph{
+			{Source: 0, Text: texts[0]},
+			{Source: 1, Text: texts[1]},
+		})
+		if err != nil {
+			t.Fatalf("rewriteParagraphs() error = %v", err)
+		}
+		if result != xml {
+			t.Errorf("rewriteParagraphs() changed the XML:\n%s", result)
+		}
+	})
+
+	t.Run("changed text keeps the text box", func(t *testing.T) {
+		result, err := rewriteParagraphs(xml, wordParagraphTags, wordTextRegex, []RenderedParagraph{
+			{Source: 0, Text: "Changed"},
+			{Source: 1, Text: "End"},
+		})
+		if err != nil {
+			t.Fatalf("rewriteParagraphs() error = %v", err)
+		}
+		want := `<w:body>` +
+			`<w:p><w:r><w:t xml:space="preserve">Changed</w:t></w:r>` + box + `<w:r><w:t></w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>End</w:t></w:r></w:p>` +
+			`</w:body>`
+		if result != want {
+			t.Errorf("rewriteParagraphs() =\n%s\nwant\n%s", result, want)
+		}
+	})
+}
+
+func TestRewriteSlideParagraphs(t *testing.T) {
+	doc, err := OpenPowerPointDocument("testdata/table_chart.pptx")
+	if err != nil {
+		t.Fatalf("failed to open presentation: %v", err)
+	}
+	defer doc.Close()
+
+	texts, err := doc.SlideParagraphTexts(1)
+	if err != nil {
+		t.Fatalf("SlideParagraphTexts() error = %v", err)
+	}
+	index := -1
+	for i, text := range texts {
+		if text == "Quarterly Report 2023" {
+			index = i
+		}
+	}
+	if index < 0 {
+		t.Fatalf("title paragraph not found in %q", texts)
+	}
+
+	var rendered []RenderedParagraph
+	for i, text := range texts {
+		if i == index {
+			text = "Quarterly Report 2024"
+		}
+		rendered = append(rendered, RenderedParagraph{Source: i, Text: text})
+	}
+	if err := doc.RewriteSlideParagraphs(1, rendered); err != nil {
+		t.Fatalf("RewriteSlideParagraphs() error = %v", err)
+	}
+
+	text, _ := doc.GetText()
+	if !strings.Contains(text, "Quarterly Report 2024") || strings.Contains(text, "Quarterly Report 2023") {
+		t.Errorf("unexpected text after rewrite:\n%s", text)
+	}
+
+	if _, err := doc.SlideParagraphTexts(99); err == nil {
+		t.Error("expected an error for a slide that does not exist")
+	}
+}
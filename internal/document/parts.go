@@ -0,0 +1,119 @@
+package document
+
+import (
+	"archive/zip"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Kinds of package parts
+const (
+	// PartKindXML is document content, relationships and content types
+	PartKindXML = "xml"
+	// PartKindMedia is an image, audio or video file under a media folder
+	PartKindMedia = "media"
+	// PartKindEmbedded is an embedded object, such as a spreadsheet or OLE object
+	PartKindEmbedded = "embedded"
+	// PartKindFont is an embedded font
+	PartKindFont = "font"
+	// PartKindOther is any other binary part, such as a thumbnail or macros
+	PartKindOther = "other"
+)
+
+// PackagePart describes one entry of a Word or PowerPoint package
+type PackagePart struct {
+	Name           string `json:"name"`
+	Kind           string `json:"kind"`
+	Size           int64  `json:"size"`
+	CompressedSize int64  `json:"compressedSize"`
+}
+
+// partKind classifies a package entry by its name
+func partKind(name string) string {
+	lower := strings.ToLower(name)
+	folders := strings.Split(path.Dir(lower), "/")
+	inFolder := func(folder string) bool {
+		for _, f := range folders {
+			if f == folder {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case isXMLPart(lower):
+		return PartKindXML
+	case inFolder("media"):
+		return PartKindMedia
+	case inFolder("embeddings"):
+		return PartKindEmbedded
+	case inFolder("fonts"):
+		return PartKindFont
+	default:
+		return PartKindOther
+	}
+}
+
+// packageParts lists the entries of a package sorted by name, leaving out
+// directory entries
+func packageParts(files []*zip.File) []PackagePart {
+	parts := make([]PackagePart, 0, len(files))
+	for _, file := range files {
+		if strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		parts = append(parts, PackagePart{
+			Name:           file.Name,
+			Kind:           partKind(file.Name),
+			Size:           int64(file.UncompressedSize64),
+			CompressedSize: int64(file.CompressedSize64),
+		})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Name < parts[j].Name })
+	return parts
+}
+
+// embeddedObjects returns the parts that are neither XML nor media: embedded
+// objects, fonts and other binary parts
+func embeddedObjects(files []*zip.File) []PackagePart {
+	var objects []PackagePart
+	for _, part := range packageParts(files) {
+		if part.Kind != PartKindXML && part.Kind != PartKindMedia {
+			objects = append(objects, part)
+		}
+	}
+	return objects
+}
+
+// ListParts returns every part of the document package with its size, as
+// stored in the file
+func (w *WordDocument) ListParts() ([]PackagePart, error) {
+	if w.closed {
+		return nil, errors.New("document is closed")
+	}
+	return packageParts(w.zipFile.File), nil
+}
+
+// ListEmbeddedObjects returns the embedded objects, fonts and other binary
+// parts bundled in the document, such as spreadsheets under word/embeddings
+func (w *WordDocument) ListEmbeddedObjects() ([]PackagePart, error) {
+	if w.closed {
+		return nil, errors.New("document is closed")
+	}
+	return embeddedObjects(w.zipFile.File), nil
+}
+
+// ListParts returns every part of the presentation package with its size, as
+// stored in the file
+func (d *PowerPointDocument) ListParts() ([]PackagePart, error) {
+	return packageParts(d.zipFile.File), nil
+}
+
+// ListEmbeddedObjects returns the embedded objects, fonts and other binary
+// parts bundled in the presentation, such as spreadsheets under ppt/embeddings
+func (d *PowerPointDocument) ListEmbeddedObjects() ([]PackagePart, error) {
+	return embeddedObjects(d.zipFile.File), nil
+}
@@ -0,0 +1,87 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListEmbeddedObjects(t *testing.T) {
+	dir := t.TempDir()
+	body := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>Text</w:t></w:r></w:p></w:body></w:document>`
+
+	docxPath := filepath.Join(dir, "report.docx")
+	writeZipParts(t, docxPath, map[string]string{
+		"word/document.xml":                              body,
+		"word/_rels/document.xml.rels":                   `<Relationships/>`,
+		"word/media/image1.png":                          "png data",
+		"word/embeddings/Microsoft_Excel_Worksheet.xlsx": "xlsx data",
+		"word/fonts/font1.odttf":                         "font",
+		"docProps/thumbnail.jpeg":                        "jpeg",
+	})
+
+	doc, err := OpenWordDocument(docxPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	parts, err := doc.ListParts()
+	if err != nil {
+		t.Fatalf("ListParts() error = %v", err)
+	}
+	kinds := make(map[string]string)
+	for _, part := range parts {
+		kinds[part.Name] = part.Kind
+	}
+	wantKinds := map[string]string{
+		"docProps/thumbnail.jpeg":                        PartKindOther,
+		"word/_rels/document.xml.rels":                   PartKindXML,
+		"word/document.xml":                              PartKindXML,
+		"word/embeddings/Microsoft_Excel_Worksheet.xlsx": PartKindEmbedded,
+		"word/fonts/font1.odttf":                         PartKindFont,
+		"word/media/image1.png":                          PartKindMedia,
+	}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Errorf("ListParts() kinds = %v, want %v", kinds, wantKinds)
+	}
+
+	objects, err := doc.ListEmbeddedObjects()
+	if err != nil {
+		t.Fatalf("ListEmbeddedObjects() error = %v", err)
+	}
+	want := []PackagePart{
+		{Name: "docProps/thumbnail.jpeg", Kind: PartKindOther, Size: 4},
+		{Name: "word/embeddings/Microsoft_Excel_Worksheet.xlsx", Kind: PartKindEmbedded, Size: 9},
+		{Name: "word/fonts/font1.odttf", Kind: PartKindFont, Size: 4},
+	}
+	for i := range objects {
+		objects[i].CompressedSize = 0
+	}
+	if !reflect.DeepEqual(objects, want) {
+		t.Errorf("ListEmbeddedObjects() =\n%+v\nwant\n%+v", objects, want)
+	}
+}
+
+func TestListEmbeddedObjectsPowerPoint(t *testing.T) {
+	pptxPath := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZipParts(t, pptxPath, map[string]string{
+		"ppt/slides/slide1.xml":         `<p:sld xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"/>`,
+		"ppt/media/image1.png":          "png data",
+		"ppt/embeddings/oleObject1.bin": "ole data",
+	})
+
+	doc, err := OpenPowerPointDocument(pptxPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	objects, err := doc.ListEmbeddedObjects()
+	if err != nil {
+		t.Fatalf("ListEmbeddedObjects() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].Name != "ppt/embeddings/oleObject1.bin" || objects[0].Kind != PartKindEmbedded || objects[0].Size != 8 {
+		t.Errorf("ListEmbeddedObjects() = %+v", objects)
+	}
+}
@@ -13,20 +13,67 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-// PowerPointDocument represents a PowerPoint presentation
+// PowerPointDocument represents a PowerPoint presentation.
+//
+// A PowerPointDocument is safe for concurrent use. Methods that change the
+// slides, such as ReplaceText, run one at a time, and Save writes either all
+// or none of the changes of a concurrent call. Reading methods such as
+// GetText may run together. Close should be called once no other call is in
+// progress.
 type PowerPointDocument struct {
+	// mu guards the slides, charts, metadata parts, modified flag and
+	// settings; the zip reader is only read after opening
+	mu       sync.RWMutex
 	path     string
 	zipFile  *zip.ReadCloser
 	slides   map[string]*slideContent
-	modified bool
+	charts   map[string]*slideContent
+	// metadataParts holds stripped docProps parts pending save
+	metadataParts map[string][]byte
+	modified      bool
+	// textOrder controls the order of text within a slide in GetText
+	textOrder TextOrder
+	// sanitizeUTF8 replaces invalid UTF-8 in GetText instead of failing
+	sanitizeUTF8 bool
+	// includeNotes adds each slide's speaker notes to GetText
+	includeNotes bool
 }
 
-// slideContent holds the content of a single slide
+// Text patterns shared by slide and chart parts. The tag name is anchored so
+// that sibling elements such as <a:tbl>, <a:tc> or <a:tab/> are never matched.
+var (
+	pptTextRegex        = regexp.MustCompile(`<a:t(?:\s[^>]*)?>([^<]+)</a:t>`)
+	pptTextReplaceRegex = regexp.MustCompile(`(<a:t(?:\s[^>]*)?>)([^<]*)(</a:t>)`)
+	chartStrCacheRegex  = regexp.MustCompile(`(?s)<c:strCache>.*?</c:strCache>`)
+	chartValueRegex     = regexp.MustCompile(`(<c:v>)([^<]*)(</c:v>)`)
+)
+
+// slideContent holds the content of a single slide or chart part
 type slideContent struct {
-	path    string
-	xmlDoc  string
+	path     string
+	xmlDoc   string
+	original string // content as loaded, used to detect real changes
+}
+
+// changed reports whether the part differs from the content loaded from the file
+func (s *slideContent) changed() bool {
+	return s.xmlDoc != s.original
+}
+
+func init() {
+	// Templates open like presentations and keep their template content type
+	for _, ext := range []string{".pptx", ".potx"} {
+		RegisterFormat(ext, func(path string) (Document, error) {
+			doc, err := OpenPowerPointDocument(path)
+			if err != nil {
+				return nil, err
+			}
+			return doc, nil
+		})
+	}
 }
 
 // OpenPowerPointDocument opens a PowerPoint file for reading and modification
@@ -36,6 +83,11 @@ func OpenPowerPointDocument(path string) (*PowerPointDocument, error) {
 		return nil, fmt.Errorf("file not found: %s", path)
 	}
 
+	// Encrypted presentations are OLE compound files, not zip archives
+	if err := checkNotEncrypted(path); err != nil {
+		return nil, err
+	}
+
 	// Open the file as a zip archive
 	reader, err := zip.OpenReader(path)
 	if err != nil {
@@ -46,6 +98,7 @@ func OpenPowerPointDocument(path string) (*PowerPointDocument, error) {
 		path:    path,
 		zipFile: reader,
 		slides:  make(map[string]*slideContent),
+		charts:  make(map[string]*slideContent),
 	}
 
 	// Load all slides
@@ -80,8 +133,27 @@ func (d *PowerPointDocument) loadSlides() error {
 			}
 
 			d.slides[file.Name] = &slideContent{
-				path:    file.Name,
-				xmlDoc:  string(content),
+				path:     file.Name,
+				xmlDoc:   string(content),
+				original: string(content),
+			}
+		} else if isChartPart(file.Name) {
+			// Charts embedded in slides live in their own parts
+			rc, err := file.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open chart %s: %w", file.Name, err)
+			}
+
+			content, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read chart %s: %w", file.Name, err)
+			}
+
+			d.charts[file.Name] = &slideContent{
+				path:     file.Name,
+				xmlDoc:   string(content),
+				original: string(content),
 			}
 		}
 	}
@@ -89,39 +161,141 @@ func (d *PowerPointDocument) loadSlides() error {
 	return nil
 }
 
+// isChartPart reports whether a zip entry is a chart part such as "ppt/charts/chart1.xml"
+func isChartPart(name string) bool {
+	return strings.HasPrefix(name, "ppt/charts/chart") &&
+		strings.HasSuffix(name, ".xml") &&
+		!strings.Contains(name, "_rels")
+}
+
+// partNumber extracts the trailing number from a part path like "ppt/charts/chart3.xml"
+func partNumber(path, prefix string) (int, bool) {
+	baseName := strings.TrimPrefix(path, prefix)
+	baseName = strings.TrimSuffix(baseName, ".xml")
+	num, err := strconv.Atoi(baseName)
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
 // GetText extracts all text from the PowerPoint presentation
 func (d *PowerPointDocument) GetText() (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	blocks, err := d.textBlocks(nil)
+	if err != nil {
+		return "", err
+	}
 	var allText strings.Builder
+	for _, block := range blocks {
+		allText.WriteString(fmt.Sprintf("%s:\n%s\n\n", block.label, block.text))
+	}
+	return checkUTF8(d.path, allText.String(), d.sanitizeUTF8)
+}
 
-	// Process slides by finding all that match the pattern
-	var slideNums []int
-	for path := range d.slides {
-		if strings.HasPrefix(path, "ppt/slides/slide") && strings.HasSuffix(path, ".xml") {
-			// Extract slide number from path like "ppt/slides/slide1.xml"
-			baseName := strings.TrimPrefix(path, "ppt/slides/slide")
-			baseName = strings.TrimSuffix(baseName, ".xml")
-			if num, err := strconv.Atoi(baseName); err == nil {
-				slideNums = append(slideNums, num)
-			}
+// GetPlainText returns the text of all slides and charts without the
+// "Slide N:" headers, with a blank line between slides
+func (d *PowerPointDocument) GetPlainText() (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	blocks, err := d.textBlocks(nil)
+	if err != nil {
+		return "", err
+	}
+	var texts []string
+	for _, block := range blocks {
+		// Notes keep a label so they stay distinguishable from slide text
+		if block.notes {
+			texts = append(texts, "Speaker notes:\n"+block.text)
+			continue
 		}
+		texts = append(texts, block.text)
 	}
-	
-	// Sort slide numbers to process in order
-	sort.Ints(slideNums)
-	
+	return checkUTF8(d.path, strings.Join(texts, "\n\n"), d.sanitizeUTF8)
+}
+
+// pptTextBlock is the text of one slide, its notes or a chart with its label
+type pptTextBlock struct {
+	label string
+	text  string
+	notes bool // the block holds a slide's speaker notes
+}
+
+// textBlocks returns the non-empty text of each slide in order, each
+// followed by its speaker notes when they are included, and then the text of
+// each chart ordered by chart number. A selection of parts (see
+// selectedSlideParts) limits the blocks to those parts; nil selects all.
+// d.mu must be held.
+func (d *PowerPointDocument) textBlocks(selected map[string]bool) ([]pptTextBlock, error) {
+	var blocks []pptTextBlock
+
 	// Process each slide in order
-	for _, num := range slideNums {
+	for _, num := range d.slideNumbers() {
 		slidePath := fmt.Sprintf("ppt/slides/slide%d.xml", num)
+		if selected != nil && !selected[slidePath] {
+			continue
+		}
 		slide := d.slides[slidePath]
-		
+
 		// Extract text from the slide
-		text := extractTextFromSlide(slide.xmlDoc)
-		if text != "" {
-			allText.WriteString(fmt.Sprintf("Slide %d:\n%s\n\n", num, text))
+		if text := d.slideText(slide.xmlDoc); text != "" {
+			blocks = append(blocks, pptTextBlock{label: fmt.Sprintf("Slide %d", num), text: text})
+		}
+
+		if d.includeNotes {
+			notes, err := d.SlideNotes(num)
+			if err != nil {
+				return nil, err
+			}
+			if notes != "" {
+				blocks = append(blocks, pptTextBlock{label: fmt.Sprintf("Slide %d speaker notes", num), text: notes, notes: true})
+			}
+		}
+	}
+
+	// Charts follow the slides, ordered by chart number
+	var chartNums []int
+	for path := range d.charts {
+		if num, ok := partNumber(path, "ppt/charts/chart"); ok {
+			chartNums = append(chartNums, num)
+		}
+	}
+	sort.Ints(chartNums)
+
+	for _, num := range chartNums {
+		chartPath := fmt.Sprintf("ppt/charts/chart%d.xml", num)
+		if selected != nil && !selected[chartPath] {
+			continue
+		}
+		chart := d.charts[chartPath]
+
+		if text := extractTextFromChart(chart.xmlDoc); text != "" {
+			blocks = append(blocks, pptTextBlock{label: fmt.Sprintf("Chart %d", num), text: text})
 		}
 	}
 
-	return allText.String(), nil
+	return blocks, nil
+}
+
+// SlideNumbers returns the numbers of the slides in the presentation, sorted
+func (d *PowerPointDocument) SlideNumbers() []int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.slideNumbers()
+}
+
+// slideNumbers is SlideNumbers without locking. d.mu must be held.
+func (d *PowerPointDocument) slideNumbers() []int {
+	var slideNums []int
+	for path := range d.slides {
+		// Extract slide number from path like "ppt/slides/slide1.xml"
+		if num, ok := partNumber(path, "ppt/slides/slide"); ok {
+			slideNums = append(slideNums, num)
+		}
+	}
+	sort.Ints(slideNums)
+	return slideNums
 }
 
 // extractTextFromSlide extracts text from a slide's XML content
@@ -129,9 +303,8 @@ func extractTextFromSlide(xmlContent string) string {
 	var texts []string
 	
 	// Find all text elements using regex
-	// PowerPoint uses <a:t> tags for text
-	re := regexp.MustCompile(`<a:t[^>]*>([^<]+)</a:t>`)
-	matches := re.FindAllStringSubmatch(xmlContent, -1)
+	// PowerPoint uses <a:t> tags for text, including runs inside table cells
+	matches := pptTextRegex.FindAllStringSubmatch(xmlContent, -1)
 	
 	for _, match := range matches {
 		if len(match) > 1 {
@@ -144,44 +317,138 @@ func extractTextFromSlide(xmlContent string) string {
 	return strings.Join(texts, "\n")
 }
 
+// extractTextFromChart extracts title runs and cached string values
+// (categories and series names) from a chart part
+func extractTextFromChart(xmlContent string) string {
+	var texts []string
+
+	if text := extractTextFromSlide(xmlContent); text != "" {
+		texts = append(texts, text)
+	}
+
+	for _, cache := range chartStrCacheRegex.FindAllString(xmlContent, -1) {
+		for _, match := range chartValueRegex.FindAllStringSubmatch(cache, -1) {
+			if match[2] != "" {
+				texts = append(texts, html.UnescapeString(match[2]))
+			}
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
 // ReplaceText replaces all occurrences of old text with new text in the presentation
 func (d *PowerPointDocument) ReplaceText(old, new string) error {
+	return d.ReplaceTextInSlides(old, new, nil)
+}
+
+// ReplaceTextCount replaces all occurrences of old text with new text in the
+// presentation, like ReplaceText, and returns the number replaced
+func (d *PowerPointDocument) ReplaceTextCount(old, new string) (int, error) {
+	return d.ReplaceTextInSlidesCount(old, new, nil)
+}
+
+// ReplaceTextInSlides replaces text only on the selected slide numbers and in the
+// charts they reference. A nil selection replaces text on every slide.
+func (d *PowerPointDocument) ReplaceTextInSlides(old, new string, slides map[int]bool) error {
+	_, err := d.ReplaceTextInSlidesCount(old, new, slides)
+	return err
+}
+
+// ReplaceTextInSlidesCount is ReplaceTextInSlides returning the number of
+// occurrences replaced
+func (d *PowerPointDocument) ReplaceTextInSlidesCount(old, new string, slides map[int]bool) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.replaceTextInSlides(old, new, slides)
+}
+
+// replaceTextInSlides is ReplaceTextInSlidesCount without locking. d.mu must
+// be held for writing.
+func (d *PowerPointDocument) replaceTextInSlides(old, new string, slides map[int]bool) (int, error) {
 	if old == "" {
-		return fmt.Errorf("search text cannot be empty")
+		return 0, fmt.Errorf("search text cannot be empty")
+	}
+
+	selected, err := selectedSlideParts(d.zipFile.File, slides)
+	if err != nil {
+		return 0, err
+	}
+
+	// Escape the old and new text for XML. Text that needs no escaping is
+	// searched for once, so a replacement containing it is not replaced again.
+	oldEscaped := escapeXMLStringPPT(old)
+	newEscaped := escapeXMLStringPPT(new)
+	replaceText := func(xmlContent string, replace func(xmlContent, old, new string) (string, int)) (string, int) {
+		modified, count := replace(xmlContent, oldEscaped, newEscaped)
+		if old != oldEscaped {
+			// Also try the non-escaped text in case it is stored that way
+			var raw int
+			modified, raw = replace(modified, old, newEscaped)
+			count += raw
+		}
+		return modified, count
 	}
 
+	count := 0
+
 	// Process each slide
-	for _, slide := range d.slides {
-		originalContent := slide.xmlDoc
-		
-		// Escape the old and new text for XML
-		oldEscaped := escapeXMLStringPPT(old)
-		newEscaped := escapeXMLStringPPT(new)
-		
+	for path, slide := range d.slides {
+		if selected != nil && !selected[path] {
+			continue
+		}
 		// Replace text in <a:t> tags
 		// We need to be careful to only replace within text content
-		modified := replaceTextInXML(slide.xmlDoc, oldEscaped, newEscaped)
-		
-		// Also try replacing non-escaped version in case text is already in the document
-		modified = replaceTextInXML(modified, old, newEscaped)
-		
-		if modified != originalContent {
+		modified, replaced := replaceText(slide.xmlDoc, replaceTextInXML)
+		if replaced > 0 {
 			slide.xmlDoc = modified
 			d.modified = true
+			count += replaced
 		}
 	}
 
-	return nil
+	// Process charts: rich-text titles use <a:t>, categories and series
+	// names are cached as <c:v> values inside <c:strCache>
+	for path, chart := range d.charts {
+		if selected != nil && !selected[path] {
+			continue
+		}
+		modified, replaced := replaceText(chart.xmlDoc, replaceTextInXML)
+		modified, cached := replaceText(modified, replaceTextInChartCache)
+		if replaced+cached > 0 {
+			chart.xmlDoc = modified
+			d.modified = true
+			count += replaced + cached
+		}
+	}
+
+	return count, nil
 }
 
-// replaceTextInXML replaces text within <a:t> tags in XML content
-func replaceTextInXML(xmlContent, old, new string) string {
-	// Create a pattern to match text within <a:t> tags
-	re := regexp.MustCompile(`(<a:t[^>]*>)([^<]*)(<\/a:t>)`)
-	
-	result := re.ReplaceAllStringFunc(xmlContent, func(match string) string {
+// replaceTextInChartCache replaces text within <c:v> values of string caches only,
+// leaving numeric caches untouched, and returns the number of occurrences replaced
+func replaceTextInChartCache(xmlContent, old, new string) (string, int) {
+	count := 0
+	result := chartStrCacheRegex.ReplaceAllStringFunc(xmlContent, func(cache string) string {
+		return chartValueRegex.ReplaceAllStringFunc(cache, func(match string) string {
+			parts := chartValueRegex.FindStringSubmatch(match)
+			if len(parts) != 4 {
+				return match
+			}
+			count += strings.Count(parts[2], old)
+			return parts[1] + strings.ReplaceAll(parts[2], old, new) + parts[3]
+		})
+	})
+	return result, count
+}
+
+// replaceTextInXML replaces text within <a:t> tags in XML content and returns
+// the number of occurrences replaced
+func replaceTextInXML(xmlContent, old, new string) (string, int) {
+	count := 0
+	result := pptTextReplaceRegex.ReplaceAllStringFunc(xmlContent, func(match string) string {
 		// Extract the parts
-		parts := re.FindStringSubmatch(match)
+		parts := pptTextReplaceRegex.FindStringSubmatch(match)
 		if len(parts) != 4 {
 			return match
 		}
@@ -191,12 +458,13 @@ func replaceTextInXML(xmlContent, old, new string) string {
 		closeTag := parts[3]
 		
 		// Replace the text
+		count += strings.Count(content, old)
 		newContent := strings.ReplaceAll(content, old, new)
 		
 		return openTag + newContent + closeTag
 	})
 	
-	return result
+	return result, count
 }
 
 // escapeXMLStringPPT escapes special XML characters in a string for PowerPoint
@@ -206,9 +474,46 @@ func escapeXMLStringPPT(s string) string {
 	return buf.String()
 }
 
+// GetMetadata returns the presentation properties, reflecting a pending StripMetadata
+func (d *PowerPointDocument) GetMetadata() (Metadata, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return readMetadata(d.zipFile.File, d.metadataParts)
+}
+
+// StripMetadata blanks author, company and other identifying properties.
+// The change is written on the next Save or SaveAs.
+func (d *PowerPointDocument) StripMetadata() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stripped, err := strippedMetadataParts(d.zipFile.File)
+	if err != nil {
+		return err
+	}
+	d.metadataParts = stripped
+	if len(stripped) > 0 {
+		d.modified = true
+	}
+	return nil
+}
+
 // Save saves the modified PowerPoint document
 func (d *PowerPointDocument) Save() error {
-	if !d.modified {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.save()
+}
+
+// save writes the document to d.path. d.mu must be held for writing, so no
+// change is half applied while the package is written.
+func (d *PowerPointDocument) save() error {
+	// Declare the main part as a presentation or template to match the extension
+	contentTypes, retyped, err := retypedContentTypes(d.zipFile.File, d.path, presentationContentType, presentationTemplateContentType)
+	if err != nil {
+		return err
+	}
+
+	if !d.modified && !retyped {
 		return nil // No changes to save
 	}
 
@@ -216,37 +521,27 @@ func (d *PowerPointDocument) Save() error {
 	buf := new(bytes.Buffer)
 	w := zip.NewWriter(buf)
 
-	// Copy all files from the original, replacing modified slides
-	for _, file := range d.zipFile.File {
-		// Check if this is a modified slide
-		if slide, exists := d.slides[file.Name]; exists && strings.HasPrefix(file.Name, "ppt/slides/slide") && strings.HasSuffix(file.Name, ".xml") {
-			// Write modified slide content
-			writer, err := w.Create(file.Name)
-			if err != nil {
-				return fmt.Errorf("failed to create %s in zip: %w", file.Name, err)
-			}
-			
-			if _, err := writer.Write([]byte(slide.xmlDoc)); err != nil {
-				return fmt.Errorf("failed to write %s: %w", file.Name, err)
-			}
-		} else {
-			// Copy original file
-			reader, err := file.Open()
-			if err != nil {
-				return fmt.Errorf("failed to open %s: %w", file.Name, err)
-			}
-			defer reader.Close()
-			
-			writer, err := w.Create(file.Name)
-			if err != nil {
-				return fmt.Errorf("failed to create %s in zip: %w", file.Name, err)
-			}
-			
-			if _, err := io.Copy(writer, reader); err != nil {
-				return fmt.Errorf("failed to copy %s: %w", file.Name, err)
+	// Re-serialize only the slide and chart parts whose content actually changed
+	// and any stripped property parts; every other entry is copied byte-for-byte
+	// without recompression
+	overrides := make(map[string][]byte)
+	for _, parts := range []map[string]*slideContent{d.slides, d.charts} {
+		for name, part := range parts {
+			if part.changed() {
+				overrides[name] = []byte(part.xmlDoc)
 			}
 		}
 	}
+	for name, data := range d.metadataParts {
+		overrides[name] = data
+	}
+	if retyped {
+		overrides[contentTypesPart] = contentTypes
+	}
+
+	if err := writePackage(w, d.zipFile.File, overrides); err != nil {
+		return err
+	}
 
 	// Close the zip writer
 	if err := w.Close(); err != nil {
@@ -254,39 +549,8 @@ func (d *PowerPointDocument) Save() error {
 	}
 
 	// Write to a temporary file first for atomic save
-	dir := filepath.Dir(d.path)
-	tmpFile, err := os.CreateTemp(dir, "ppt_save_*.tmp")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-	
-	// Ensure temp file is cleaned up
-	defer func() {
-		if _, err := os.Stat(tmpPath); err == nil {
-			os.Remove(tmpPath)
-		}
-	}()
-	
-	// Write content to temp file
-	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-	
-	// Ensure data is flushed to disk
-	if err := tmpFile.Sync(); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("failed to sync temp file: %w", err)
-	}
-	
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("failed to close temp file: %w", err)
-	}
-	
-	// Atomically replace the original file
-	if err := os.Rename(tmpPath, d.path); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	if err := writeFileAtomic(d.path, buf.Bytes(), 0644); err != nil {
+		return err
 	}
 
 	return nil
@@ -300,12 +564,15 @@ func (d *PowerPointDocument) SaveAs(path string) error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	// Temporarily change the path
 	originalPath := d.path
 	d.path = path
 	
 	// Save to the new path
-	err := d.Save()
+	err := d.save()
 	
 	// Restore the original path
 	d.path = originalPath
@@ -315,6 +582,8 @@ func (d *PowerPointDocument) SaveAs(path string) error {
 
 // Close closes the PowerPoint document
 func (d *PowerPointDocument) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	if d.zipFile != nil {
 		return d.zipFile.Close()
 	}
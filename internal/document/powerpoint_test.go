@@ -1,9 +1,13 @@
 package document
 
 import (
+	"archive/zip"
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -83,6 +87,33 @@ func TestPowerPointDocument_GetText(t *testing.T) {
 	}
 }
 
+func TestPowerPointDocument_GetPlainText(t *testing.T) {
+	doc, err := OpenPowerPointDocument("testdata/table_chart.pptx")
+	if err != nil {
+		t.Fatalf("Failed to open PowerPoint: %v", err)
+	}
+	defer doc.Close()
+
+	text, err := doc.GetPlainText()
+	if err != nil {
+		t.Fatalf("GetPlainText() error = %v", err)
+	}
+
+	for _, header := range []string{"Slide 1:", "Chart 1:"} {
+		if strings.Contains(text, header) {
+			t.Errorf("GetPlainText() should not contain %q:\n%s", header, text)
+		}
+	}
+	for _, expected := range []string{"Quarterly Report 2023", "Sales 2023"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("GetPlainText() missing expected text: %s", expected)
+		}
+	}
+	if strings.HasSuffix(text, "\n") {
+		t.Error("GetPlainText() should not end with a newline")
+	}
+}
+
 func TestPowerPointDocument_ReplaceText(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -169,6 +200,55 @@ func TestPowerPointDocument_ReplaceText(t *testing.T) {
 	}
 }
 
+func TestPowerPointDocument_ConcurrentUse(t *testing.T) {
+	// Run with -race: replacements, reads and saves share one document
+	testFile := filepath.Join(t.TempDir(), "test.pptx")
+	if err := createTestPowerPoint(testFile); err != nil {
+		t.Fatalf("Failed to create test PowerPoint: %v", err)
+	}
+	doc, err := OpenPowerPointDocument(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	var wg sync.WaitGroup
+	run := func(fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	run(func() error { return doc.ReplaceText("Version 1.0", "Version 2.0") })
+	run(func() error { return doc.ReplaceText("2023", "2024") })
+	run(func() error { _, err := doc.ReplaceTextOccurrenceInSlides("Version", "Release", 1, nil); return err })
+	run(func() error { _, err := doc.GetText(); return err })
+	run(func() error { _, err := doc.GetPlainText(); return err })
+	run(func() error { doc.SlideNumbers(); return nil })
+	run(func() error { doc.SetIncludeNotes(true); return nil })
+	run(doc.Save)
+	wg.Wait()
+
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := OpenPowerPointDocument(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+	text, err := saved.GetText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(text, "Version 1.0") || strings.Contains(text, "2023") || !strings.Contains(text, "2024") {
+		t.Errorf("saved text is missing replacements:\n%s", text)
+	}
+}
+
 func TestPowerPointDocument_Save(t *testing.T) {
 	// Create a test PowerPoint file
 	testFile := filepath.Join(t.TempDir(), "test.pptx")
@@ -276,4 +356,163 @@ func TestPowerPointDocument_SaveAs(t *testing.T) {
 	if !strings.Contains(originalText, "Draft") {
 		t.Error("Original file should still contain 'Draft'")
 	}
-}
\ No newline at end of file
+}
+
+func TestPowerPointDocument_TablesAndCharts(t *testing.T) {
+	t.Run("GetText includes table cells and chart text", func(t *testing.T) {
+		doc, err := OpenPowerPointDocument("testdata/table_chart.pptx")
+		if err != nil {
+			t.Fatalf("Failed to open PowerPoint: %v", err)
+		}
+		defer doc.Close()
+
+		text, err := doc.GetText()
+		if err != nil {
+			t.Fatalf("GetText() error = %v", err)
+		}
+
+		expectedTexts := []string{
+			"Quarterly Report 2023",
+			"Region",
+			"Revenue 2023",
+			"Chart 1:",
+			"Sales 2023",
+			"North",
+			"South",
+		}
+		for _, expected := range expectedTexts {
+			if !strings.Contains(text, expected) {
+				t.Errorf("GetText() missing expected text: %s", expected)
+			}
+		}
+	})
+
+	t.Run("ReplaceText reaches table cells and charts", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "table_chart.pptx")
+		copyFile(t, "testdata/table_chart.pptx", testFile)
+
+		doc, err := OpenPowerPointDocument(testFile)
+		if err != nil {
+			t.Fatalf("Failed to open PowerPoint: %v", err)
+		}
+
+		if err := doc.ReplaceText("2023", "2024"); err != nil {
+			t.Fatalf("ReplaceText() error = %v", err)
+		}
+		if err := doc.ReplaceText("South", "South & East"); err != nil {
+			t.Fatalf("ReplaceText() error = %v", err)
+		}
+		if err := doc.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		doc.Close()
+
+		doc2, err := OpenPowerPointDocument(testFile)
+		if err != nil {
+			t.Fatalf("Failed to open saved PowerPoint: %v", err)
+		}
+		defer doc2.Close()
+
+		text, err := doc2.GetText()
+		if err != nil {
+			t.Fatalf("GetText() error = %v", err)
+		}
+		for _, expected := range []string{"Revenue 2024", "Sales 2024", "South & East"} {
+			if !strings.Contains(text, expected) {
+				t.Errorf("Expected %q in saved document", expected)
+			}
+		}
+		if strings.Contains(text, "2023") {
+			t.Error("'2023' should have been replaced in tables and charts")
+		}
+
+		// Numeric chart values must not be rewritten
+		chart := doc2.charts["ppt/charts/chart1.xml"]
+		if chart == nil {
+			t.Fatal("chart part not loaded")
+		}
+		if !strings.Contains(chart.xmlDoc, "<c:numCache><c:ptCount val=\"2\"/><c:pt idx=\"0\"><c:v>1200</c:v></c:pt><c:pt idx=\"1\"><c:v>2023</c:v>") {
+			t.Error("numeric chart cache should be left untouched")
+		}
+	})
+}
+
+func TestPowerPointDocument_SaveCopiesUnchangedParts(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.pptx")
+	if err := createTestPowerPoint(testFile); err != nil {
+		t.Fatalf("Failed to create test PowerPoint: %v", err)
+	}
+
+	original, err := zip.OpenReader(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalRaw := make(map[string][]byte)
+	for _, f := range original.File {
+		originalRaw[f.Name] = readRawEntry(t, f)
+	}
+	original.Close()
+
+	doc, err := OpenPowerPointDocument(testFile)
+	if err != nil {
+		t.Fatalf("Failed to open PowerPoint: %v", err)
+	}
+	// Slide 1 changes; slide 2 is touched but ends up identical
+	if err := doc.ReplaceText("Draft", "Final"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ReplaceText("2023", "2024"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ReplaceText("2024", "2023"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	saved, err := zip.OpenReader(testFile)
+	if err != nil {
+		t.Fatalf("saved file is not a valid zip: %v", err)
+	}
+	defer saved.Close()
+
+	if len(saved.File) != len(originalRaw) {
+		t.Errorf("saved package has %d entries, want %d", len(saved.File), len(originalRaw))
+	}
+	for _, f := range saved.File {
+		// Every entry must still decompress cleanly
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		if _, err := io.ReadAll(rc); err != nil {
+			t.Errorf("failed to read %s: %v", f.Name, err)
+		}
+		rc.Close()
+
+		same := bytes.Equal(readRawEntry(t, f), originalRaw[f.Name])
+		if f.Name == "ppt/slides/slide1.xml" {
+			if same {
+				t.Error("changed slide should have been rewritten")
+			}
+		} else if !same {
+			t.Errorf("unchanged entry %s was not copied byte-for-byte", f.Name)
+		}
+	}
+}
+
+// readRawEntry returns the compressed bytes of a zip entry
+func readRawEntry(t *testing.T, f *zip.File) []byte {
+	t.Helper()
+	r, err := f.OpenRaw()
+	if err != nil {
+		t.Fatalf("failed to open raw %s: %v", f.Name, err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read raw %s: %v", f.Name, err)
+	}
+	return data
+}
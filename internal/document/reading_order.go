@@ -0,0 +1,242 @@
+package document
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// TextOrder controls the order in which text is extracted from a slide
+type TextOrder int
+
+const (
+	// TextOrderReading orders shapes as they are read on the slide: title
+	// first, then top-to-bottom and left-to-right, with footers last
+	TextOrderReading TextOrder = iota
+	// TextOrderDocument keeps the order in which text appears in the slide XML
+	TextOrderDocument
+)
+
+// Names of the text orders accepted by ParseTextOrder
+const (
+	TextOrderNameReading  = "reading"
+	TextOrderNameDocument = "document"
+)
+
+// ParseTextOrder parses a text order name: "reading" (the default) or
+// "document" for the XML order used by earlier versions
+func ParseTextOrder(name string) (TextOrder, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case TextOrderNameReading, "":
+		return TextOrderReading, nil
+	case TextOrderNameDocument:
+		return TextOrderDocument, nil
+	}
+	return TextOrderReading, pkgErrors.NewValidationError("text-order", name,
+		fmt.Sprintf("unknown text order (supported: %s, %s)", TextOrderNameReading, TextOrderNameDocument))
+}
+
+// rowTolerance groups shapes whose tops are within this distance (in EMU,
+// 1/8 inch) into one row, so slightly misaligned columns read left to right
+const rowTolerance = 114300
+
+// slideShape is a top-level shape of a slide's shape tree
+type slideShape struct {
+	index  int
+	phType string
+	hasPos bool
+	x, y   int64
+	row    int
+	texts  []string
+}
+
+// rank orders titles before the body and footers after it
+func (s slideShape) rank() int {
+	switch s.phType {
+	case "title", "ctrTitle":
+		return 0
+	case "dt", "ftr", "sldNum":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// shapeElements are the spTree children that can carry text
+var shapeElements = map[string]bool{
+	"sp":               true,
+	"grpSp":            true,
+	"graphicFrame":     true,
+	"cxnSp":            true,
+	"pic":              true,
+	"AlternateContent": true,
+}
+
+// extractTextInReadingOrder extracts slide text shape by shape in visual
+// reading order. Shapes without their own position (placeholders inheriting
+// it from the layout) keep their XML order within their rank. It falls back
+// to XML order if the slide cannot be parsed.
+func extractTextInReadingOrder(xmlContent string) string {
+	shapes, err := parseSlideShapes(xmlContent)
+	if err != nil {
+		return extractTextFromSlide(xmlContent)
+	}
+
+	assignRows(shapes)
+	sort.SliceStable(shapes, func(i, j int) bool {
+		a, b := shapes[i], shapes[j]
+		if a.rank() != b.rank() {
+			return a.rank() < b.rank()
+		}
+		if !a.hasPos || !b.hasPos {
+			return a.index < b.index
+		}
+		if a.row != b.row {
+			return a.row < b.row
+		}
+		if a.x != b.x {
+			return a.x < b.x
+		}
+		return a.index < b.index
+	})
+
+	var texts []string
+	for _, shape := range shapes {
+		texts = append(texts, shape.texts...)
+	}
+	return strings.Join(texts, "\n")
+}
+
+// assignRows numbers the rows of positioned shapes from top to bottom. A new
+// row starts when a shape's top is more than rowTolerance below the top of
+// the first shape in the current row.
+func assignRows(shapes []slideShape) {
+	var positioned []*slideShape
+	for i := range shapes {
+		if shapes[i].hasPos {
+			positioned = append(positioned, &shapes[i])
+		}
+	}
+	sort.SliceStable(positioned, func(i, j int) bool {
+		return positioned[i].y < positioned[j].y
+	})
+
+	row := -1
+	var rowTop int64
+	for _, shape := range positioned {
+		if row < 0 || shape.y-rowTop > rowTolerance {
+			row++
+			rowTop = shape.y
+		}
+		shape.row = row
+	}
+}
+
+// parseSlideShapes collects the position, placeholder type and text runs of
+// each top-level shape in the slide's shape tree
+func parseSlideShapes(xmlContent string) ([]slideShape, error) {
+	decoder := xml.NewDecoder(strings.NewReader(xmlContent))
+
+	var shapes []slideShape
+	var current *slideShape
+	depth, treeDepth, shapeDepth := 0, -1, -1
+	inText := false
+	var text strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch el := token.(type) {
+		case xml.StartElement:
+			depth++
+			name := el.Name.Local
+
+			switch {
+			case name == "spTree" && treeDepth < 0:
+				treeDepth = depth
+			case current == nil && treeDepth > 0 && depth == treeDepth+1 && shapeElements[name]:
+				shapes = append(shapes, slideShape{index: len(shapes)})
+				current = &shapes[len(shapes)-1]
+				shapeDepth = depth
+			case current != nil && name == "ph" && current.phType == "":
+				current.phType = "obj"
+				if t := attrValue(el, "type"); t != "" {
+					current.phType = t
+				}
+			case current != nil && name == "off" && !current.hasPos:
+				x, errX := strconv.ParseInt(attrValue(el, "x"), 10, 64)
+				y, errY := strconv.ParseInt(attrValue(el, "y"), 10, 64)
+				if errX == nil && errY == nil {
+					current.x, current.y, current.hasPos = x, y, true
+				}
+			case current != nil && name == "t":
+				inText = true
+				text.Reset()
+			}
+
+		case xml.CharData:
+			if inText {
+				text.Write(el)
+			}
+
+		case xml.EndElement:
+			if inText && el.Name.Local == "t" {
+				inText = false
+				if text.Len() > 0 {
+					current.texts = append(current.texts, text.String())
+				}
+			}
+			if current != nil && depth == shapeDepth {
+				current = nil
+			}
+			if depth == treeDepth {
+				treeDepth = -2 // only the first shape tree is read
+			}
+			depth--
+		}
+	}
+
+	if treeDepth == -1 {
+		return nil, errors.New("slide has no shape tree")
+	}
+	return shapes, nil
+}
+
+// attrValue returns the value of the attribute with the given local name
+func attrValue(el xml.StartElement, local string) string {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// SetTextOrder sets how GetText orders the text within each slide. The
+// default is TextOrderReading; TextOrderDocument restores the XML order used
+// by earlier versions.
+func (d *PowerPointDocument) SetTextOrder(order TextOrder) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.textOrder = order
+}
+
+// slideText extracts a slide's text using the document's text order
+func (d *PowerPointDocument) slideText(xmlContent string) string {
+	if d.textOrder == TextOrderDocument {
+		return extractTextFromSlide(xmlContent)
+	}
+	return extractTextInReadingOrder(xmlContent)
+}
@@ -0,0 +1,85 @@
+package document
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// unorderedSlideXML lists its shapes in an order that differs from how the
+// slide reads: footer and body before the title, and a right column before
+// the left one on the same row
+const unorderedSlideXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"><p:cSld><p:spTree>
+<p:nvGrpSpPr><p:cNvPr id="1" name=""/></p:nvGrpSpPr><p:grpSpPr><a:xfrm><a:off x="0" y="0"/></a:xfrm></p:grpSpPr>
+<p:sp><p:nvSpPr><p:nvPr><p:ph type="sldNum"/></p:nvPr></p:nvSpPr><p:spPr/><p:txBody><a:p><a:r><a:t>7</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:spPr><a:xfrm><a:off x="457200" y="4000000"/></a:xfrm></p:spPr><p:txBody><a:p><a:r><a:t>Closing note</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:spPr><a:xfrm><a:off x="4800000" y="1600000"/></a:xfrm></p:spPr><p:txBody><a:p><a:r><a:t>Right column</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:nvSpPr><p:nvPr><p:ph type="title"/></p:nvPr></p:nvSpPr><p:spPr/><p:txBody><a:p><a:r><a:t>Quarterly &amp; Annual</a:t></a:r></a:p></p:txBody></p:sp>
+<p:sp><p:spPr><a:xfrm><a:off x="457200" y="1650000"/></a:xfrm></p:spPr><p:txBody><a:p><a:r><a:t>Left column</a:t></a:r></a:p><a:p><a:r><a:t>second line</a:t></a:r></a:p></p:txBody></p:sp>
+</p:spTree></p:cSld></p:sld>`
+
+func TestExtractTextInReadingOrder(t *testing.T) {
+	got := extractTextInReadingOrder(unorderedSlideXML)
+	want := strings.Join([]string{
+		"Quarterly & Annual",
+		"Left column",
+		"second line",
+		"Right column",
+		"Closing note",
+		"7",
+	}, "\n")
+	if got != want {
+		t.Errorf("reading order:\ngot:  %q\nwant: %q", got, want)
+	}
+
+	// Legacy extraction keeps the XML order
+	if legacy := extractTextFromSlide(unorderedSlideXML); !strings.HasPrefix(legacy, "7\nClosing note") {
+		t.Errorf("XML order changed: %q", legacy)
+	}
+}
+
+func TestExtractTextInReadingOrderFallsBack(t *testing.T) {
+	malformed := `<p:sld><a:t>Loose text</a:t>`
+	if got := extractTextInReadingOrder(malformed); got != "Loose text" {
+		t.Errorf("expected fallback to XML order, got %q", got)
+	}
+}
+
+func TestPowerPointSetTextOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deck.pptx")
+	writeZipParts(t, path, map[string]string{"ppt/slides/slide1.xml": unorderedSlideXML})
+
+	doc, err := OpenPowerPointDocument(path)
+	if err != nil {
+		t.Fatalf("OpenPowerPointDocument failed: %v", err)
+	}
+	defer doc.Close()
+
+	text, _ := doc.GetText()
+	if !strings.HasPrefix(text, "Slide 1:\nQuarterly & Annual\n") {
+		t.Errorf("default order should start with the title: %q", text)
+	}
+
+	doc.SetTextOrder(TextOrderDocument)
+	text, _ = doc.GetText()
+	if !strings.HasPrefix(text, "Slide 1:\n7\nClosing note\n") {
+		t.Errorf("document order should follow the XML: %q", text)
+	}
+}
+
+func TestParseTextOrder(t *testing.T) {
+	for name, want := range map[string]TextOrder{
+		"reading":   TextOrderReading,
+		"":          TextOrderReading,
+		"Document":  TextOrderDocument,
+		" document": TextOrderDocument,
+	} {
+		if got, err := ParseTextOrder(name); err != nil || got != want {
+			t.Errorf("ParseTextOrder(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseTextOrder("xml"); err == nil {
+		t.Error("ParseTextOrder(xml) should fail")
+	}
+}
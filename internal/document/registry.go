@@ -0,0 +1,87 @@
+package document
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// Opener opens a document of a registered format
+type Opener func(path string) (Document, error)
+
+// formats maps lower-case extensions such as ".docx" to their openers
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]Opener)
+)
+
+// RegisterFormat registers an opener for files with the given extension, so
+// that Open and directory operations handle the format. The extension is
+// matched case-insensitively and may omit the leading dot. Registering an
+// extension again replaces its opener.
+func RegisterFormat(ext string, opener Opener) {
+	ext = normalizeExtension(ext)
+	if ext == "." || opener == nil {
+		panic("document: RegisterFormat requires an extension and an opener")
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[ext] = opener
+}
+
+// UnregisterFormat removes a registered format
+func UnregisterFormat(ext string) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	delete(formats, normalizeExtension(ext))
+}
+
+// IsSupportedFormat reports whether a format is registered for the file's extension
+func IsSupportedFormat(path string) bool {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	_, ok := formats[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
+
+// SupportedExtensions returns the registered extensions in sorted order
+func SupportedExtensions() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	exts := make([]string, 0, len(formats))
+	for ext := range formats {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// Open opens a document using the format registered for its extension. It
+// returns an error wrapping errors.ErrUnsupportedFormat for other files.
+func Open(path string) (Document, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	formatsMu.RLock()
+	opener, ok := formats[ext]
+	formatsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (supported: %s)", pkgErrors.ErrUnsupportedFormat, ext, strings.Join(SupportedExtensions(), ", "))
+	}
+	return opener(path)
+}
+
+// normalizeExtension lower-cases an extension and ensures a leading dot
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
@@ -0,0 +1,77 @@
+package document
+
+import (
+	"errors"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestFormatRegistry(t *testing.T) {
+	exts := SupportedExtensions()
+	for _, want := range []string{".docx", ".dotx", ".pptx", ".potx"} {
+		if !IsSupportedFormat("file" + want) {
+			t.Errorf("%s should be registered, got %v", want, exts)
+		}
+	}
+	if !IsSupportedFormat("REPORT.DOCX") {
+		t.Error("extensions should match case-insensitively")
+	}
+
+	if _, err := Open("notes.xyz"); !errors.Is(err, pkgErrors.ErrUnsupportedFormat) {
+		t.Errorf("Open(unregistered) = %v, want ErrUnsupportedFormat", err)
+	}
+
+	opened := ""
+	RegisterFormat("XYZ", func(path string) (Document, error) {
+		opened = path
+		return nil, errors.New("fake open")
+	})
+	defer UnregisterFormat(".xyz")
+
+	if !IsSupportedFormat("notes.xyz") {
+		t.Fatal("registered format should be supported")
+	}
+	if _, err := Open("notes.xyz"); err == nil || opened != "notes.xyz" {
+		t.Errorf("Open should use the registered opener, opened %q, err %v", opened, err)
+	}
+
+	UnregisterFormat("xyz")
+	if IsSupportedFormat("notes.xyz") {
+		t.Error("unregistered format should no longer be supported")
+	}
+}
+
+func TestOpenRegisteredFormats(t *testing.T) {
+	doc, err := Open("testdata/sample.docx")
+	if err != nil {
+		t.Fatalf("Open(.docx) failed: %v", err)
+	}
+	defer doc.Close()
+	if _, ok := doc.(*WordDocument); !ok {
+		t.Errorf("Open(.docx) returned %T", doc)
+	}
+
+	pres, err := Open("testdata/table_chart.pptx")
+	if err != nil {
+		t.Fatalf("Open(.pptx) failed: %v", err)
+	}
+	defer pres.Close()
+	if _, ok := pres.(*PowerPointDocument); !ok {
+		t.Errorf("Open(.pptx) returned %T", pres)
+	}
+
+	// Open errors must not come back as a typed nil document
+	if doc, err := Open("testdata/corrupted.docx"); err == nil || doc != nil {
+		t.Errorf("Open(corrupted) = %v, %v", doc, err)
+	}
+}
+
+func TestRegisterFormatRequiresOpener(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterFormat with a nil opener should panic")
+		}
+	}()
+	RegisterFormat(".none", nil)
+}
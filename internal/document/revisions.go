@@ -0,0 +1,259 @@
+package document
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Properties whose <w:xxxChange> element records the previous value. Inner
+// properties are listed before the properties containing them, since a
+// paragraph's properties hold its mark's run and section properties.
+var changedProperties = []string{"rPr", "sectPr", "tblPrEx", "tblPr", "tblGrid", "trPr", "tcPr", "pPr"}
+
+var (
+	revisionPattern        = regexp.MustCompile(`<w:(?:ins|del|moveFrom|moveTo)(?:\s[^>]*)?>|<w:(?:\w+PrChange|tblPrExChange|tblGridChange|numberingChange)[\s/>]`)
+	moveRangePattern       = regexp.MustCompile(`<w:move(?:From|To)Range(?:Start|End)(?:\s[^>]*)?/>`)
+	numberingChangePattern = regexp.MustCompile(`(?s)<w:numberingChange(?:\s[^>]*)?(?:/>|>.*?</w:numberingChange>)`)
+	rowTags                = elementTags("w:tr")
+	paraPropsTags          = elementTags("w:pPr")
+	cellStartPattern       = regexp.MustCompile(`<w:tc[\s>]`)
+
+	revisionContainers = make(map[string]*regexp.Regexp)
+	revisionMarkers    = make(map[string]*regexp.Regexp)
+	propertyChanges    = make(map[string]*regexp.Regexp)
+)
+
+func init() {
+	for _, name := range []string{"ins", "del", "moveFrom", "moveTo"} {
+		// A container's start tag does not end in "/>"
+		revisionContainers[name] = regexp.MustCompile(`(?s)<w:` + name + `(?:\s[^>]*[^/>])?>(.*?)</w:` + name + `>`)
+		revisionMarkers[name] = regexp.MustCompile(`<w:` + name + `(?:\s[^>]*)?/>`)
+	}
+	for _, name := range changedProperties {
+		propertyChanges[name] = regexp.MustCompile(`(?s)<w:` + name + `Change(?:\s[^>]*)?(?:/>|>(.*?)</w:` + name + `Change>)`)
+	}
+}
+
+// TrackedChanges returns the number of tracked changes (revisions) in the
+// document body: insertions, deletions, moves and formatting changes
+func (w *WordDocument) TrackedChanges() int {
+	if w.closed {
+		return 0
+	}
+	return len(revisionPattern.FindAllIndex(w.content.rawXML, -1))
+}
+
+// AcceptAllChanges accepts every tracked change: inserted text is kept,
+// deleted text is dropped and the current formatting is kept
+func (w *WordDocument) AcceptAllChanges() error {
+	return w.resolveChanges(true)
+}
+
+// RejectAllChanges rejects every tracked change: inserted text is dropped,
+// deleted text is restored and the previous formatting is restored
+func (w *WordDocument) RejectAllChanges() error {
+	return w.resolveChanges(false)
+}
+
+func (w *WordDocument) resolveChanges(accept bool) error {
+	if w.closed {
+		return errors.New("document is closed")
+	}
+
+	xmlStr := string(w.content.rawXML)
+	resolved := resolveRevisions(xmlStr, accept)
+	if resolved != xmlStr {
+		w.content.rawXML = []byte(resolved)
+		w.modified = true
+	}
+	return nil
+}
+
+// resolveRevisions accepts or rejects all revisions in document.xml
+func resolveRevisions(xmlStr string, accept bool) string {
+	kept, dropped := []string{"ins", "moveTo"}, []string{"del", "moveFrom"}
+	if !accept {
+		kept, dropped = dropped, kept
+	}
+
+	xmlStr = removeMarkedRows(xmlStr, dropped)
+	for _, name := range changedProperties {
+		xmlStr = resolvePropertyChanges(xmlStr, name, accept)
+	}
+	xmlStr = numberingChangePattern.ReplaceAllString(xmlStr, "")
+	xmlStr = mergeMarkedParagraphs(xmlStr, dropped)
+
+	for _, name := range dropped {
+		xmlStr = revisionContainers[name].ReplaceAllString(xmlStr, "")
+	}
+	for _, name := range kept {
+		xmlStr = revisionContainers[name].ReplaceAllString(xmlStr, "$1")
+	}
+	if !accept {
+		// Restored runs hold their text as deleted text
+		xmlStr = strings.NewReplacer(
+			"<w:delText>", "<w:t>", "<w:delText ", "<w:t ", "</w:delText>", "</w:t>",
+			"<w:delInstrText>", "<w:instrText>", "<w:delInstrText ", "<w:instrText ", "</w:delInstrText>", "</w:instrText>",
+		).Replace(xmlStr)
+	}
+
+	for _, marker := range revisionMarkers {
+		xmlStr = marker.ReplaceAllString(xmlStr, "")
+	}
+	return moveRangePattern.ReplaceAllString(xmlStr, "")
+}
+
+// removeMarkedRows removes table rows whose row properties carry one of the
+// given revision markers, such as an inserted row when rejecting. Rows of
+// tables nested in a cell are checked against their own properties.
+func removeMarkedRows(xmlStr string, markers []string) string {
+	var out strings.Builder
+	last := 0
+	for _, row := range findElements(xmlStr, rowTags) {
+		if row.start < last {
+			continue // Inside a removed row
+		}
+		if !hasRevisionMarker(rowProperties(xmlStr[row.start:row.end]), markers) {
+			continue
+		}
+		out.WriteString(xmlStr[last:row.start])
+		last = row.end
+	}
+	if last == 0 {
+		return xmlStr
+	}
+	out.WriteString(xmlStr[last:])
+	return out.String()
+}
+
+// rowProperties returns the content of a row's <w:trPr>, which comes before
+// its first cell and so before the rows of any nested table
+func rowProperties(row string) string {
+	if loc := cellStartPattern.FindStringIndex(row); loc != nil {
+		row = row[:loc[0]]
+	}
+	start := strings.Index(row, "<w:trPr>")
+	end := strings.Index(row, "</w:trPr>")
+	if start < 0 || end < start {
+		return ""
+	}
+	return row[start:end]
+}
+
+// resolvePropertyChanges removes the <w:nameChange> elements, restoring the
+// previous properties they record when rejecting
+func resolvePropertyChanges(xmlStr, name string, accept bool) string {
+	pattern := propertyChanges[name]
+	open, closeTag := "<w:"+name, "</w:"+name+">"
+
+	for {
+		loc := pattern.FindStringSubmatchIndex(xmlStr)
+		if loc == nil {
+			return xmlStr
+		}
+		if accept {
+			xmlStr = xmlStr[:loc[0]] + xmlStr[loc[1]:]
+			continue
+		}
+
+		// The properties before the change, without their wrapping element
+		var previous string
+		if loc[2] >= 0 {
+			previous = xmlStr[loc[2]:loc[3]]
+			if start := strings.Index(previous, ">"); strings.HasPrefix(previous, open) && start >= 0 {
+				if end := strings.LastIndex(previous, closeTag); end > start {
+					previous = previous[start+1 : end]
+				} else {
+					previous = "" // Self-closing: no properties before
+				}
+			}
+		}
+
+		start := max(strings.LastIndex(xmlStr[:loc[0]], open+">"), strings.LastIndex(xmlStr[:loc[0]], open+" "))
+		end := strings.Index(xmlStr[loc[1]:], closeTag)
+		if start < 0 || end < 0 {
+			xmlStr = xmlStr[:loc[0]] + xmlStr[loc[1]:]
+			continue
+		}
+		end += loc[1]
+
+		// A paragraph's change does not cover its mark's run properties or
+		// section properties, which follow the other properties
+		if name == "pPr" {
+			current := xmlStr[start:end]
+			for _, inner := range []string{"rPr", "sectPr"} {
+				if i := strings.Index(current, "<w:"+inner); i >= 0 {
+					if j := strings.Index(current[i:], "</w:"+inner+">"); j >= 0 {
+						previous += current[i : i+j+len("</w:"+inner+">")]
+					}
+				}
+			}
+		}
+
+		startTag := xmlStr[start : start+strings.Index(xmlStr[start:], ">")+1]
+		xmlStr = xmlStr[:start] + startTag + previous + xmlStr[end:]
+	}
+}
+
+// mergeMarkedParagraphs joins each paragraph whose mark carries one of the
+// given revision markers with the paragraph after it. The joined paragraph
+// takes the properties of the following paragraph, whose mark remains.
+func mergeMarkedParagraphs(xmlStr string, markers []string) string {
+	offset := 0
+	for {
+		propsStart, propsEnd := nextElement(xmlStr, offset, paraPropsTags)
+		if propsStart < 0 {
+			return xmlStr
+		}
+		props := xmlStr[propsStart:propsEnd]
+
+		runPropsStart := strings.Index(props, "<w:rPr>")
+		runPropsEnd := strings.Index(props, "</w:rPr>")
+		if runPropsStart < 0 || runPropsEnd < runPropsStart || !hasRevisionMarker(props[runPropsStart:runPropsEnd], markers) {
+			offset = propsEnd
+			continue
+		}
+
+		// Locate the end of the paragraph, past any paragraphs nested in it,
+		// and the next paragraph, which must directly follow this one
+		next := elementEnd(xmlStr, propsEnd, wordParagraphTags)
+		if next < 0 {
+			return xmlStr
+		}
+		paraEnd := next - len("</w:p>")
+		for next < len(xmlStr) && strings.ContainsRune(" \t\r\n", rune(xmlStr[next])) {
+			next++
+		}
+		rest := xmlStr[next:]
+		if !strings.HasPrefix(rest, "<w:p>") && !strings.HasPrefix(rest, "<w:p ") {
+			// Last paragraph of its container: there is nothing to join
+			offset = propsEnd
+			continue
+		}
+		nextOpenEnd := next + strings.Index(rest, ">") + 1
+
+		nextProps := ""
+		contentStart := nextOpenEnd
+		if start, end := nextElement(xmlStr, nextOpenEnd, paraPropsTags); start == nextOpenEnd {
+			if xmlStr[start:end] != "<w:pPr/>" {
+				nextProps = xmlStr[start:end]
+			}
+			contentStart = end
+		}
+
+		xmlStr = xmlStr[:propsStart] + nextProps + xmlStr[propsEnd:paraEnd] + xmlStr[contentStart:]
+		offset = propsStart
+	}
+}
+
+// hasRevisionMarker reports whether props holds a self-closing marker such
+// as <w:del w:id="1" .../>
+func hasRevisionMarker(props string, markers []string) bool {
+	for _, name := range markers {
+		if revisionMarkers[name].MatchString(props) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDocument_TrackedChanges(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/changes.docx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	if got := doc.TrackedChanges(); got != 8 {
+		t.Errorf("TrackedChanges() = %d, want 8", got)
+	}
+}
+
+func TestWordDocument_ResolveChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		resolve func(*WordDocument) error
+		want    []string
+	}{
+		{
+			name:    "accept",
+			resolve: (*WordDocument).AcceptAllChanges,
+			want:    []string{"The quick brown fox.", "Jumps over the dog.", "Formatted text", "Inserted paragraph.", "End.", "Row one", "Row two"},
+		},
+		{
+			name:    "reject",
+			resolve: (*WordDocument).RejectAllChanges,
+			want:    []string{"The quick red fox.", "Jumps over ", "the dog.", "Formatted text", "End.", "Row one"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "changes.docx")
+			copyFile(t, "testdata/changes.docx", path)
+
+			doc, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.resolve(doc); err != nil {
+				t.Fatalf("resolving changes: %v", err)
+			}
+			if err := doc.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			doc.Close()
+
+			reopened, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reopened.Close()
+
+			if got := reopened.GetTextParagraphs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paragraphs = %q, want %q", got, tt.want)
+			}
+			if got := reopened.TrackedChanges(); got != 0 {
+				t.Errorf("TrackedChanges() = %d after resolving", got)
+			}
+			xml := string(reopened.content.rawXML)
+			if strings.Contains(xml, "delText") {
+				t.Error("deleted text markup remains")
+			}
+			if !strings.Contains(xml, "<w:insideH ") {
+				t.Error("table border was removed as if it were an insertion")
+			}
+		})
+	}
+}
+
+func TestResolveRevisions_Formatting(t *testing.T) {
+	xml := `<w:p><w:pPr><w:jc w:val="center"/><w:rPr><w:b/></w:rPr><w:pPrChange w:id="1"><w:pPr><w:jc w:val="left"/></w:pPr></w:pPrChange></w:pPr>` +
+		`<w:r><w:rPr><w:b/><w:rPrChange w:id="2"><w:rPr/></w:rPrChange></w:rPr><w:t>x</w:t></w:r></w:p>`
+
+	accepted := `<w:p><w:pPr><w:jc w:val="center"/><w:rPr><w:b/></w:rPr></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>x</w:t></w:r></w:p>`
+	if got := resolveRevisions(xml, true); got != accepted {
+		t.Errorf("accept = %q, want %q", got, accepted)
+	}
+
+	rejected := `<w:p><w:pPr><w:jc w:val="left"/><w:rPr><w:b/></w:rPr></w:pPr><w:r><w:rPr></w:rPr><w:t>x</w:t></w:r></w:p>`
+	if got := resolveRevisions(xml, false); got != rejected {
+		t.Errorf("reject = %q, want %q", got, rejected)
+	}
+}
+
+func TestResolveRevisions_Moves(t *testing.T) {
+	xml := `<w:p><w:moveFromRangeStart w:id="1" w:name="move1"/><w:moveFrom w:id="2"><w:r><w:t>moved</w:t></w:r></w:moveFrom><w:moveFromRangeEnd w:id="1"/></w:p>` +
+		`<w:p><w:moveToRangeStart w:id="3" w:name="move1"/><w:moveTo w:id="4"><w:r><w:t>moved</w:t></w:r></w:moveTo><w:moveToRangeEnd w:id="3"/></w:p>`
+
+	if got, want := resolveRevisions(xml, true), `<w:p></w:p><w:p><w:r><w:t>moved</w:t></w:r></w:p>`; got != want {
+		t.Errorf("accept = %q, want %q", got, want)
+	}
+	if got, want := resolveRevisions(xml, false), `<w:p><w:r><w:t>moved</w:t></w:r></w:p><w:p></w:p>`; got != want {
+		t.Errorf("reject = %q, want %q", got, want)
+	}
+}
+
+func TestResolveRevisions_NestedTables(t *testing.T) {
+	inserted := `<w:trPr><w:ins w:id="1"/></w:trPr>`
+	nested := func(first, second string) string {
+		return `<w:tbl><w:tr>` + first + `<w:tc><w:p><w:r><w:t>Inner one</w:t></w:r></w:p></w:tc></w:tr>` +
+			`<w:tr>` + second + `<w:tc><w:p><w:r><w:t>Inner two</w:t></w:r></w:p></w:tc></w:tr></w:tbl>`
+	}
+	xml := `<w:tbl><w:tr>` + inserted + `<w:tc>` + nested("", "") + `<w:p/></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Kept</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`<w:tbl><w:tr><w:tc>` + nested(inserted, "") + `<w:p/></w:tc></w:tr></w:tbl>`
+
+	// Rejecting removes the inserted outer row with its nested table, and
+	// only the inserted row of the other nested table
+	want := `<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Kept</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`<w:tbl><w:tr><w:tc><w:tbl><w:tr><w:tc><w:p><w:r><w:t>Inner two</w:t></w:r></w:p></w:tc></w:tr></w:tbl><w:p/></w:tc></w:tr></w:tbl>`
+	if got := resolveRevisions(xml, false); got != want {
+		t.Errorf("reject =\n%s\nwant\n%s", got, want)
+	}
+
+	accepted := strings.ReplaceAll(xml, inserted, "<w:trPr></w:trPr>")
+	if got := resolveRevisions(xml, true); got != accepted {
+		t.Errorf("accept =\n%s\nwant\n%s", got, accepted)
+	}
+}
+
+func TestResolveRevisions_MergesParagraphWithTextBox(t *testing.T) {
+	box := `<w:r><w:txbxContent><w:p><w:pPr><w:jc w:val="left"/></w:pPr><w:r><w:t>Box</w:t></w:r></w:p></w:txbxContent></w:r>`
+	xml := `<w:p><w:pPr><w:rPr><w:ins w:id="1"/></w:rPr></w:pPr><w:r><w:t>One </w:t></w:r>` + box + `</w:p>` +
+		`<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:t>two</w:t></w:r></w:p>`
+
+	// Rejecting the inserted paragraph mark joins the paragraph with the
+	// next one, keeping the text box paragraph whole
+	want := `<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:t>One </w:t></w:r>` + box + `<w:r><w:t>two</w:t></w:r></w:p>`
+	if got := resolveRevisions(xml, false); got != want {
+		t.Errorf("reject =\n%s\nwant\n%s", got, want)
+	}
+}
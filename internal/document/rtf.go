@@ -0,0 +1,454 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+func init() {
+	RegisterFormat(".rtf", func(path string) (Document, error) {
+		doc, err := OpenRTFDocument(path)
+		if err != nil {
+			return nil, err
+		}
+		return doc, nil
+	})
+}
+
+// RTFDocument represents a Rich Text Format document. Text is read from the
+// body and from field results; font tables, style sheets, pictures and other
+// destinations are skipped. Replacement works on runs of text between control
+// words, so formatting such as \b or \cf is kept and text that changes
+// formatting mid-way is not matched. Bytes written as \'hh are decoded as
+// Windows-1252, the default ANSI code page.
+type RTFDocument struct {
+	path     string
+	content  string
+	modified bool
+	closed   bool
+}
+
+// OpenRTFDocument opens an RTF document for reading and editing
+func OpenRTFDocument(path string) (*RTFDocument, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file does not exist: %s", path)
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".rtf") {
+		return nil, fmt.Errorf("not a .rtf file: %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if !strings.HasPrefix(strings.TrimLeft(string(data), " \t\r\n"), `{\rtf`) {
+		return nil, fmt.Errorf("invalid RTF document: missing {\\rtf header: %s", path)
+	}
+
+	return &RTFDocument{path: path, content: string(data)}, nil
+}
+
+// GetText extracts the document text, one paragraph per line
+func (d *RTFDocument) GetText() (string, error) {
+	if d.closed {
+		return "", errors.New("document is closed")
+	}
+
+	var b strings.Builder
+	for _, seg := range parseRTF(d.content) {
+		b.WriteString(seg.text)
+	}
+	return b.String(), nil
+}
+
+// GetPlainText returns the document text without surrounding whitespace
+func (d *RTFDocument) GetPlainText() (string, error) {
+	text, err := d.GetText()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// ReplaceText replaces all occurrences of old with new inside text runs
+func (d *RTFDocument) ReplaceText(old, new string) error {
+	_, err := d.ReplaceTextCount(old, new)
+	return err
+}
+
+// ReplaceTextCount replaces all occurrences of old with new inside text runs,
+// like ReplaceText, and returns the number replaced
+func (d *RTFDocument) ReplaceTextCount(old, new string) (int, error) {
+	if d.closed {
+		return 0, errors.New("document is closed")
+	}
+	if old == "" {
+		return 0, errors.New("old text cannot be empty")
+	}
+
+	segments := parseRTF(d.content)
+	count := 0
+	var b strings.Builder
+	b.Grow(len(d.content))
+	for i, seg := range segments {
+		if seg.run && strings.Contains(seg.text, old) {
+			// A control word written without its delimiter, such as \par
+			// before \'e9, would run into replaced text starting with a letter
+			if i > 0 && isUndelimitedControlWord(segments[i-1].raw) {
+				b.WriteByte(' ')
+			}
+			b.WriteString(encodeRTFText(strings.ReplaceAll(seg.text, old, new), seg.uc))
+			count += strings.Count(seg.text, old)
+			continue
+		}
+		b.WriteString(seg.raw)
+	}
+
+	if count > 0 {
+		d.content = b.String()
+		d.modified = true
+	}
+	return count, nil
+}
+
+// Save saves changes to the original file
+func (d *RTFDocument) Save() error {
+	if d.closed {
+		return errors.New("document is closed")
+	}
+	if !d.modified {
+		return nil
+	}
+	return d.SaveAs(d.path)
+}
+
+// SaveAs saves the document to a new file
+func (d *RTFDocument) SaveAs(path string) error {
+	if d.closed {
+		return errors.New("document is closed")
+	}
+	if !strings.EqualFold(filepath.Ext(path), ".rtf") {
+		return fmt.Errorf("output file must have .rtf extension")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(d.content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Close closes the document
+func (d *RTFDocument) Close() error {
+	d.closed = true
+	return nil
+}
+
+// rtfSegment is a piece of the raw document. Text runs can be rewritten;
+// everything else is copied unchanged.
+type rtfSegment struct {
+	raw  string
+	text string // contribution to the extracted text
+	run  bool   // replaceable run of visible text
+	uc   int    // fallback characters after \u in effect for the run
+}
+
+// rtfGroupState is the parser state saved and restored with each group
+type rtfGroupState struct {
+	skip bool // inside a destination whose text is not shown
+	uc   int
+}
+
+// rtfSkippedDestinations are destinations whose content is not document text
+var rtfSkippedDestinations = map[string]bool{
+	"fonttbl": true, "colortbl": true, "stylesheet": true, "info": true,
+	"pict": true, "object": true, "listtable": true, "listoverridetable": true,
+	"rsidtbl": true, "generator": true, "filetbl": true, "revtbl": true,
+	"themedata": true, "colorschememapping": true, "datastore": true,
+	"latentstyles": true, "xmlnstbl": true, "header": true, "footer": true,
+	"headerl": true, "headerr": true, "headerf": true, "footerl": true,
+	"footerr": true, "footerf": true, "footnote": true, "fldinst": true,
+}
+
+// rtfControlText is the text produced by control words that stand for characters
+var rtfControlText = map[string]string{
+	"par": "\n", "line": "\n", "sect": "\n", "row": "\n", "page": "\n",
+	"tab": "\t", "cell": "\t",
+	"emdash": "—", "endash": "–", "bullet": "•",
+	"lquote": "‘", "rquote": "’", "ldblquote": "“", "rdblquote": "”",
+	"emspace": " ", "enspace": " ",
+}
+
+// parseRTF splits RTF content into segments. Consecutive characters, escaped
+// symbols (\\, \{, \}), \'hh bytes and \u characters with their fallbacks form
+// one text run; any other control word or group boundary ends the run.
+func parseRTF(content string) []rtfSegment {
+	var segments []rtfSegment
+	state := rtfGroupState{uc: 1}
+	var stack []rtfGroupState
+	groupStart := false
+
+	// Current text run
+	runStart := -1
+	var runText strings.Builder
+	var pendingHigh rune
+
+	flushRun := func(end int) {
+		if runStart < 0 {
+			return
+		}
+		if pendingHigh != 0 {
+			runText.WriteRune(pendingHigh)
+			pendingHigh = 0
+		}
+		seg := rtfSegment{raw: content[runStart:end], uc: state.uc}
+		if !state.skip {
+			seg.text = runText.String()
+			seg.run = true
+		}
+		segments = append(segments, seg)
+		runStart = -1
+		runText.Reset()
+	}
+	addRunText := func(start int, r rune) {
+		if runStart < 0 {
+			runStart = start
+		}
+		if pendingHigh != 0 {
+			if utf16.IsSurrogate(r) {
+				r = utf16.DecodeRune(pendingHigh, r)
+			} else {
+				runText.WriteRune(pendingHigh)
+			}
+			pendingHigh = 0
+		}
+		if r >= 0xD800 && r < 0xDC00 {
+			pendingHigh = r
+			return
+		}
+		runText.WriteRune(r)
+	}
+	addControl := func(raw, text string) {
+		seg := rtfSegment{raw: raw}
+		if !state.skip {
+			seg.text = text
+		}
+		segments = append(segments, seg)
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == '{':
+			flushRun(i)
+			stack = append(stack, state)
+			groupStart = true
+			addControl("{", "")
+			i++
+
+		case c == '}':
+			flushRun(i)
+			if len(stack) > 0 {
+				state = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+			groupStart = false
+			addControl("}", "")
+			i++
+
+		case c == '\\' && i+1 < len(content):
+			next := content[i+1]
+			switch {
+			case isASCIILetter(next):
+				word, param, hasParam, end := readRTFControlWord(content, i)
+				if word == "u" && hasParam {
+					// Unicode character followed by uc fallback characters
+					r := rune(param)
+					if r < 0 {
+						r += 65536
+					}
+					start := i
+					i = skipRTFFallback(content, end, state.uc)
+					addRunText(start, r)
+					groupStart = false
+					continue
+				}
+				flushRun(i)
+				if groupStart && rtfSkippedDestinations[word] {
+					state.skip = true
+				}
+				if word == "uc" && hasParam {
+					state.uc = param
+				}
+				groupStart = false
+				addControl(content[i:end], rtfControlText[word])
+				i = end
+
+			case next == '\'' && i+3 < len(content) && isHexDigit(content[i+2]) && isHexDigit(content[i+3]):
+				b, _ := strconv.ParseUint(content[i+2:i+4], 16, 8)
+				addRunText(i, decodeWindows1252(byte(b)))
+				groupStart = false
+				i += 4
+
+			case next == '\\' || next == '{' || next == '}':
+				addRunText(i, rune(next))
+				groupStart = false
+				i += 2
+
+			case next == '*':
+				flushRun(i)
+				if groupStart {
+					state.skip = true
+				}
+				addControl(`\*`, "")
+				i += 2
+
+			default:
+				// Control symbols: \~ non-breaking space, \_ non-breaking hyphen, others have no text
+				flushRun(i)
+				text := ""
+				switch next {
+				case '~':
+					text = " "
+				case '_':
+					text = "-"
+				}
+				groupStart = false
+				addControl(content[i:i+2], text)
+				i += 2
+			}
+
+		case c == '\r' || c == '\n':
+			// Line breaks in RTF source are not text; keep them inside the run
+			if runStart < 0 {
+				addControl(content[i:i+1], "")
+			}
+			i++
+
+		default:
+			addRunText(i, rune(c))
+			groupStart = false
+			i++
+		}
+	}
+	flushRun(len(content))
+
+	return segments
+}
+
+// readRTFControlWord reads a control word starting at the backslash at i. It
+// returns the word, its numeric parameter, whether a parameter was present
+// and the index after the word and its optional space delimiter.
+func readRTFControlWord(content string, i int) (string, int, bool, int) {
+	j := i + 1
+	for j < len(content) && isASCIILetter(content[j]) {
+		j++
+	}
+	word := content[i+1 : j]
+
+	paramStart := j
+	if j < len(content) && content[j] == '-' {
+		j++
+	}
+	digitsStart := j
+	for j < len(content) && content[j] >= '0' && content[j] <= '9' {
+		j++
+	}
+	param, hasParam := 0, false
+	if j > digitsStart {
+		param, _ = strconv.Atoi(content[paramStart:j])
+		hasParam = true
+	} else {
+		j = paramStart
+	}
+
+	if j < len(content) && content[j] == ' ' {
+		j++
+	}
+	return word, param, hasParam, j
+}
+
+// isUndelimitedControlWord reports whether raw is a control word, such as
+// \par or \fs24, not followed by the space that may delimit it
+func isUndelimitedControlWord(raw string) bool {
+	return len(raw) > 1 && raw[0] == '\\' && isASCIILetter(raw[1]) && !strings.HasSuffix(raw, " ")
+}
+
+// skipRTFFallback skips the n fallback characters that follow a \u character
+func skipRTFFallback(content string, i, n int) int {
+	for ; n > 0 && i < len(content); n-- {
+		switch {
+		case content[i] == '\\' && i+3 < len(content) && content[i+1] == '\'':
+			i += 4
+		case content[i] == '\\' || content[i] == '{' || content[i] == '}':
+			return i
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// encodeRTFText encodes text for an RTF text run. Non-ASCII characters are
+// written as \uN followed by uc '?' fallback characters, or by a space
+// delimiter when there are none, so a following digit is not read as part of N.
+func encodeRTFText(text string, uc int) string {
+	var b strings.Builder
+	fallback := strings.Repeat("?", uc)
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '{' || r == '}':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\par `)
+		case r == '\t':
+			b.WriteString(`\tab `)
+		case r < 0x80:
+			b.WriteRune(r)
+		default:
+			units := []uint16{uint16(r)}
+			if r > 0xFFFF {
+				high, low := utf16.EncodeRune(r)
+				units = []uint16{uint16(high), uint16(low)}
+			}
+			for _, u := range units {
+				if uc == 0 {
+					fmt.Fprintf(&b, `\u%d `, int16(u))
+				} else {
+					fmt.Fprintf(&b, `\u%d%s`, int16(u), fallback)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// windows1252 maps bytes 0x80-0x9F, which differ from Latin-1, to Unicode
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// decodeWindows1252 converts a Windows-1252 byte to its Unicode character
+func decodeWindows1252(b byte) rune {
+	if b >= 0x80 && b < 0xA0 {
+		return windows1252[b-0x80]
+	}
+	return rune(b)
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,172 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const styledRTF = `{\rtf1\ansi\deff0{\fonttbl{\f0 Arial;}}{\colortbl;\red255\green0\blue0;}
+{\*\generator Test;}\pard\b Version 1.0\b0  is \cf1 ready\cf0 .\par
+Caf\'e9 \u8364? price\par
+{\field{\*\fldinst HYPERLINK "http://example.com"}{\fldrslt Example}}\par
+}`
+
+func writeRTF(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sample.rtf")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRTFDocument_GetText(t *testing.T) {
+	doc, err := OpenRTFDocument(writeRTF(t, styledRTF))
+	if err != nil {
+		t.Fatalf("OpenRTFDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	text, err := doc.GetPlainText()
+	if err != nil {
+		t.Fatalf("GetPlainText() error = %v", err)
+	}
+
+	want := "Version 1.0 is ready.\nCafé € price\nExample"
+	if text != want {
+		t.Errorf("GetPlainText() = %q, want %q", text, want)
+	}
+	for _, hidden := range []string{"Arial", "Test", "HYPERLINK", "red255"} {
+		if strings.Contains(text, hidden) {
+			t.Errorf("text contains destination content %q", hidden)
+		}
+	}
+}
+
+func TestRTFDocument_ReplaceText(t *testing.T) {
+	path := writeRTF(t, styledRTF)
+	doc, err := OpenRTFDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replacements := map[string]string{
+		"Version 1.0": "Version 2.0",
+		"ready":       "shipped",
+		"Café":        "Bistro {new}",
+		"Arial":       "Times", // font table, not text
+	}
+	for old, new := range replacements {
+		if err := doc.ReplaceText(old, new); err != nil {
+			t.Fatalf("ReplaceText(%q) error = %v", old, err)
+		}
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := string(data)
+	for _, want := range []string{`\b Version 2.0\b0`, `\cf1 shipped\cf0`, `Bistro \{new\}`, `{\f0 Arial;}`, `{\colortbl;\red255\green0\blue0;}`} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("saved RTF missing %q:\n%s", want, raw)
+		}
+	}
+
+	reopened, err := OpenRTFDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	text, _ := reopened.GetPlainText()
+	want := "Version 2.0 is shipped.\nBistro {new} € price\nExample"
+	if text != want {
+		t.Errorf("GetPlainText() after replace = %q, want %q", text, want)
+	}
+}
+
+func TestRTFDocument_ReplaceAcrossFormattingNotMatched(t *testing.T) {
+	doc, err := OpenRTFDocument(writeRTF(t, `{\rtf1 Hello \b World\b0\par}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceText("Hello World", "Bye"); err != nil {
+		t.Fatal(err)
+	}
+	if doc.modified {
+		t.Error("text spanning a formatting change should not be replaced")
+	}
+}
+
+func TestRTFDocument_NonASCIIReplacement(t *testing.T) {
+	doc, err := OpenRTFDocument(writeRTF(t, `{\rtf1\uc2 Name\par}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceText("Name", "이름 😀"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(doc.content, `\u-14476??`) {
+		t.Errorf("expected \\uN with uc fallbacks, got %s", doc.content)
+	}
+	text, _ := doc.GetPlainText()
+	if text != "이름 😀" {
+		t.Errorf("GetPlainText() = %q", text)
+	}
+}
+
+func TestRTFDocument_ReplaceAfterUndelimitedControlWord(t *testing.T) {
+	doc, err := OpenRTFDocument(writeRTF(t, `{\rtf1 Intro\par\'e9t\'e9\par\uc0 x\par}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	// "\par" is directly followed by the run, so the replacement must not extend it
+	if err := doc.ReplaceText("été", "summer"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ReplaceText("x", "é1"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(doc.content, `\par summer`) || !strings.Contains(doc.content, `\u233 1`) {
+		t.Errorf("expected delimited control words, got %s", doc.content)
+	}
+	text, _ := doc.GetPlainText()
+	if text != "Intro\nsummer\né1" {
+		t.Errorf("GetPlainText() = %q", text)
+	}
+}
+
+func TestOpenRTFDocument_Invalid(t *testing.T) {
+	if _, err := OpenRTFDocument(writeRTF(t, "plain text")); err == nil {
+		t.Error("expected error for content without {\\rtf header")
+	}
+	if _, err := OpenRTFDocument(filepath.Join(t.TempDir(), "missing.rtf")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestRTFRegistered(t *testing.T) {
+	if !IsSupportedFormat("notes.RTF") {
+		t.Error(".rtf should be a registered format")
+	}
+	doc, err := Open(writeRTF(t, styledRTF))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer doc.Close()
+	if _, ok := doc.(*RTFDocument); !ok {
+		t.Errorf("Open() returned %T, want *RTFDocument", doc)
+	}
+}
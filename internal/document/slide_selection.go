@@ -0,0 +1,132 @@
+package document
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// chartTargetRegex matches chart relationships in a slide's .rels part
+var chartTargetRegex = regexp.MustCompile(`Target="([^"]*charts/chart\d+\.xml)"`)
+
+// ParseSlideRange parses a slide selection such as "1,3-5" into a set of slide numbers
+func ParseSlideRange(spec string) (map[int]bool, error) {
+	slides := make(map[int]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if bounds := strings.SplitN(part, "-", 2); len(bounds) == 2 {
+			start, err1 := strconv.Atoi(strings.TrimSpace(bounds[0]))
+			end, err2 := strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err1 != nil || err2 != nil || start < 1 || end < start {
+				return nil, pkgErrors.NewValidationError("slides", spec, fmt.Sprintf("invalid slide range %q", part))
+			}
+			for i := start; i <= end; i++ {
+				slides[i] = true
+			}
+			continue
+		}
+
+		num, err := strconv.Atoi(part)
+		if err != nil || num < 1 {
+			return nil, pkgErrors.NewValidationError("slides", spec, fmt.Sprintf("invalid slide number %q", part))
+		}
+		slides[num] = true
+	}
+
+	if len(slides) == 0 {
+		return nil, pkgErrors.NewValidationError("slides", spec, "no slides selected")
+	}
+
+	return slides, nil
+}
+
+// MissingSlides returns the selected slide numbers that do not exist in the presentation, sorted
+func MissingSlides(selected map[int]bool, existing []int) []int {
+	present := make(map[int]bool, len(existing))
+	for _, num := range existing {
+		present[num] = true
+	}
+
+	var missing []int
+	for num := range selected {
+		if !present[num] {
+			missing = append(missing, num)
+		}
+	}
+	sort.Ints(missing)
+	return missing
+}
+
+// selectedSlideParts returns the zip entries that belong to the selected slides:
+// the slide parts themselves and any chart parts they reference.
+// A nil selection returns nil, meaning every part is selected.
+func selectedSlideParts(files []*zip.File, slides map[int]bool) (map[string]bool, error) {
+	if slides == nil {
+		return nil, nil
+	}
+
+	parts := make(map[string]bool)
+	for num := range slides {
+		parts[fmt.Sprintf("ppt/slides/slide%d.xml", num)] = true
+	}
+
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, "ppt/slides/_rels/slide") || !strings.HasSuffix(file.Name, ".xml.rels") {
+			continue
+		}
+		num, ok := partNumber(strings.TrimSuffix(file.Name, ".rels"), "ppt/slides/_rels/slide")
+		if !ok || !slides[num] {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+
+		for _, match := range chartTargetRegex.FindAllStringSubmatch(string(content), -1) {
+			// Targets are relative to ppt/slides/
+			parts[path.Clean(path.Join("ppt/slides", match[1]))] = true
+		}
+	}
+
+	return parts, nil
+}
+
+// SelectedSlidesText returns the text of the selected slides and the charts
+// they reference, formatted like GetText: the text ReplaceTextInSlides
+// replaces in. A nil selection returns the text of every slide.
+func (d *PowerPointDocument) SelectedSlidesText(slides map[int]bool) (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	selected, err := selectedSlideParts(d.zipFile.File, slides)
+	if err != nil {
+		return "", err
+	}
+	blocks, err := d.textBlocks(selected)
+	if err != nil {
+		return "", err
+	}
+	var text strings.Builder
+	for _, block := range blocks {
+		text.WriteString(fmt.Sprintf("%s:\n%s\n\n", block.label, block.text))
+	}
+	return checkUTF8(d.path, text.String(), d.sanitizeUTF8)
+}
@@ -0,0 +1,121 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSlideRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "single slide", spec: "2", want: []int{2}},
+		{name: "list and range", spec: "1,3-5", want: []int{1, 3, 4, 5}},
+		{name: "spaces and duplicates", spec: " 1, 1 , 2-3 ", want: []int{1, 2, 3}},
+		{name: "empty", spec: "", wantErr: true},
+		{name: "zero", spec: "0", wantErr: true},
+		{name: "reversed range", spec: "5-3", wantErr: true},
+		{name: "not a number", spec: "a", wantErr: true},
+		{name: "open range", spec: "3-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSlideRange(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSlideRange(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := make(map[int]bool)
+			for _, n := range tt.want {
+				want[n] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseSlideRange(%q) = %v, want %v", tt.spec, got, want)
+			}
+		})
+	}
+}
+
+func TestMissingSlides(t *testing.T) {
+	got := MissingSlides(map[int]bool{1: true, 4: true, 9: true}, []int{1, 2, 3})
+	if !reflect.DeepEqual(got, []int{4, 9}) {
+		t.Errorf("MissingSlides() = %v, want [4 9]", got)
+	}
+	if got := MissingSlides(map[int]bool{2: true}, []int{1, 2}); got != nil {
+		t.Errorf("MissingSlides() = %v, want nil", got)
+	}
+}
+
+func TestPowerPointDocument_ReplaceTextInSlides(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "test.pptx")
+	if err := createTestPowerPoint(testFile); err != nil {
+		t.Fatalf("Failed to create test PowerPoint: %v", err)
+	}
+
+	doc, err := OpenPowerPointDocument(testFile)
+	if err != nil {
+		t.Fatalf("Failed to open PowerPoint: %v", err)
+	}
+	defer doc.Close()
+
+	if got := doc.SlideNumbers(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("SlideNumbers() = %v, want [1 2]", got)
+	}
+
+	// "2023" only appears on slide 2, "Draft" only on slide 1
+	selection := map[int]bool{1: true}
+	if err := doc.ReplaceTextInSlides("Draft", "Final", selection); err != nil {
+		t.Fatalf("ReplaceTextInSlides() error = %v", err)
+	}
+	if err := doc.ReplaceTextInSlides("2023", "2024", selection); err != nil {
+		t.Fatalf("ReplaceTextInSlides() error = %v", err)
+	}
+
+	text, _ := doc.GetText()
+	if !strings.Contains(text, "Status: Final") {
+		t.Error("expected replacement on selected slide 1")
+	}
+	if !strings.Contains(text, "Year: 2023") || strings.Contains(text, "2024") {
+		t.Error("slide 2 should not be modified")
+	}
+}
+
+func TestReplaceTextInSlides_Charts(t *testing.T) {
+	tests := []struct {
+		name      string
+		slides    map[int]bool
+		wantChart bool
+	}{
+		{name: "selected slide includes its chart", slides: map[int]bool{1: true}, wantChart: true},
+		{name: "unselected slide skips its chart", slides: map[int]bool{2: true}, wantChart: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "table_chart.pptx")
+			copyFile(t, "testdata/table_chart.pptx", testFile)
+
+			doc, err := OpenPowerPointDocumentStreaming(testFile, nil)
+			if err != nil {
+				t.Fatalf("Failed to open PowerPoint: %v", err)
+			}
+			count, err := doc.ReplaceTextInSelectedSlidesStreaming("Sales", "Revenue", tt.slides)
+			doc.Close()
+			if err != nil {
+				t.Fatalf("ReplaceTextInSelectedSlidesStreaming() error = %v", err)
+			}
+			// Only the chart title contains "Sales"
+			if (count > 0) != tt.wantChart {
+				t.Errorf("replacement count = %d, want chart replaced = %v", count, tt.wantChart)
+			}
+		})
+	}
+}
@@ -15,6 +15,12 @@ import (
 type StreamingOptions struct {
 	// ChunkSize is the size of each chunk to process (default: 64KB)
 	ChunkSize int
+	// ChunkOverlap is how many bytes at the end of a chunk that was split
+	// because of ChunkSize are repeated at the start of the next one, so text
+	// spanning the split is still seen whole. 0 repeats nothing; callers
+	// searching for patterns should pass them to ProcessTextChunkedOverlapped
+	// or ProcessSlidesChunkedOverlapped, which size the overlap for them.
+	ChunkOverlap int
 	// MaxMemory is the maximum memory to use (default: 100MB)
 	MaxMemory int64
 	// EnableMemoryPool enables memory pool for better performance
@@ -65,6 +71,15 @@ type StreamingWordDocument struct {
 	modified bool
 	closed   bool
 	
+	// includeHidden replaces text in runs hidden with <w:vanish/> too
+	includeHidden bool
+	
+	// parts limits replacement to these parts (nil means all, see SetParts)
+	parts map[string]bool
+	
+	// allParts replaces text runs in every XML part (see SetAllParts)
+	allParts bool
+	
 	// Memory management
 	memPool  *sync.Pool
 	memUsage int64
@@ -88,10 +103,15 @@ func OpenWordDocumentStreaming(path string, opts *StreamingOptions) (*StreamingW
 	}
 	
 	// Check file extension
-	if !strings.HasSuffix(strings.ToLower(path), ".docx") {
+	if !IsWordFile(path) {
 		return nil, fmt.Errorf("not a .docx file: %s", path)
 	}
 	
+	// Encrypted documents are OLE compound files, not zip archives
+	if err := checkNotEncrypted(path); err != nil {
+		return nil, err
+	}
+	
 	// Open file for reading
 	file, err := os.Open(path)
 	if err != nil {
@@ -124,8 +144,23 @@ func OpenWordDocumentStreaming(path string, opts *StreamingOptions) (*StreamingW
 	return doc, nil
 }
 
-// ProcessTextChunked processes document text in chunks to save memory
+// ProcessTextChunked processes document text in chunks to save memory. Each
+// text node is passed separately, split into chunks of about ChunkSize bytes;
+// with ChunkOverlap, a chunk following a split starts with the end of the
+// previous chunk. Without it, text spanning a split is never seen whole, so
+// use ProcessTextChunkedOverlapped to search for patterns.
 func (d *StreamingWordDocument) ProcessTextChunked(processor func(chunk string) error) error {
+	return d.ProcessTextChunkedOverlapped(nil, func(chunk string, carried int) error {
+		return processor(chunk)
+	})
+}
+
+// ProcessTextChunkedOverlapped is ProcessTextChunked for finding patterns:
+// chunks overlap by at least ChunkOverlapFor(patterns...) bytes, so every
+// match is seen whole, and processor gets the number of bytes at the start of
+// each chunk that repeat the end of the previous chunk, so a caller counting
+// matches can skip those that lie entirely within them
+func (d *StreamingWordDocument) ProcessTextChunkedOverlapped(patterns []string, processor func(chunk string, carried int) error) error {
 	if d.closed {
 		return fmt.Errorf("document is closed")
 	}
@@ -155,7 +190,7 @@ func (d *StreamingWordDocument) ProcessTextChunked(processor func(chunk string)
 	
 	// Process XML in streaming mode
 	decoder := xml.NewDecoder(reader)
-	var currentText strings.Builder
+	buffer := newChunkBuffer(d.options, patterns...)
 	inText := false
 	
 	for {
@@ -176,36 +211,26 @@ func (d *StreamingWordDocument) ProcessTextChunked(processor func(chunk string)
 			if element.Name.Local == "t" {
 				inText = false
 				// Process accumulated text
-				if currentText.Len() > 0 {
-					if err := processor(currentText.String()); err != nil {
-						return err
-					}
-					currentText.Reset()
+				if err := buffer.flush(false, processor); err != nil {
+					return err
 				}
 			}
 		case xml.CharData:
 			if inText {
-				currentText.Write(element)
+				buffer.write(element)
 			}
 		}
 		
-		// Check memory usage periodically
-		if currentText.Len() > d.options.ChunkSize {
-			if err := processor(currentText.String()); err != nil {
+		// Check memory usage periodically, keeping the overlap for the next chunk
+		if buffer.full() {
+			if err := buffer.flush(true, processor); err != nil {
 				return err
 			}
-			currentText.Reset()
 		}
 	}
 	
 	// Process any remaining text
-	if currentText.Len() > 0 {
-		if err := processor(currentText.String()); err != nil {
-			return err
-		}
-	}
-	
-	return nil
+	return buffer.flush(false, processor)
 }
 
 // ReplaceTextStreaming replaces text in the document using streaming
@@ -233,13 +258,17 @@ func (d *StreamingWordDocument) ReplaceTextStreaming(oldText, newText string) (i
 	
 	// Process each file in the source zip
 	for _, file := range d.zipFile.File {
-		if file.Name == "word/document.xml" {
-			// Stream and modify this file
+		selected := partSelected(d.parts, wordPartKind(file.Name))
+		if d.allParts {
+			selected = isTextPart(file.Name)
+		}
+		if selected {
+			// Stream and modify the body, header, footer and note parts
 			count, err := d.streamAndModifyXML(file, zipWriter, oldText, newText)
 			if err != nil {
 				zipWriter.Close()
 				tmpFile.Close()
-				return 0, fmt.Errorf("failed to process document.xml: %w", err)
+				return 0, fmt.Errorf("failed to process %s: %w", file.Name, err)
 			}
 			replacementCount += count
 		} else {
@@ -309,66 +338,54 @@ func (d *StreamingWordDocument) streamAndModifyXML(src *zip.File, dst *zip.Write
 	}
 	defer reader.Close()
 	
-	writer, err := dst.Create(src.Name)
+	writer, err := createLike(dst, src)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create destination file: %w", err)
 	}
 	
-	// Use XML decoder/encoder for proper streaming
-	decoder := xml.NewDecoder(reader)
-	encoder := xml.NewEncoder(writer)
-	
 	replacementCount := 0
-	var buffer []byte
 	if d.options.EnableMemoryPool && d.memPool != nil {
-		buffer = d.memPool.Get().([]byte)
+		buffer := d.memPool.Get().([]byte)
 		defer d.memPool.Put(buffer)
 	}
 	
-	// Stream XML tokens
-	for {
-		token, err := decoder.Token()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return replacementCount, fmt.Errorf("XML decode error: %w", err)
-		}
-		
+	// Hidden runs are left alone unless they were opted in
+	var filter *hiddenRunFilter
+	if !d.includeHidden {
+		filter = &hiddenRunFilter{}
+	}
+	
+	// Across all parts, only the text of text elements is replaced
+	stream := streamReplaceFilteredText
+	if d.allParts {
+		stream = streamReplaceTextElements
+	}
+	
+	// Stream tokens, copying everything but modified text verbatim so
+	// namespace declarations and tag forms survive the round trip
+	err = stream(reader, writer, func(original string) string {
 		// Modify text content
-		if charData, ok := token.(xml.CharData); ok {
-			original := string(charData)
-			modified := strings.ReplaceAll(original, oldText, newText)
-			if original != modified {
-				replacementCount += strings.Count(original, oldText)
-				token = xml.CharData(modified)
-			}
-			
-			// Update memory usage tracking (only tracks current chunk size, not cumulative)
-			// This represents the memory used for the current processing buffer
-			d.mu.Lock()
-			// Track the larger of the current chunk or configured chunk size
-			currentChunkSize := len(modified)
-			if currentChunkSize < d.options.ChunkSize {
-				d.memUsage = int64(d.options.ChunkSize)
-			} else {
-				d.memUsage = int64(currentChunkSize)
-			}
-			d.mu.Unlock()
+		modified := strings.ReplaceAll(original, oldText, newText)
+		if original != modified {
+			replacementCount += strings.Count(original, oldText)
 		}
 		
-		// Write token immediately (true streaming)
-		if err := encoder.EncodeToken(token); err != nil {
-			return replacementCount, fmt.Errorf("XML encode error: %w", err)
+		// Update memory usage tracking (only tracks current chunk size, not cumulative)
+		// This represents the memory used for the current processing buffer
+		d.mu.Lock()
+		// Track the larger of the current chunk or configured chunk size
+		currentChunkSize := len(modified)
+		if currentChunkSize < d.options.ChunkSize {
+			d.memUsage = int64(d.options.ChunkSize)
+		} else {
+			d.memUsage = int64(currentChunkSize)
 		}
-	}
-	
-	// Flush encoder
-	if err := encoder.Flush(); err != nil {
-		return replacementCount, fmt.Errorf("failed to flush encoder: %w", err)
-	}
+		d.mu.Unlock()
+		
+		return modified
+	}, filter)
 	
-	return replacementCount, nil
+	return replacementCount, err
 }
 
 // copyZipFile copies a file from source zip to destination zip without modification
@@ -20,6 +20,9 @@ type StreamingPowerPointDocument struct {
 	modified bool
 	closed   bool
 	
+	// allParts replaces text runs in every XML part (see SetAllParts)
+	allParts bool
+	
 	// Memory management
 	memPool  *sync.Pool
 	memUsage int64
@@ -43,10 +46,15 @@ func OpenPowerPointDocumentStreaming(path string, opts *StreamingOptions) (*Stre
 	}
 	
 	// Check file extension
-	if !strings.HasSuffix(strings.ToLower(path), ".pptx") {
+	if !IsPowerPointFile(path) {
 		return nil, fmt.Errorf("not a .pptx file: %s", path)
 	}
 	
+	// Encrypted documents are OLE compound files, not zip archives
+	if err := checkNotEncrypted(path); err != nil {
+		return nil, err
+	}
+	
 	// Open file for reading
 	file, err := os.Open(path)
 	if err != nil {
@@ -79,8 +87,20 @@ func OpenPowerPointDocumentStreaming(path string, opts *StreamingOptions) (*Stre
 	return doc, nil
 }
 
-// ProcessSlidesChunked processes all slides text in chunks
+// ProcessSlidesChunked processes all slides text in chunks. With
+// ChunkOverlap, a chunk following a split starts with the end of the
+// previous chunk; use ProcessSlidesChunkedOverlapped to search for patterns.
 func (d *StreamingPowerPointDocument) ProcessSlidesChunked(processor func(slideNum int, chunk string) error) error {
+	return d.ProcessSlidesChunkedOverlapped(nil, func(slideNum int, chunk string, carried int) error {
+		return processor(slideNum, chunk)
+	})
+}
+
+// ProcessSlidesChunkedOverlapped is ProcessSlidesChunked for finding
+// patterns: chunks overlap by at least ChunkOverlapFor(patterns...) bytes, and
+// processor gets the number of bytes at the start of each chunk that repeat
+// the end of the previous chunk
+func (d *StreamingPowerPointDocument) ProcessSlidesChunkedOverlapped(patterns []string, processor func(slideNum int, chunk string, carried int) error) error {
 	if d.closed {
 		return fmt.Errorf("document is closed")
 	}
@@ -97,7 +117,7 @@ func (d *StreamingPowerPointDocument) ProcessSlidesChunked(processor func(slideN
 	
 	// Process each slide
 	for i, slideFile := range slideFiles {
-		if err := d.processSlideChunked(slideFile, i+1, processor); err != nil {
+		if err := d.processSlideChunked(slideFile, i+1, patterns, processor); err != nil {
 			return fmt.Errorf("error processing slide %d: %w", i+1, err)
 		}
 	}
@@ -106,7 +126,7 @@ func (d *StreamingPowerPointDocument) ProcessSlidesChunked(processor func(slideN
 }
 
 // processSlideChunked processes a single slide in chunks
-func (d *StreamingPowerPointDocument) processSlideChunked(slideFile *zip.File, slideNum int, processor func(int, string) error) error {
+func (d *StreamingPowerPointDocument) processSlideChunked(slideFile *zip.File, slideNum int, patterns []string, processor func(int, string, int) error) error {
 	// Open slide XML
 	rc, err := slideFile.Open()
 	if err != nil {
@@ -119,7 +139,10 @@ func (d *StreamingPowerPointDocument) processSlideChunked(slideFile *zip.File, s
 	
 	// Process XML in streaming mode
 	decoder := xml.NewDecoder(reader)
-	var currentText strings.Builder
+	buffer := newChunkBuffer(d.options, patterns...)
+	emit := func(chunk string, carried int) error {
+		return processor(slideNum, chunk, carried)
+	}
 	inText := false
 	
 	for {
@@ -141,44 +164,45 @@ func (d *StreamingPowerPointDocument) processSlideChunked(slideFile *zip.File, s
 			if element.Name.Local == "t" {
 				inText = false
 				// Process accumulated text
-				if currentText.Len() > 0 {
-					if err := processor(slideNum, currentText.String()); err != nil {
-						return err
-					}
-					currentText.Reset()
+				if err := buffer.flush(false, emit); err != nil {
+					return err
 				}
 			}
 		case xml.CharData:
 			if inText {
-				currentText.Write(element)
+				buffer.write(element)
 			}
 		}
 		
-		// Check chunk size
-		if currentText.Len() > d.options.ChunkSize {
-			if err := processor(slideNum, currentText.String()); err != nil {
+		// Check chunk size, keeping the overlap for the next chunk
+		if buffer.full() {
+			if err := buffer.flush(true, emit); err != nil {
 				return err
 			}
-			currentText.Reset()
 		}
 	}
 	
 	// Process any remaining text
-	if currentText.Len() > 0 {
-		if err := processor(slideNum, currentText.String()); err != nil {
-			return err
-		}
-	}
-	
-	return nil
+	return buffer.flush(false, emit)
 }
 
 // ReplaceTextInSlidesStreaming replaces text in all slides using streaming
 // Returns the number of replacements made
 func (d *StreamingPowerPointDocument) ReplaceTextInSlidesStreaming(oldText, newText string) (int, error) {
+	return d.ReplaceTextInSelectedSlidesStreaming(oldText, newText, nil)
+}
+
+// ReplaceTextInSelectedSlidesStreaming replaces text only on the selected slide numbers
+// and in the charts they reference. A nil selection replaces text on every slide.
+func (d *StreamingPowerPointDocument) ReplaceTextInSelectedSlidesStreaming(oldText, newText string, slides map[int]bool) (int, error) {
 	if d.closed {
 		return 0, fmt.Errorf("document is closed")
 	}
+
+	selected, err := selectedSlideParts(d.zipFile.File, slides)
+	if err != nil {
+		return 0, err
+	}
 	
 	// Create temporary file for output
 	tmpFile, err := os.CreateTemp("", "pptx-stream-*.tmp")
@@ -198,10 +222,12 @@ func (d *StreamingPowerPointDocument) ReplaceTextInSlidesStreaming(oldText, newT
 	
 	// Process each file in the source zip
 	for _, file := range d.zipFile.File {
-		if strings.HasPrefix(file.Name, "ppt/slides/slide") && 
+		if (d.allParts && isTextPart(file.Name)) ||
+		   (((strings.HasPrefix(file.Name, "ppt/slides/slide") && 
 		   strings.HasSuffix(file.Name, ".xml") &&
-		   !strings.Contains(file.Name, "_rels") {
-			// Stream and modify slide files
+		   !strings.Contains(file.Name, "_rels")) || isChartPart(file.Name)) &&
+		   (selected == nil || selected[file.Name])) {
+			// Stream and modify slide and chart files
 			count, err := d.streamAndModifySlide(file, zipWriter, oldText, newText)
 			if err != nil {
 				zipWriter.Close()
@@ -276,66 +302,54 @@ func (d *StreamingPowerPointDocument) streamAndModifySlide(src *zip.File, dst *z
 	}
 	defer reader.Close()
 	
-	writer, err := dst.Create(src.Name)
+	writer, err := createLike(dst, src)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create destination file: %w", err)
 	}
 	
-	// Use XML decoder/encoder for proper streaming
-	decoder := xml.NewDecoder(reader)
-	encoder := xml.NewEncoder(writer)
-	
 	replacementCount := 0
-	var buffer []byte
 	if d.options.EnableMemoryPool && d.memPool != nil {
-		buffer = d.memPool.Get().([]byte)
+		buffer := d.memPool.Get().([]byte)
 		defer d.memPool.Put(buffer)
 	}
 	
-	// Stream XML tokens
-	for {
-		token, err := decoder.Token()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return replacementCount, fmt.Errorf("XML decode error: %w", err)
+	// Across all parts, only the text of text elements is replaced; in
+	// charts, only text and string values, never numbers or formulas
+	stream := streamReplaceText
+	switch {
+	case isChartPart(src.Name):
+		stream = streamReplaceChartText
+	case d.allParts:
+		stream = func(r io.Reader, w io.Writer, replace func(string) string) error {
+			return streamReplaceTextElements(r, w, replace, nil)
 		}
-		
+	}
+	
+	// Stream tokens, copying everything but modified text verbatim so
+	// namespace declarations and tag forms survive the round trip
+	err = stream(reader, writer, func(original string) string {
 		// Modify text content (PowerPoint uses 'a:t' elements for text)
-		if charData, ok := token.(xml.CharData); ok {
-			original := string(charData)
-			modified := strings.ReplaceAll(original, oldText, newText)
-			if original != modified {
-				replacementCount += strings.Count(original, oldText)
-				token = xml.CharData(modified)
-			}
-			
-			// Update memory usage tracking (only tracks current chunk size, not cumulative)
-			// This represents the memory used for the current processing buffer
-			d.mu.Lock()
-			// Track the larger of the current chunk or configured chunk size
-			currentChunkSize := len(modified)
-			if currentChunkSize < d.options.ChunkSize {
-				d.memUsage = int64(d.options.ChunkSize)
-			} else {
-				d.memUsage = int64(currentChunkSize)
-			}
-			d.mu.Unlock()
+		modified := strings.ReplaceAll(original, oldText, newText)
+		if original != modified {
+			replacementCount += strings.Count(original, oldText)
 		}
 		
-		// Write token immediately (true streaming)
-		if err := encoder.EncodeToken(token); err != nil {
-			return replacementCount, fmt.Errorf("XML encode error: %w", err)
+		// Update memory usage tracking (only tracks current chunk size, not cumulative)
+		// This represents the memory used for the current processing buffer
+		d.mu.Lock()
+		// Track the larger of the current chunk or configured chunk size
+		currentChunkSize := len(modified)
+		if currentChunkSize < d.options.ChunkSize {
+			d.memUsage = int64(d.options.ChunkSize)
+		} else {
+			d.memUsage = int64(currentChunkSize)
 		}
-	}
-	
-	// Flush encoder
-	if err := encoder.Flush(); err != nil {
-		return replacementCount, fmt.Errorf("failed to flush encoder: %w", err)
-	}
+		d.mu.Unlock()
+		
+		return modified
+	})
 	
-	return replacementCount, nil
+	return replacementCount, err
 }
 
 // copyZipFile copies a file from source zip to destination zip without modification
@@ -0,0 +1,70 @@
+package document
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// ValidateXML fully decodes every XML part of a Word or PowerPoint package,
+// including relationships and content types, and returns a DocumentError
+// wrapping ErrDocumentCorrupted for the first part that is not well-formed.
+// Opening a document only reads what an operation needs, so a malformed part
+// may otherwise go unnoticed until it breaks a save. Other formats have no
+// XML parts and always pass.
+func ValidateXML(path string) error {
+	if !IsWordFile(path) && !IsPowerPointFile(path) {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return pkgErrors.NewDocumentError(path, ext, "not a valid Office package", pkgErrors.ErrDocumentCorrupted)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		if !isXMLPart(file.Name) {
+			continue
+		}
+		if err := validateXMLPart(file); err != nil {
+			return pkgErrors.NewDocumentError(path, ext,
+				fmt.Sprintf("malformed XML in %s: %v", file.Name, err), pkgErrors.ErrDocumentCorrupted)
+		}
+	}
+	return nil
+}
+
+// isXMLPart reports whether a package entry holds XML
+func isXMLPart(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".xml") || strings.HasSuffix(name, ".rels")
+}
+
+// validateXMLPart reads every token of a part with a strict decoder
+func validateXMLPart(file *zip.File) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(rc)
+	decoder.Strict = true
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package document
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestValidateXML(t *testing.T) {
+	dir := t.TempDir()
+	body := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>Text</w:t></w:r></w:p></w:body></w:document>`
+
+	valid := filepath.Join(dir, "valid.docx")
+	writeZipParts(t, valid, map[string]string{
+		"word/document.xml":            body,
+		"word/_rels/document.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"/>`,
+	})
+	if err := ValidateXML(valid); err != nil {
+		t.Errorf("ValidateXML(valid) error = %v", err)
+	}
+
+	// A malformed part the replacement never reads is still reported
+	broken := filepath.Join(dir, "broken.docx")
+	writeZipParts(t, broken, map[string]string{
+		"word/document.xml": body,
+		"word/settings.xml": "<w:settings>\n<w:zoom w:percent=\"100\">\n</w:settings>",
+	})
+	err := ValidateXML(broken)
+	if !errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
+		t.Fatalf("ValidateXML(broken) error = %v, want ErrDocumentCorrupted", err)
+	}
+	if !strings.Contains(err.Error(), "word/settings.xml") || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("error should name the part and line: %v", err)
+	}
+
+	// The lenient default still opens it
+	doc, err := OpenWordDocument(broken)
+	if err != nil {
+		t.Fatalf("OpenWordDocument(broken) error = %v", err)
+	}
+	doc.Close()
+
+	notZip := filepath.Join(dir, "plain.docx")
+	os.WriteFile(notZip, []byte("not a package"), 0644)
+	if err := ValidateXML(notZip); !errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
+		t.Errorf("ValidateXML(not a zip) error = %v, want ErrDocumentCorrupted", err)
+	}
+
+	if err := ValidateXML(filepath.Join(dir, "notes.rtf")); err != nil {
+		t.Errorf("ValidateXML(rtf) error = %v, want nil", err)
+	}
+}
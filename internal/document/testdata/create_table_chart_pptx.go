@@ -0,0 +1,136 @@
+//go:build ignore
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+)
+
+func main() {
+	createTableChartPresentation()
+}
+
+// createTableChartPresentation writes table_chart.pptx: one slide holding a
+// table and a chart frame, with the chart stored in ppt/charts/chart1.xml.
+func createTableChartPresentation() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="ppt/presentation.xml"/>
+</Relationships>`))
+
+	// Add ppt/_rels/presentation.xml.rels
+	pptRels, _ := w.Create("ppt/_rels/presentation.xml.rels")
+	pptRels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide" Target="slides/slide1.xml"/>
+</Relationships>`))
+
+	// Add ppt/presentation.xml
+	pres, _ := w.Create("ppt/presentation.xml")
+	pres.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:presentation xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<p:sldIdLst>
+<p:sldId id="256" r:id="rId1"/>
+</p:sldIdLst>
+</p:presentation>`))
+
+	// Add ppt/slides/_rels/slide1.xml.rels linking the chart part
+	slideRels, _ := w.Create("ppt/slides/_rels/slide1.xml.rels")
+	slideRels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart" Target="../charts/chart1.xml"/>
+</Relationships>`))
+
+	// Add ppt/slides/slide1.xml with a table and a chart frame
+	slide1, _ := w.Create("ppt/slides/slide1.xml")
+	slide1.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<p:sld xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main">
+<p:cSld>
+<p:spTree>
+<p:sp>
+<p:txBody>
+<a:bodyPr/>
+<a:p><a:r><a:t>Quarterly Report 2023</a:t></a:r></a:p>
+</p:txBody>
+</p:sp>
+<p:graphicFrame>
+<a:graphic>
+<a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/table">
+<a:tbl>
+<a:tblGrid><a:gridCol w="3048000"/><a:gridCol w="3048000"/></a:tblGrid>
+<a:tr h="370840">
+<a:tc><a:txBody><a:bodyPr/><a:p><a:r><a:t>Region</a:t></a:r></a:p></a:txBody><a:tcPr/></a:tc>
+<a:tc><a:txBody><a:bodyPr/><a:p><a:r><a:t>Revenue 2023</a:t></a:r></a:p></a:txBody><a:tcPr/></a:tc>
+</a:tr>
+<a:tr h="370840">
+<a:tc><a:txBody><a:bodyPr/><a:p><a:r><a:t>North</a:t></a:r></a:p></a:txBody><a:tcPr/></a:tc>
+<a:tc><a:txBody><a:bodyPr/><a:p><a:r><a:t>1200</a:t></a:r></a:p></a:txBody><a:tcPr/></a:tc>
+</a:tr>
+</a:tbl>
+</a:graphicData>
+</a:graphic>
+</p:graphicFrame>
+<p:graphicFrame>
+<a:graphic>
+<a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/chart">
+<c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" r:id="rId2"/>
+</a:graphicData>
+</a:graphic>
+</p:graphicFrame>
+</p:spTree>
+</p:cSld>
+</p:sld>`))
+
+	// Add ppt/charts/chart1.xml with a rich-text title, string categories and numeric values
+	chart1, _ := w.Create("ppt/charts/chart1.xml")
+	chart1.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">
+<c:chart>
+<c:title>
+<c:tx>
+<c:rich>
+<a:bodyPr/>
+<a:p><a:r><a:t>Sales 2023</a:t></a:r></a:p>
+</c:rich>
+</c:tx>
+</c:title>
+<c:plotArea>
+<c:barChart>
+<c:ser>
+<c:tx><c:strRef><c:f>Sheet1!$B$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>Revenue 2023</c:v></c:pt></c:strCache></c:strRef></c:tx>
+<c:cat><c:strRef><c:f>Sheet1!$A$2:$A$3</c:f><c:strCache><c:ptCount val="2"/><c:pt idx="0"><c:v>North</c:v></c:pt><c:pt idx="1"><c:v>South</c:v></c:pt></c:strCache></c:strRef></c:cat>
+<c:val><c:numRef><c:f>Sheet1!$B$2:$B$3</c:f><c:numCache><c:ptCount val="2"/><c:pt idx="0"><c:v>1200</c:v></c:pt><c:pt idx="1"><c:v>2023</c:v></c:pt></c:numCache></c:numRef></c:val>
+</c:ser>
+</c:barChart>
+</c:plotArea>
+</c:chart>
+</c:chartSpace>`))
+
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/ppt/presentation.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.presentation.main+xml"/>
+<Override PartName="/ppt/slides/slide1.xml" ContentType="application/vnd.openxmlformats-officedocument.presentationml.slide+xml"/>
+<Override PartName="/ppt/charts/chart1.xml" ContentType="application/vnd.openxmlformats-officedocument.drawingml.chart+xml"/>
+</Types>`))
+
+	w.Close()
+
+	err := os.WriteFile("table_chart.pptx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating table_chart.pptx: %v\n", err)
+	} else {
+		fmt.Println("Created table_chart.pptx")
+	}
+}
@@ -20,6 +20,14 @@ func main() {
 	createEmptyDocx()
 	// Create a unicode .docx file
 	createUnicodeDocx()
+	// Create a Word template (.dotx) with placeholders
+	createSampleDotx()
+	// Create a Word form with content controls
+	createFormDocx()
+	createCommentsDocx()
+	createChangesDocx()
+	createHiddenDocx()
+	createTextBoxDocx()
 }
 
 func createSampleDocx() {
@@ -178,4 +186,296 @@ func createUnicodeDocx() {
 	} else {
 		fmt.Println("Created unicode.docx")
 	}
-}
\ No newline at end of file
+}
+
+func createSampleDotx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with placeholders
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>Dear {{name}},</w:t></w:r></w:p>
+<w:p><w:r><w:t>Thank you for your order of {{date}}.</w:t></w:r></w:p>
+</w:body>
+</w:document>`))
+	
+	// Add [Content_Types].xml declaring the template content type
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.template.main+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("sample.dotx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating sample.dotx: %v\n", err)
+	} else {
+		fmt.Println("Created sample.dotx")
+	}
+}
+
+func createFormDocx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with a filled block-level control, an empty
+	// inline control showing its placeholder and a two-paragraph control
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>Customer form</w:t></w:r></w:p>
+<w:sdt><w:sdtPr><w:alias w:val="Customer name"/><w:tag w:val="customer_name"/><w:id w:val="101"/></w:sdtPr><w:sdtContent><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Jane Doe</w:t></w:r></w:p></w:sdtContent></w:sdt>
+<w:p><w:r><w:t xml:space="preserve">Order date: </w:t></w:r><w:sdt><w:sdtPr><w:alias w:val="Order date"/><w:tag w:val="order_date"/><w:id w:val="102"/><w:showingPlcHdr/></w:sdtPr><w:sdtContent><w:r><w:rPr><w:rStyle w:val="PlaceholderText"/></w:rPr><w:t>Click here to enter a date.</w:t></w:r></w:sdtContent></w:sdt></w:p>
+<w:sdt><w:sdtPr><w:tag w:val="notes"/><w:id w:val="103"/></w:sdtPr><w:sdtContent><w:p><w:r><w:t>Deliver to the back door.</w:t></w:r></w:p><w:p><w:r><w:t>Call on arrival.</w:t></w:r></w:p></w:sdtContent></w:sdt>
+</w:body>
+</w:document>`))
+	
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("form.docx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating form.docx: %v\n", err)
+	} else {
+		fmt.Println("Created form.docx")
+	}
+}
+
+func createCommentsDocx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with a comment spanning two runs, a comment
+	// covering a whole paragraph and a paragraph holding only a reference
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t xml:space="preserve">The project is </w:t></w:r><w:commentRangeStart w:id="0"/><w:r><w:t>on schedule</w:t></w:r><w:commentRangeEnd w:id="0"/><w:r><w:rPr><w:rStyle w:val="CommentReference"/></w:rPr><w:commentReference w:id="0"/></w:r><w:r><w:t>.</w:t></w:r></w:p>
+<w:p><w:commentRangeStart w:id="1"/><w:r><w:t>Budget: 10,000 USD</w:t></w:r><w:commentRangeEnd w:id="1"/><w:r><w:commentReference w:id="1"/></w:r></w:p>
+<w:p><w:r><w:commentReference w:id="2"/></w:r></w:p>
+</w:body>
+</w:document>`))
+	
+	// Add word/comments.xml
+	comments, _ := w.Create("word/comments.xml")
+	comments.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:comment w:id="0" w:author="Kim Reviewer" w:date="2024-05-01T09:30:00Z" w:initials="KR"><w:p><w:r><w:annotationRef/></w:r><w:r><w:t>Is this still true?</w:t></w:r></w:p></w:comment>
+<w:comment w:id="1" w:author="Lee &amp; Partners" w:date="2024-05-02T14:00:00Z"><w:p><w:r><w:t>Check the amount.</w:t></w:r></w:p><w:p><w:r><w:t>Finance has 12,000.</w:t></w:r></w:p></w:comment>
+<w:comment w:id="2" w:author="Kim Reviewer"><w:p><w:r><w:t>Add a summary here.</w:t></w:r></w:p></w:comment>
+</w:comments>`))
+	
+	// Add word/commentsExtended.xml
+	extended, _ := w.Create("word/commentsExtended.xml")
+	extended.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w15:commentsEx xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml"/>`))
+	
+	// Add word/_rels/document.xml.rels
+	docRels, _ := w.Create("word/_rels/document.xml.rels")
+	docRels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="comments.xml"/>
+<Relationship Id="rId2" Type="http://schemas.microsoft.com/office/2011/relationships/commentsExtended" Target="commentsExtended.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`))
+	
+	// Add word/styles.xml
+	styles, _ := w.Create("word/styles.xml")
+	styles.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"/>`))
+	
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/comments.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml"/>
+<Override PartName="/word/commentsExtended.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.commentsExtended+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("comments.docx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating comments.docx: %v\n", err)
+	} else {
+		fmt.Println("Created comments.docx")
+	}
+}
+
+func createChangesDocx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with an insertion next to a deletion, a deleted
+	// paragraph mark, a formatting change, an inserted paragraph and an
+	// inserted table row
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t xml:space="preserve">The quick </w:t></w:r><w:ins w:id="1" w:author="Kim" w:date="2024-05-01T09:30:00Z"><w:r><w:t xml:space="preserve">brown </w:t></w:r></w:ins><w:del w:id="2" w:author="Kim" w:date="2024-05-01T09:30:00Z"><w:r><w:delText xml:space="preserve">red </w:delText></w:r></w:del><w:r><w:t>fox.</w:t></w:r></w:p>
+<w:p><w:pPr><w:rPr><w:del w:id="3" w:author="Kim" w:date="2024-05-01T09:31:00Z"/></w:rPr></w:pPr><w:r><w:t xml:space="preserve">Jumps over </w:t></w:r></w:p>
+<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:t>the dog.</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:b/><w:rPrChange w:id="4" w:author="Lee" w:date="2024-05-02T10:00:00Z"><w:rPr><w:i/></w:rPr></w:rPrChange></w:rPr><w:t>Formatted text</w:t></w:r></w:p>
+<w:p><w:pPr><w:rPr><w:ins w:id="5" w:author="Lee" w:date="2024-05-02T10:01:00Z"/></w:rPr></w:pPr><w:ins w:id="6" w:author="Lee" w:date="2024-05-02T10:01:00Z"><w:r><w:t>Inserted paragraph.</w:t></w:r></w:ins></w:p>
+<w:p><w:r><w:t>End.</w:t></w:r></w:p>
+<w:tbl><w:tblPr><w:tblW w:w="0" w:type="auto"/><w:tblBorders><w:insideH w:val="single"/></w:tblBorders></w:tblPr><w:tblGrid><w:gridCol w:w="4000"/></w:tblGrid>
+<w:tr><w:tc><w:p><w:r><w:t>Row one</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:trPr><w:ins w:id="7" w:author="Lee" w:date="2024-05-02T10:02:00Z"/></w:trPr><w:tc><w:p><w:ins w:id="8" w:author="Lee" w:date="2024-05-02T10:02:00Z"><w:r><w:t>Row two</w:t></w:r></w:ins></w:p></w:tc></w:tr>
+</w:tbl>
+</w:body>
+</w:document>`))
+	
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("changes.docx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating changes.docx: %v\n", err)
+	} else {
+		fmt.Println("Created changes.docx")
+	}
+}
+
+func createHiddenDocx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with a hidden run inside a paragraph, a fully
+	// hidden paragraph, a vanish turned off, a vanish only in the formatting
+	// before a tracked change, and a hidden paragraph mark
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t xml:space="preserve">Status: Draft </w:t></w:r><w:r><w:rPr><w:vanish/></w:rPr><w:t xml:space="preserve">Draft note for reviewers </w:t></w:r><w:r><w:t>end.</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:b/><w:vanish/></w:rPr><w:t>Hidden Draft paragraph</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:vanish w:val="false"/></w:rPr><w:t>Shown Draft text</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:b/><w:rPrChange w:id="1" w:author="Kim" w:date="2024-05-01T09:30:00Z"><w:rPr><w:vanish/></w:rPr></w:rPrChange></w:rPr><w:t>Formerly hidden</w:t></w:r></w:p>
+<w:p><w:pPr><w:rPr><w:vanish/></w:rPr></w:pPr><w:r><w:t>Visible with hidden mark</w:t></w:r></w:p>
+</w:body>
+</w:document>`))
+	
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("hidden.docx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating hidden.docx: %v\n", err)
+	} else {
+		fmt.Println("Created hidden.docx")
+	}
+}
+
+func createTextBoxDocx() {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	
+	// Add _rels/.rels
+	rels, _ := w.Create("_rels/.rels")
+	rels.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`))
+	
+	// Add word/document.xml with a text box anchored mid-paragraph, stored the
+	// way Word saves it: a DrawingML shape plus a VML fallback copy
+	doc, _ := w.Create("word/document.xml")
+	doc.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:wps="http://schemas.microsoft.com/office/word/2010/wordprocessingShape" xmlns:v="urn:schemas-microsoft-com:vml" mc:Ignorable="wps">
+<w:body>
+<w:p><w:r><w:t xml:space="preserve">Intro </w:t></w:r><w:r><mc:AlternateContent><mc:Choice Requires="wps"><w:drawing><wp:anchor><wp:extent cx="1828800" cy="457200"/><wp:docPr id="1" name="Text Box 1"/><a:graphic><a:graphicData uri="http://schemas.microsoft.com/office/word/2010/wordprocessingShape"><wps:wsp><wps:txbx><w:txbxContent><w:p><w:r><w:t>Box says Draft</w:t></w:r></w:p><w:p><w:r><w:t>Second line</w:t></w:r></w:p></w:txbxContent></wps:txbx><wps:bodyPr/></wps:wsp></a:graphicData></a:graphic></wp:anchor></w:drawing></mc:Choice><mc:Fallback><w:pict><v:shape id="Text Box 1" style="width:144pt;height:36pt"><v:textbox><w:txbxContent><w:p><w:r><w:t>Box says Draft</w:t></w:r></w:p><w:p><w:r><w:t>Second line</w:t></w:r></w:p></w:txbxContent></v:textbox></v:shape></w:pict></mc:Fallback></mc:AlternateContent></w:r><w:r><w:t>outro.</w:t></w:r></w:p>
+<w:p><w:r><w:t>Body Draft</w:t></w:r></w:p>
+</w:body>
+</w:document>`))
+	
+	// Add [Content_Types].xml
+	contentTypes, _ := w.Create("[Content_Types].xml")
+	contentTypes.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`))
+	
+	w.Close()
+	
+	err := os.WriteFile("textbox.docx", buf.Bytes(), 0644)
+	if err != nil {
+		fmt.Printf("Error creating textbox.docx: %v\n", err)
+	} else {
+		fmt.Println("Created textbox.docx")
+	}
+}
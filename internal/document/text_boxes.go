@@ -0,0 +1,49 @@
+package document
+
+import (
+	"bytes"
+	"regexp"
+)
+
+var (
+	// fallbackPattern matches the VML copy Word stores next to each DrawingML
+	// text box or shape for older readers
+	fallbackPattern = regexp.MustCompile(`(?s)<mc:Fallback>.*?</mc:Fallback>`)
+	// textBoxPattern matches the paragraphs inside a text box or shape
+	textBoxPattern = regexp.MustCompile(`(?s)<w:txbxContent>(.*?)</w:txbxContent>`)
+)
+
+// flattenTextBoxes prepares Word XML for paragraph extraction. Text box
+// paragraphs are nested inside a run of the paragraph that anchors them, so
+// they are moved after that paragraph, and the duplicate VML fallback copy of
+// each text box is dropped. The result is only meant for reading text.
+func flattenTextBoxes(data []byte) []byte {
+	if !bytes.Contains(data, []byte("<w:txbxContent>")) {
+		return data
+	}
+	data = fallbackPattern.ReplaceAll(data, nil)
+
+	for {
+		loc := textBoxPattern.FindSubmatchIndex(data)
+		if loc == nil {
+			return data
+		}
+		content := append([]byte(nil), data[loc[2]:loc[3]]...)
+
+		// Remove the text box, then reinsert its paragraphs after the
+		// paragraph that contained it
+		rest := data[loc[1]:]
+		insertAt := len(rest)
+		if end := bytes.Index(rest, []byte("</w:p>")); end >= 0 {
+			insertAt = end + len("</w:p>")
+		}
+
+		var buf bytes.Buffer
+		buf.Grow(len(data))
+		buf.Write(data[:loc[0]])
+		buf.Write(rest[:insertAt])
+		buf.Write(content)
+		buf.Write(rest[insertAt:])
+		data = buf.Bytes()
+	}
+}
@@ -0,0 +1,86 @@
+package document
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDocument_GetTextIncludesTextBoxes(t *testing.T) {
+	doc, err := OpenWordDocument("testdata/textbox.docx")
+	if err != nil {
+		t.Fatalf("OpenWordDocument() error = %v", err)
+	}
+	defer doc.Close()
+
+	// Text box paragraphs follow their anchor paragraph and appear once,
+	// although Word stores a second VML copy of the text box
+	want := []string{"Intro outro.", "Box says Draft", "Second line", "Body Draft"}
+	if got := doc.GetTextParagraphs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTextParagraphs() = %q, want %q", got, want)
+	}
+}
+
+func TestWordDocument_ReplaceTextInTextBoxes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "textbox.docx")
+	copyFile(t, "testdata/textbox.docx", path)
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.ReplaceText("Draft", "Final"); err != nil {
+		t.Fatalf("ReplaceText() error = %v", err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	doc.Close()
+
+	xml := readZipEntry(t, path, "word/document.xml")
+	// Both the DrawingML text box and its VML fallback are updated
+	if got := strings.Count(xml, "Box says Final"); got != 2 {
+		t.Errorf("text box replaced %d times, want 2 (shape and fallback)", got)
+	}
+	if strings.Contains(xml, "Draft") {
+		t.Error("Draft remains in document.xml")
+	}
+}
+
+func TestStreamingWordDocument_ReplaceTextInTextBoxes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "textbox.docx")
+	copyFile(t, "testdata/textbox.docx", path)
+
+	doc, err := OpenWordDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := doc.ReplaceTextStreaming("Draft", "Final"); err != nil {
+		doc.Close()
+		t.Fatalf("ReplaceTextStreaming() error = %v", err)
+	}
+	doc.Close()
+
+	text := documentText(t, path)
+	if !strings.Contains(text, "Box says Final") || !strings.Contains(text, "Body Final") {
+		t.Errorf("replacement missing from text: %q", text)
+	}
+	if xml := readZipEntry(t, path, "word/document.xml"); strings.Contains(xml, "Draft") {
+		t.Error("Draft remains in document.xml")
+	}
+}
+
+func TestFlattenTextBoxes(t *testing.T) {
+	// Without a text box the XML is returned unchanged
+	plain := []byte(`<w:p><w:r><w:t>a</w:t></w:r></w:p>`)
+	if got := flattenTextBoxes(plain); string(got) != string(plain) {
+		t.Errorf("flattenTextBoxes() changed XML without text boxes: %s", got)
+	}
+
+	xml := `<w:p><w:r><w:t>a</w:t></w:r><w:r><w:drawing><w:txbxContent><w:p><w:r><w:t>box</w:t></w:r></w:p></w:txbxContent></w:drawing></w:r><w:r><w:t>b</w:t></w:r></w:p><w:p><w:r><w:t>c</w:t></w:r></w:p>`
+	want := `<w:p><w:r><w:t>a</w:t></w:r><w:r><w:drawing></w:drawing></w:r><w:r><w:t>b</w:t></w:r></w:p><w:p><w:r><w:t>box</w:t></w:r></w:p><w:p><w:r><w:t>c</w:t></w:r></w:p>`
+	if got := string(flattenTextBoxes([]byte(xml))); got != want {
+		t.Errorf("flattenTextBoxes() =\n%s\nwant\n%s", got, want)
+	}
+}
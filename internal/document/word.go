@@ -12,15 +12,22 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 )
 
 // WordDocument represents an open Word document
 type WordDocument struct {
-	path     string
-	zipFile  *zip.Reader
-	content  *documentContent
-	modified bool
-	closed   bool
+	path          string
+	zipFile       *zip.Reader
+	content       *documentContent
+	metadataParts map[string][]byte // stripped docProps parts pending save
+	commentParts  map[string][]byte // rewritten parts, or nil for removed parts, pending save
+	includeHidden bool              // include runs hidden with <w:vanish/> in GetText and ReplaceText
+	parts         map[string]bool   // parts ReplaceText changes (nil means all, see SetParts)
+	sanitizeUTF8  bool              // replace invalid UTF-8 in GetText instead of failing
+	modified      bool
+	closed        bool
 }
 
 // documentContent holds the parsed document.xml content
@@ -47,6 +54,19 @@ type text struct {
 	Value string `xml:",chardata"`
 }
 
+func init() {
+	// Templates open like documents and keep their template content type
+	for _, ext := range []string{".docx", ".dotx"} {
+		RegisterFormat(ext, func(path string) (Document, error) {
+			doc, err := OpenWordDocument(path)
+			if err != nil {
+				return nil, err
+			}
+			return doc, nil
+		})
+	}
+}
+
 // OpenWordDocument opens a Word document for reading and editing
 func OpenWordDocument(path string) (*WordDocument, error) {
 	// Check if file exists
@@ -55,7 +75,7 @@ func OpenWordDocument(path string) (*WordDocument, error) {
 	}
 	
 	// Check file extension
-	if !strings.HasSuffix(strings.ToLower(path), ".docx") {
+	if !IsWordFile(path) {
 		return nil, fmt.Errorf("not a .docx file: %s", path)
 	}
 	
@@ -65,6 +85,11 @@ func OpenWordDocument(path string) (*WordDocument, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 	
+	// Encrypted documents are OLE compound files, not zip archives
+	if isCompoundBinary(data) {
+		return nil, pkgErrors.NewPasswordProtectedError(path)
+	}
+	
 	// Open as zip
 	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
 	if err != nil {
@@ -118,7 +143,17 @@ func (w *WordDocument) GetText() (string, error) {
 	}
 	
 	paragraphs := w.GetTextParagraphs()
-	return strings.Join(paragraphs, "\n"), nil
+	return checkUTF8(w.path, strings.Join(paragraphs, "\n"), w.sanitizeUTF8)
+}
+
+// GetPlainText returns the document text as a single block, one paragraph
+// per line, without surrounding whitespace
+func (w *WordDocument) GetPlainText() (string, error) {
+	text, err := w.GetText()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text), nil
 }
 
 // GetTextParagraphs returns text content as separate paragraphs
@@ -127,6 +162,11 @@ func (w *WordDocument) GetTextParagraphs() []string {
 		return nil
 	}
 	
+	return w.paragraphsFromXML(w.content.rawXML)
+}
+
+// paragraphsFromXML extracts the non-empty paragraphs of a part's XML
+func (w *WordDocument) paragraphsFromXML(xmlContent []byte) []string {
 	// Parse the raw XML to extract text
 	// For simplicity, we'll use regex to extract text from <w:t> tags
 	var paragraphs []string
@@ -135,7 +175,11 @@ func (w *WordDocument) GetTextParagraphs() []string {
 	paraPattern := regexp.MustCompile(`<w:p[^>]*>.*?</w:p>`)
 	textPattern := regexp.MustCompile(`<w:t[^>]*>([^<]*)</w:t>`)
 	
-	paras := paraPattern.FindAllString(string(w.content.rawXML), -1)
+	if !w.includeHidden {
+		xmlContent = visibleXML(xmlContent)
+	}
+	xmlContent = flattenTextBoxes(xmlContent)
+	paras := paraPattern.FindAllString(string(xmlContent), -1)
 	
 	for _, para := range paras {
 		matches := textPattern.FindAllStringSubmatch(para, -1)
@@ -164,45 +208,70 @@ func escapeXMLString(s string) string {
 
 // ReplaceText replaces all occurrences of old text with new text
 func (w *WordDocument) ReplaceText(old, new string) error {
+	_, err := w.ReplaceTextCount(old, new)
+	return err
+}
+
+// ReplaceTextCount replaces all occurrences of old text with new text, like
+// ReplaceText, and returns the number replaced
+func (w *WordDocument) ReplaceTextCount(old, new string) (int, error) {
 	if w.closed {
-		return errors.New("document is closed")
+		return 0, errors.New("document is closed")
 	}
 	
 	if old == "" {
-		return errors.New("old text cannot be empty")
+		return 0, errors.New("old text cannot be empty")
 	}
 	
 	// Escape the new text to prevent XML injection
 	newEscaped := escapeXMLString(new)
 	
+	count := 0
+	if partSelected(w.parts, WordPartBody) {
+		xmlStr, replaced := w.replaceTextNodes(string(w.content.rawXML), old, newEscaped)
+		if replaced > 0 {
+			w.content.rawXML = []byte(xmlStr)
+			w.modified = true
+			count += replaced
+		}
+	}
+	
+	replaced, err := w.replaceInStoryParts(old, newEscaped)
+	return count + replaced, err
+}
+
+// replaceTextNodes replaces old with the escaped new text inside the text
+// nodes of a part's XML, skipping hidden runs unless they are included, and
+// returns the number of occurrences replaced
+func (w *WordDocument) replaceTextNodes(xmlStr, old, newEscaped string) (string, int) {
 	// Replace in raw XML
 	// We need to be careful to only replace text content, not XML tags
-	xmlStr := string(w.content.rawXML)
-	
 	// Use a more sophisticated approach to replace only within text nodes
-	textPattern := regexp.MustCompile(`(<w:t[^>]*>)([^<]*)(</w:t>)`)
-	
-	replaced := false
-	xmlStr = textPattern.ReplaceAllStringFunc(xmlStr, func(match string) string {
-		submatches := textPattern.FindStringSubmatch(match)
-		if len(submatches) == 4 {
-			textContent := submatches[2]
-			if strings.Contains(textContent, old) {
-				replaced = true
-				// Note: old text is not escaped as we're searching for it as-is in the document
-				newContent := strings.ReplaceAll(textContent, old, newEscaped)
-				return submatches[1] + newContent + submatches[3]
-			}
-		}
-		return match
-	})
+	textPattern := wordTextNodePattern
 	
-	if replaced {
-		w.content.rawXML = []byte(xmlStr)
-		w.modified = true
+	replaced := 0
+	replaceNodes := func(xmlStr string) string {
+		return textPattern.ReplaceAllStringFunc(xmlStr, func(match string) string {
+			submatches := textPattern.FindStringSubmatch(match)
+			if len(submatches) == 4 {
+				textContent := submatches[2]
+				if strings.Contains(textContent, old) {
+					replaced += strings.Count(textContent, old)
+					// Note: old text is not escaped as we're searching for it as-is in the document
+					newContent := strings.ReplaceAll(textContent, old, newEscaped)
+					return submatches[1] + newContent + submatches[3]
+				}
+			}
+			return match
+		})
+	}
+	if w.includeHidden {
+		xmlStr = replaceNodes(xmlStr)
+	} else {
+		xmlStr = mapVisibleXML(xmlStr, replaceNodes)
 	}
 	
-	return nil
+	return xmlStr, replaced
 }
 
 // SaveAs saves the document to a new file
@@ -216,8 +285,14 @@ func (w *WordDocument) SaveAs(path string) error {
 	}
 	
 	// Check file extension
-	if !strings.HasSuffix(strings.ToLower(path), ".docx") {
-		return fmt.Errorf("output file must have .docx extension")
+	if !IsWordFile(path) {
+		return fmt.Errorf("output file must have .docx or .dotx extension")
+	}
+	
+	// Declare the main part as a document or template to match the extension
+	contentTypes, retyped, err := retypedContentTypes(w.zipFile.File, path, wordDocumentContentType, wordTemplateContentType)
+	if err != nil {
+		return err
 	}
 	
 	// Create directory if needed
@@ -230,35 +305,28 @@ func (w *WordDocument) SaveAs(path string) error {
 	buf := new(bytes.Buffer)
 	zipWriter := zip.NewWriter(buf)
 	
-	// Copy all files from original, replacing document.xml if modified
-	for _, file := range w.zipFile.File {
-		var data []byte
-		
-		if file.Name == "word/document.xml" && w.modified {
-			// Use modified content
-			data = w.content.rawXML
-		} else {
-			// Copy original file
-			rc, err := file.Open()
-			if err != nil {
-				return fmt.Errorf("failed to open file in zip: %w", err)
-			}
-			data, err = io.ReadAll(rc)
-			rc.Close()
-			if err != nil {
-				return fmt.Errorf("failed to read file in zip: %w", err)
-			}
-		}
-		
-		// Write to new zip
-		writer, err := zipWriter.Create(file.Name)
-		if err != nil {
-			return fmt.Errorf("failed to create file in zip: %w", err)
-		}
-		
-		if _, err := writer.Write(data); err != nil {
-			return fmt.Errorf("failed to write file in zip: %w", err)
+	// Collect the parts whose content changed, in increasing precedence;
+	// every other entry is copied byte-for-byte without recompression
+	overrides := make(map[string][]byte)
+	if w.modified {
+		overrides["word/document.xml"] = w.content.rawXML
+	}
+	for name, data := range w.metadataParts {
+		// Use stripped document properties
+		overrides[name] = data
+	}
+	if retyped {
+		overrides[contentTypesPart] = contentTypes
+	}
+	for name, edited := range w.commentParts {
+		if edited != nil && name == contentTypesPart {
+			edited, _ = retypeContentTypes(edited, path, wordDocumentContentType, wordTemplateContentType)
 		}
+		overrides[name] = edited // nil removes the part
+	}
+	
+	if err := writePackage(zipWriter, w.zipFile.File, overrides); err != nil {
+		return err
 	}
 	
 	// Close zip writer
@@ -266,14 +334,38 @@ func (w *WordDocument) SaveAs(path string) error {
 		return fmt.Errorf("failed to close zip writer: %w", err)
 	}
 	
-	// Write to file
-	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+	// Write to a temporary file and rename it, so a failed save cannot
+	// leave a truncated document in place of the original
+	if err := writeFileAtomic(path, buf.Bytes(), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 	
 	return nil
 }
 
+// GetMetadata returns the document properties, reflecting a pending StripMetadata
+func (w *WordDocument) GetMetadata() (Metadata, error) {
+	if w.closed {
+		return Metadata{}, errors.New("document is closed")
+	}
+	return readMetadata(w.zipFile.File, w.metadataParts)
+}
+
+// StripMetadata blanks author, company and other identifying properties.
+// The change is written on the next Save or SaveAs.
+func (w *WordDocument) StripMetadata() error {
+	if w.closed {
+		return errors.New("document is closed")
+	}
+
+	stripped, err := strippedMetadataParts(w.zipFile.File)
+	if err != nil {
+		return err
+	}
+	w.metadataParts = stripped
+	return nil
+}
+
 // Save saves changes to the original file
 func (w *WordDocument) Save() error {
 	if w.closed {
@@ -0,0 +1,176 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// Word document parts that text replacement can be limited to
+const (
+	// WordPartBody is the main document text, word/document.xml
+	WordPartBody = "body"
+	// WordPartHeaders are the page headers, word/header*.xml
+	WordPartHeaders = "headers"
+	// WordPartFooters are the page footers, word/footer*.xml
+	WordPartFooters = "footers"
+	// WordPartFootnotes are the footnotes and endnotes, word/footnotes.xml
+	// and word/endnotes.xml
+	WordPartFootnotes = "footnotes"
+)
+
+// WordParts lists the selectable Word parts in the order their text is read
+var WordParts = []string{WordPartBody, WordPartHeaders, WordPartFooters, WordPartFootnotes}
+
+var (
+	headerPartRegex = regexp.MustCompile(`^word/header\d*\.xml$`)
+	footerPartRegex = regexp.MustCompile(`^word/footer\d*\.xml$`)
+)
+
+// ParseWordParts parses a part selection such as "body,footers" into a set
+// of part names. Singular names ("header", "footnote") and "endnotes" are
+// accepted; "all" selects every part.
+func ParseWordParts(spec string) (map[string]bool, error) {
+	parts := make(map[string]bool)
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "all":
+			for _, part := range WordParts {
+				parts[part] = true
+			}
+		case "body", "document":
+			parts[WordPartBody] = true
+		case "headers", "header":
+			parts[WordPartHeaders] = true
+		case "footers", "footer":
+			parts[WordPartFooters] = true
+		case "footnotes", "footnote", "endnotes", "endnote", "notes":
+			parts[WordPartFootnotes] = true
+		default:
+			return nil, pkgErrors.NewValidationError("parts", spec,
+				fmt.Sprintf("unknown part %q (supported: %s)", name, strings.Join(WordParts, ", ")))
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil, pkgErrors.NewValidationError("parts", spec, "no parts selected")
+	}
+
+	return parts, nil
+}
+
+// wordPartKind returns the selectable part a package entry belongs to, or ""
+// for entries that hold no replaceable text
+func wordPartKind(name string) string {
+	switch {
+	case name == "word/document.xml":
+		return WordPartBody
+	case headerPartRegex.MatchString(name):
+		return WordPartHeaders
+	case footerPartRegex.MatchString(name):
+		return WordPartFooters
+	case name == "word/footnotes.xml" || name == "word/endnotes.xml":
+		return WordPartFootnotes
+	default:
+		return ""
+	}
+}
+
+// partSelected reports whether a part is selected; nil selects all parts
+func partSelected(parts map[string]bool, kind string) bool {
+	return kind != "" && (parts == nil || parts[kind])
+}
+
+// SetParts limits ReplaceText and SelectedText to the given parts (see
+// ParseWordParts); nil, the default, selects every part
+func (w *WordDocument) SetParts(parts map[string]bool) {
+	w.parts = parts
+}
+
+// SetParts limits ReplaceTextStreaming to the given parts; nil, the
+// default, selects every part
+func (d *StreamingWordDocument) SetParts(parts map[string]bool) {
+	d.parts = parts
+}
+
+// storyPartNames returns the header, footer and note parts of the package
+// that the selection includes, sorted by part then name
+func (w *WordDocument) storyPartNames() []string {
+	var names []string
+	for _, file := range w.zipFile.File {
+		if kind := wordPartKind(file.Name); kind != WordPartBody && partSelected(w.parts, kind) {
+			names = append(names, file.Name)
+		}
+	}
+	order := make(map[string]int, len(WordParts))
+	for i, part := range WordParts {
+		order[part] = i
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ki, kj := order[wordPartKind(names[i])], order[wordPartKind(names[j])]
+		if ki != kj {
+			return ki < kj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// storyPartXML returns the XML of a header, footer or note part, including
+// edits pending save
+func (w *WordDocument) storyPartXML(name string) ([]byte, error) {
+	if edited, ok := w.commentParts[name]; ok {
+		return edited, nil
+	}
+	return readZipPart(w.zipFile.File, name)
+}
+
+// replaceInStoryParts replaces text in the selected header, footer and note
+// parts, keeping the edited XML until the document is saved, and returns the
+// number of occurrences replaced
+func (w *WordDocument) replaceInStoryParts(old, newEscaped string) (int, error) {
+	count := 0
+	for _, name := range w.storyPartNames() {
+		data, err := w.storyPartXML(name)
+		if err != nil {
+			return count, err
+		}
+		if xmlStr, replaced := w.replaceTextNodes(string(data), old, newEscaped); replaced > 0 {
+			if w.commentParts == nil {
+				w.commentParts = make(map[string][]byte)
+			}
+			w.commentParts[name] = []byte(xmlStr)
+			count += replaced
+		}
+	}
+	return count, nil
+}
+
+// SelectedText returns the text of the selected parts: the body, as
+// GetText returns it, followed by headers, footers and notes, one paragraph
+// per line
+func (w *WordDocument) SelectedText() (string, error) {
+	if w.closed {
+		return "", fmt.Errorf("document is closed")
+	}
+
+	var paragraphs []string
+	if partSelected(w.parts, WordPartBody) {
+		paragraphs = w.GetTextParagraphs()
+	}
+	for _, name := range w.storyPartNames() {
+		data, err := w.storyPartXML(name)
+		if err != nil {
+			return "", err
+		}
+		paragraphs = append(paragraphs, w.paragraphsFromXML(data)...)
+	}
+	return checkUTF8(w.path, strings.Join(paragraphs, "\n"), w.sanitizeUTF8)
+}
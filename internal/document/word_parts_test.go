@@ -0,0 +1,160 @@
+package document
+
+import (
+	"archive/zip"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeDocxWithStoryParts writes a document whose body, header, footer and
+// footnotes each mention "(c) 2024"
+func writeDocxWithStoryParts(t *testing.T) string {
+	t.Helper()
+
+	story := func(root, text string) string {
+		return `<w:` + root + ` xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+			`<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:` + root + `>`
+	}
+	parts := map[string]string{
+		"word/document.xml":  story("document", "Body (c) 2024"),
+		"word/header1.xml":   story("hdr", "Header (c) 2024"),
+		"word/footer1.xml":   story("ftr", "Footer (c) 2024"),
+		"word/footer2.xml":   story("ftr", "First page footer (c) 2024"),
+		"word/footnotes.xml": story("footnotes", "Note (c) 2024"),
+		"word/styles.xml":    story("styles", "(c) 2024"),
+	}
+
+	path := filepath.Join(t.TempDir(), "story.docx")
+	writeZipParts(t, path, parts)
+	return path
+}
+
+// readZipText returns the content of a part of the package at path
+func readZipText(t *testing.T, path, name string) string {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	data, err := readZipPart(reader.File, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseWordParts(t *testing.T) {
+	parts, err := ParseWordParts("Body, footer,endnotes")
+	if err != nil {
+		t.Fatalf("ParseWordParts failed: %v", err)
+	}
+	want := map[string]bool{WordPartBody: true, WordPartFooters: true, WordPartFootnotes: true}
+	if !reflect.DeepEqual(parts, want) {
+		t.Errorf("ParseWordParts() = %v, want %v", parts, want)
+	}
+
+	if parts, _ := ParseWordParts("all"); len(parts) != len(WordParts) {
+		t.Errorf("ParseWordParts(all) = %v", parts)
+	}
+	for _, spec := range []string{"", " , ", "body,comments"} {
+		if _, err := ParseWordParts(spec); err == nil {
+			t.Errorf("ParseWordParts(%q) should fail", spec)
+		}
+	}
+}
+
+func TestWordDocument_ReplaceTextParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		parts   map[string]bool
+		changed []string
+	}{
+		{"all parts by default", nil, []string{"word/document.xml", "word/header1.xml", "word/footer1.xml", "word/footer2.xml", "word/footnotes.xml"}},
+		{"footers only", map[string]bool{WordPartFooters: true}, []string{"word/footer1.xml", "word/footer2.xml"}},
+		{"body and notes", map[string]bool{WordPartBody: true, WordPartFootnotes: true}, []string{"word/document.xml", "word/footnotes.xml"}},
+	}
+
+	allParts := []string{"word/document.xml", "word/header1.xml", "word/footer1.xml", "word/footer2.xml", "word/footnotes.xml", "word/styles.xml"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeDocxWithStoryParts(t)
+			doc, err := OpenWordDocument(path)
+			if err != nil {
+				t.Fatalf("OpenWordDocument failed: %v", err)
+			}
+			doc.SetParts(tt.parts)
+			if err := doc.ReplaceText("2024", "2025"); err != nil {
+				t.Fatalf("ReplaceText failed: %v", err)
+			}
+			if err := doc.Save(); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			doc.Close()
+
+			changed := make(map[string]bool)
+			for _, name := range tt.changed {
+				changed[name] = true
+			}
+			for _, name := range allParts {
+				got := strings.Contains(readZipText(t, path, name), "2025")
+				if got != changed[name] {
+					t.Errorf("%s replaced = %v, want %v", name, got, changed[name])
+				}
+			}
+		})
+	}
+}
+
+func TestWordDocument_SelectedText(t *testing.T) {
+	doc, err := OpenWordDocument(writeDocxWithStoryParts(t))
+	if err != nil {
+		t.Fatalf("OpenWordDocument failed: %v", err)
+	}
+	defer doc.Close()
+
+	text, err := doc.SelectedText()
+	if err != nil {
+		t.Fatalf("SelectedText failed: %v", err)
+	}
+	want := "Body (c) 2024\nHeader (c) 2024\nFooter (c) 2024\nFirst page footer (c) 2024\nNote (c) 2024"
+	if text != want {
+		t.Errorf("SelectedText() = %q, want %q", text, want)
+	}
+
+	doc.SetParts(map[string]bool{WordPartHeaders: true})
+	if text, _ := doc.SelectedText(); text != "Header (c) 2024" {
+		t.Errorf("SelectedText(headers) = %q", text)
+	}
+	if body, _ := doc.GetText(); body != "Body (c) 2024" {
+		t.Errorf("GetText() = %q, want only the body", body)
+	}
+}
+
+func TestStreamingWordDocument_ReplaceTextParts(t *testing.T) {
+	path := writeDocxWithStoryParts(t)
+	doc, err := OpenWordDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatalf("OpenWordDocumentStreaming failed: %v", err)
+	}
+	doc.SetParts(map[string]bool{WordPartHeaders: true, WordPartFooters: true})
+	count, err := doc.ReplaceTextStreaming("2024", "2025")
+	doc.Close()
+	if err != nil {
+		t.Fatalf("ReplaceTextStreaming failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+	for name, want := range map[string]bool{
+		"word/document.xml": false, "word/header1.xml": true, "word/footer1.xml": true,
+		"word/footer2.xml": true, "word/footnotes.xml": false, "word/styles.xml": false,
+	} {
+		if got := strings.Contains(readZipText(t, path, name), "2025"); got != want {
+			t.Errorf("%s replaced = %v, want %v", name, got, want)
+		}
+	}
+}
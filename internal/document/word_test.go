@@ -1,10 +1,14 @@
 package document
 
 import (
+	"archive/zip"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
 )
 
 func TestOpenWordDocument(t *testing.T) {
@@ -406,6 +410,76 @@ func TestWordDocument_Save(t *testing.T) {
 	}
 }
 
+func TestWordDocument_SaveInterrupted(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test.docx")
+	copyFile(t, "testdata/sample.docx", testFile)
+	if err := os.Chmod(testFile, 0640); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	doc, err := OpenWordDocument(testFile)
+	if err != nil {
+		t.Fatalf("Failed to open test document: %v", err)
+	}
+	defer doc.Close()
+	if err := doc.ReplaceText("sample", "modified"); err != nil {
+		t.Fatalf("Failed to replace text: %v", err)
+	}
+	
+	// Fail after half of the document has been written
+	errDiskFull := errors.New("no space left on device")
+	defer func(write func(*os.File, []byte) error) { writeTempData = write }(writeTempData)
+	writeTempData = func(f *os.File, data []byte) error {
+		f.Write(data[:len(data)/2])
+		return errDiskFull
+	}
+	
+	if err := doc.Save(); !errors.Is(err, errDiskFull) {
+		t.Fatalf("Save() error = %v, want the write error", err)
+	}
+	
+	after, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(original) {
+		t.Error("interrupted Save() changed the original file")
+	}
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+	
+	// A save that completes replaces the file and keeps its permissions
+	writeTempData = func(f *os.File, data []byte) error {
+		_, err := f.Write(data)
+		return err
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	info, err := os.Stat(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("permissions = %v, want 0640", info.Mode().Perm())
+	}
+	saved, err := OpenWordDocument(testFile)
+	if err != nil {
+		t.Fatalf("Failed to re-open document: %v", err)
+	}
+	defer saved.Close()
+	if text, _ := saved.GetText(); !strings.Contains(text, "modified") {
+		t.Error("Save() did not persist changes")
+	}
+}
+
 func TestWordDocument_Close(t *testing.T) {
 	doc, err := OpenWordDocument("testdata/sample.docx")
 	if err != nil {
@@ -446,6 +520,31 @@ func findSubstring(s, substr string) int {
 	return -1
 }
 
+// writeZipParts writes a package at path made of the given parts, for tests
+// that build their documents part by part
+func writeZipParts(t *testing.T, path string, parts map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range parts {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func copyFile(t *testing.T, src, dst string) {
 	t.Helper()
 	
@@ -458,4 +557,56 @@ func copyFile(t *testing.T, src, dst string) {
 	if err != nil {
 		t.Fatalf("Failed to write destination file: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func TestOpenEncryptedDocument(t *testing.T) {
+	// Encrypted OOXML files are OLE compound files starting with this signature
+	header := []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	data := append(header, make([]byte, 504)...)
+
+	tempDir := t.TempDir()
+	docxPath := filepath.Join(tempDir, "encrypted.docx")
+	pptxPath := filepath.Join(tempDir, "encrypted.pptx")
+	for _, path := range []string{docxPath, pptxPath} {
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("Failed to write encrypted fixture: %v", err)
+		}
+	}
+
+	openers := map[string]func() error{
+		"word": func() error {
+			_, err := OpenWordDocument(docxPath)
+			return err
+		},
+		"powerpoint": func() error {
+			_, err := OpenPowerPointDocument(pptxPath)
+			return err
+		},
+		"word streaming": func() error {
+			_, err := OpenWordDocumentStreaming(docxPath, nil)
+			return err
+		},
+		"powerpoint streaming": func() error {
+			_, err := OpenPowerPointDocumentStreaming(pptxPath, nil)
+			return err
+		},
+	}
+
+	for name, open := range openers {
+		t.Run(name, func(t *testing.T) {
+			err := open()
+			if err == nil {
+				t.Fatal("expected error for encrypted document")
+			}
+			if code := pkgErrors.GetErrorCode(err); code != pkgErrors.ErrCodeUnsupportedFormat {
+				t.Errorf("error code = %v, want %v", code, pkgErrors.ErrCodeUnsupportedFormat)
+			}
+			if !errors.Is(err, pkgErrors.ErrPasswordProtected) {
+				t.Errorf("expected ErrPasswordProtected, got: %v", err)
+			}
+			if contains(err.Error(), "invalid docx format") {
+				t.Errorf("encrypted file should not be reported as corrupted: %v", err)
+			}
+		})
+	}
+}
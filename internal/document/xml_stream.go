@@ -0,0 +1,167 @@
+package document
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// recordingReader keeps every byte handed to the XML decoder so the original
+// bytes of each token can be written back unchanged
+type recordingReader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func (rr *recordingReader) Read(p []byte) (int, error) {
+	n, err := rr.r.Read(p)
+	rr.buf = append(rr.buf, p[:n]...)
+	return n, err
+}
+
+func (rr *recordingReader) ReadByte() (byte, error) {
+	b, err := rr.r.ReadByte()
+	if err == nil {
+		rr.buf = append(rr.buf, b)
+	}
+	return b, err
+}
+
+// streamReplaceText copies XML from r to w, passing each text node through
+// replace. Re-encoding tokens with xml.Encoder rewrites namespace prefixes
+// into xmlns attributes on every element and expands self-closing tags, which
+// Office may reject. Instead, every token is copied byte-for-byte from the
+// input and only text nodes that replace actually changed are re-escaped.
+func streamReplaceText(r io.Reader, w io.Writer, replace func(text string) string) error {
+	return streamReplaceFilteredText(r, w, replace, nil)
+}
+
+// streamReplaceFilteredText is streamReplaceText for Word XML that leaves text
+// in hidden runs unchanged when filter is not nil
+func streamReplaceFilteredText(r io.Reader, w io.Writer, replace func(text string) string, filter *hiddenRunFilter) error {
+	return streamReplace(r, w, replace, filter, scopeAllText)
+}
+
+// streamReplaceTextElements is streamReplaceFilteredText limited to the text
+// of <w:t> and <a:t> elements, for parts whose other text nodes, such as
+// field codes or custom XML values, must not change
+func streamReplaceTextElements(r io.Reader, w io.Writer, replace func(text string) string, filter *hiddenRunFilter) error {
+	return streamReplace(r, w, replace, filter, scopeTextElements)
+}
+
+// streamReplaceChartText is streamReplaceText for chart parts. Like the
+// in-memory replacement, it changes rich text such as titles and the string
+// values of <c:strCache> and <c:tx>, but never number caches or <c:f>
+// formula references, whose values are data rather than text.
+func streamReplaceChartText(r io.Reader, w io.Writer, replace func(text string) string) error {
+	return streamReplace(r, w, replace, nil, scopeChartStrings)
+}
+
+// textScope selects the text nodes streamReplace may change
+type textScope int
+
+const (
+	scopeAllText      textScope = iota // Every text node
+	scopeTextElements                  // Text of <w:t> and <a:t> elements
+	scopeChartStrings                  // Text elements and chart string values
+)
+
+// isTextElement reports whether name is a WordprocessingML or DrawingML text
+// element. RawToken leaves the namespace prefix in Space.
+func isTextElement(name xml.Name) bool {
+	return name.Local == "t" && (name.Space == "w" || name.Space == "a")
+}
+
+// isChartElement reports whether name is the chart element <c:local>
+func isChartElement(name xml.Name, local string) bool {
+	return name.Space == "c" && name.Local == local
+}
+
+// includes reports whether text directly inside the innermost of the open
+// elements in stack is in scope
+func (s textScope) includes(stack []xml.Name) bool {
+	if s == scopeAllText {
+		return true
+	}
+	if len(stack) == 0 {
+		return false
+	}
+	current := stack[len(stack)-1]
+	if isTextElement(current) {
+		return true
+	}
+	if s != scopeChartStrings || !isChartElement(current, "v") {
+		return false
+	}
+	// A series name may be a literal <c:tx><c:v>; other values count only
+	// inside a string cache
+	if len(stack) > 1 && isChartElement(stack[len(stack)-2], "tx") {
+		return true
+	}
+	for _, name := range stack {
+		if isChartElement(name, "strCache") {
+			return true
+		}
+	}
+	return false
+}
+
+func streamReplace(r io.Reader, w io.Writer, replace func(text string) string, filter *hiddenRunFilter, scope textScope) error {
+	rec := &recordingReader{r: bufio.NewReader(r)}
+	decoder := xml.NewDecoder(rec)
+	var consumed int64
+	var stack []xml.Name // open elements, tracked unless every text node is in scope
+
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("XML decode error: %w", err)
+		}
+
+		// The decoder may have read one byte ahead; InputOffset excludes it
+		end := decoder.InputOffset()
+		raw := rec.buf[:end-consumed]
+
+		if filter != nil {
+			filter.observe(token)
+		}
+
+		if scope != scopeAllText {
+			switch t := token.(type) {
+			case xml.StartElement:
+				stack = append(stack, t.Name)
+			case xml.EndElement:
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+
+		out := raw
+		if charData, ok := token.(xml.CharData); ok && !filter.inHiddenRun() && scope.includes(stack) {
+			original := string(charData)
+			if modified := replace(original); modified != original {
+				out = []byte(escapeXMLString(modified))
+			}
+		}
+		if _, err := w.Write(out); err != nil {
+			return fmt.Errorf("XML write error: %w", err)
+		}
+
+		rec.buf = append(rec.buf[:0], rec.buf[end-consumed:]...)
+		consumed = end
+	}
+
+	// Anything left after the last token, such as trailing whitespace
+	if len(rec.buf) > 0 {
+		if _, err := w.Write(rec.buf); err != nil {
+			return fmt.Errorf("XML write error: %w", err)
+		}
+	}
+
+	return nil
+}
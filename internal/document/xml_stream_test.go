@@ -0,0 +1,239 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// namespacedDocumentXML mirrors the document.xml Word writes: several namespace
+// declarations, mc:Ignorable, prefixed attributes, self-closing tags and entities
+const namespacedDocumentXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:wpc="http://schemas.microsoft.com/office/word/2010/wordprocessingCanvas" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" mc:Ignorable="w14"><w:body><w:p w14:paraId="1A2B3C4D" w14:textId="77777777" w:rsidR="00A1"><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>Version 1.0 Release</w:t></w:r></w:p><w:p><w:r><w:t xml:space="preserve">Terms &amp; conditions for Version 1.0 </w:t></w:r><w:r><w:br/></w:r><w:r><w:t>apply.</w:t></w:r></w:p><w:sectPr><w:pgSz w:w="11906" w:h="16838"/></w:sectPr></w:body></w:document>`
+
+func TestStreamReplaceTextPreservesBytes(t *testing.T) {
+	var out bytes.Buffer
+	err := streamReplaceText(strings.NewReader(namespacedDocumentXML), &out, func(text string) string {
+		return text
+	})
+	if err != nil {
+		t.Fatalf("streamReplaceText failed: %v", err)
+	}
+	if out.String() != namespacedDocumentXML {
+		t.Errorf("unmodified stream should be byte-identical\ngot:  %s\nwant: %s", out.String(), namespacedDocumentXML)
+	}
+}
+
+func TestStreamReplaceTextOnlyChangesText(t *testing.T) {
+	var out bytes.Buffer
+	err := streamReplaceText(strings.NewReader(namespacedDocumentXML), &out, func(text string) string {
+		return strings.ReplaceAll(text, "Version 1.0", "Version <2.0>")
+	})
+	if err != nil {
+		t.Fatalf("streamReplaceText failed: %v", err)
+	}
+
+	want := strings.ReplaceAll(namespacedDocumentXML, "Version 1.0", "Version &lt;2.0&gt;")
+	if out.String() != want {
+		t.Errorf("unexpected output\ngot:  %s\nwant: %s", out.String(), want)
+	}
+}
+
+func TestStreamingWordRoundTripWithNamespaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "namespaced.docx")
+	writeDocxWithDocumentXML(t, "testdata/sample.docx", path, namespacedDocumentXML)
+
+	before := documentText(t, path)
+
+	doc, err := OpenWordDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatalf("Failed to open streaming document: %v", err)
+	}
+	count, err := doc.ReplaceTextStreaming("Version 1.0", "Version 2.0")
+	doc.Close()
+	if err != nil {
+		t.Fatalf("ReplaceTextStreaming failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("replacement count = %d, want 2", count)
+	}
+
+	xmlContent := readZipEntry(t, path, "word/document.xml")
+	for _, expected := range []string{
+		`xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`,
+		`mc:Ignorable="w14"`,
+		`<w:p w14:paraId="1A2B3C4D" w14:textId="77777777" w:rsidR="00A1">`,
+		`<w:br/>`,
+		`Terms &amp; conditions`,
+	} {
+		if !strings.Contains(xmlContent, expected) {
+			t.Errorf("document.xml lost %q after streaming", expected)
+		}
+	}
+	if strings.Contains(xmlContent, "_xmlns") || strings.Contains(xmlContent, `<document xmlns=`) {
+		t.Error("document.xml namespaces were rewritten by the encoder")
+	}
+
+	after := documentText(t, path)
+	if want := strings.ReplaceAll(before, "Version 1.0", "Version 2.0"); after != want {
+		t.Errorf("text changed beyond the replacement\ngot:  %q\nwant: %q", after, want)
+	}
+}
+
+func TestStreamingPowerPointRoundTripWithNamespaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presentation.pptx")
+	copyFile(t, "testdata/table_chart.pptx", path)
+	original := readZipEntry(t, path, "ppt/slides/slide1.xml")
+
+	doc, err := OpenPowerPointDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatalf("Failed to open streaming presentation: %v", err)
+	}
+	if _, err := doc.ReplaceTextInSlidesStreaming("Quarterly Report", "Annual Report"); err != nil {
+		doc.Close()
+		t.Fatalf("ReplaceTextInSlidesStreaming failed: %v", err)
+	}
+	doc.Close()
+
+	want := strings.ReplaceAll(original, "Quarterly Report", "Annual Report")
+	if got := readZipEntry(t, path, "ppt/slides/slide1.xml"); got != want {
+		t.Errorf("slide XML changed beyond the replacement\ngot:  %s\nwant: %s", got, want)
+	}
+
+	reopened, err := OpenPowerPointDocument(path)
+	if err != nil {
+		t.Fatalf("Modified presentation does not reopen: %v", err)
+	}
+	defer reopened.Close()
+	text, err := reopened.GetText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "Annual Report 2023") {
+		t.Errorf("replacement missing from reopened presentation: %q", text)
+	}
+}
+
+func TestStreamingPowerPointChartKeepsNumbersAndFormulas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presentation.pptx")
+	copyFile(t, "testdata/table_chart.pptx", path)
+	original := readZipEntry(t, path, "ppt/charts/chart1.xml")
+
+	doc, err := OpenPowerPointDocumentStreaming(path, nil)
+	if err != nil {
+		t.Fatalf("Failed to open streaming presentation: %v", err)
+	}
+	if _, err := doc.ReplaceTextInSlidesStreaming("2023", "2024"); err != nil {
+		doc.Close()
+		t.Fatalf("ReplaceTextInSlidesStreaming failed: %v", err)
+	}
+	if _, err := doc.ReplaceTextInSlidesStreaming("Sheet1", "Data"); err != nil {
+		doc.Close()
+		t.Fatalf("ReplaceTextInSlidesStreaming failed: %v", err)
+	}
+	doc.Close()
+
+	// The title and series name change; the data point and formulas do not
+	want := strings.NewReplacer(
+		"<a:t>Sales 2023</a:t>", "<a:t>Sales 2024</a:t>",
+		"<c:v>Revenue 2023</c:v>", "<c:v>Revenue 2024</c:v>",
+	).Replace(original)
+	got := readZipEntry(t, path, "ppt/charts/chart1.xml")
+	if got != want {
+		t.Errorf("chart XML changed beyond its text\ngot:  %s\nwant: %s", got, want)
+	}
+	if !strings.Contains(got, "<c:v>2023</c:v>") || !strings.Contains(got, "<c:f>Sheet1!$B$1</c:f>") {
+		t.Errorf("number cache or formula was replaced: %s", got)
+	}
+}
+
+func TestStreamReplaceChartText(t *testing.T) {
+	input := `<c:ser><c:tx><c:v>Plan 2023</c:v></c:tx><c:val><c:numLit><c:pt idx="0"><c:v>2023</c:v></c:pt></c:numLit></c:val></c:ser>`
+	want := `<c:ser><c:tx><c:v>Plan 2024</c:v></c:tx><c:val><c:numLit><c:pt idx="0"><c:v>2023</c:v></c:pt></c:numLit></c:val></c:ser>`
+
+	var out strings.Builder
+	err := streamReplaceChartText(strings.NewReader(input), &out, func(text string) string {
+		return strings.ReplaceAll(text, "2023", "2024")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want {
+		t.Errorf("streamReplaceChartText() = %s, want %s", out.String(), want)
+	}
+}
+
+// writeDocxWithDocumentXML copies the docx at src to dst with document.xml replaced
+func writeDocxWithDocumentXML(t *testing.T, src, dst, documentXML string) {
+	t.Helper()
+
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	writer := zip.NewWriter(out)
+	for _, file := range reader.File {
+		w, err := writer.Create(file.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if file.Name == "word/document.xml" {
+			w.Write([]byte(documentXML))
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(w, rc)
+		rc.Close()
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// documentText opens the docx with the regular reader and returns its text
+func documentText(t *testing.T, path string) string {
+	t.Helper()
+
+	doc, err := OpenWordDocument(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer doc.Close()
+
+	text, err := doc.GetText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return text
+}
+
+func readZipEntry(t *testing.T, path, name string) string {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	data, err := readZipPart(reader.File, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -41,6 +41,8 @@ const (
 	ErrCodeAITimeout         ErrorCode = "DOX302"
 	ErrCodeAIInvalidResponse ErrorCode = "DOX303"
 	ErrCodeAIServiceDown     ErrorCode = "DOX304"
+	ErrCodeContextWindowExceeded ErrorCode = "DOX305"
+	ErrCodeBudgetExceeded    ErrorCode = "DOX307"
 	
 	// Validation errors (DOX400-DOX499)
 	ErrCodeInvalidInput      ErrorCode = "DOX400"
@@ -85,7 +87,34 @@ func (e *CodedError) Error() string {
 // LocalizedError returns a localized error message
 func (e *CodedError) LocalizedError() string {
 	var sb strings.Builder
+	sb.WriteString(e.LocalizedMessage())
 	
+	// Add additional context if not already in the localized message
+	if len(e.Context) > 0 && !strings.Contains(sb.String(), "Context:") {
+		sb.WriteString("\nContext:")
+		for key, value := range e.Context {
+			sb.WriteString(fmt.Sprintf("\n  %s: %v", key, value))
+		}
+	}
+	
+	// Add solution if available
+	if e.Solution != "" {
+		sb.WriteString("\nSolution: ")
+		sb.WriteString(e.Solution)
+	}
+	
+	// Add cause if available
+	if e.Cause != nil {
+		sb.WriteString("\nCause: ")
+		sb.WriteString(e.Cause.Error())
+	}
+	
+	return sb.String()
+}
+
+// LocalizedMessage returns the localized message of the error code, without
+// the context, solution and cause
+func (e *CodedError) LocalizedMessage() string {
 	// Try to get localized error message
 	errorMsgKey := fmt.Sprintf("error.code.%s", strings.ToLower(string(e.Code)))
 	errorMsgKey = strings.ReplaceAll(errorMsgKey, "dox", "")
@@ -114,6 +143,8 @@ func (e *CodedError) LocalizedError() string {
 		ErrCodeAITimeout:         i18n.MsgErrCodeAITimeout,
 		ErrCodeAIInvalidResponse: i18n.MsgErrCodeAIInvalidResponse,
 		ErrCodeAIServiceDown:     i18n.MsgErrCodeAIServiceDown,
+		ErrCodeContextWindowExceeded: i18n.MsgErrCodeContextWindowExceeded,
+		ErrCodeBudgetExceeded:    i18n.MsgErrCodeBudgetExceeded,
 		ErrCodeInvalidInput:      i18n.MsgErrCodeInvalidInput,
 		ErrCodeMissingRequired:   i18n.MsgErrCodeMissingRequired,
 		ErrCodeInvalidFormat:     i18n.MsgErrCodeInvalidFormat,
@@ -127,34 +158,10 @@ func (e *CodedError) LocalizedError() string {
 	
 	// Get localized message or fallback to default
 	if msgKey, ok := msgKeyMap[e.Code]; ok {
-		localizedMsg := i18n.T(msgKey, e.Context)
-		sb.WriteString(localizedMsg)
-	} else {
-		// Fallback to original format
-		sb.WriteString(fmt.Sprintf("[%s] [%s]: %s", e.Level, e.Code, e.Message))
-	}
-	
-	// Add additional context if not already in the localized message
-	if len(e.Context) > 0 && !strings.Contains(sb.String(), "Context:") {
-		sb.WriteString("\nContext:")
-		for key, value := range e.Context {
-			sb.WriteString(fmt.Sprintf("\n  %s: %v", key, value))
-		}
-	}
-	
-	// Add solution if available
-	if e.Solution != "" {
-		sb.WriteString("\nSolution: ")
-		sb.WriteString(e.Solution)
+		return i18n.T(msgKey, e.Context)
 	}
-	
-	// Add cause if available
-	if e.Cause != nil {
-		sb.WriteString("\nCause: ")
-		sb.WriteString(e.Cause.Error())
-	}
-	
-	return sb.String()
+	// Fallback to original format
+	return fmt.Sprintf("[%s] [%s]: %s", e.Level, e.Code, e.Message)
 }
 
 // Unwrap returns the underlying error
@@ -243,6 +250,19 @@ func NewInvalidFormatError(format, expected string) *CodedError {
 	).WithContext("Format", format).WithContext("Expected", expected)
 }
 
+// NewPasswordProtectedError creates an error for encrypted OOXML files, which are
+// stored as OLE compound files instead of zip archives
+func NewPasswordProtectedError(path string) *CodedError {
+	solution := i18n.T(i18n.MsgSolutionRemovePassword, nil)
+	return NewCodedError(
+		ErrCodeUnsupportedFormat,
+		LevelError,
+		fmt.Sprintf("File appears to be password-protected: %s", path),
+		solution,
+		ErrPasswordProtected,
+	).WithContext("Format", "password-protected document").WithContext("Path", path)
+}
+
 // NewPermissionDeniedError creates a permission denied error
 func NewPermissionDeniedError(path string) *CodedError {
 	solution := i18n.T(i18n.MsgSolutionCheckPermission, nil)
@@ -273,6 +293,58 @@ func NewRateLimitError(provider string, retryAfter string) *CodedError {
 	).WithContext("Provider", provider).WithContext("RetryAfter", retryAfter)
 }
 
+// NewInvalidAPIKeyError creates an error for an API key rejected by the provider
+func NewInvalidAPIKeyError(provider string, cause error) *CodedError {
+	solution := i18n.T(i18n.MsgSolutionCheckAPIKey, nil)
+	return NewCodedError(
+		ErrCodeInvalidAPIKey,
+		LevelError,
+		fmt.Sprintf("%s rejected the API key", provider),
+		solution,
+		cause,
+	).WithContext("Provider", provider)
+}
+
+// NewAIServiceDownError creates an error for an AI provider that cannot be reached
+func NewAIServiceDownError(provider string, cause error) *CodedError {
+	solution := i18n.T(i18n.MsgSolutionCheckConnection, nil)
+	return NewCodedError(
+		ErrCodeAIServiceDown,
+		LevelError,
+		fmt.Sprintf("%s API is unavailable", provider),
+		solution,
+		cause,
+	).WithContext("Provider", provider)
+}
+
+// NewContextWindowExceededError creates an error for a prompt that leaves no
+// room for the response in the model's context window
+func NewContextWindowExceededError(model string, promptTokens, limit int) *CodedError {
+	solution := i18n.T(i18n.MsgSolutionTruncatePrompt, nil)
+	return NewCodedError(
+		ErrCodeContextWindowExceeded,
+		LevelError,
+		fmt.Sprintf("Prompt of ~%d tokens exceeds the %d tokens available for %s", promptTokens, limit, model),
+		solution,
+		nil,
+	).WithContext("Model", model).WithContext("PromptTokens", promptTokens).WithContext("Limit", limit)
+}
+
+// NewBudgetExceededError creates an error for a request whose estimated cost
+// would take the session's spend past its budget (all amounts in USD)
+func NewBudgetExceededError(spent, estimate, budget float64) *CodedError {
+	solution := i18n.T(i18n.MsgSolutionRaiseBudget, nil)
+	return NewCodedError(
+		ErrCodeBudgetExceeded,
+		LevelError,
+		fmt.Sprintf("Request estimated at $%.4f would exceed the budget of $%.2f ($%.4f spent)", estimate, budget, spent),
+		solution,
+		nil,
+	).WithContext("Spent", fmt.Sprintf("$%.4f", spent)).
+		WithContext("Estimate", fmt.Sprintf("$%.4f", estimate)).
+		WithContext("Budget", fmt.Sprintf("$%.2f", budget))
+}
+
 // IsCodedError checks if an error is a CodedError
 func IsCodedError(err error) bool {
 	var ce *CodedError
@@ -243,6 +243,32 @@ func TestHelperFunctions(t *testing.T) {
 			t.Errorf("Message does not contain provider name")
 		}
 	})
+
+	t.Run("NewInvalidAPIKeyError", func(t *testing.T) {
+		cause := errors.New("HTTP 401")
+		err := NewInvalidAPIKeyError("openai", cause)
+
+		if err.Code != ErrCodeInvalidAPIKey {
+			t.Errorf("Code = %v, want %v", err.Code, ErrCodeInvalidAPIKey)
+		}
+		if !errors.Is(err, cause) {
+			t.Error("error should wrap its cause")
+		}
+		if err.Solution == "" {
+			t.Error("Solution should not be empty")
+		}
+	})
+
+	t.Run("NewAIServiceDownError", func(t *testing.T) {
+		err := NewAIServiceDownError("claude", errors.New("connection refused"))
+
+		if err.Code != ErrCodeAIServiceDown {
+			t.Errorf("Code = %v, want %v", err.Code, ErrCodeAIServiceDown)
+		}
+		if err.Context["Provider"] != "claude" {
+			t.Errorf("Provider context = %v, want claude", err.Context["Provider"])
+		}
+	})
 }
 
 func TestErrorChecking(t *testing.T) {
@@ -17,6 +17,9 @@ var (
 	ErrDocumentCorrupted = errors.New("document is corrupted or invalid")
 	ErrUnsupportedFormat = errors.New("unsupported document format")
 	ErrEmptyDocument     = errors.New("document is empty")
+	ErrPasswordProtected = errors.New("file appears to be password-protected")
+	// ErrInvalidUTF8 is a kind of ErrDocumentCorrupted: document text that is not valid UTF-8
+	ErrInvalidUTF8 = fmt.Errorf("%w: text is not valid UTF-8", ErrDocumentCorrupted)
 	
 	// Configuration errors
 	ErrConfigNotFound  = errors.New("configuration file not found")
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Exit codes of the dox command. They are a stable contract for scripts:
+// new codes may be added, but a failure keeps the code it has.
+const (
+	ExitOK          = 0 // Success
+	ExitError       = 1 // Any error not covered by a more specific code
+	ExitUsage       = 2 // Invalid flags, arguments or input (DOX4xx)
+	ExitConfig      = 3 // Configuration errors, including missing API keys (DOX0xx)
+	ExitFile        = 4 // File errors: not found, permission denied, cannot read or write (DOX1xx)
+	ExitAI          = 5 // AI provider and network errors (DOX3xx, DOX5xx)
+	ExitDocument    = 6 // Document errors: corrupted, unsupported or unparsable (DOX2xx)
+	ExitCheckFailed = 7 // dox check found forbidden terms or could not check a document
+)
+
+// ExitCode returns the exit code for err (see ExitOK and the codes after it).
+// Errors with an error code exit by the code's category; other errors are
+// classified the way Explain classifies them, and anything unrecognized
+// exits with ExitError.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var code ErrorCode
+	var ce *CodedError
+	var ee *EnhancedError
+	var configErr *ConfigError
+	switch {
+	case errors.As(err, &ce):
+		code = ce.Code
+	case errors.As(err, &ee):
+		code = ee.Code
+	case errors.As(err, &configErr), errors.Is(err, ErrConfigNotFound),
+		errors.Is(err, ErrInvalidConfig), errors.Is(err, ErrMissingAPIKey):
+		return ExitConfig
+	default:
+		if classified := classify(err); classified != nil {
+			code = classified.Code
+		}
+	}
+	if exit := exitCodeOf(code); exit != ExitError {
+		return exit
+	}
+
+	var fileErr *FileError
+	var docErr *DocumentError
+	switch {
+	case errors.As(err, &docErr):
+		return ExitDocument
+	case errors.As(err, &fileErr):
+		return ExitFile
+	}
+	return ExitError
+}
+
+// exitCodeOf maps an error code to the exit code of its category
+func exitCodeOf(code ErrorCode) int {
+	// A missing API key is numbered with the AI errors but is fixed in the configuration
+	if code == ErrCodeMissingAPIKey {
+		return ExitConfig
+	}
+
+	number := strings.TrimPrefix(string(code), "DOX")
+	if len(number) != 3 || number == string(code) {
+		return ExitError
+	}
+	switch number[0] {
+	case '0':
+		return ExitConfig
+	case '1':
+		return ExitFile
+	case '2':
+		return ExitDocument
+	case '3', '5':
+		return ExitAI
+	case '4':
+		return ExitUsage
+	}
+	return ExitError
+}
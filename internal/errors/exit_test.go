@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitOK},
+		{"unrecognized", errors.New("something went wrong"), ExitError},
+		{"validation", NewValidationError("tone", "loud", "must be one of: formal, casual"), ExitUsage},
+		{"missing API key", NewAPIKeyNotFoundError("openai"), ExitConfig},
+		{"missing API key (enhanced)", NewError(ErrCodeMissingAPIKey, "OpenAI API key is not configured").Build(), ExitConfig},
+		{"config file", NewConfigError("config.yml", "invalid YAML", nil), ExitConfig},
+		{"file not found", NewFileError("rules.yml", "loading rules", ErrFileNotFound), ExitFile},
+		{"os not found", &os.PathError{Op: "open", Path: "missing.docx", Err: os.ErrNotExist}, ExitFile},
+		{"file read", NewFileError("rules.yml", "loading rules", errors.New("bad YAML")), ExitFile},
+		{"permission", fmt.Errorf("saving: %w", NewPermissionDeniedError("out.docx")), ExitFile},
+		{"corrupted", NewDocumentError("report.docx", ".docx", "document appears to be corrupted", ErrDocumentCorrupted), ExitDocument},
+		{"document", NewDocumentError("report.docx", ".docx", "processing failed", errors.New("bad run")), ExitDocument},
+		{"rate limited", NewRateLimitError("openai", "20s"), ExitAI},
+		{"context window", NewContextWindowExceededError("gpt-4", 9000, 8000), ExitAI},
+		{"budget", fmt.Errorf("generating: %w", NewBudgetExceededError(1, 0.5, 1)), ExitAI},
+		{"timeout", fmt.Errorf("request: %w", context.DeadlineExceeded), ExitAI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/i18n"
+)
+
+// errorDocsURL is the error code reference linked by Explain
+const errorDocsURL = "https://github.com/pyhub-kr/pyhub-documents-cli/blob/main/docs/guides/errors.md"
+
+// DocLink returns the documentation link for an error code, or for the
+// error reference when code is empty
+func DocLink(code ErrorCode) string {
+	if code == "" {
+		return errorDocsURL
+	}
+	return errorDocsURL + "#" + strings.ToLower(string(code))
+}
+
+// Explain describes err in full, for --explain: the localized message of its
+// error code, the context, the suggested solution, every error in its cause
+// chain and a documentation link. Errors without a code are classified (file
+// not found, permission denied, invalid input and so on) so they still get
+// guidance.
+func Explain(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var ce *CodedError
+	if !errors.As(err, &ce) {
+		ce = classify(err)
+	}
+
+	var sb strings.Builder
+	if ce != nil {
+		sb.WriteString(ce.LocalizedMessage())
+	} else {
+		sb.WriteString(firstLine(err.Error()))
+	}
+	sb.WriteString("\n")
+
+	if ce != nil && len(ce.Context) > 0 {
+		keys := make([]string, 0, len(ce.Context))
+		for key, value := range ce.Context {
+			if value != nil && value != "" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		if len(keys) > 0 {
+			sb.WriteString("\nContext:")
+			for _, key := range keys {
+				sb.WriteString(fmt.Sprintf("\n  %s: %v", key, ce.Context[key]))
+			}
+		}
+	}
+
+	solution := i18n.T(i18n.MsgSolutionReportIssue)
+	if ce != nil && ce.Solution != "" {
+		solution = ce.Solution
+	}
+	sb.WriteString("\nSolution: ")
+	sb.WriteString(solution)
+
+	sb.WriteString("\nCause chain:")
+	for i, cause := range causeChain(err) {
+		sb.WriteString(fmt.Sprintf("\n  %d. %s", i+1, cause))
+	}
+
+	var code ErrorCode
+	if ce != nil {
+		code = ce.Code
+	}
+	sb.WriteString("\nDocumentation: ")
+	sb.WriteString(DocLink(code))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// classify describes an error without a code as the CodedError it would
+// have been, or returns nil when it is not recognized
+func classify(err error) *CodedError {
+	path := errorPath(err)
+
+	var validationErr *ValidationError
+	var docErr *DocumentError
+	switch {
+	case errors.Is(err, ErrPasswordProtected):
+		return NewPasswordProtectedError(path)
+	case errors.Is(err, os.ErrNotExist) || errors.Is(err, ErrFileNotFound) || errors.Is(err, ErrConfigNotFound):
+		return NewFileNotFoundError(path)
+	case errors.Is(err, os.ErrPermission) || errors.Is(err, ErrPermissionDenied):
+		return NewPermissionDeniedError(path)
+	case errors.Is(err, ErrFileAlreadyExists):
+		return NewCodedError(ErrCodeFileAlreadyExists, LevelError, "File already exists", i18n.T(i18n.MsgSolutionUseForce), nil).
+			WithContext("Path", path)
+	case errors.As(err, &validationErr):
+		return NewCodedError(ErrCodeInvalidInput, LevelError, validationErr.Message,
+			i18n.T(i18n.MsgSolutionCheckInput, map[string]interface{}{"Field": validationErr.Field}), nil).
+			WithContext("Field", validationErr.Field).WithContext("Value", validationErr.Value)
+	case errors.Is(err, ErrUnsupportedFormat):
+		format := ""
+		if errors.As(err, &docErr) {
+			format = docErr.Type
+		}
+		return NewCodedError(ErrCodeUnsupportedFormat, LevelError, "Unsupported document format", i18n.T(i18n.MsgSolutionConvertFormat), nil).
+			WithContext("Format", format).WithContext("Path", path)
+	case errors.Is(err, ErrDocumentCorrupted):
+		return NewCodedError(ErrCodeDocumentCorrupted, LevelError, "Document is corrupted or invalid", i18n.T(i18n.MsgSolutionRepairDocument), nil).
+			WithContext("Path", path)
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewCodedError(ErrCodeNetworkTimeout, LevelError, "Network timeout", i18n.T(i18n.MsgSolutionCheckConnection), nil)
+	}
+	return nil
+}
+
+// errorPath returns the file an error is about, if it says
+func errorPath(err error) string {
+	var fileErr *FileError
+	if errors.As(err, &fileErr) {
+		return fileErr.Path
+	}
+	var docErr *DocumentError
+	if errors.As(err, &docErr) {
+		return docErr.Path
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Path
+	}
+	return ""
+}
+
+// causeChain lists err and the errors it wraps, outermost first. Each entry
+// is the first line of the error's message without the message of the error
+// it wraps, so "failed to open: file not found" becomes "failed to open"
+// followed by "file not found".
+func causeChain(err error) []string {
+	var chain []string
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		msg := firstLine(e.Error())
+		if next := errors.Unwrap(e); next != nil {
+			msg = strings.TrimSuffix(msg, ": "+firstLine(next.Error()))
+		}
+		if msg != "" {
+			chain = append(chain, msg)
+		}
+	}
+	return chain
+}
+
+// firstLine returns s up to its first line break
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
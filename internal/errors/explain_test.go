@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/i18n"
+)
+
+func TestExplainCodedError(t *testing.T) {
+	if err := i18n.Init("en"); err != nil {
+		t.Fatal(err)
+	}
+
+	cause := errors.New("HTTP 429: slow down")
+	coded := NewRateLimitError("openai", "20s")
+	coded.Cause = cause
+	err := fmt.Errorf("failed to generate content: %w", coded)
+
+	got := Explain(err)
+	for _, want := range []string{
+		"[WARNING] [DOX301]: openai API rate limit exceeded\n",
+		"Context:\n  Provider: openai\n  RetryAfter: 20s",
+		"Solution: Wait 20s before retrying",
+		"Cause chain:\n  1. failed to generate content\n  2. [WARNING] [DOX301]: openai API rate limit exceeded\n  3. HTTP 429: slow down",
+		"Documentation: " + errorDocsURL + "#dox301",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Explain() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestExplainClassifiesPlainErrors(t *testing.T) {
+	if err := i18n.Init("en"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, statErr := os.Stat("missing/report.docx")
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "file not found",
+			err:  NewFileError("missing/report.docx", "opening document", statErr),
+			want: []string{"[DOX100]: File not found: missing/report.docx", "Solution: Check if the file exists", "#dox100"},
+		},
+		{
+			name: "permission denied",
+			err:  fmt.Errorf("saving: %w", NewFileError("out.docx", "writing", os.ErrPermission)),
+			want: []string{"[DOX103]: Permission denied: out.docx", "#dox103"},
+		},
+		{
+			name: "validation",
+			err:  NewValidationError("budget", "-1", "budget must be greater than 0"),
+			want: []string{"[DOX400]: Invalid input: budget", "Value: -1", "Solution: Check the value of budget", "#dox400"},
+		},
+		{
+			name: "unrecognized",
+			err:  errors.New("something odd"),
+			want: []string{"something odd\n", "Solution: Run the command again with --verbose", "Documentation: " + errorDocsURL + "\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Explain(tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Explain() missing %q in:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCauseChain(t *testing.T) {
+	inner := errors.New("unexpected EOF")
+	err := fmt.Errorf("failed to replace: %w", NewFileError("a.docx", "reading", inner))
+
+	got := causeChain(err)
+	want := []string{"failed to replace", "reading failed for 'a.docx'", "unexpected EOF"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("causeChain() = %q, want %q", got, want)
+	}
+}
@@ -2,7 +2,9 @@ package export
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/pyhub/pyhub-docs/internal/pdf"
 )
@@ -13,20 +15,81 @@ type Format string
 const (
 	FormatHTML     Format = "html"
 	FormatMarkdown Format = "markdown"
+	FormatDOCX     Format = "docx"
+	FormatJSON     Format = "json"
 )
 
+// ParseFormat parses an export format name, accepting "md" and "word" as
+// aliases
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "html":
+		return FormatHTML, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "docx", "word":
+		return FormatDOCX, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use 'html', 'markdown', 'docx' or 'json')", name)
+	}
+}
+
+// FormatForPath returns the export format implied by an output file
+// extension, and false for extensions that imply none
+func FormatForPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return FormatHTML, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	case ".docx":
+		return FormatDOCX, true
+	case ".json":
+		return FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+// DefaultHeadingMaxLength is the number of characters a line of unstructured
+// text must stay below to be taken for a heading
+const DefaultHeadingMaxLength = 50
+
+// HeadingOptions control how headings are guessed in the plain text of pages
+// the extractor found no structured elements on
+type HeadingOptions struct {
+	// MaxLength is the number of characters a heading must stay below;
+	// 0 turns guessing off, so every line is a paragraph
+	MaxLength int
+	// Level is the level of guessed headings; 0 uses the format's default
+	// (2 for Markdown and Word, 3 for HTML)
+	Level int
+	// StructuredOnly turns guessing off when the extractor found structured
+	// elements on any page, so that headings only come from the extractor
+	StructuredOnly bool
+}
+
 // Converter handles conversion from PDF extraction result to various formats
 type Converter struct {
-	result *pdf.ExtractResult
+	result   *pdf.ExtractResult
+	headings HeadingOptions
 }
 
 // NewConverter creates a new converter
 func NewConverter(result *pdf.ExtractResult) *Converter {
 	return &Converter{
-		result: result,
+		result:   result,
+		headings: HeadingOptions{MaxLength: DefaultHeadingMaxLength},
 	}
 }
 
+// SetHeadingOptions sets how headings are guessed in unstructured text
+func (c *Converter) SetHeadingOptions(options HeadingOptions) {
+	c.headings = options
+}
+
 // Convert converts the extraction result to the specified format
 func (c *Converter) Convert(format Format) (string, error) {
 	switch format {
@@ -34,6 +97,15 @@ func (c *Converter) Convert(format Format) (string, error) {
 		return c.ToHTML()
 	case FormatMarkdown:
 		return c.ToMarkdown()
+	case FormatDOCX:
+		// The package is binary; callers write the returned string as raw bytes
+		data, err := c.ToDOCX()
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatJSON:
+		return c.ToJSON()
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -121,8 +193,9 @@ func (c *Converter) ToHTML() (string, error) {
 				}
 
 				// Simple heading detection (lines that are short and might be titles)
-				if len(line) < 50 && !strings.HasSuffix(line, ".") && !strings.HasSuffix(line, ",") {
-					builder.WriteString(fmt.Sprintf("  <h3>%s</h3>\n", escapeHTML(line)))
+				if c.isHeading(line) {
+					level := c.headingLevel(3)
+					builder.WriteString(fmt.Sprintf("  <h%d>%s</h%d>\n", level, escapeHTML(line), level))
 				} else {
 					builder.WriteString(fmt.Sprintf("  <p>%s</p>\n", escapeHTML(line)))
 				}
@@ -227,8 +300,8 @@ func (c *Converter) ToMarkdown() (string, error) {
 				}
 
 				// Simple heading detection
-				if len(line) < 50 && !strings.HasSuffix(line, ".") && !strings.HasSuffix(line, ",") {
-					builder.WriteString(fmt.Sprintf("## %s\n\n", line))
+				if c.isHeading(line) {
+					builder.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", c.headingLevel(2)), line))
 				} else {
 					builder.WriteString(fmt.Sprintf("%s\n\n", line))
 				}
@@ -275,6 +348,42 @@ func escapeHTML(s string) string {
 	return s
 }
 
+// isHeading reports whether a line of unstructured text is treated as a
+// heading under the converter's heading options
+func (c *Converter) isHeading(line string) bool {
+	if c.headings.MaxLength <= 0 {
+		return false
+	}
+	if c.headings.StructuredOnly && c.hasElements() {
+		return false
+	}
+	return looksLikeHeading(line, c.headings.MaxLength)
+}
+
+// headingLevel returns the level of guessed headings, or the format default
+func (c *Converter) headingLevel(defaultLevel int) int {
+	if c.headings.Level > 0 {
+		// Markdown and HTML have six heading levels
+		return min(c.headings.Level, 6)
+	}
+	return defaultLevel
+}
+
+// hasElements reports whether the extractor found structured elements on any page
+func (c *Converter) hasElements() bool {
+	for _, page := range c.result.Pages {
+		if len(page.Elements) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeHeading checks if a plain text line is short enough and unpunctuated enough to be a title
+func looksLikeHeading(line string, maxLength int) bool {
+	return utf8.RuneCountInString(line) < maxLength && !strings.HasSuffix(line, ".") && !strings.HasSuffix(line, ",")
+}
+
 // looksLikeHeader checks if a row looks like table headers
 func looksLikeHeader(row []string) bool {
 	for _, cell := range row {
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+)
+
+func TestConverterHeadingOptions(t *testing.T) {
+	unstructured := &pdf.ExtractResult{
+		Pages: []pdf.Page{{Number: 1, Text: "Quarterly Overview\nSales grew in every region.\n분기별 매출 현황과 지역별 성장률 요약"}},
+	}
+	mixed := &pdf.ExtractResult{
+		Pages: []pdf.Page{
+			{Number: 1, Elements: []pdf.Element{{Type: "heading", Content: "Report", Level: 1}}},
+			{Number: 2, Text: "Summary"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		result  *pdf.ExtractResult
+		options *HeadingOptions
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "default length counts characters",
+			result: unstructured,
+			want:   []string{"## Quarterly Overview\n", "Sales grew in every region.\n", "## 분기별 매출 현황과 지역별 성장률 요약\n"},
+		},
+		{
+			name:    "shorter maximum",
+			result:  unstructured,
+			options: &HeadingOptions{MaxLength: 10},
+			notWant: []string{"## Quarterly Overview"},
+		},
+		{
+			name:    "guessing off",
+			result:  unstructured,
+			options: &HeadingOptions{},
+			notWant: []string{"##"},
+		},
+		{
+			name:    "custom level",
+			result:  unstructured,
+			options: &HeadingOptions{MaxLength: DefaultHeadingMaxLength, Level: 4},
+			want:    []string{"#### Quarterly Overview\n"},
+		},
+		{
+			name:   "unstructured page of a structured result",
+			result: mixed,
+			want:   []string{"# Report\n", "## Summary\n"},
+		},
+		{
+			name:    "structured only",
+			result:  mixed,
+			options: &HeadingOptions{MaxLength: DefaultHeadingMaxLength, StructuredOnly: true},
+			want:    []string{"# Report\n", "\nSummary\n"},
+			notWant: []string{"## Summary"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(tt.result)
+			if tt.options != nil {
+				converter.SetHeadingOptions(*tt.options)
+			}
+			got, err := converter.ToMarkdown()
+			if err != nil {
+				t.Fatalf("ToMarkdown() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output missing %q:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output should not contain %q:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
+
+func TestConverterHeadingLevelHTML(t *testing.T) {
+	result := &pdf.ExtractResult{Pages: []pdf.Page{{Number: 1, Text: "Overview"}}}
+
+	converter := NewConverter(result)
+	if got, _ := converter.ToHTML(); !strings.Contains(got, "<h3>Overview</h3>") {
+		t.Errorf("default HTML heading level should be 3:\n%s", got)
+	}
+	converter.SetHeadingOptions(HeadingOptions{MaxLength: DefaultHeadingMaxLength, Level: 9})
+	if got, _ := converter.ToHTML(); !strings.Contains(got, "<h6>Overview</h6>") {
+		t.Errorf("heading level should be capped at 6:\n%s", got)
+	}
+}
@@ -0,0 +1,233 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// maxDOCXHeadingLevel is the deepest heading style defined in the generated styles part
+const maxDOCXHeadingLevel = 6
+
+// ToDOCX converts the extraction result to a minimal Word document
+func (c *Converter) ToDOCX() ([]byte, error) {
+	var body strings.Builder
+
+	// Add metadata as a title block if available
+	if c.result.Metadata.Title != "" {
+		body.WriteString(docxParagraph("Title", c.result.Metadata.Title))
+	}
+	if c.result.Metadata.Author != "" {
+		body.WriteString(docxParagraph("", "Author: "+c.result.Metadata.Author))
+	}
+	if c.result.Metadata.Subject != "" {
+		body.WriteString(docxParagraph("", "Subject: "+c.result.Metadata.Subject))
+	}
+
+	// Process each page
+	for i, page := range c.result.Pages {
+		if i > 0 {
+			body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+		}
+
+		// Process structured elements if available
+		if len(page.Elements) > 0 {
+			for _, elem := range page.Elements {
+				switch elem.Type {
+				case "heading":
+					level := elem.Level
+					if level == 0 {
+						level = 2
+					}
+					body.WriteString(docxHeading(level, elem.Content))
+				case "list_item":
+					marker := elem.Marker
+					if marker == "" {
+						marker = "•"
+					}
+					text := strings.TrimSpace(strings.TrimPrefix(elem.Content, elem.Marker))
+					body.WriteString(docxParagraph("ListParagraph", marker+" "+text))
+				case "table_row":
+					// Skip, will be handled in tables section
+					continue
+				default:
+					body.WriteString(docxParagraph("", elem.Content))
+				}
+			}
+		} else if page.Text != "" {
+			// Fallback to simple text processing
+			for _, line := range strings.Split(page.Text, "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+
+				if c.isHeading(line) {
+					body.WriteString(docxHeading(c.headingLevel(2), line))
+				} else {
+					body.WriteString(docxParagraph("", line))
+				}
+			}
+		}
+
+		// Process tables
+		for _, table := range page.Tables {
+			if len(table.Data) == 0 {
+				continue
+			}
+			body.WriteString(docxTable(table.Data))
+		}
+	}
+
+	return buildDOCXPackage(body.String())
+}
+
+// docxHeading returns a paragraph using the Heading style for the given level
+func docxHeading(level int, text string) string {
+	if level < 1 {
+		level = 1
+	}
+	if level > maxDOCXHeadingLevel {
+		level = maxDOCXHeadingLevel
+	}
+	return docxParagraph(fmt.Sprintf("Heading%d", level), text)
+}
+
+// docxParagraph returns a paragraph with an optional paragraph style
+func docxParagraph(style, text string) string {
+	var p strings.Builder
+	p.WriteString("<w:p>")
+	if style != "" {
+		p.WriteString(fmt.Sprintf(`<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style))
+	}
+	p.WriteString(fmt.Sprintf(`<w:r><w:t xml:space="preserve">%s</w:t></w:r>`, escapeXML(text)))
+	p.WriteString("</w:p>")
+	return p.String()
+}
+
+// docxTable returns a Word table; the first row is repeated as a header row
+// when it looks like headers
+func docxTable(data [][]string) string {
+	cols := 0
+	for _, row := range data {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	var t strings.Builder
+	t.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/></w:tblPr>`)
+	t.WriteString("<w:tblGrid>")
+	for i := 0; i < cols; i++ {
+		t.WriteString(`<w:gridCol/>`)
+	}
+	t.WriteString("</w:tblGrid>")
+
+	for rowIdx, row := range data {
+		header := rowIdx == 0 && looksLikeHeader(row)
+		t.WriteString("<w:tr>")
+		if header {
+			t.WriteString("<w:trPr><w:tblHeader/></w:trPr>")
+		}
+		for i := 0; i < cols; i++ {
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			t.WriteString("<w:tc><w:p><w:r>")
+			if header {
+				t.WriteString("<w:rPr><w:b/></w:rPr>")
+			}
+			t.WriteString(fmt.Sprintf(`<w:t xml:space="preserve">%s</w:t>`, escapeXML(cell)))
+			t.WriteString("</w:r></w:p></w:tc>")
+		}
+		t.WriteString("</w:tr>")
+	}
+
+	t.WriteString("</w:tbl>")
+	// Word requires a paragraph between consecutive tables and at the end of a cell/body
+	t.WriteString("<w:p/>")
+	return t.String()
+}
+
+// buildDOCXPackage assembles the OOXML package around the document body
+func buildDOCXPackage(body string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`},
+		{"word/_rels/document.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`},
+		{"word/styles.xml", docxStyles()},
+		{"word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>` + body + `</w:body>
+</w:document>`},
+	}
+
+	for _, part := range parts {
+		fw, err := w.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", part.name, err)
+		}
+		if _, err := fw.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize docx: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// docxStyles returns a styles part defining the title, heading, list and table styles used by ToDOCX
+func docxStyles() string {
+	var s strings.Builder
+	s.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:rPr><w:b/><w:sz w:val="56"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/><w:basedOn w:val="Normal"/><w:pPr><w:ind w:left="720"/></w:pPr></w:style>
+<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders>` +
+		`<w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`</w:tblBorders></w:tblPr></w:style>
+`)
+	for level := 1; level <= maxDOCXHeadingLevel; level++ {
+		// Sizes are in half-points: 32pt for level 1 down to 12pt for level 6
+		size := 64 - (level-1)*8
+		s.WriteString(fmt.Sprintf(`<w:style w:type="paragraph" w:styleId="Heading%d"><w:name w:val="heading %d"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:outlineLvl w:val="%d"/></w:pPr><w:rPr><w:b/><w:sz w:val="%d"/></w:rPr></w:style>
+`, level, level, level-1, size))
+	}
+	s.WriteString(`</w:styles>`)
+	return s.String()
+}
+
+// escapeXML escapes XML special characters
+func escapeXML(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	s = strings.ReplaceAll(s, "\"", "&quot;")
+	return s
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+)
+
+func TestConverterToDOCX(t *testing.T) {
+	result := &pdf.ExtractResult{
+		Filename: "report.pdf",
+		Metadata: pdf.Metadata{Title: "Annual Report"},
+		Pages: []pdf.Page{
+			{
+				Number: 1,
+				Elements: []pdf.Element{
+					{Type: "heading", Content: "Overview", Level: 1},
+					{Type: "text", Content: "Sales grew by 10% & costs fell."},
+					{Type: "list_item", Content: "- First point", Marker: "-"},
+				},
+				Tables: []pdf.Table{
+					{Data: [][]string{{"Name", "Value"}, {"North", "1200"}}},
+				},
+			},
+			{
+				Number: 2,
+				Text:   "Summary\nThis page has no structured elements.",
+			},
+		},
+	}
+
+	data, err := NewConverter(result).ToDOCX()
+	if err != nil {
+		t.Fatalf("ToDOCX failed: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a valid zip package: %v", err)
+	}
+
+	parts := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	doc := parts["word/document.xml"]
+	for _, expected := range []string{
+		`<w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">Annual Report</w:t>`,
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Overview</w:t>`,
+		`Sales grew by 10% &amp; costs fell.`,
+		`<w:t xml:space="preserve">- First point</w:t>`,
+		`<w:tbl>`,
+		`<w:tblHeader/>`,
+		`<w:t xml:space="preserve">1200</w:t>`,
+		`<w:br w:type="page"/>`,
+		`<w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t xml:space="preserve">Summary</w:t>`,
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("document.xml missing %q", expected)
+		}
+	}
+
+	if !strings.Contains(parts["word/styles.xml"], `w:styleId="Heading1"`) {
+		t.Error("styles.xml should define heading styles")
+	}
+}
+
+func TestConvertDOCXFormat(t *testing.T) {
+	result := &pdf.ExtractResult{Pages: []pdf.Page{{Number: 1, Text: "Hello world, this line is long enough to be body text."}}}
+
+	output, err := NewConverter(result).Convert(FormatDOCX)
+	if err != nil {
+		t.Fatalf("Convert(FormatDOCX) failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "PK") {
+		t.Error("DOCX output should be a zip package")
+	}
+}
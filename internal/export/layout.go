@@ -0,0 +1,101 @@
+package export
+
+import (
+	"encoding/json"
+
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+)
+
+// LayoutDocument is the JSON export of an extraction result. It keeps the
+// bounding box of every element and table so the layout can be rebuilt or
+// searched by position. Coordinates are in PDF points with the origin at the
+// top-left corner of the page, y growing downwards.
+type LayoutDocument struct {
+	Filename string       `json:"filename"`
+	Metadata pdf.Metadata `json:"metadata"`
+	Units    string       `json:"units"`
+	Origin   string       `json:"origin"`
+	Pages    []LayoutPage `json:"pages"`
+}
+
+// LayoutPage is one page of a LayoutDocument. Text holds the page's plain
+// text when the extractor found no positioned elements on it.
+type LayoutPage struct {
+	Number   int           `json:"number"`
+	Width    float64       `json:"width"`
+	Height   float64       `json:"height"`
+	Elements []pdf.Element `json:"elements"`
+	Tables   []LayoutTable `json:"tables"`
+	Text     string        `json:"text,omitempty"`
+}
+
+// LayoutTable is a table with its bounding box, omitted when the extractor
+// reported none
+type LayoutTable struct {
+	Index int        `json:"index"`
+	Rows  int        `json:"rows"`
+	Cols  int        `json:"cols"`
+	BBox  *pdf.BBox  `json:"bbox,omitempty"`
+	Data  [][]string `json:"data"`
+}
+
+// ToLayout converts the extraction result to a LayoutDocument
+func (c *Converter) ToLayout() LayoutDocument {
+	doc := LayoutDocument{
+		Filename: c.result.Filename,
+		Metadata: c.result.Metadata,
+		Units:    "pt",
+		Origin:   "top-left",
+		Pages:    make([]LayoutPage, 0, len(c.result.Pages)),
+	}
+
+	for _, page := range c.result.Pages {
+		layoutPage := LayoutPage{
+			Number:   page.Number,
+			Width:    page.Layout.Width,
+			Height:   page.Layout.Height,
+			Elements: page.Elements,
+			Tables:   make([]LayoutTable, 0, len(page.Tables)),
+		}
+		if layoutPage.Elements == nil {
+			layoutPage.Elements = []pdf.Element{}
+		}
+		if len(page.Elements) == 0 {
+			layoutPage.Text = page.Text
+		}
+		for i, table := range page.Tables {
+			layoutPage.Tables = append(layoutPage.Tables, LayoutTable{
+				Index: i,
+				Rows:  table.Rows,
+				Cols:  table.Cols,
+				BBox:  tableBBox(table),
+				Data:  table.Data,
+			})
+		}
+		doc.Pages = append(doc.Pages, layoutPage)
+	}
+	return doc
+}
+
+// ToJSON converts the extraction result to indented JSON with the
+// coordinates of every element and table (see LayoutDocument)
+func (c *Converter) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(c.ToLayout(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// tableBBox returns a table's bounding box, falling back to the deprecated
+// position that older extraction scripts report
+func tableBBox(table pdf.Table) *pdf.BBox {
+	if table.BBox != nil {
+		return table.BBox
+	}
+	p := table.Position
+	if p.Width == 0 && p.Height == 0 {
+		return nil
+	}
+	return &pdf.BBox{X: p.X, Y: p.Y, Width: p.Width, Height: p.Height}
+}
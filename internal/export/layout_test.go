@@ -0,0 +1,68 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+)
+
+func TestConverterToJSON(t *testing.T) {
+	result := &pdf.ExtractResult{
+		Filename: "report.pdf",
+		Pages: []pdf.Page{
+			{
+				Number: 1,
+				Layout: pdf.Layout{Width: 595, Height: 842},
+				Elements: []pdf.Element{
+					{Type: "heading", Content: "Report", Level: 1, BBox: pdf.BBox{X: 72, Y: 60, Width: 120, Height: 18}},
+				},
+				Tables: []pdf.Table{
+					{Rows: 1, Cols: 2, Data: [][]string{{"a", "b"}}, BBox: &pdf.BBox{X: 72, Y: 200, Width: 300, Height: 40}},
+					{Rows: 1, Cols: 1, Data: [][]string{{"c"}}, Position: pdf.Position{X: 72, Y: 300, Width: 100, Height: 20}},
+				},
+			},
+			{Number: 2, Text: "plain text", Tables: []pdf.Table{{Rows: 1, Cols: 1, Data: [][]string{{"d"}}}}},
+		},
+	}
+
+	output, err := NewConverter(result).Convert(FormatJSON)
+	if err != nil {
+		t.Fatalf("Convert(FormatJSON) failed: %v", err)
+	}
+	var doc LayoutDocument
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if doc.Units != "pt" || doc.Origin != "top-left" || len(doc.Pages) != 2 {
+		t.Fatalf("got units %q, origin %q, %d pages", doc.Units, doc.Origin, len(doc.Pages))
+	}
+	first := doc.Pages[0]
+	if first.Width != 595 || first.Height != 842 || first.Text != "" {
+		t.Errorf("first page = %+v", first)
+	}
+	if len(first.Elements) != 1 || first.Elements[0].BBox != (pdf.BBox{X: 72, Y: 60, Width: 120, Height: 18}) {
+		t.Errorf("element coordinates not kept: %+v", first.Elements)
+	}
+	if first.Tables[0].BBox == nil || first.Tables[0].BBox.Y != 200 {
+		t.Errorf("table bbox = %+v, want y 200", first.Tables[0].BBox)
+	}
+	if first.Tables[1].BBox == nil || first.Tables[1].BBox.Y != 300 || first.Tables[1].Index != 1 {
+		t.Errorf("table position should fill in the bbox: %+v", first.Tables[1])
+	}
+
+	second := doc.Pages[1]
+	if second.Text != "plain text" || second.Elements == nil || second.Tables[0].BBox != nil {
+		t.Errorf("second page = %+v", second)
+	}
+}
+
+func TestParseFormatJSON(t *testing.T) {
+	if format, err := ParseFormat("json"); err != nil || format != FormatJSON {
+		t.Errorf("ParseFormat(json) = %q, %v", format, err)
+	}
+	if format, ok := FormatForPath("out/layout.JSON"); !ok || format != FormatJSON {
+		t.Errorf("FormatForPath(layout.JSON) = %q, %v", format, ok)
+	}
+}
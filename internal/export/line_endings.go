@@ -0,0 +1,50 @@
+package export
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// LineEnding is the line ending written to text output files
+type LineEnding string
+
+const (
+	LineEndingLF   LineEnding = "lf"
+	LineEndingCRLF LineEnding = "crlf"
+	// LineEndingAuto uses the platform's convention: CRLF on Windows, LF elsewhere
+	LineEndingAuto LineEnding = "auto"
+)
+
+// ParseLineEnding parses a line ending name
+func ParseLineEnding(name string) (LineEnding, error) {
+	switch ending := LineEnding(strings.ToLower(strings.TrimSpace(name))); ending {
+	case LineEndingLF, LineEndingCRLF, LineEndingAuto:
+		return ending, nil
+	default:
+		return "", fmt.Errorf("unsupported line ending: %s (use 'lf', 'crlf' or 'auto')", name)
+	}
+}
+
+// resolve returns LF or CRLF, resolving auto for the platform
+func (e LineEnding) resolve() LineEnding {
+	if e != LineEndingAuto {
+		return e
+	}
+	if runtime.GOOS == "windows" {
+		return LineEndingCRLF
+	}
+	return LineEndingLF
+}
+
+// NormalizeLineEndings converts every CRLF, CR and LF line break in text to
+// the given line ending, so that output mixing them, as model responses and
+// text from different platforms can, is written consistently
+func NormalizeLineEndings(text string, ending LineEnding) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	if ending.resolve() == LineEndingCRLF {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return text
+}
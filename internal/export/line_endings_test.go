@@ -0,0 +1,36 @@
+package export
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestNormalizeLineEndings(t *testing.T) {
+	mixed := "# Title\r\n\r\nFirst line\nSecond line\rThird line\n"
+
+	if got, want := NormalizeLineEndings(mixed, LineEndingLF), "# Title\n\nFirst line\nSecond line\nThird line\n"; got != want {
+		t.Errorf("lf: got %q, want %q", got, want)
+	}
+	if got, want := NormalizeLineEndings(mixed, LineEndingCRLF), "# Title\r\n\r\nFirst line\r\nSecond line\r\nThird line\r\n"; got != want {
+		t.Errorf("crlf: got %q, want %q", got, want)
+	}
+
+	native := LineEndingLF
+	if runtime.GOOS == "windows" {
+		native = LineEndingCRLF
+	}
+	if got, want := NormalizeLineEndings(mixed, LineEndingAuto), NormalizeLineEndings(mixed, native); got != want {
+		t.Errorf("auto: got %q, want %q", got, want)
+	}
+}
+
+func TestParseLineEnding(t *testing.T) {
+	for name, want := range map[string]LineEnding{"lf": LineEndingLF, "CRLF": LineEndingCRLF, " auto ": LineEndingAuto} {
+		if got, err := ParseLineEnding(name); err != nil || got != want {
+			t.Errorf("ParseLineEnding(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseLineEnding("cr"); err == nil {
+		t.Error("ParseLineEnding(\"cr\") should fail")
+	}
+}
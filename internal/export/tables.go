@@ -0,0 +1,69 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// tableCSVName returns the file name of the m-th table (1-based) on page n
+func tableCSVName(page, table int) string {
+	return fmt.Sprintf("page%d_table%d.csv", page, table)
+}
+
+// WriteTablesCSV writes every extracted table to dir as a separate CSV file
+// named page{N}_table{M}.csv and returns the paths written. Rows are padded
+// to the widest row so every record has the same number of fields. Cells
+// containing commas, quotes or newlines are quoted.
+func (c *Converter) WriteTablesCSV(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tables directory: %w", err)
+	}
+
+	var written []string
+	for _, page := range c.result.Pages {
+		for i, table := range page.Tables {
+			if len(table.Data) == 0 {
+				continue
+			}
+			path := filepath.Join(dir, tableCSVName(page.Number, i+1))
+			if err := writeTableCSV(path, table.Data); err != nil {
+				return written, err
+			}
+			written = append(written, path)
+		}
+	}
+	return written, nil
+}
+
+// writeTableCSV writes table rows to a CSV file
+func writeTableCSV(path string, data [][]string) error {
+	width := 0
+	for _, row := range data {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	writer := csv.NewWriter(file)
+	for _, row := range data {
+		record := make([]string, width)
+		copy(record, row)
+		if err := writer.Write(record); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
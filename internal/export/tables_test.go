@@ -0,0 +1,60 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/pdf"
+)
+
+func TestWriteTablesCSV(t *testing.T) {
+	result := &pdf.ExtractResult{
+		Pages: []pdf.Page{
+			{
+				Number: 1,
+				Tables: []pdf.Table{
+					{Data: [][]string{{"Region", "Note"}, {"North", "Up 10%, best quarter"}, {"South"}}},
+					{Data: [][]string{}},
+					{Data: [][]string{{"Quote", "Line"}, {`He said "hi"`, "first\nsecond"}}},
+				},
+			},
+			{Number: 2},
+			{
+				Number: 3,
+				Tables: []pdf.Table{{Data: [][]string{{"매출", "1,200"}}}},
+			},
+		},
+	}
+
+	dir := filepath.Join(t.TempDir(), "tables")
+	written, err := NewConverter(result).WriteTablesCSV(dir)
+	if err != nil {
+		t.Fatalf("WriteTablesCSV failed: %v", err)
+	}
+
+	wantFiles := []string{
+		filepath.Join(dir, "page1_table1.csv"),
+		filepath.Join(dir, "page1_table3.csv"),
+		filepath.Join(dir, "page3_table1.csv"),
+	}
+	if !reflect.DeepEqual(written, wantFiles) {
+		t.Fatalf("written = %v, want %v", written, wantFiles)
+	}
+
+	wantContent := map[string]string{
+		"page1_table1.csv": "Region,Note\nNorth,\"Up 10%, best quarter\"\nSouth,\n",
+		"page1_table3.csv": "Quote,Line\n\"He said \"\"hi\"\"\",\"first\nsecond\"\n",
+		"page3_table1.csv": "매출,\"1,200\"\n",
+	}
+	for name, want := range wantContent {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", name, data, want)
+		}
+	}
+}
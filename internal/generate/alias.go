@@ -0,0 +1,36 @@
+package generate
+
+import "strings"
+
+// BuiltinModelAliases are short names for commonly used models. Aliases in
+// the configuration file's models section take precedence over them.
+var BuiltinModelAliases = map[string]string{
+	"fast":   "claude-3-haiku-20240307",
+	"smart":  "gpt-4",
+	"haiku":  "claude-3-haiku-20240307",
+	"sonnet": "claude-3-sonnet-20240229",
+	"opus":   "claude-3-opus-20240229",
+	"gpt4":   "gpt-4",
+	"gpt4t":  "gpt-4-turbo-preview",
+	"gpt35":  "gpt-3.5-turbo",
+}
+
+// ResolveModelAlias expands a model alias to the full model name. Aliases
+// are matched without regard to case, those in aliases first and then the
+// built-in ones. A name that is not an alias is returned unchanged, so full
+// model names and models dox does not know pass through as given.
+func ResolveModelAlias(name string, aliases map[string]string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return name
+	}
+	for alias, model := range aliases {
+		if strings.ToLower(alias) == key && model != "" {
+			return model
+		}
+	}
+	if model, ok := BuiltinModelAliases[key]; ok {
+		return model
+	}
+	return name
+}
@@ -0,0 +1,44 @@
+package generate
+
+import "testing"
+
+func TestResolveModelAlias(t *testing.T) {
+	configured := map[string]string{
+		"Draft": "gpt-3.5-turbo-16k",
+		"fast":  "gpt-3.5-turbo", // overrides the built-in alias
+	}
+
+	tests := []struct {
+		name    string
+		aliases map[string]string
+		want    string
+	}{
+		{"sonnet", nil, "claude-3-sonnet-20240229"},
+		{"FAST", nil, "claude-3-haiku-20240307"},
+		{"fast", configured, "gpt-3.5-turbo"},
+		{"draft", configured, "gpt-3.5-turbo-16k"},
+		{"smart", configured, "gpt-4"},
+		{"claude-3-opus-20240229", configured, "claude-3-opus-20240229"},
+		{"my-finetune", configured, "my-finetune"},
+		{"", configured, ""},
+	}
+	for _, tt := range tests {
+		if got := ResolveModelAlias(tt.name, tt.aliases); got != tt.want {
+			t.Errorf("ResolveModelAlias(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Every built-in alias names a model dox knows, and resolves to its provider
+	known := map[string]bool{}
+	for _, info := range GetModelInfo("") {
+		known[info.Model] = true
+	}
+	for alias, model := range BuiltinModelAliases {
+		if !known[model] {
+			t.Errorf("built-in alias %q names unknown model %q", alias, model)
+		}
+	}
+	if DetectProviderFromModel(ResolveModelAlias("haiku", nil)) != ProviderClaude {
+		t.Error("haiku should resolve to a Claude model")
+	}
+}
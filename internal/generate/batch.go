@@ -0,0 +1,103 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pyhub/pyhub-docs/internal/template"
+	"gopkg.in/yaml.v3"
+)
+
+// BatchEntry describes a single generation in a batch file
+type BatchEntry struct {
+	Prompt      string `yaml:"prompt"`
+	ContentType string `yaml:"type,omitempty"`
+	Output      string `yaml:"output"`
+	// Values fill the placeholders of an output template (see ParseBatchEntriesWithOutputTemplate)
+	Values map[string]string `yaml:"values,omitempty"`
+}
+
+// ParseBatchEntries parses YAML data into a slice of batch entries
+func ParseBatchEntries(data []byte) ([]BatchEntry, error) {
+	return parseBatchEntries(data, nil, "")
+}
+
+// ParseBatchEntriesWithOutputTemplate parses batch entries, naming entries
+// without an output from an output template such as "post_{{topic}}.md".
+// Placeholders resolve from the entry's values, its type (defaultType when
+// the entry has none) and the built-in {{index}} and {{date}}.
+func ParseBatchEntriesWithOutputTemplate(data []byte, pattern, defaultType string) ([]BatchEntry, error) {
+	names, err := template.NewFilenameTemplate(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return parseBatchEntries(data, names, defaultType)
+}
+
+func parseBatchEntries(data []byte, names *template.FilenameTemplate, defaultType string) ([]BatchEntry, error) {
+	if len(data) == 0 {
+		return []BatchEntry{}, nil
+	}
+
+	var entries []BatchEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	outputs := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		if entry.Prompt == "" {
+			return nil, fmt.Errorf("entry at index %d: missing required field 'prompt'", i)
+		}
+		if entry.Output == "" && names != nil {
+			values := map[string]interface{}{"type": defaultType}
+			if entry.ContentType != "" {
+				values["type"] = entry.ContentType
+			}
+			for key, value := range entry.Values {
+				values[key] = value
+			}
+			output, err := names.Resolve(i+1, values)
+			if err != nil {
+				return nil, fmt.Errorf("entry at index %d: %w", i, err)
+			}
+			entry.Output = output
+			entries[i].Output = output
+		}
+		if entry.Output == "" {
+			return nil, fmt.Errorf("entry at index %d: missing required field 'output'", i)
+		}
+		if prev, exists := outputs[entry.Output]; exists {
+			return nil, fmt.Errorf("entry at index %d: output %s already used by entry at index %d", i, entry.Output, prev)
+		}
+		outputs[entry.Output] = i
+	}
+
+	return entries, nil
+}
+
+// LoadBatchFile loads batch generation entries from a YAML file
+func LoadBatchFile(filename string) ([]BatchEntry, error) {
+	return LoadBatchFileWithOutputTemplate(filename, "", "")
+}
+
+// LoadBatchFileWithOutputTemplate loads batch entries from a YAML file,
+// naming entries without an output from pattern when it is not empty
+func LoadBatchFileWithOutputTemplate(filename, pattern, defaultType string) ([]BatchEntry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var entries []BatchEntry
+	if pattern != "" {
+		entries, err = ParseBatchEntriesWithOutputTemplate(data, pattern, defaultType)
+	} else {
+		entries, err = ParseBatchEntries(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse batch entries from %s: %w", filename, err)
+	}
+
+	return entries, nil
+}
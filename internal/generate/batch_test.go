@@ -0,0 +1,122 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBatchEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr string
+	}{
+		{
+			name: "valid entries",
+			data: `
+- prompt: "Spring campaign"
+  type: blog
+  output: spring.md
+- prompt: "Summer campaign"
+  output: summer.md
+`,
+			want: 2,
+		},
+		{
+			name: "empty data",
+			data: "",
+			want: 0,
+		},
+		{
+			name:    "missing prompt",
+			data:    "- output: a.md\n",
+			wantErr: "missing required field 'prompt'",
+		},
+		{
+			name:    "missing output",
+			data:    "- prompt: hello\n",
+			wantErr: "missing required field 'output'",
+		},
+		{
+			name:    "duplicate output",
+			data:    "- prompt: a\n  output: same.md\n- prompt: b\n  output: same.md\n",
+			wantErr: "already used by entry at index 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseBatchEntries([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(entries) != tt.want {
+				t.Errorf("got %d entries, want %d", len(entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.yml")
+	if err := os.WriteFile(path, []byte("- prompt: hello\n  type: email\n  output: hello.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadBatchFile(path)
+	if err != nil {
+		t.Fatalf("LoadBatchFile failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ContentType != "email" || entries[0].Output != "hello.md" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	if _, err := LoadBatchFile(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestParseBatchEntriesWithOutputTemplate(t *testing.T) {
+	data := `
+- prompt: "Spring campaign"
+  values: {topic: "Spring / Easter"}
+- prompt: "Spring follow-up"
+  type: email
+  values: {topic: "Spring / Easter"}
+- prompt: "Fixed name"
+  output: fixed.md
+`
+	entries, err := ParseBatchEntriesWithOutputTemplate([]byte(data), "{{index}}_{{type}}_{{topic}}.md", "blog")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"1_blog_Spring _ Easter.md", "2_email_Spring _ Easter.md", "fixed.md"}
+	for i, entry := range entries {
+		if entry.Output != want[i] {
+			t.Errorf("entry %d output = %q, want %q", i, entry.Output, want[i])
+		}
+	}
+
+	// Entries resolving to the same name get a numeric suffix
+	entries, err = ParseBatchEntriesWithOutputTemplate([]byte("- prompt: a\n- prompt: b\n"), "post.md", "blog")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries[0].Output != "post.md" || entries[1].Output != "post_2.md" {
+		t.Errorf("outputs = %q, %q", entries[0].Output, entries[1].Output)
+	}
+
+	if _, err := ParseBatchEntriesWithOutputTemplate([]byte("- prompt: a\n"), "{{topic}}.md", "blog"); err == nil || !strings.Contains(err.Error(), "entry at index 0") {
+		t.Errorf("expected missing value error, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package generate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// budgetState is the content of a budget state file, which carries the
+// spend of a session across runs
+type budgetState struct {
+	Spent    float64   `json:"spent"`
+	Requests int       `json:"requests"`
+	Updated  time.Time `json:"updated"`
+}
+
+// Budget caps the estimated cost of the API requests of a session. Before
+// each request the cost of the prompt plus the full completion budget is
+// estimated with the TokenEstimator pricing, and the request is refused if
+// it could take the spend past the limit. After a request the cost of the
+// tokens the API reported is added to the spend. Models without a known
+// price are warned about once, as their estimates use a default price.
+//
+// A nil *Budget is valid and allows every request.
+type Budget struct {
+	mu       sync.Mutex
+	limit    float64
+	spent    float64
+	requests int
+	path     string
+	unpriced map[string]bool // Models already warned about
+}
+
+// NewBudget creates a budget of limit USD. With a state path, the spend
+// saved there by earlier runs counts against the limit and every request
+// adds to it.
+func NewBudget(limit float64, statePath string) (*Budget, error) {
+	if limit <= 0 {
+		return nil, pkgErrors.NewValidationError("budget", fmt.Sprintf("%.2f", limit), "budget must be greater than 0")
+	}
+	b := &Budget{limit: limit, path: statePath}
+	if statePath == "" {
+		return b, nil
+	}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, pkgErrors.NewFileError(statePath, "reading budget state", err)
+	}
+	var state budgetState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, pkgErrors.NewFileError(statePath, "reading budget state", fmt.Errorf("invalid budget state file: %w", err))
+	}
+	b.spent = state.Spent
+	b.requests = state.Requests
+	return b, nil
+}
+
+// Check returns a budget exceeded error if a request to model with a prompt
+// of promptTokens that may generate up to maxTokens could exceed the budget
+func (b *Budget) Check(model string, promptTokens, maxTokens int) error {
+	if b == nil {
+		return nil
+	}
+	estimator := NewTokenEstimator(model)
+	estimate, _ := estimator.EstimateCost(promptTokens, maxTokens)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !estimator.HasKnownPrice() && !b.unpriced[model] {
+		if b.unpriced == nil {
+			b.unpriced = make(map[string]bool)
+		}
+		b.unpriced[model] = true
+		ui.PrintWarning("No price is known for model %s; --budget estimates its cost at a default $1/$2 per 1M input/output tokens", model)
+	}
+	if b.spent+estimate > b.limit {
+		return pkgErrors.NewBudgetExceededError(b.spent, estimate, b.limit)
+	}
+	return nil
+}
+
+// Record adds the cost of a completed request to the spend and saves it to
+// the state file, if there is one
+func (b *Budget) Record(model string, promptTokens, completionTokens int) error {
+	if b == nil {
+		return nil
+	}
+	cost, _ := NewTokenEstimator(model).EstimateCost(promptTokens, completionTokens)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += cost
+	b.requests++
+	if b.path == "" {
+		return nil
+	}
+	return b.saveLocked()
+}
+
+// saveLocked writes the state file through a temporary file, so an
+// interruption while saving keeps the previous spend
+func (b *Budget) saveLocked() error {
+	data, err := json.MarshalIndent(budgetState{Spent: b.spent, Requests: b.requests, Updated: time.Now()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return pkgErrors.NewFileError(b.path, "saving budget state", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return pkgErrors.NewFileError(b.path, "saving budget state", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return pkgErrors.NewFileError(b.path, "saving budget state", err)
+	}
+	if err := os.Rename(tmp.Name(), b.path); err != nil {
+		return pkgErrors.NewFileError(b.path, "saving budget state", err)
+	}
+	return nil
+}
+
+// Spent returns the estimated spend so far in USD, including earlier runs
+// recorded in the state file
+func (b *Budget) Spent() float64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+// Limit returns the budget in USD
+func (b *Budget) Limit() float64 {
+	if b == nil {
+		return 0
+	}
+	return b.limit
+}
+
+// IsBudgetExceeded reports whether err is a budget exceeded error, which
+// should stop a session rather than be retried or sent to a fallback model
+func IsBudgetExceeded(err error) bool {
+	return pkgErrors.GetErrorCode(err) == pkgErrors.ErrCodeBudgetExceeded
+}
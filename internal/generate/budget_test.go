@@ -0,0 +1,149 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pyhub/pyhub-docs/internal/cache"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestNewBudget(t *testing.T) {
+	var validationErr *pkgErrors.ValidationError
+	if _, err := NewBudget(0, ""); !errors.As(err, &validationErr) {
+		t.Errorf("NewBudget(0) error = %v, want validation error", err)
+	}
+
+	budget, err := NewBudget(5, filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("NewBudget() with a missing state file error = %v", err)
+	}
+	if budget.Spent() != 0 || budget.Limit() != 5 {
+		t.Errorf("Spent() = %v, Limit() = %v; want 0, 5", budget.Spent(), budget.Limit())
+	}
+}
+
+func TestBudgetCheck(t *testing.T) {
+	// gpt-4 costs $30 per 1M prompt tokens and $60 per 1M completion tokens
+	budget, err := NewBudget(0.10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := budget.Check("gpt-4", 1000, 1000); err != nil {
+		t.Errorf("Check() of a $0.09 request error = %v", err)
+	}
+	if err := budget.Record("gpt-4", 1000, 500); err != nil {
+		t.Fatal(err)
+	}
+	if got := budget.Spent(); got < 0.0599 || got > 0.0601 {
+		t.Errorf("Spent() = %v, want 0.06", got)
+	}
+
+	err = budget.Check("gpt-4", 1000, 1000)
+	if !IsBudgetExceeded(err) {
+		t.Fatalf("Check() past the budget error = %v, want budget exceeded", err)
+	}
+	var coded *pkgErrors.CodedError
+	if !errors.As(err, &coded) || coded.Code != pkgErrors.ErrCodeBudgetExceeded {
+		t.Errorf("error = %v, want code %s", err, pkgErrors.ErrCodeBudgetExceeded)
+	}
+
+	var none *Budget
+	if err := none.Check("gpt-4", 1_000_000, 1_000_000); err != nil {
+		t.Errorf("nil budget Check() error = %v", err)
+	}
+	if err := none.Record("gpt-4", 1, 1); err != nil {
+		t.Errorf("nil budget Record() error = %v", err)
+	}
+}
+
+func TestBudgetPricesFromCatalog(t *testing.T) {
+	// Claude Opus 4.1 costs $75 per 1M completion tokens, far above the
+	// default price assumed for unknown models
+	for _, model := range []string{"claude-opus-4-1", "claude-opus-4-1-20250805"} {
+		estimator := NewTokenEstimator(model)
+		if cost, _ := estimator.EstimateCost(0, 5000); cost < 0.3749 || cost > 0.3751 {
+			t.Errorf("EstimateCost(%s) = %v, want 0.375", model, cost)
+		}
+		if !estimator.HasKnownPrice() {
+			t.Errorf("HasKnownPrice(%s) = false", model)
+		}
+	}
+	budget, err := NewBudget(0.30, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := budget.Check("claude-opus-4-1", 0, 5000); !IsBudgetExceeded(err) {
+		t.Errorf("Check() of a $0.375 request under a $0.30 budget error = %v, want budget exceeded", err)
+	}
+
+	for _, info := range GetModelInfo("") {
+		if info.InputPrice <= 0 || info.OutputPrice <= 0 {
+			t.Errorf("catalog model %s has no price", info.Model)
+		}
+	}
+	if NewTokenEstimator("custom-model").HasKnownPrice() {
+		t.Error("HasKnownPrice(custom-model) = true, want false")
+	}
+}
+
+func TestBudgetState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spend.json")
+	first, err := NewBudget(1, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Record("gpt-4", 10000, 5000); err != nil {
+		t.Fatal(err)
+	}
+
+	// A later run starts from the saved spend
+	second, err := NewBudget(1, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Spent() != first.Spent() {
+		t.Errorf("reloaded Spent() = %v, want %v", second.Spent(), first.Spent())
+	}
+}
+
+func TestGeneratorBudget(t *testing.T) {
+	budget, err := NewBudget(0.000001, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen, err := NewGenerator(ProviderOpenAI, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen.EnableCache(time.Minute, 10)
+	gen.SetBudget(budget)
+	if err := gen.SetFallback("claude-3-haiku-20240307", "claude-key", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fallback could answer from the cache, but is not tried once the
+	// budget is exhausted
+	options := DefaultGenerateOptions()
+	fallbackOptions := options
+	fallbackOptions.Model = "claude-3-haiku-20240307"
+	gen.cache.Set(context.Background(), newCacheRequest(ProviderClaude, "budget prompt", fallbackOptions), &cache.AIResponse{Content: "from claude"})
+
+	if _, err := gen.GenerateContent("budget prompt", options); !IsBudgetExceeded(err) {
+		t.Fatalf("GenerateContent() error = %v, want budget exceeded", err)
+	}
+
+	// Cache hits cost nothing and are never refused
+	gen.cache.Set(context.Background(), newCacheRequest(ProviderOpenAI, "budget prompt", options), &cache.AIResponse{Content: "cached"})
+	content, err := gen.GenerateContent("budget prompt", options)
+	if err != nil {
+		t.Fatalf("GenerateContent() of a cached prompt error = %v", err)
+	}
+	if content != "cached" || budget.Spent() != 0 {
+		t.Errorf("GenerateContent() = %q with $%v spent; want the cached content for free", content, budget.Spent())
+	}
+}
@@ -0,0 +1,125 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// ContentGenerator generates content for a prompt. *Generator implements it.
+type ContentGenerator interface {
+	GenerateContent(prompt string, options GenerateOptions) (string, error)
+}
+
+// CompareTarget is one provider/model combination in a comparison
+type CompareTarget struct {
+	Provider  AIProvider
+	Model     string
+	Generator ContentGenerator
+}
+
+// CompareResult holds one model's output and estimated usage in a comparison
+type CompareResult struct {
+	Provider         AIProvider
+	Model            string
+	Content          string
+	Err              error
+	Duration         time.Duration
+	PromptTokens     int // Estimated
+	CompletionTokens int // Estimated from the returned content
+	Cost             float64
+	Currency         string
+}
+
+// ParseCompareModels parses a --compare-providers value of two
+// comma-separated model names, e.g. "gpt-4o,claude-3-5-sonnet-latest"
+func ParseCompareModels(value string) ([]string, error) {
+	var models []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			models = append(models, part)
+		}
+	}
+	if len(models) != 2 {
+		return nil, pkgErrors.NewValidationError("compare-providers", value, "must name exactly two models separated by a comma")
+	}
+	if strings.EqualFold(models[0], models[1]) {
+		return nil, pkgErrors.NewValidationError("compare-providers", value, "must name two different models")
+	}
+	return models, nil
+}
+
+// CompareModels generates the same prompt with every target concurrently and
+// returns the results in target order. The temperature is clamped to each
+// provider's range; a failed target is reported in its result's Err.
+func CompareModels(prompt string, options GenerateOptions, targets []CompareTarget) []CompareResult {
+	results := make([]CompareResult, len(targets))
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target CompareTarget) {
+			defer wg.Done()
+
+			targetOptions := options
+			targetOptions.Model = target.Model
+			targetOptions.Temperature = ClampTemperature(target.Provider, options.Temperature)
+
+			started := time.Now()
+			content, err := target.Generator.GenerateContent(prompt, targetOptions)
+			result := CompareResult{
+				Provider: target.Provider,
+				Model:    target.Model,
+				Content:  content,
+				Err:      err,
+				Duration: time.Since(started),
+			}
+
+			estimator := NewTokenEstimator(target.Model)
+			result.PromptTokens = estimator.EstimateTokens(prompt)
+			if err == nil {
+				result.CompletionTokens = estimator.EstimateTokens(content)
+			}
+			result.Cost, result.Currency = estimator.EstimateCost(result.PromptTokens, result.CompletionTokens)
+			results[i] = result
+		}(i, target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// FormatComparison renders comparison results as Markdown: a summary table
+// of time and estimated usage, then one section per model
+func FormatComparison(results []CompareResult) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Model Comparison\n\n")
+	sb.WriteString("| Model | Provider | Time | Prompt tokens | Output tokens | Est. cost |\n")
+	sb.WriteString("|-------|----------|------|---------------|---------------|-----------|\n")
+	for _, r := range results {
+		if r.Err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | ~%d | failed | - |\n",
+				r.Model, r.Provider, ui.FormatDuration(r.Duration), r.PromptTokens))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | ~%d | ~%d | ~$%.4f %s |\n",
+			r.Model, r.Provider, ui.FormatDuration(r.Duration), r.PromptTokens, r.CompletionTokens, r.Cost, r.Currency))
+	}
+
+	for _, r := range results {
+		sb.WriteString(fmt.Sprintf("\n## %s (%s)\n\n", r.Model, r.Provider))
+		if r.Err != nil {
+			sb.WriteString(fmt.Sprintf("> Generation failed: %v\n", ui.RedactError(r.Err)))
+			continue
+		}
+		sb.WriteString(strings.TrimSpace(r.Content))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGenerator returns canned content and records the options it received
+type fakeGenerator struct {
+	content string
+	err     error
+
+	mu      sync.Mutex
+	options GenerateOptions
+}
+
+func (f *fakeGenerator) GenerateContent(prompt string, options GenerateOptions) (string, error) {
+	f.mu.Lock()
+	f.options = options
+	f.mu.Unlock()
+	return f.content, f.err
+}
+
+func TestParseCompareModels(t *testing.T) {
+	models, err := ParseCompareModels(" gpt-4o , claude-3-5-sonnet-latest ")
+	if err != nil {
+		t.Fatalf("ParseCompareModels() error = %v", err)
+	}
+	if len(models) != 2 || models[0] != "gpt-4o" || models[1] != "claude-3-5-sonnet-latest" {
+		t.Errorf("ParseCompareModels() = %q", models)
+	}
+
+	for _, value := range []string{"gpt-4o", "gpt-4o,claude-3-opus,gpt-4", "gpt-4o,", "gpt-4o,GPT-4o"} {
+		if _, err := ParseCompareModels(value); err == nil {
+			t.Errorf("ParseCompareModels(%q) expected error", value)
+		}
+	}
+}
+
+func TestCompareModels(t *testing.T) {
+	openai := &fakeGenerator{content: "OpenAI answer"}
+	claude := &fakeGenerator{err: errors.New("overloaded")}
+
+	results := CompareModels("Write a haiku", GenerateOptions{Temperature: 1.5, MaxTokens: 100}, []CompareTarget{
+		{Provider: ProviderOpenAI, Model: "gpt-4o", Generator: openai},
+		{Provider: ProviderClaude, Model: "claude-3-opus-20240229", Generator: claude},
+	})
+
+	if len(results) != 2 {
+		t.Fatalf("CompareModels() returned %d results, want 2", len(results))
+	}
+	if results[0].Model != "gpt-4o" || results[0].Content != "OpenAI answer" || results[0].Err != nil {
+		t.Errorf("first result = %+v", results[0])
+	}
+	if results[0].PromptTokens == 0 || results[0].CompletionTokens == 0 {
+		t.Errorf("first result has no token estimate: %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].CompletionTokens != 0 {
+		t.Errorf("failed result = %+v", results[1])
+	}
+
+	// Each target gets its own model, and the temperature is clamped per provider
+	if openai.options.Model != "gpt-4o" || openai.options.Temperature != 1.5 {
+		t.Errorf("openai options = %+v", openai.options)
+	}
+	if claude.options.Model != "claude-3-opus-20240229" || claude.options.Temperature != 1.0 {
+		t.Errorf("claude options = %+v", claude.options)
+	}
+}
+
+func TestFormatComparison(t *testing.T) {
+	out := FormatComparison([]CompareResult{
+		{Provider: ProviderOpenAI, Model: "gpt-4o", Content: "First answer\n", PromptTokens: 10, CompletionTokens: 3, Currency: "USD"},
+		{Provider: ProviderClaude, Model: "claude-3-opus-20240229", Err: errors.New("overloaded"), PromptTokens: 10},
+	})
+
+	for _, want := range []string{
+		"# Model Comparison",
+		"| gpt-4o | openai |",
+		"## gpt-4o (openai)\n\nFirst answer\n",
+		"## claude-3-opus-20240229 (claude)",
+		"> Generation failed: overloaded",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatComparison() missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "## gpt-4o") > strings.Index(out, "## claude") {
+		t.Error("sections should follow the result order")
+	}
+}
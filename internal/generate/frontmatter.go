@@ -0,0 +1,151 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// maxTitleRunes limits the title taken from a prompt's first line
+const maxTitleRunes = 100
+
+// Frontmatter is the YAML front matter prepended to generated Markdown for
+// static site generators. Fields keep the order they were given in.
+type Frontmatter struct {
+	keys   []*yaml.Node
+	values []*yaml.Node
+}
+
+// ParseFrontmatter parses a --frontmatter value: comma-separated key=value
+// pairs such as "title=Release notes,tags=go,cli", where a part without "="
+// adds another item to the previous key, making it a list, or "@file" to read
+// a YAML mapping from a file
+func ParseFrontmatter(spec string) (*Frontmatter, error) {
+	if strings.HasPrefix(spec, "@") {
+		return loadFrontmatterFile(strings.TrimPrefix(spec, "@"))
+	}
+
+	fm := &Frontmatter{}
+	var last *yaml.Node
+	for _, part := range strings.Split(spec, ",") {
+		key, value, isPair := strings.Cut(part, "=")
+		if !isPair {
+			if last == nil {
+				if strings.TrimSpace(part) == "" {
+					continue
+				}
+				return nil, pkgErrors.NewValidationError("frontmatter", spec, fmt.Sprintf("%q is not a key=value pair", part))
+			}
+			// A further item of the previous key turns its value into a list
+			if last.Kind == yaml.ScalarNode {
+				*last = yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{scalarNode(last.Value)}}
+			}
+			last.Content = append(last.Content, scalarNode(strings.TrimSpace(part)))
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, pkgErrors.NewValidationError("frontmatter", spec, "field name cannot be empty")
+		}
+		if fm.Has(key) {
+			return nil, pkgErrors.NewValidationError("frontmatter", spec, fmt.Sprintf("field %q is given twice", key))
+		}
+		last = scalarNode(strings.TrimSpace(value))
+		fm.keys = append(fm.keys, scalarNode(key))
+		fm.values = append(fm.values, last)
+	}
+
+	if len(fm.keys) == 0 {
+		return nil, pkgErrors.NewValidationError("frontmatter", spec, "no fields given")
+	}
+	return fm, nil
+}
+
+// loadFrontmatterFile reads front matter fields from a YAML mapping
+func loadFrontmatterFile(path string) (*Frontmatter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, pkgErrors.NewFileError(path, "reading front matter", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, pkgErrors.NewFileError(path, "reading front matter", fmt.Errorf("failed to parse YAML: %w", err))
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, pkgErrors.NewFileError(path, "reading front matter", fmt.Errorf("expected a YAML mapping of field names to values"))
+	}
+
+	fm := &Frontmatter{}
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		fm.keys = append(fm.keys, mapping.Content[i])
+		fm.values = append(fm.values, mapping.Content[i+1])
+	}
+	return fm, nil
+}
+
+// scalarNode returns an untagged YAML scalar, so "true" and "3" keep their
+// YAML types and the encoder quotes text where needed
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+// Has reports whether the front matter sets a field
+func (f *Frontmatter) Has(key string) bool {
+	for _, k := range f.keys {
+		if k.Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Prepend returns content with the front matter block before it. A missing
+// title is taken from the prompt's first line and a missing date is the
+// given day; such defaults come before the given fields.
+func (f *Frontmatter) Prepend(content, prompt string, now time.Time) (string, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	if !f.Has("title") {
+		if title := TitleFromPrompt(prompt); title != "" {
+			// The title is always text, even if it reads as a number
+			mapping.Content = append(mapping.Content, scalarNode("title"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: title})
+		}
+	}
+	if !f.Has("date") {
+		mapping.Content = append(mapping.Content, scalarNode("date"), scalarNode(now.Format("2006-01-02")))
+	}
+	for i := range f.keys {
+		mapping.Content = append(mapping.Content, f.keys[i], f.values[i])
+	}
+
+	var buf strings.Builder
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(mapping); err != nil {
+		return "", fmt.Errorf("failed to write front matter: %w", err)
+	}
+	encoder.Close()
+	return "---\n" + buf.String() + "---\n\n" + strings.TrimLeft(content, "\n"), nil
+}
+
+// TitleFromPrompt returns the first non-empty line of a prompt, without
+// Markdown heading marks, shortened to a title length
+func TitleFromPrompt(prompt string) string {
+	for _, line := range strings.Split(prompt, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxTitleRunes {
+			line = strings.TrimSpace(string(runes[:maxTitleRunes])) + "…"
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrontmatterPrepend(t *testing.T) {
+	day := time.Date(2025, 3, 4, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		spec   string
+		prompt string
+		want   string
+	}{
+		{
+			name:   "defaults title and date",
+			spec:   "tags=go,cli,draft=true",
+			prompt: "## Writing Go CLIs\nCover cobra and flags",
+			want:   "---\ntitle: Writing Go CLIs\ndate: 2025-03-04\ntags:\n  - go\n  - cli\ndraft: true\n---\n\n# Post\n",
+		},
+		{
+			name:   "given title and date",
+			spec:   "title=Release: v2, date=2025-01-01",
+			prompt: "ignored",
+			want:   "---\ntitle: 'Release: v2'\ndate: 2025-01-01\n---\n\n# Post\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, err := ParseFrontmatter(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseFrontmatter(%q) error = %v", tt.spec, err)
+			}
+			got, err := fm.Prepend("\n# Post\n", tt.prompt, day)
+			if err != nil {
+				t.Fatalf("Prepend() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Prepend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFrontmatterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "front.yml")
+	os.WriteFile(path, []byte("layout: post\ncategories: [news, releases]\n"), 0644)
+
+	fm, err := ParseFrontmatter("@" + path)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter(@file) error = %v", err)
+	}
+	got, _ := fm.Prepend("Body", "Quarterly update", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC))
+	if !strings.Contains(got, "title: Quarterly update\n") || !strings.Contains(got, "layout: post\ncategories: [news, releases]\n") {
+		t.Errorf("Prepend() = %q", got)
+	}
+
+	os.WriteFile(path, []byte("- not\n- a mapping\n"), 0644)
+	if _, err := ParseFrontmatter("@" + path); err == nil {
+		t.Error("ParseFrontmatter should reject a YAML list")
+	}
+}
+
+func TestParseFrontmatterInvalid(t *testing.T) {
+	for _, spec := range []string{"", "draft", "=x", "title=a,title=b"} {
+		if _, err := ParseFrontmatter(spec); err == nil {
+			t.Errorf("ParseFrontmatter(%q) should fail", spec)
+		}
+	}
+}
+
+func TestTitleFromPrompt(t *testing.T) {
+	if got := TitleFromPrompt("\n  # Go generics  \nmore"); got != "Go generics" {
+		t.Errorf("TitleFromPrompt() = %q", got)
+	}
+	long := strings.Repeat("word ", 40)
+	if got := TitleFromPrompt(long); len([]rune(got)) > maxTitleRunes+1 || !strings.HasSuffix(got, "…") {
+		t.Errorf("TitleFromPrompt(long) = %q", got)
+	}
+}
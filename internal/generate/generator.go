@@ -27,9 +27,38 @@ const (
 // Generator handles content generation using AI
 type Generator struct {
 	provider      AIProvider
-	openaiClient  *openai.Client
-	claudeClient  *claude.Client
+	providerClients
 	cache         *cache.AICache
+	fallback      *fallbackTarget
+	servedBy      AIProvider
+	servedModel   string
+	limiter       *RateLimiter
+	budget        *Budget
+	retries       RetryStats
+	onRetry       func(attempt int, err error, delay time.Duration)
+}
+
+// RetryStats describes the retries made by the last GenerateContent call,
+// including retries against the fallback provider
+type RetryStats struct {
+	Retries int           // Failed attempts that were retried
+	Waited  time.Duration // Total backoff delay between attempts
+}
+
+// providerClients holds the API client of a provider; only the field for
+// that provider is set
+type providerClients struct {
+	openaiClient *openai.Client
+	claudeClient *claude.Client
+}
+
+// fallbackTarget is the provider/model tried when the primary provider fails.
+// It has clients of its own, so a fallback on the primary's provider keeps
+// the primary's client and API key intact.
+type fallbackTarget struct {
+	provider AIProvider
+	model    string
+	providerClients
 }
 
 // GenerateOptions contains options for content generation (provider-agnostic)
@@ -38,6 +67,40 @@ type GenerateOptions struct {
 	Model       string
 	MaxTokens   int
 	Temperature float64
+	TopP        float64 // Nucleus sampling; 0 leaves the provider default
+}
+
+// TemperatureRange returns the valid temperature range for a provider
+func TemperatureRange(provider AIProvider) (float64, float64) {
+	if provider == ProviderClaude {
+		return 0, 1
+	}
+	return 0, 2
+}
+
+// ValidateSamplingOptions rejects temperature and top-p values outside the provider's valid range
+func ValidateSamplingOptions(provider AIProvider, temperature, topP float64) error {
+	minTemp, maxTemp := TemperatureRange(provider)
+	if temperature < minTemp || temperature > maxTemp {
+		return pkgErrors.NewValidationError("temperature", temperature,
+			fmt.Sprintf("must be between %.1f and %.1f for %s", minTemp, maxTemp, provider))
+	}
+	if topP < 0 || topP > 1 {
+		return pkgErrors.NewValidationError("top-p", topP, "must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+// ClampTemperature limits temperature to the provider's valid range
+func ClampTemperature(provider AIProvider, temperature float64) float64 {
+	minTemp, maxTemp := TemperatureRange(provider)
+	if temperature < minTemp {
+		return minTemp
+	}
+	if temperature > maxTemp {
+		return maxTemp
+	}
+	return temperature
 }
 
 // NewGenerator creates a new content generator
@@ -46,6 +109,18 @@ func NewGenerator(provider AIProvider, apiKey string) (*Generator, error) {
 		provider: provider,
 	}
 
+	clients, err := gen.newClients(provider, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	gen.providerClients = clients
+
+	return gen, nil
+}
+
+// newClients creates the API client for the given provider
+func (g *Generator) newClients(provider AIProvider, apiKey string) (providerClients, error) {
+	var clients providerClients
 	switch provider {
 	case ProviderOpenAI:
 		// Check for API key from environment if not provided
@@ -54,14 +129,15 @@ func NewGenerator(provider AIProvider, apiKey string) (*Generator, error) {
 		}
 		
 		if apiKey == "" {
-			return nil, pkgErrors.NewConfigError("", "OpenAI API key not found", pkgErrors.ErrMissingAPIKey)
+			return clients, pkgErrors.NewConfigError("", "OpenAI API key not found", pkgErrors.ErrMissingAPIKey)
 		}
 
 		client, err := openai.NewClient(apiKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create OpenAI client: %w", err)
+			return clients, fmt.Errorf("failed to create OpenAI client: %w", err)
 		}
-		gen.openaiClient = client
+		client.SetRetryConfig(g.withRetryHook(client.RetryConfig()))
+		clients.openaiClient = client
 
 	case ProviderClaude:
 		// Check for API key from environment if not provided
@@ -73,20 +149,21 @@ func NewGenerator(provider AIProvider, apiKey string) (*Generator, error) {
 		}
 		
 		if apiKey == "" {
-			return nil, pkgErrors.NewConfigError("", "Claude API key not found", pkgErrors.ErrMissingAPIKey)
+			return clients, pkgErrors.NewConfigError("", "Claude API key not found", pkgErrors.ErrMissingAPIKey)
 		}
 
 		client, err := claude.NewClient(apiKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create Claude client: %w", err)
+			return clients, fmt.Errorf("failed to create Claude client: %w", err)
 		}
-		gen.claudeClient = client
+		client.SetRetryConfig(g.withRetryHook(client.RetryConfig()))
+		clients.claudeClient = client
 
 	default:
-		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
+		return clients, fmt.Errorf("unsupported AI provider: %s", provider)
 	}
 
-	return gen, nil
+	return clients, nil
 }
 
 // NewGeneratorWithConfig creates a new content generator with retry configuration and caching
@@ -106,35 +183,139 @@ func NewGeneratorWithConfig(provider AIProvider, apiKey string, cfg *config.Conf
 	gen.cache = cache.NewAICache(lruCache, 1*time.Hour)
 
 	// Apply retry configuration based on provider
+	gen.applyRetryConfig(&gen.providerClients, provider, cfg)
+
+	return gen, nil
+}
+
+// applyRetryConfig applies the provider's retry settings from the configuration
+// to its client
+func (g *Generator) applyRetryConfig(clients *providerClients, provider AIProvider, cfg *config.Config) {
 	switch provider {
 	case ProviderOpenAI:
-		if gen.openaiClient != nil && cfg != nil {
-			retryConfig := retry.Config{
-				MaxRetries:   cfg.OpenAI.Retry.MaxRetries,
-				InitialDelay: time.Duration(cfg.OpenAI.Retry.InitialDelay) * time.Millisecond,
-				MaxDelay:     time.Duration(cfg.OpenAI.Retry.MaxDelay) * time.Millisecond,
-				Multiplier:   cfg.OpenAI.Retry.Multiplier,
-				Jitter:       cfg.OpenAI.Retry.Jitter,
-				RetryableCheck: nil, // Will use the default retryable check
-			}
-			gen.openaiClient.SetRetryConfig(retryConfig)
+		if clients.openaiClient != nil && cfg != nil {
+			clients.openaiClient.SetRetryConfig(g.withRetryHook(retryConfigFrom(cfg.OpenAI.Retry)))
 		}
 
 	case ProviderClaude:
-		if gen.claudeClient != nil && cfg != nil {
-			retryConfig := retry.Config{
-				MaxRetries:   cfg.Claude.Retry.MaxRetries,
-				InitialDelay: time.Duration(cfg.Claude.Retry.InitialDelay) * time.Millisecond,
-				MaxDelay:     time.Duration(cfg.Claude.Retry.MaxDelay) * time.Millisecond,
-				Multiplier:   cfg.Claude.Retry.Multiplier,
-				Jitter:       cfg.Claude.Retry.Jitter,
-				RetryableCheck: nil, // Will use the default retryable check
-			}
-			gen.claudeClient.SetRetryConfig(retryConfig)
+		if clients.claudeClient != nil && cfg != nil {
+			clients.claudeClient.SetRetryConfig(g.withRetryHook(retryConfigFrom(cfg.Claude.Retry)))
 		}
 	}
+}
 
-	return gen, nil
+// String describes the retries, e.g. "2 retries (waited 3.1s)"
+func (s RetryStats) String() string {
+	noun := "retries"
+	if s.Retries == 1 {
+		noun = "retry"
+	}
+	return fmt.Sprintf("%d %s (waited %.1fs)", s.Retries, noun, s.Waited.Seconds())
+}
+
+// withRetryHook returns rc with a hook that counts retries into the
+// generator's RetryStats and calls the SetOnRetry callback
+func (g *Generator) withRetryHook(rc retry.Config) retry.Config {
+	rc.OnRetry = func(attempt int, err error, delay time.Duration) {
+		g.retries.Retries++
+		g.retries.Waited += delay
+		if g.onRetry != nil {
+			g.onRetry(attempt, err, delay)
+		}
+	}
+	return rc
+}
+
+// retryConfigFrom converts configured retry settings. With a preset, the
+// preset supplies the values and only settings written in the configuration
+// file override them.
+func retryConfigFrom(rc config.RetryConfig) retry.Config {
+	retryConfig := retry.Config{
+		MaxRetries:   rc.MaxRetries,
+		InitialDelay: time.Duration(rc.InitialDelay) * time.Millisecond,
+		MaxDelay:     time.Duration(rc.MaxDelay) * time.Millisecond,
+		Multiplier:   rc.Multiplier,
+		Jitter:       rc.Jitter,
+		RetryableCheck: nil, // Will use the default retryable check
+	}
+
+	preset, ok := retry.Preset(rc.Preset)
+	if !ok {
+		return retryConfig
+	}
+	if rc.IsSet("max_retries") {
+		preset.MaxRetries = retryConfig.MaxRetries
+	}
+	if rc.IsSet("initial_delay_ms") {
+		preset.InitialDelay = retryConfig.InitialDelay
+	}
+	if rc.IsSet("max_delay_ms") {
+		preset.MaxDelay = retryConfig.MaxDelay
+	}
+	if rc.IsSet("multiplier") {
+		preset.Multiplier = retryConfig.Multiplier
+	}
+	if rc.IsSet("jitter") {
+		preset.Jitter = retryConfig.Jitter
+	}
+	return preset
+}
+
+// SetFallback configures a fallback model that is tried when the primary provider
+// fails after exhausting its retries. The fallback provider is detected from the model name.
+func (g *Generator) SetFallback(model, apiKey string, cfg *config.Config) error {
+	if model == "" {
+		return pkgErrors.NewValidationError("fallback-model", model, "fallback model cannot be empty")
+	}
+
+	provider := DetectProviderFromModel(model)
+	clients, err := g.newClients(provider, apiKey)
+	if err != nil {
+		return err
+	}
+	g.applyRetryConfig(&clients, provider, cfg)
+
+	g.fallback = &fallbackTarget{
+		provider:        provider,
+		model:           model,
+		providerClients: clients,
+	}
+	return nil
+}
+
+// SetRateLimit throttles requests to at most rpm requests and tpm tokens per
+// minute. A zero value leaves that limit off; zero for both disables throttling.
+func (g *Generator) SetRateLimit(rpm, tpm int) error {
+	limiter, err := NewRateLimiter(rpm, tpm)
+	if err != nil {
+		return err
+	}
+	g.limiter = limiter
+	return nil
+}
+
+// SetBudget refuses requests whose estimated cost could take the spend past
+// the budget, and adds the cost of every request made to it. Cache hits cost
+// nothing. A nil budget removes the limit.
+func (g *Generator) SetBudget(budget *Budget) {
+	g.budget = budget
+}
+
+// SetOnRetry sets a callback called before each wait to retry a failed
+// request, with the failed attempt (starting at 1) and the delay before the
+// next one, e.g. to show a countdown. A nil callback removes it.
+func (g *Generator) SetOnRetry(fn func(attempt int, err error, delay time.Duration)) {
+	g.onRetry = fn
+}
+
+// LastRetries returns the retries made by the last GenerateContent call
+func (g *Generator) LastRetries() RetryStats {
+	return g.retries
+}
+
+// ServedBy returns the provider and model that produced the last response
+func (g *Generator) ServedBy() (AIProvider, string) {
+	return g.servedBy, g.servedModel
 }
 
 // EnableCache enables caching with custom settings
@@ -153,50 +334,129 @@ func (g *Generator) DisableCache() {
 	g.cache = nil
 }
 
-// GenerateContent generates content based on the provided options
+// GenerateContent generates content based on the provided options. The
+// prompt is sent as given: "@file" prompts are read by the caller with
+// ResolvePrompt, so CheckCache looks up the same request.
 func (g *Generator) GenerateContent(prompt string, options GenerateOptions) (string, error) {
 	// Validate prompt
 	if strings.TrimSpace(prompt) == "" {
 		return "", pkgErrors.NewValidationError("prompt", prompt, "prompt cannot be empty")
 	}
 
-	// Check if prompt is a file path (starts with @ or looks like a file)
-	if strings.HasPrefix(prompt, "@") {
-		filePath := strings.TrimPrefix(prompt, "@")
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			return "", pkgErrors.NewFileError(filePath, "reading prompt file", err)
-		}
-		prompt = string(content)
+	g.retries = RetryStats{}
+
+	content, err := g.generateWithProvider(&g.providerClients, g.provider, prompt, options)
+	if err == nil {
+		return content, nil
 	}
 
-	ctx := context.Background()
+	// An exhausted budget would be exceeded by the fallback as well
+	if g.fallback == nil || IsBudgetExceeded(err) {
+		return "", err
+	}
 
-	// Create cache request
-	cacheRequest := &cache.AIRequest{
-		Provider:    string(g.provider),
+	// Primary provider failed after retries; try the fallback provider
+	ui.PrintWarning("%s request failed, falling back to %s (%s): %v", g.provider, g.fallback.provider, g.fallback.model, ui.RedactError(err))
+
+	fallbackOptions := options
+	fallbackOptions.Model = g.fallback.model
+
+	// The fallback provider may accept a narrower temperature range
+	if clamped := ClampTemperature(g.fallback.provider, options.Temperature); clamped != options.Temperature {
+		ui.PrintWarning("Temperature %.2f is out of range for %s, using %.2f", options.Temperature, g.fallback.provider, clamped)
+		fallbackOptions.Temperature = clamped
+	}
+
+	content, fallbackErr := g.generateWithProvider(&g.fallback.providerClients, g.fallback.provider, prompt, fallbackOptions)
+	if fallbackErr != nil {
+		return "", fmt.Errorf("fallback to %s also failed: %w (primary error: %v)", g.fallback.provider, fallbackErr, err)
+	}
+
+	return content, nil
+}
+
+// ResolvePrompt returns the contents of the file named by an "@file" prompt,
+// or the prompt itself otherwise. The file's encoding is detected, see
+// PromptEncodingAuto.
+func ResolvePrompt(prompt string) (string, error) {
+	return ResolvePromptWithEncoding(prompt, PromptEncodingAuto)
+}
+
+// SystemMessage returns the system message the provider's client sends for a
+// content type, so cached responses are only reused for the same instructions
+func SystemMessage(provider AIProvider, contentType string) string {
+	switch provider {
+	case ProviderClaude:
+		return claude.SystemMessage(contentType)
+	default:
+		return openai.SystemMessage(contentType)
+	}
+}
+
+// newCacheRequest builds the cache key request for a prompt. Streamed and
+// non-streamed generation must both use it so they share cache entries.
+func newCacheRequest(provider AIProvider, prompt string, options GenerateOptions) *cache.AIRequest {
+	return &cache.AIRequest{
+		Provider:    string(provider),
 		Model:       options.Model,
 		Prompt:      prompt,
+		System:      SystemMessage(provider, options.ContentType),
 		ContentType: options.ContentType,
 		MaxTokens:   options.MaxTokens,
 		Temperature: options.Temperature,
+		TopP:        options.TopP,
 	}
+}
+
+// NewStreamRecorder starts recording a streamed response for prompt so the
+// assembled content is cached under the same key as a non-streamed request.
+// Call Complete on the recorder only after the stream succeeded, and Abort
+// when it fails. It returns nil, which records nothing, when caching is
+// disabled.
+func (g *Generator) NewStreamRecorder(provider AIProvider, prompt string, options GenerateOptions) *cache.StreamRecorder {
+	return g.cache.NewStreamRecorder(newCacheRequest(provider, prompt, options))
+}
+
+// generateWithProvider generates content with a specific provider, consulting the
+// cache first. Cache entries are keyed by provider and model so responses from
+// different providers never mix.
+func (g *Generator) generateWithProvider(clients *providerClients, provider AIProvider, prompt string, options GenerateOptions) (string, error) {
+	ctx := context.Background()
+
+	cacheRequest := newCacheRequest(provider, prompt, options)
 
 	// Check cache if enabled
 	if g.cache != nil {
 		if cachedResponse, found := g.cache.Get(ctx, cacheRequest); found {
 			ui.PrintInfo("Using cached response (cache hit)")
+			g.servedBy = provider
+			g.servedModel = options.Model
 			return cachedResponse.Content, nil
 		}
 	}
 
+	// Refuse a request that could exceed the budget before it is sent
+	promptTokens := NewTokenEstimator(options.Model).EstimateTokens(prompt)
+	if err := g.budget.Check(options.Model, promptTokens, options.MaxTokens); err != nil {
+		return "", err
+	}
+
+	// Throttle before calling the provider; cache hits are never throttled.
+	// The token estimate counts the prompt and the full completion budget.
+	if g.limiter != nil {
+		tokens := promptTokens + options.MaxTokens
+		if err := g.limiter.Wait(ctx, tokens); err != nil {
+			return "", err
+		}
+	}
+
 	// Generate content based on provider
 	var content string
 	var err error
 
-	switch g.provider {
+	switch provider {
 	case ProviderOpenAI:
-		if g.openaiClient == nil {
+		if clients.openaiClient == nil {
 			return "", fmt.Errorf("OpenAI client not initialized")
 		}
 		openaiOpts := openai.GenerateOptions{
@@ -204,11 +464,12 @@ func (g *Generator) GenerateContent(prompt string, options GenerateOptions) (str
 			Model:       options.Model,
 			MaxTokens:   options.MaxTokens,
 			Temperature: options.Temperature,
+			TopP:        options.TopP,
 		}
-		content, err = g.openaiClient.GenerateContent(prompt, openaiOpts)
+		content, err = clients.openaiClient.GenerateContent(prompt, openaiOpts)
 
 	case ProviderClaude:
-		if g.claudeClient == nil {
+		if clients.claudeClient == nil {
 			return "", fmt.Errorf("Claude client not initialized")
 		}
 		claudeOpts := claude.GenerateOptions{
@@ -216,22 +477,27 @@ func (g *Generator) GenerateContent(prompt string, options GenerateOptions) (str
 			Model:       options.Model,
 			MaxTokens:   options.MaxTokens,
 			Temperature: options.Temperature,
+			TopP:        options.TopP,
 		}
-		content, err = g.claudeClient.GenerateContent(prompt, claudeOpts)
+		content, err = clients.claudeClient.GenerateContent(prompt, claudeOpts)
 
 	default:
-		return "", fmt.Errorf("unsupported provider: %s", g.provider)
+		return "", fmt.Errorf("unsupported provider: %s", provider)
 	}
 
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
 
+	g.servedBy = provider
+	g.servedModel = options.Model
+	g.recordSpend(clients, provider, options.Model, promptTokens, content)
+
 	// Cache the response if cache is enabled
 	if g.cache != nil && content != "" {
 		cacheResponse := &cache.AIResponse{
 			Content:   content,
-			Provider:  string(g.provider),
+			Provider:  string(provider),
 			Model:     options.Model,
 			Timestamp: time.Now(),
 		}
@@ -244,6 +510,53 @@ func (g *Generator) GenerateContent(prompt string, options GenerateOptions) (str
 	return content, nil
 }
 
+// recordSpend adds the cost of a completed request to the budget, from the
+// usage the provider reported or, if it reported none, from estimates
+func (g *Generator) recordSpend(clients *providerClients, provider AIProvider, model string, promptTokens int, content string) {
+	if g.budget == nil {
+		return
+	}
+	var input, output int
+	switch provider {
+	case ProviderOpenAI:
+		input, output = clients.openaiClient.LastUsage()
+	case ProviderClaude:
+		input, output = clients.claudeClient.LastUsage()
+	}
+	if input == 0 && output == 0 {
+		input, output = promptTokens, NewTokenEstimator(model).EstimateTokens(content)
+	}
+	if err := g.budget.Record(model, input, output); err != nil {
+		ui.PrintWarning("Failed to save budget state: %v", err)
+	}
+}
+
+// CacheCheck is the outcome of looking up a prompt in the response cache
+type CacheCheck struct {
+	// Key is the cache key of the request
+	Key string
+	// Hit reports whether a response is cached under Key
+	Hit bool
+	// CachedAt is when the cached response was stored (zero on a miss)
+	CachedAt time.Time
+}
+
+// CheckCache reports whether generating prompt with the primary provider
+// would be served from the cache, without calling the provider and without
+// changing the cache or its statistics
+func (g *Generator) CheckCache(prompt string, options GenerateOptions) (CacheCheck, error) {
+	if g.cache == nil {
+		return CacheCheck{}, fmt.Errorf("response cache is disabled")
+	}
+	request := newCacheRequest(g.provider, prompt, options)
+	check := CacheCheck{Key: g.cache.Key(request)}
+	if response, found := g.cache.Peek(context.Background(), request); found {
+		check.Hit = true
+		check.CachedAt = response.Timestamp
+	}
+	return check, nil
+}
+
 // GetCacheStats returns cache statistics if cache is enabled
 func (g *Generator) GetCacheStats() *cache.Statistics {
 	if g.cache != nil {
@@ -272,6 +585,51 @@ func SaveToFile(content string, filePath string) error {
 	return nil
 }
 
+// AppendSeparator separates content appended to an existing file from what
+// was there before; it is a Markdown horizontal rule
+const AppendSeparator = "\n---\n\n"
+
+// AppendToFile adds the generated content to the end of a file, after
+// AppendSeparator when the file already has content. The separator follows
+// the content's line endings. The file is created if it does not exist.
+func AppendToFile(content string, filePath string) error {
+	if filePath == "" {
+		return nil // No file specified, skip saving
+	}
+
+	existing, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return pkgErrors.NewFileError(filePath, "appending output", err)
+	}
+
+	newline, separator := "\n", AppendSeparator
+	if strings.Contains(content, "\r\n") {
+		newline, separator = "\r\n", strings.ReplaceAll(AppendSeparator, "\n", "\r\n")
+	}
+
+	var data strings.Builder
+	if len(existing) > 0 {
+		if existing[len(existing)-1] != '\n' {
+			data.WriteString(newline)
+		}
+		data.WriteString(separator)
+	}
+	data.WriteString(content)
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return pkgErrors.NewFileError(filePath, "appending output", err)
+	}
+	if _, err := file.WriteString(data.String()); err != nil {
+		file.Close()
+		return pkgErrors.NewFileError(filePath, "appending output", err)
+	}
+	if err := file.Close(); err != nil {
+		return pkgErrors.NewFileError(filePath, "appending output", err)
+	}
+	return nil
+}
+
 // EnhancePrompt adds context or improvements to the user's prompt based on content type
 func EnhancePrompt(prompt string, contentType string) string {
 	switch contentType {
@@ -327,17 +685,61 @@ func GetAvailableModels(provider AIProvider) []string {
 	case ProviderClaude:
 		return claude.AvailableModels()
 	case ProviderOpenAI:
-		return []string{
-			"gpt-4-turbo-preview",
-			"gpt-4",
-			"gpt-3.5-turbo",
-			"gpt-3.5-turbo-16k",
-		}
+		return openai.AvailableModels()
 	default:
 		return []string{}
 	}
 }
 
+// GetModelInfo returns the capabilities of the provider's supported models,
+// or of both providers' models when provider is empty
+func GetModelInfo(provider AIProvider) []ModelInfo {
+	var infos []ModelInfo
+	if provider == "" || provider == ProviderOpenAI {
+		for _, m := range openai.GetModelInfo() {
+			infos = append(infos, ModelInfo{
+				Model:         m.Name,
+				Provider:      ProviderOpenAI,
+				Description:   m.Description,
+				BestFor:       m.Best4,
+				ContextWindow: m.ContextWindow,
+				MaxOutput:     m.MaxTokens,
+				InputPrice:    m.InputPrice,
+				OutputPrice:   m.OutputPrice,
+			})
+		}
+	}
+	if provider == "" || provider == ProviderClaude {
+		for _, m := range claude.GetModelInfo() {
+			infos = append(infos, ModelInfo{
+				Model:         m.Name,
+				Provider:      ProviderClaude,
+				Description:   m.Description,
+				BestFor:       m.Best4,
+				ContextWindow: m.ContextWindow,
+				MaxOutput:     m.MaxTokens,
+				InputPrice:    m.InputPrice,
+				OutputPrice:   m.OutputPrice,
+			})
+		}
+	}
+	return infos
+}
+
+// LookupModelInfo returns the capabilities of a supported model by name, or
+// for Claude by alias or dated snapshot (see claude.LookupModel)
+func LookupModelInfo(model string) (ModelInfo, bool) {
+	if m, ok := claude.LookupModel(model); ok {
+		model = m.Name
+	}
+	for _, info := range GetModelInfo("") {
+		if info.Model == model {
+			return info, true
+		}
+	}
+	return ModelInfo{}, false
+}
+
 // DefaultGenerateOptions returns default generation options
 func DefaultGenerateOptions() GenerateOptions {
 	return GenerateOptions{
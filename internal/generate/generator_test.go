@@ -1,13 +1,21 @@
 package generate
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pyhub/pyhub-docs/internal/cache"
 	"github.com/pyhub/pyhub-docs/internal/config"
+	"github.com/pyhub/pyhub-docs/internal/retry"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewGenerator(t *testing.T) {
@@ -182,6 +190,53 @@ func TestSaveToFile(t *testing.T) {
 	}
 }
 
+func TestAppendToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+
+	// A missing file is created without a separator
+	if err := AppendToFile("First", path); err != nil {
+		t.Fatalf("AppendToFile() error = %v", err)
+	}
+	if err := AppendToFile("Second\n", path); err != nil {
+		t.Fatalf("AppendToFile() error = %v", err)
+	}
+	if err := AppendToFile("Third", path); err != nil {
+		t.Fatalf("AppendToFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "First\n" + AppendSeparator + "Second\n" + AppendSeparator + "Third"
+	if string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	if err := AppendToFile("ignored", ""); err != nil {
+		t.Errorf("empty path should be skipped, got %v", err)
+	}
+}
+
+func TestAppendToFileCRLF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("First"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The separator follows the appended content's line endings
+	if err := AppendToFile("Second\r\nline\r\n", path); err != nil {
+		t.Fatalf("AppendToFile() error = %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "First\r\n\r\n---\r\n\r\nSecond\r\nline\r\n"; string(content) != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+}
+
 func TestDetectProviderFromModel(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -268,6 +323,33 @@ func TestGetAvailableModels(t *testing.T) {
 	}
 }
 
+func TestGetModelInfo(t *testing.T) {
+	openaiModels := GetModelInfo(ProviderOpenAI)
+	claudeModels := GetModelInfo(ProviderClaude)
+	if len(openaiModels) == 0 || len(claudeModels) == 0 {
+		t.Fatalf("got %d OpenAI and %d Claude models, want both", len(openaiModels), len(claudeModels))
+	}
+	if all := GetModelInfo(""); len(all) != len(openaiModels)+len(claudeModels) {
+		t.Errorf("GetModelInfo(\"\") returned %d models, want %d", len(all), len(openaiModels)+len(claudeModels))
+	}
+	for _, info := range append(openaiModels, claudeModels...) {
+		if info.Provider != DetectProviderFromModel(info.Model) {
+			t.Errorf("%s: provider %q does not match its name", info.Model, info.Provider)
+		}
+		if info.BestFor == "" || info.ContextWindow <= 0 || info.MaxOutput <= 0 {
+			t.Errorf("incomplete model info: %+v", info)
+		}
+	}
+
+	// Catalog models report their catalog entry; others fall back to estimates
+	if info := NewTokenEstimator("claude-2.1").GetModelInfo(); info.ContextWindow != 200000 || info.BestFor == "" {
+		t.Errorf("claude-2.1 info = %+v, want the catalog entry", info)
+	}
+	if info := NewTokenEstimator("gpt-4-0613").GetModelInfo(); info.ContextWindow != 8192 || info.Provider != "" {
+		t.Errorf("gpt-4-0613 info = %+v, want the gpt-4 family estimate", info)
+	}
+}
+
 func TestDefaultGenerateOptions(t *testing.T) {
 	opts := DefaultGenerateOptions()
 	
@@ -328,6 +410,43 @@ func TestGeneratorCache(t *testing.T) {
 	})
 }
 
+func TestGeneratorCheckCache(t *testing.T) {
+	gen, err := NewGeneratorWithConfig(ProviderOpenAI, "test-key", config.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := GenerateOptions{ContentType: "custom", Model: "gpt-4", MaxTokens: 10}
+
+	miss, err := gen.CheckCache("checked prompt", options)
+	if err != nil {
+		t.Fatalf("CheckCache() error = %v", err)
+	}
+	if miss.Hit || miss.Key == "" || !miss.CachedAt.IsZero() {
+		t.Errorf("CheckCache() = %+v, want a miss with a key", miss)
+	}
+	if _, found := gen.cache.Get(context.Background(), newCacheRequest(ProviderOpenAI, "checked prompt", options)); found {
+		t.Fatal("CheckCache() populated the cache")
+	}
+
+	recorder := gen.NewStreamRecorder(ProviderOpenAI, "checked prompt", options)
+	recorder.Write("content")
+	if err := recorder.Complete(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	hit, err := gen.CheckCache("checked prompt", options)
+	if err != nil {
+		t.Fatalf("CheckCache() error = %v", err)
+	}
+	if !hit.Hit || hit.Key != miss.Key || hit.CachedAt.IsZero() {
+		t.Errorf("CheckCache() = %+v, want a hit under %q with its timestamp", hit, miss.Key)
+	}
+
+	gen.DisableCache()
+	if _, err := gen.CheckCache("checked prompt", options); err == nil {
+		t.Error("CheckCache() should fail when caching is disabled")
+	}
+}
+
 func TestNewGeneratorWithConfig(t *testing.T) {
 	// Save original env vars
 	originalOpenAI := os.Getenv("OPENAI_API_KEY")
@@ -454,12 +573,6 @@ func TestGenerateContent(t *testing.T) {
 			options: DefaultGenerateOptions(),
 			wantErr: true,
 		},
-		{
-			name:    "File prompt with non-existent file",
-			prompt:  "@/non/existent/file.txt",
-			options: DefaultGenerateOptions(),
-			wantErr: true,
-		},
 	}
 	
 	for _, tt := range tests {
@@ -471,27 +584,23 @@ func TestGenerateContent(t *testing.T) {
 		})
 	}
 	
-	// Test with file prompt
-	t.Run("File prompt with existing file", func(t *testing.T) {
-		// Create a temp file
-		tempFile, err := os.CreateTemp("", "prompt_test")
-		if err != nil {
+	// "@file" prompts are read by the caller, so the prompt is used as given
+	// and CheckCache looks up the request GenerateContent makes
+	t.Run("prompt is used as given", func(t *testing.T) {
+		gen.EnableCache(time.Hour, 10)
+		options := GenerateOptions{ContentType: "custom", Model: "gpt-4", MaxTokens: 10}
+		request := &cache.AIRequest{Provider: "openai", Model: "gpt-4", Prompt: "@notes.txt", System: SystemMessage(ProviderOpenAI, "custom"), ContentType: "custom", MaxTokens: 10}
+		if err := gen.cache.Set(context.Background(), request, &cache.AIResponse{Content: "cached"}); err != nil {
 			t.Fatal(err)
 		}
-		defer os.Remove(tempFile.Name())
-		
-		promptContent := "Test prompt from file"
-		if _, err := tempFile.WriteString(promptContent); err != nil {
-			t.Fatal(err)
+
+		check, err := gen.CheckCache("@notes.txt", options)
+		if err != nil || !check.Hit {
+			t.Fatalf("CheckCache() = %+v, %v; want a hit", check, err)
 		}
-		tempFile.Close()
-		
-		// Note: This will still fail because we don't have a real OpenAI client
-		// but it will test the file reading logic
-		_, err = gen.GenerateContent("@"+tempFile.Name(), DefaultGenerateOptions())
-		// We expect an error here because the client isn't actually configured
-		if err == nil {
-			t.Error("Expected error with test OpenAI client")
+		content, err := gen.GenerateContent("@notes.txt", options)
+		if err != nil || content != "cached" {
+			t.Errorf("GenerateContent() = %q, %v; want the cached content", content, err)
 		}
 	})
 }
@@ -568,4 +677,278 @@ func TestUnsupportedProvider(t *testing.T) {
 	if !strings.Contains(err.Error(), "unsupported AI provider") {
 		t.Errorf("Expected error about unsupported provider, got: %v", err)
 	}
-}
\ No newline at end of file
+}
+
+func TestGeneratorFallback(t *testing.T) {
+	t.Run("SetFallback requires a model", func(t *testing.T) {
+		gen, err := NewGenerator(ProviderOpenAI, "test-key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.SetFallback("", "test-key", nil); err == nil {
+			t.Error("SetFallback with empty model should return error")
+		}
+	})
+
+	t.Run("SetFallback detects provider", func(t *testing.T) {
+		gen, err := NewGenerator(ProviderOpenAI, "test-key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gen.SetFallback("claude-3-haiku-20240307", "claude-key", nil); err != nil {
+			t.Fatalf("SetFallback() error = %v", err)
+		}
+		if gen.fallback.provider != ProviderClaude {
+			t.Errorf("fallback provider = %v, want %v", gen.fallback.provider, ProviderClaude)
+		}
+		if gen.fallback.claudeClient == nil {
+			t.Error("fallback Claude client was not initialized")
+		}
+	})
+
+	t.Run("SetFallback on the same provider keeps the primary client", func(t *testing.T) {
+		gen, err := NewGenerator(ProviderOpenAI, "primary-key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		primary := gen.openaiClient
+		if err := gen.SetFallback("gpt-4o-mini", "fallback-key", nil); err != nil {
+			t.Fatalf("SetFallback() error = %v", err)
+		}
+		if gen.openaiClient != primary {
+			t.Error("SetFallback replaced the primary OpenAI client")
+		}
+		if gen.fallback.openaiClient == nil || gen.fallback.openaiClient == primary {
+			t.Error("fallback should have an OpenAI client of its own")
+		}
+	})
+
+	t.Run("falls back when primary fails", func(t *testing.T) {
+		var primaryHits, fallbackHits int32
+		primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&primaryHits, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": {"message": "internal error", "type": "server_error"}}`))
+		}))
+		defer primary.Close()
+		fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&fallbackHits, 1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "msg_1", "type": "message", "role": "assistant", "model": "claude-3-haiku-20240307",
+				"content": [{"type": "text", "text": "from claude"}], "stop_reason": "end_turn",
+				"usage": {"input_tokens": 3, "output_tokens": 2}}`))
+		}))
+		defer fallback.Close()
+
+		gen, err := NewGenerator(ProviderOpenAI, "test-key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		gen.openaiClient.SetAPIURL(primary.URL)
+		gen.openaiClient.SetRetryConfig(retry.Config{MaxRetries: 0})
+		if err := gen.SetFallback("claude-3-haiku-20240307", "claude-key", nil); err != nil {
+			t.Fatal(err)
+		}
+		gen.fallback.claudeClient.SetAPIURL(fallback.URL)
+
+		content, err := gen.GenerateContent("fallback prompt", DefaultGenerateOptions())
+		if err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+		if content != "from claude" {
+			t.Errorf("GenerateContent() = %q, want %q", content, "from claude")
+		}
+		if atomic.LoadInt32(&primaryHits) != 1 || atomic.LoadInt32(&fallbackHits) != 1 {
+			t.Errorf("primary hit %d times, fallback %d times; want 1 each", primaryHits, fallbackHits)
+		}
+
+		provider, model := gen.ServedBy()
+		if provider != ProviderClaude || model != "claude-3-haiku-20240307" {
+			t.Errorf("ServedBy() = %v, %v; want claude, claude-3-haiku-20240307", provider, model)
+		}
+	})
+
+	t.Run("cache keys distinguish providers", func(t *testing.T) {
+		openaiRequest := &cache.AIRequest{Provider: "openai", Model: "m", Prompt: "p"}
+		claudeRequest := &cache.AIRequest{Provider: "claude", Model: "m", Prompt: "p"}
+		if openaiRequest.Hash() == claudeRequest.Hash() {
+			t.Error("requests for different providers should hash differently")
+		}
+	})
+}
+
+func TestValidateSamplingOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    AIProvider
+		temperature float64
+		topP        float64
+		wantErr     bool
+	}{
+		{name: "OpenAI default", provider: ProviderOpenAI, temperature: 0.7},
+		{name: "OpenAI high temperature", provider: ProviderOpenAI, temperature: 1.8},
+		{name: "OpenAI too high", provider: ProviderOpenAI, temperature: 2.5, wantErr: true},
+		{name: "Claude max", provider: ProviderClaude, temperature: 1.0},
+		{name: "Claude too high", provider: ProviderClaude, temperature: 1.5, wantErr: true},
+		{name: "negative temperature", provider: ProviderOpenAI, temperature: -0.1, wantErr: true},
+		{name: "valid top-p", provider: ProviderClaude, temperature: 0.5, topP: 0.9},
+		{name: "top-p too high", provider: ProviderOpenAI, temperature: 0.5, topP: 1.2, wantErr: true},
+		{name: "negative top-p", provider: ProviderOpenAI, temperature: 0.5, topP: -0.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSamplingOptions(tt.provider, tt.temperature, tt.topP)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSamplingOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClampTemperature(t *testing.T) {
+	if got := ClampTemperature(ProviderClaude, 1.5); got != 1.0 {
+		t.Errorf("ClampTemperature(claude, 1.5) = %v, want 1.0", got)
+	}
+	if got := ClampTemperature(ProviderOpenAI, 1.5); got != 1.5 {
+		t.Errorf("ClampTemperature(openai, 1.5) = %v, want 1.5", got)
+	}
+	if got := ClampTemperature(ProviderOpenAI, -1); got != 0 {
+		t.Errorf("ClampTemperature(openai, -1) = %v, want 0", got)
+	}
+}
+
+func TestRetryConfigFrom(t *testing.T) {
+	cfg := config.DefaultConfig()
+	data := []byte("claude:\n  retry:\n    preset: conservative\n    max_delay_ms: 5000\n")
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// The preset supplies the values the file does not set
+	got := retryConfigFrom(cfg.Claude.Retry)
+	if got.MaxRetries != 2 || got.InitialDelay != 2*time.Second || got.Multiplier != 2.0 || !got.Jitter {
+		t.Errorf("preset values not applied: %+v", got)
+	}
+	if got.MaxDelay != 5*time.Second {
+		t.Errorf("MaxDelay = %v, want the configured 5s", got.MaxDelay)
+	}
+
+	// Without a preset the configured values are used as before
+	got = retryConfigFrom(cfg.OpenAI.Retry)
+	if got.MaxRetries != 3 || got.InitialDelay != time.Second || got.MaxDelay != 10*time.Second {
+		t.Errorf("configured values not applied: %+v", got)
+	}
+}
+
+func TestGeneratorLastRetries(t *testing.T) {
+	gen, err := NewGeneratorWithConfig(ProviderOpenAI, "test-key", config.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.SetFallback("claude-3-haiku-20240307", "test-key", config.DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the primary and the fallback client report retries to the generator
+	onRetry := gen.openaiClient.RetryConfig().OnRetry
+	fallbackOnRetry := gen.fallback.claudeClient.RetryConfig().OnRetry
+	if onRetry == nil || fallbackOnRetry == nil {
+		t.Fatal("client retry configs should have the generator's retry hook")
+	}
+	onRetry(1, errors.New("timeout"), 1*time.Second)
+	onRetry(2, errors.New("timeout"), 2*time.Second)
+	fallbackOnRetry(1, errors.New("overloaded"), 100*time.Millisecond)
+
+	want := RetryStats{Retries: 3, Waited: 3100 * time.Millisecond}
+	if got := gen.LastRetries(); got != want {
+		t.Errorf("LastRetries() = %+v, want %+v", got, want)
+	}
+
+	// Every GenerateContent call starts counting from zero; a cached response
+	// makes no requests at all
+	cacheRequest := &cache.AIRequest{Provider: "openai", Model: "gpt-4", Prompt: "cached prompt", System: SystemMessage(ProviderOpenAI, "custom"), ContentType: "custom", MaxTokens: 10}
+	if err := gen.cache.Set(context.Background(), cacheRequest, &cache.AIResponse{Content: "cached"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.GenerateContent("cached prompt", GenerateOptions{ContentType: "custom", Model: "gpt-4", MaxTokens: 10}); err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if got := gen.LastRetries(); got != (RetryStats{}) {
+		t.Errorf("LastRetries() after a cache hit = %+v, want zero", got)
+	}
+}
+
+func TestGeneratorSetOnRetry(t *testing.T) {
+	gen, err := NewGeneratorWithConfig(ProviderOpenAI, "test-key", config.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attempts []int
+	var delays []time.Duration
+	gen.SetOnRetry(func(attempt int, err error, delay time.Duration) {
+		attempts = append(attempts, attempt)
+		delays = append(delays, delay)
+	})
+	onRetry := gen.openaiClient.RetryConfig().OnRetry
+	onRetry(1, errors.New("timeout"), 4*time.Second)
+
+	if len(attempts) != 1 || attempts[0] != 1 || delays[0] != 4*time.Second {
+		t.Errorf("callback got attempts %v and delays %v", attempts, delays)
+	}
+	if gen.LastRetries().Retries != 1 {
+		t.Error("retries should still be counted")
+	}
+
+	gen.SetOnRetry(nil)
+	onRetry(2, errors.New("timeout"), time.Second)
+	if len(attempts) != 1 {
+		t.Error("a removed callback should not be called")
+	}
+}
+
+func TestRetryStatsString(t *testing.T) {
+	if got := (RetryStats{Retries: 2, Waited: 3100 * time.Millisecond}).String(); got != "2 retries (waited 3.1s)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (RetryStats{Retries: 1, Waited: 500 * time.Millisecond}).String(); got != "1 retry (waited 0.5s)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestGeneratorStreamRecorder(t *testing.T) {
+	gen, err := NewGeneratorWithConfig(ProviderOpenAI, "test-key", config.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := GenerateOptions{ContentType: "custom", Model: "gpt-4", MaxTokens: 10}
+
+	// An interrupted stream leaves nothing behind for later requests
+	interrupted := gen.NewStreamRecorder(ProviderOpenAI, "streamed prompt", options)
+	interrupted.Write("partial")
+	interrupted.Abort()
+	if _, found := gen.cache.Get(context.Background(), newCacheRequest(ProviderOpenAI, "streamed prompt", options)); found {
+		t.Fatal("interrupted stream was cached")
+	}
+
+	// A completed stream serves a later non-streamed call from the cache
+	recorder := gen.NewStreamRecorder(ProviderOpenAI, "streamed prompt", options)
+	recorder.Write("streamed ")
+	recorder.Write("content")
+	if err := recorder.Complete(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	got, err := gen.GenerateContent("streamed prompt", options)
+	if err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if got != "streamed content" {
+		t.Errorf("GenerateContent() = %q, want the streamed content", got)
+	}
+
+	gen.DisableCache()
+	if gen.NewStreamRecorder(ProviderOpenAI, "streamed prompt", options) != nil {
+		t.Error("NewStreamRecorder() should return nil when caching is disabled")
+	}
+}
@@ -0,0 +1,182 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// PartialSuffix is appended to an output path to name the file holding a
+// streamed generation that has not finished yet
+const PartialSuffix = ".partial"
+
+// DefaultPartialFlushInterval is how often a streamed generation is saved
+const DefaultPartialFlushInterval = 5 * time.Second
+
+// partialState is the content of a .partial file. The prompt hash ties the
+// saved content to the request it answers, so a changed prompt starts over.
+type partialState struct {
+	PromptHash string    `json:"promptHash"`
+	Content    string    `json:"content"`
+	Updated    time.Time `json:"updated"`
+}
+
+// PartialPath returns the path of the partial file for an output file
+func PartialPath(output string) string {
+	return output + PartialSuffix
+}
+
+// promptHash identifies a prompt in a partial file without storing it
+func promptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// PartialWriter saves the content of a streamed generation to the output's
+// partial file as it arrives, at most once per flush interval, so a dropped
+// connection loses only the last few seconds. Call Complete once the stream
+// finished and the output was saved; an interrupted stream leaves the file
+// for LoadPartial.
+//
+// A nil *PartialWriter is valid and saves nothing, for output to stdout.
+type PartialWriter struct {
+	mu        sync.Mutex
+	path      string
+	hash      string
+	interval  time.Duration
+	lastFlush time.Time
+	content   strings.Builder
+}
+
+// NewPartialWriter starts saving a streamed answer to prompt for output,
+// continuing after resumed content already generated. It returns nil when
+// output is empty.
+func NewPartialWriter(output, prompt, resumed string, interval time.Duration) *PartialWriter {
+	if output == "" {
+		return nil
+	}
+	w := &PartialWriter{
+		path:      PartialPath(output),
+		hash:      promptHash(prompt),
+		interval:  interval,
+		lastFlush: time.Now(),
+	}
+	w.content.WriteString(resumed)
+	return w
+}
+
+// Write appends a chunk and saves the content if the flush interval passed
+func (w *PartialWriter) Write(chunk string) error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.content.WriteString(chunk)
+	if time.Since(w.lastFlush) < w.interval {
+		return nil
+	}
+	return w.flushLocked()
+}
+
+// Flush saves the content received so far
+func (w *PartialWriter) Flush() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushLocked()
+}
+
+// flushLocked writes the partial file through a temporary file, so an
+// interruption while saving keeps the previous state
+func (w *PartialWriter) flushLocked() error {
+	data, err := json.Marshal(partialState{PromptHash: w.hash, Content: w.content.String(), Updated: time.Now()})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(w.path), filepath.Base(w.path)+".*.tmp")
+	if err != nil {
+		return pkgErrors.NewFileError(w.path, "saving partial output", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return pkgErrors.NewFileError(w.path, "saving partial output", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return pkgErrors.NewFileError(w.path, "saving partial output", err)
+	}
+	if err := os.Rename(tmp.Name(), w.path); err != nil {
+		return pkgErrors.NewFileError(w.path, "saving partial output", err)
+	}
+	w.lastFlush = time.Now()
+	return nil
+}
+
+// Content returns the content received so far, including resumed content
+func (w *PartialWriter) Content() string {
+	if w == nil {
+		return ""
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.content.String()
+}
+
+// Complete removes the partial file after the full output was saved
+func (w *PartialWriter) Complete() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := os.Remove(w.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return pkgErrors.NewFileError(w.path, "removing partial output", err)
+	}
+	return nil
+}
+
+// LoadPartial returns the content saved for output by an interrupted
+// generation of the same prompt. It reports false when there is no partial
+// file, or when it was written for a different prompt.
+func LoadPartial(output, prompt string) (string, bool, error) {
+	path := PartialPath(output)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, pkgErrors.NewFileError(path, "reading partial output", err)
+	}
+
+	var state partialState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return "", false, pkgErrors.NewFileError(path, "reading partial output", fmt.Errorf("invalid partial file: %w", err))
+	}
+	if state.PromptHash != promptHash(prompt) || state.Content == "" {
+		return "", false, nil
+	}
+	return state.Content, true, nil
+}
+
+// ContinuationPrompt asks the model to continue a response that was cut off,
+// given the prompt and the content generated before the interruption. The
+// answer is meant to be appended to partial as is.
+func ContinuationPrompt(prompt, partial string) string {
+	return prompt + "\n\n" +
+		"A previous response to this request was interrupted. It is reproduced below " +
+		"between the markers. Continue it exactly where it stops, mid-sentence if " +
+		"necessary, without repeating any of it or adding an introduction.\n\n" +
+		"--- Response so far ---\n" + partial + "\n--- End of response so far ---"
+}
@@ -0,0 +1,69 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPartialWriterResume(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.md")
+	prompt := "Write a long report"
+
+	// Chunks within the flush interval are kept in memory only
+	w := NewPartialWriter(output, prompt, "", time.Hour)
+	w.Write("Intro. ")
+	if _, err := os.Stat(PartialPath(output)); !os.IsNotExist(err) {
+		t.Fatal("partial file written before the flush interval passed")
+	}
+	w.Write("Section one")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	// A restart with the same prompt finds the saved content
+	saved, ok, err := LoadPartial(output, prompt)
+	if err != nil || !ok || saved != "Intro. Section one" {
+		t.Fatalf("LoadPartial() = %q, %v, %v", saved, ok, err)
+	}
+	if _, ok, _ := LoadPartial(output, "A different prompt"); ok {
+		t.Error("LoadPartial() resumed content of a different prompt")
+	}
+
+	resumed := NewPartialWriter(output, prompt, saved, 0)
+	if err := resumed.Write(" continues."); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if saved, _, _ := LoadPartial(output, prompt); saved != "Intro. Section one continues." {
+		t.Errorf("flushed content = %q", saved)
+	}
+
+	if err := resumed.Complete(); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if _, err := os.Stat(PartialPath(output)); !os.IsNotExist(err) {
+		t.Error("partial file left after Complete")
+	}
+	if _, ok, err := LoadPartial(output, prompt); ok || err != nil {
+		t.Errorf("LoadPartial() after Complete = %v, %v", ok, err)
+	}
+}
+
+func TestPartialWriterNil(t *testing.T) {
+	w := NewPartialWriter("", "prompt", "", 0)
+	if w != nil {
+		t.Fatal("NewPartialWriter() should return nil without an output file")
+	}
+	if err := w.Write("chunk"); err != nil || w.Flush() != nil || w.Complete() != nil || w.Content() != "" {
+		t.Error("a nil PartialWriter should do nothing")
+	}
+}
+
+func TestContinuationPrompt(t *testing.T) {
+	got := ContinuationPrompt("Write a report", "The first half")
+	if !strings.HasPrefix(got, "Write a report\n\n") || !strings.Contains(got, "The first half\n--- End of response so far ---") {
+		t.Errorf("ContinuationPrompt() = %q", got)
+	}
+}
@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pyhub/pyhub-docs/internal/claude"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/openai"
+	"github.com/pyhub/pyhub-docs/internal/retry"
+)
+
+// PingResult describes a successful connectivity check
+type PingResult struct {
+	Provider AIProvider
+	Latency  time.Duration
+	Models   []string
+}
+
+// Ping checks that the provider is reachable and accepts the API key by
+// listing its models, which costs no tokens. The configured retry policy is
+// kept but capped at maxRetries so a failing check returns quickly.
+func (g *Generator) Ping(ctx context.Context, maxRetries int) (*PingResult, error) {
+	var models []string
+	var err error
+
+	start := time.Now()
+	switch g.provider {
+	case ProviderOpenAI:
+		g.openaiClient.SetRetryConfig(capRetries(g.openaiClient.RetryConfig(), maxRetries))
+		models, err = g.openaiClient.ListModels(ctx)
+	case ProviderClaude:
+		g.claudeClient.SetRetryConfig(capRetries(g.claudeClient.RetryConfig(), maxRetries))
+		models, err = g.claudeClient.ListModels(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported AI provider: %s", g.provider)
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		return nil, classifyPingError(g.provider, err)
+	}
+
+	return &PingResult{
+		Provider: g.provider,
+		Latency:  latency,
+		Models:   models,
+	}, nil
+}
+
+// capRetries limits the number of retries of a retry policy
+func capRetries(config retry.Config, maxRetries int) retry.Config {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if config.MaxRetries > maxRetries {
+		config.MaxRetries = maxRetries
+	}
+	return config
+}
+
+// classifyPingError turns a failed check into a coded error: rejected
+// credentials become ErrCodeInvalidAPIKey, everything else ErrCodeAIServiceDown
+func classifyPingError(provider AIProvider, err error) error {
+	status := 0
+	var openAIErr *openai.OpenAIError
+	var claudeErr *claude.ClaudeError
+	var httpErr *retry.HTTPError
+	switch {
+	case errors.As(err, &openAIErr):
+		status = openAIErr.StatusCode
+	case errors.As(err, &claudeErr):
+		status = claudeErr.StatusCode
+	case errors.As(err, &httpErr):
+		status = httpErr.StatusCode
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return pkgErrors.NewInvalidAPIKeyError(string(provider), err)
+	}
+	return pkgErrors.NewAIServiceDownError(string(provider), err)
+}
@@ -0,0 +1,54 @@
+package generate
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/claude"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/openai"
+	"github.com/pyhub/pyhub-docs/internal/retry"
+)
+
+func TestClassifyPingError(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider AIProvider
+		err      error
+		want     pkgErrors.ErrorCode
+	}{
+		{"openai unauthorized", ProviderOpenAI, &openai.OpenAIError{StatusCode: http.StatusUnauthorized}, pkgErrors.ErrCodeInvalidAPIKey},
+		{"claude forbidden", ProviderClaude, &claude.ClaudeError{StatusCode: http.StatusForbidden}, pkgErrors.ErrCodeInvalidAPIKey},
+		{"wrapped http 401", ProviderOpenAI, errors.Join(errors.New("max retries"), retry.NewHTTPError(401, "")), pkgErrors.ErrCodeInvalidAPIKey},
+		{"server error", ProviderOpenAI, &openai.OpenAIError{StatusCode: http.StatusServiceUnavailable}, pkgErrors.ErrCodeAIServiceDown},
+		{"network error", ProviderClaude, errors.New("dial tcp: no such host"), pkgErrors.ErrCodeAIServiceDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyPingError(tt.provider, tt.err)
+			if got := pkgErrors.GetErrorCode(err); got != tt.want {
+				t.Errorf("code = %s, want %s", got, tt.want)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("classified error should wrap the original error")
+			}
+		})
+	}
+}
+
+func TestCapRetries(t *testing.T) {
+	config := retry.DefaultConfig()
+	config.MaxRetries = 5
+
+	if got := capRetries(config, 1).MaxRetries; got != 1 {
+		t.Errorf("MaxRetries = %d, want 1", got)
+	}
+	if got := capRetries(config, 10).MaxRetries; got != 5 {
+		t.Errorf("MaxRetries = %d, want the configured 5", got)
+	}
+	if got := capRetries(config, -1).MaxRetries; got != 0 {
+		t.Errorf("MaxRetries = %d, want 0", got)
+	}
+}
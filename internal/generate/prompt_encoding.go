@@ -0,0 +1,160 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Prompt file encodings accepted by --prompt-encoding
+const (
+	// PromptEncodingAuto detects UTF-8 and UTF-16 from a byte order mark, or
+	// UTF-16 without one from its zero bytes, and otherwise expects UTF-8
+	PromptEncodingAuto    = "auto"
+	PromptEncodingUTF8    = "utf-8"
+	PromptEncodingUTF16LE = "utf-16le"
+	PromptEncodingUTF16BE = "utf-16be"
+	PromptEncodingWindows = "windows-1252"
+)
+
+// PromptEncodings lists the supported prompt file encodings
+var PromptEncodings = []string{PromptEncodingAuto, PromptEncodingUTF8, PromptEncodingUTF16LE, PromptEncodingUTF16BE, PromptEncodingWindows}
+
+// Byte order marks
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// ParsePromptEncoding normalizes a --prompt-encoding value, accepting common
+// spellings such as "UTF8" or "cp1252"
+func ParsePromptEncoding(s string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	switch strings.NewReplacer("-", "", "_", "").Replace(name) {
+	case "", "auto":
+		return PromptEncodingAuto, nil
+	case "utf8":
+		return PromptEncodingUTF8, nil
+	case "utf16le":
+		return PromptEncodingUTF16LE, nil
+	case "utf16be":
+		return PromptEncodingUTF16BE, nil
+	case "windows1252", "cp1252":
+		return PromptEncodingWindows, nil
+	default:
+		return "", pkgErrors.NewValidationError("prompt-encoding", s, "must be one of: "+strings.Join(PromptEncodings, ", "))
+	}
+}
+
+// DecodePrompt converts the contents of a prompt file to UTF-8 text without a
+// byte order mark. With PromptEncodingAuto, text that is not valid UTF-8 is
+// an error rather than being sent to the model garbled.
+func DecodePrompt(data []byte, encodingName string) (string, error) {
+	name, err := ParsePromptEncoding(encodingName)
+	if err != nil {
+		return "", err
+	}
+	if name == PromptEncodingAuto {
+		name = detectPromptEncoding(data)
+	}
+
+	var decoder *encoding.Decoder
+	switch name {
+	case PromptEncodingUTF16LE:
+		decoder = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder()
+		data = bytes.TrimPrefix(data, bomUTF16LE)
+	case PromptEncodingUTF16BE:
+		decoder = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder()
+		data = bytes.TrimPrefix(data, bomUTF16BE)
+	case PromptEncodingWindows:
+		decoder = charmap.Windows1252.NewDecoder()
+	default:
+		data = bytes.TrimPrefix(data, bomUTF8)
+		if offset := invalidUTF8Offset(data); offset >= 0 {
+			return "", fmt.Errorf("prompt file is not valid UTF-8 (invalid byte at offset %d); set --prompt-encoding to the file's encoding", offset)
+		}
+		return string(data), nil
+	}
+
+	decoded, err := decoder.Bytes(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode prompt file as %s: %w", name, err)
+	}
+	return strings.TrimPrefix(string(decoded), "\uFEFF"), nil
+}
+
+// detectPromptEncoding guesses the encoding of a prompt file. A byte order
+// mark decides; without one, UTF-16 is recognized by ASCII characters whose
+// other byte is zero, which never happens in UTF-8 text.
+func detectPromptEncoding(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return PromptEncodingUTF8
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return PromptEncodingUTF16LE
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return PromptEncodingUTF16BE
+	}
+
+	if len(data) < 2 || len(data)%2 != 0 {
+		return PromptEncodingUTF8
+	}
+	evenZeros, oddZeros := 0, 0
+	for i := 0; i+1 < len(data); i += 2 {
+		if data[i] == 0 {
+			evenZeros++
+		}
+		if data[i+1] == 0 {
+			oddZeros++
+		}
+	}
+	// Most characters of a prompt are ASCII, so most pairs have a zero byte
+	pairs := len(data) / 2
+	switch {
+	case oddZeros*2 > pairs && evenZeros == 0:
+		return PromptEncodingUTF16LE
+	case evenZeros*2 > pairs && oddZeros == 0:
+		return PromptEncodingUTF16BE
+	default:
+		return PromptEncodingUTF8
+	}
+}
+
+// invalidUTF8Offset returns the offset of the first byte that is not valid
+// UTF-8, or -1 if data is valid
+func invalidUTF8Offset(data []byte) int {
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size <= 1 {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
+
+// ResolvePromptWithEncoding is ResolvePrompt with the encoding of "@file"
+// prompts given explicitly instead of detected
+func ResolvePromptWithEncoding(prompt, encodingName string) (string, error) {
+	if !strings.HasPrefix(prompt, "@") {
+		return prompt, nil
+	}
+	filePath := strings.TrimPrefix(prompt, "@")
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", pkgErrors.NewFileError(filePath, "reading prompt file", err)
+	}
+	text, err := DecodePrompt(content, encodingName)
+	if err != nil {
+		return "", pkgErrors.NewFileError(filePath, "reading prompt file", err)
+	}
+	return text, nil
+}
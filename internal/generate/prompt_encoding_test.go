@@ -0,0 +1,98 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, with a BOM if asked
+func encodeUTF16(s string, bigEndian, bom bool) []byte {
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	data := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		if bigEndian {
+			data = append(data, byte(u>>8), byte(u))
+		} else {
+			data = append(data, byte(u), byte(u>>8))
+		}
+	}
+	return data
+}
+
+func TestDecodePrompt(t *testing.T) {
+	const text = "Summarize the café notes 요약"
+
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+		want     string
+	}{
+		{"utf-8", []byte(text), "auto", text},
+		{"utf-8 with BOM", append([]byte{0xEF, 0xBB, 0xBF}, text...), "auto", text},
+		{"utf-16le with BOM", encodeUTF16(text, false, true), "auto", text},
+		{"utf-16be with BOM", encodeUTF16(text, true, true), "auto", text},
+		{"utf-16le without BOM", encodeUTF16(text, false, false), "auto", text},
+		{"utf-16be without BOM", encodeUTF16(text, true, false), "auto", text},
+		{"explicit utf-16le", encodeUTF16(text, false, true), "UTF-16LE", text},
+		{"windows-1252", []byte("caf\xe9 \x93quoted\x94"), "cp1252", "café “quoted”"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePrompt(tt.data, tt.encoding)
+			if err != nil {
+				t.Fatalf("DecodePrompt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodePrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("invalid utf-8", func(t *testing.T) {
+		_, err := DecodePrompt([]byte("caf\xe9"), "auto")
+		if err == nil || !strings.Contains(err.Error(), "offset 3") || !strings.Contains(err.Error(), "--prompt-encoding") {
+			t.Errorf("expected invalid UTF-8 error with offset and hint, got %v", err)
+		}
+	})
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		if _, err := DecodePrompt([]byte(text), "ebcdic"); err == nil {
+			t.Error("expected an error for an unknown encoding")
+		}
+	})
+}
+
+func TestResolvePromptWithEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(path, encodeUTF16("Write a haiku", false, true), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ResolvePrompt("@" + path)
+	if err != nil {
+		t.Fatalf("ResolvePrompt() error = %v", err)
+	}
+	if got != "Write a haiku" {
+		t.Errorf("ResolvePrompt() = %q", got)
+	}
+
+	// Plain prompts are returned as given
+	if got, _ := ResolvePromptWithEncoding("caf\xe9", PromptEncodingUTF8); got != "caf\xe9" {
+		t.Errorf("plain prompt changed: %q", got)
+	}
+
+	if _, err := ResolvePromptWithEncoding("@"+path, PromptEncodingUTF8); err == nil {
+		t.Error("expected UTF-16 read as UTF-8 to fail")
+	}
+
+	if _, err := ResolvePrompt("@/non/existent/file.txt"); err == nil {
+		t.Error("expected an error for a prompt file that does not exist")
+	}
+}
@@ -0,0 +1,137 @@
+package generate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// RateLimiter throttles AI requests on the client side so bulk runs stay
+// below a provider's requests-per-minute and tokens-per-minute quotas.
+// Both limits use a token bucket that refills continuously and starts full.
+type RateLimiter struct {
+	mu       sync.Mutex
+	requests *tokenBucket
+	tokens   *tokenBucket
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// tokenBucket holds up to capacity units and refills at rate units per second.
+// The level may go negative: callers reserve units up front and wait for the debt.
+type tokenBucket struct {
+	capacity float64
+	level    float64
+	rate     float64
+	last     time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rpm requests and tpm tokens per
+// minute. A zero limit is not enforced; it returns nil when both are zero.
+func NewRateLimiter(rpm, tpm int) (*RateLimiter, error) {
+	if rpm < 0 {
+		return nil, pkgErrors.NewValidationError("rpm", rpm, "requests per minute cannot be negative")
+	}
+	if tpm < 0 {
+		return nil, pkgErrors.NewValidationError("tpm", tpm, "tokens per minute cannot be negative")
+	}
+	if rpm == 0 && tpm == 0 {
+		return nil, nil
+	}
+
+	l := &RateLimiter{
+		now:   time.Now,
+		sleep: sleepContext,
+	}
+	start := l.now()
+	l.requests = newTokenBucket(rpm, start)
+	l.tokens = newTokenBucket(tpm, start)
+	return l, nil
+}
+
+// newTokenBucket returns a full bucket for a per-minute limit, or nil for no limit
+func newTokenBucket(perMinute int, start time.Time) *tokenBucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &tokenBucket{
+		capacity: float64(perMinute),
+		level:    float64(perMinute),
+		rate:     float64(perMinute) / 60,
+		last:     start,
+	}
+}
+
+// reserve takes n units from the bucket and returns how long the caller must
+// wait before the units are available. Requests larger than the bucket are
+// capped to its capacity so they wait at most one full refill.
+func (b *tokenBucket) reserve(n float64, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.level += elapsed * b.rate
+		if b.level > b.capacity {
+			b.level = b.capacity
+		}
+		b.last = now
+	}
+
+	if n > b.capacity {
+		n = b.capacity
+	}
+	b.level -= n
+	if b.level >= 0 {
+		return 0
+	}
+	return time.Duration(-b.level / b.rate * float64(time.Second))
+}
+
+// Reserve accounts for one request using the estimated number of tokens and
+// returns how long the caller must wait before sending it
+func (l *RateLimiter) Reserve(tokens int) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	delay := l.requests.reserve(1, now)
+	if d := l.tokens.reserve(float64(tokens), now); d > delay {
+		delay = d
+	}
+	return delay
+}
+
+// Wait blocks until one request using the estimated number of tokens may be
+// sent, or until ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, tokens int) error {
+	delay := l.Reserve(tokens)
+	if delay <= 0 {
+		return nil
+	}
+	if delay >= time.Second {
+		ui.PrintInfo("Rate limit reached, waiting %s before the next request", delay.Round(time.Second))
+	}
+	return l.sleep(ctx, delay)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock drives a RateLimiter without real sleeping
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func newTestLimiter(t *testing.T, rpm, tpm int) (*RateLimiter, *fakeClock) {
+	t.Helper()
+
+	l, err := NewRateLimiter(rpm, tpm)
+	if err != nil {
+		t.Fatalf("NewRateLimiter failed: %v", err)
+	}
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	l.now = func() time.Time { return clock.now }
+	l.requests = newTokenBucket(rpm, clock.now)
+	l.tokens = newTokenBucket(tpm, clock.now)
+	l.sleep = func(ctx context.Context, d time.Duration) error {
+		clock.slept = append(clock.slept, d)
+		clock.now = clock.now.Add(d)
+		return nil
+	}
+	return l, clock
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	if l, err := NewRateLimiter(0, 0); l != nil || err != nil {
+		t.Errorf("no limits should disable throttling, got %v, %v", l, err)
+	}
+	if _, err := NewRateLimiter(-1, 0); err == nil {
+		t.Error("negative rpm should be rejected")
+	}
+	if _, err := NewRateLimiter(0, -1); err == nil {
+		t.Error("negative tpm should be rejected")
+	}
+
+	// A nil limiter never waits
+	var l *RateLimiter
+	if err := l.Wait(context.Background(), 1000); err != nil {
+		t.Errorf("nil limiter returned %v", err)
+	}
+}
+
+func TestRateLimiterRequestsPerMinute(t *testing.T) {
+	l, clock := newTestLimiter(t, 2, 0)
+
+	// The bucket starts full, so the first two requests go out immediately
+	for i := 0; i < 2; i++ {
+		if d := l.Reserve(0); d != 0 {
+			t.Fatalf("request %d delayed by %v", i+1, d)
+		}
+	}
+
+	// The third request waits for one refill (30s at 2 requests per minute)
+	if err := l.Wait(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.slept) != 1 || clock.slept[0] != 30*time.Second {
+		t.Fatalf("slept %v, want [30s]", clock.slept)
+	}
+
+	// After a full minute idle the bucket is full again but not overfilled
+	clock.now = clock.now.Add(5 * time.Minute)
+	for i := 0; i < 2; i++ {
+		if d := l.Reserve(0); d != 0 {
+			t.Fatalf("request %d after idle delayed by %v", i+1, d)
+		}
+	}
+	if d := l.Reserve(0); d != 30*time.Second {
+		t.Errorf("bucket should hold at most 2 requests, delay = %v", d)
+	}
+}
+
+func TestRateLimiterTokensPerMinute(t *testing.T) {
+	l, _ := newTestLimiter(t, 0, 6000)
+
+	if d := l.Reserve(4000); d != 0 {
+		t.Fatalf("first request delayed by %v", d)
+	}
+	// 2000 tokens remain; 3000 more need 1000 tokens refilled at 100/s
+	if d := l.Reserve(3000); d != 10*time.Second {
+		t.Errorf("delay = %v, want 10s", d)
+	}
+
+	// A request larger than the whole budget waits at most one full refill
+	l, _ = newTestLimiter(t, 0, 600)
+	l.Reserve(600)
+	if d := l.Reserve(10000); d != time.Minute {
+		t.Errorf("oversized request delay = %v, want 1m", d)
+	}
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	l, err := NewRateLimiter(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Reserve(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, 0); err != context.Canceled {
+		t.Errorf("Wait = %v, want context.Canceled", err)
+	}
+}
+
+func TestGeneratorSetRateLimit(t *testing.T) {
+	gen, err := NewGenerator(ProviderOpenAI, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gen.SetRateLimit(-5, 0); err == nil {
+		t.Error("negative rpm should be rejected")
+	}
+	if err := gen.SetRateLimit(10, 1000); err != nil || gen.limiter == nil {
+		t.Errorf("SetRateLimit(10, 1000) = %v, limiter = %v", err, gen.limiter)
+	}
+	if err := gen.SetRateLimit(0, 0); err != nil || gen.limiter != nil {
+		t.Errorf("zero limits should disable throttling, limiter = %v", gen.limiter)
+	}
+}
@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/markdown"
+)
+
+// IsPowerPointOutput reports whether generated content saved to path is
+// turned into a PowerPoint deck instead of being written as text
+func IsPowerPointOutput(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".pptx")
+}
+
+// SaveToPowerPoint parses generated Markdown as an outline and saves it as a
+// text-only PowerPoint deck with one slide per top-level section. Like
+// SaveToFile, it does not overwrite an existing file.
+func SaveToPowerPoint(content string, filePath string) error {
+	if filePath == "" {
+		return nil // No file specified, skip saving
+	}
+
+	if _, err := os.Stat(filePath); err == nil {
+		return pkgErrors.NewFileError(filePath, "writing output", pkgErrors.ErrFileAlreadyExists)
+	}
+
+	doc, err := markdown.Parse([]byte(content))
+	if err != nil {
+		return pkgErrors.NewDocumentError(filePath, "pptx", "failed to parse generated content", err)
+	}
+	converter := markdown.NewPowerPointConverter()
+	if err := converter.ConvertOutline(doc); err != nil {
+		return pkgErrors.NewDocumentError(filePath, "pptx", "generated content has nothing to put on slides", err)
+	}
+	if err := converter.SaveAs(filePath); err != nil {
+		return pkgErrors.NewFileError(filePath, "writing output", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// ValidTones lists the tones accepted by --tone
+var ValidTones = []string{"formal", "casual", "technical"}
+
+// languageNames maps common language codes to the names used in instructions
+var languageNames = map[string]string{
+	"en": "English",
+	"ko": "Korean",
+	"ja": "Japanese",
+	"zh": "Chinese",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"vi": "Vietnamese",
+	"th": "Thai",
+	"id": "Indonesian",
+}
+
+// toneInstructions describes each tone to the model
+var toneInstructions = map[string]string{
+	"formal":    "Use a formal, professional tone.",
+	"casual":    "Use a casual, conversational tone.",
+	"technical": "Use a precise, technical tone suited to an expert audience.",
+}
+
+// PromptStyle holds optional language and tone instructions for generated
+// content, and text to wrap the prompt in. The zero value adds nothing to the
+// prompt.
+type PromptStyle struct {
+	Language string // Language code (ko) or name (Korean); empty leaves it to the model
+	Tone     string // One of ValidTones; empty leaves it to the model
+	Prefix   string // Text placed before the prompt, such as standard context
+	Suffix   string // Text placed after the prompt, such as standard constraints
+}
+
+// ValidateTone checks that a tone is empty or one of ValidTones
+func ValidateTone(tone string) error {
+	if tone == "" {
+		return nil
+	}
+	for _, t := range ValidTones {
+		if tone == t {
+			return nil
+		}
+	}
+	return pkgErrors.NewValidationError("tone", tone, "must be one of: "+strings.Join(ValidTones, ", "))
+}
+
+// LanguageName returns the English name for a language code such as "ko" or
+// "ko-KR". Anything else, including names like "Korean", is returned as given.
+func LanguageName(language string) string {
+	language = strings.TrimSpace(language)
+	code := strings.ToLower(language)
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return language
+}
+
+// Instructions returns the sentences appended to the prompt, or "" when no
+// language or tone is set
+func (s PromptStyle) Instructions() string {
+	var parts []string
+	if language := LanguageName(s.Language); language != "" {
+		parts = append(parts, fmt.Sprintf("Respond in %s.", language))
+	}
+	if instruction, ok := toneInstructions[s.Tone]; ok {
+		parts = append(parts, instruction)
+	}
+	return strings.Join(parts, " ")
+}
+
+// WrapPrompt places prefix before the prompt and suffix after it, separated
+// by blank lines. Blank parts are left out and the surrounding whitespace of
+// the others trimmed; without a prefix or suffix the prompt is returned as is.
+func WrapPrompt(prompt, prefix, suffix string) string {
+	if strings.TrimSpace(prefix) == "" && strings.TrimSpace(suffix) == "" {
+		return prompt
+	}
+	var parts []string
+	for _, part := range []string{prefix, prompt, suffix} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// EnhancePromptWithStyle wraps the prompt in the style's prefix and suffix,
+// enhances it for its content type and appends the style's language and tone
+// instructions
+func EnhancePromptWithStyle(prompt string, contentType string, style PromptStyle) string {
+	enhanced := EnhancePrompt(WrapPrompt(prompt, style.Prefix, style.Suffix), contentType)
+	if instructions := style.Instructions(); instructions != "" {
+		enhanced += "\n\n" + instructions
+	}
+	return enhanced
+}
@@ -0,0 +1,90 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTone(t *testing.T) {
+	for _, tone := range []string{"", "formal", "casual", "technical"} {
+		if err := ValidateTone(tone); err != nil {
+			t.Errorf("ValidateTone(%q) returned error: %v", tone, err)
+		}
+	}
+	for _, tone := range []string{"Formal", "friendly", " "} {
+		if err := ValidateTone(tone); err == nil {
+			t.Errorf("ValidateTone(%q) should fail", tone)
+		}
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	tests := map[string]string{
+		"ko":      "Korean",
+		"KO":      "Korean",
+		"ko-KR":   "Korean",
+		"ja_JP":   "Japanese",
+		"Korean":  "Korean",
+		"Swahili": "Swahili",
+		"":        "",
+	}
+	for input, want := range tests {
+		if got := LanguageName(input); got != want {
+			t.Errorf("LanguageName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEnhancePromptWithStyle(t *testing.T) {
+	t.Run("neutral by default", func(t *testing.T) {
+		got := EnhancePromptWithStyle("Quarterly sales", "report", PromptStyle{})
+		if got != EnhancePrompt("Quarterly sales", "report") {
+			t.Errorf("empty style changed the prompt: %q", got)
+		}
+	})
+
+	t.Run("language and tone", func(t *testing.T) {
+		got := EnhancePromptWithStyle("Quarterly sales", "report", PromptStyle{Language: "ko", Tone: "formal"})
+		if !strings.HasPrefix(got, EnhancePrompt("Quarterly sales", "report")) {
+			t.Errorf("style should extend the enhanced prompt: %q", got)
+		}
+		if !strings.HasSuffix(got, "\n\nRespond in Korean. Use a formal, professional tone.") {
+			t.Errorf("unexpected instructions: %q", got)
+		}
+	})
+
+	t.Run("custom content type", func(t *testing.T) {
+		got := EnhancePromptWithStyle("Hello", "custom", PromptStyle{Tone: "casual"})
+		if got != "Hello\n\nUse a casual, conversational tone." {
+			t.Errorf("unexpected prompt: %q", got)
+		}
+	})
+}
+
+func TestWrapPrompt(t *testing.T) {
+	tests := []struct {
+		prefix, suffix string
+		want           string
+	}{
+		{"", "", "Quarterly sales"},
+		{"We sell tea.", "", "We sell tea.\n\nQuarterly sales"},
+		{"", "Under 200 words.", "Quarterly sales\n\nUnder 200 words."},
+		{"We sell tea.", "Under 200 words.", "We sell tea.\n\nQuarterly sales\n\nUnder 200 words."},
+		{"  ", "\n", "Quarterly sales"},
+		{"We sell tea.\n", "\nUnder 200 words.\n", "We sell tea.\n\nQuarterly sales\n\nUnder 200 words."},
+	}
+	for _, tt := range tests {
+		if got := WrapPrompt("Quarterly sales", tt.prefix, tt.suffix); got != tt.want {
+			t.Errorf("WrapPrompt(%q, %q) = %q, want %q", tt.prefix, tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestEnhancePromptWithStylePrefixSuffix(t *testing.T) {
+	style := PromptStyle{Prefix: "We sell tea.", Suffix: "Under 200 words.", Tone: "casual"}
+	got := EnhancePromptWithStyle("Quarterly sales", "report", style)
+	want := EnhancePrompt("We sell tea.\n\nQuarterly sales\n\nUnder 200 words.", "report") + "\n\nUse a casual, conversational tone."
+	if got != want {
+		t.Errorf("EnhancePromptWithStyle() = %q, want %q", got, want)
+	}
+}
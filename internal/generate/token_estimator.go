@@ -47,12 +47,34 @@ func (te *TokenEstimator) EstimateTokens(text string) int {
 
 // EstimateCost calculates the estimated cost based on model pricing
 func (te *TokenEstimator) EstimateCost(promptTokens, completionTokens int) (float64, string) {
-	// Pricing per 1M tokens (as of 2024)
-	// These are example prices and should be updated based on current pricing
-	
-	var promptPrice, completionPrice float64
 	var currency = "USD"
+	promptPrice, completionPrice, _ := te.prices()
+	
+	// Calculate cost (price is per 1M tokens)
+	promptCost := (float64(promptTokens) / 1_000_000) * promptPrice
+	completionCost := (float64(completionTokens) / 1_000_000) * completionPrice
+	totalCost := promptCost + completionCost
 	
+	return totalCost, currency
+}
+
+// HasKnownPrice reports whether EstimateCost uses the model's own price
+// rather than the default assumed for unknown models
+func (te *TokenEstimator) HasKnownPrice() bool {
+	_, _, known := te.prices()
+	return known
+}
+
+// prices returns the model's price per 1M prompt and completion tokens: the
+// catalog price of a supported model (see LookupModelInfo), or the price of
+// its model family otherwise. For other models it returns a default price
+// and false.
+func (te *TokenEstimator) prices() (promptPrice, completionPrice float64, known bool) {
+	if info, ok := LookupModelInfo(te.model); ok && info.InputPrice > 0 {
+		return info.InputPrice, info.OutputPrice, true
+	}
+	
+	// Pricing per 1M tokens of models outside the catalog
 	switch {
 	// OpenAI Models
 	case strings.HasPrefix(te.model, "gpt-4-turbo"), strings.HasPrefix(te.model, "gpt-4-1106"):
@@ -78,20 +100,20 @@ func (te *TokenEstimator) EstimateCost(promptTokens, completionTokens int) (floa
 		
 	default:
 		// Default pricing for unknown models
-		promptPrice = 1.00
-		completionPrice = 2.00
+		return 1.00, 2.00, false
 	}
 	
-	// Calculate cost (price is per 1M tokens)
-	promptCost := (float64(promptTokens) / 1_000_000) * promptPrice
-	completionCost := (float64(completionTokens) / 1_000_000) * completionPrice
-	totalCost := promptCost + completionCost
-	
-	return totalCost, currency
+	return promptPrice, completionPrice, true
 }
 
-// GetModelInfo returns information about the model's capabilities
+// GetModelInfo returns information about the model's capabilities: the
+// catalog entry of a supported model (see LookupModelInfo), or limits
+// estimated from the model family otherwise
 func (te *TokenEstimator) GetModelInfo() ModelInfo {
+	if info, ok := LookupModelInfo(te.model); ok {
+		return info
+	}
+
 	info := ModelInfo{
 		Model: te.model,
 	}
@@ -126,8 +148,13 @@ func (te *TokenEstimator) GetModelInfo() ModelInfo {
 // ModelInfo contains information about a model's capabilities
 type ModelInfo struct {
 	Model         string
-	ContextWindow int // Maximum input tokens
-	MaxOutput     int // Maximum output tokens
+	Provider      AIProvider // Empty for models outside the catalog
+	Description   string
+	BestFor       string // Use cases the model suits best
+	ContextWindow int     // Maximum input tokens
+	MaxOutput     int     // Maximum output tokens
+	InputPrice    float64 // USD per 1M input tokens, 0 if unknown
+	OutputPrice   float64 // USD per 1M output tokens, 0 if unknown
 }
 
 // FormatCostEstimate formats the cost estimate for display
@@ -152,6 +179,12 @@ func FormatModelInfo(info ModelInfo) string {
 	sb.WriteString(fmt.Sprintf("  Model:         %s\n", info.Model))
 	sb.WriteString(fmt.Sprintf("  Context:       %d tokens\n", info.ContextWindow))
 	sb.WriteString(fmt.Sprintf("  Max output:    %d tokens\n", info.MaxOutput))
+	if info.Description != "" {
+		sb.WriteString(fmt.Sprintf("  Description:   %s\n", info.Description))
+	}
+	if info.BestFor != "" {
+		sb.WriteString(fmt.Sprintf("  Best for:      %s\n", info.BestFor))
+	}
 	
 	return sb.String()
 }
\ No newline at end of file
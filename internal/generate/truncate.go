@@ -0,0 +1,139 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// TruncateMode selects which part of an oversized prompt is removed
+type TruncateMode string
+
+const (
+	// TruncateTail keeps the beginning of the prompt
+	TruncateTail TruncateMode = "tail"
+	// TruncateMiddle keeps the beginning and the end of the prompt
+	TruncateMiddle TruncateMode = "middle"
+)
+
+// truncationMarker replaces the removed part of a truncated prompt
+const truncationMarker = "\n\n[... truncated ...]\n\n"
+
+// ParseTruncateMode parses a --truncate-from value
+func ParseTruncateMode(s string) (TruncateMode, error) {
+	switch mode := TruncateMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case TruncateTail, TruncateMiddle:
+		return mode, nil
+	default:
+		return "", pkgErrors.NewValidationError("truncate-from", s, "must be 'tail' or 'middle'")
+	}
+}
+
+// PromptTokenLimit returns the tokens available for the prompt once maxTokens
+// are reserved for the response
+func PromptTokenLimit(info ModelInfo, maxTokens int) int {
+	return info.ContextWindow - maxTokens
+}
+
+// PromptLimitFor returns the tokens available for a prompt to model once
+// maxTokens are reserved for the response, and whether the limit can be
+// enforced. Context windows of models outside the catalog are guessed from
+// their names, and a limit of zero or less leaves nothing to check against,
+// so such limits should only be warned about.
+func PromptLimitFor(model string, maxTokens int) (limit int, enforced bool) {
+	info, known := LookupModelInfo(model)
+	if !known {
+		info = NewTokenEstimator(model).GetModelInfo()
+	}
+	limit = PromptTokenLimit(info, maxTokens)
+	return limit, known && limit > 0
+}
+
+// TruncateToFit shortens text until its estimated token count is at most
+// limit, removing text according to mode and marking the cut. It reports
+// whether the text was shortened.
+func (te *TokenEstimator) TruncateToFit(text string, limit int, mode TruncateMode) (string, bool) {
+	if te.EstimateTokens(text) <= limit {
+		return text, false
+	}
+
+	runes := []rune(text)
+	build := func(keep int) string {
+		if mode == TruncateMiddle {
+			head := keep - keep/2
+			return string(runes[:head]) + truncationMarker + string(runes[len(runes)-keep/2:])
+		}
+		return string(runes[:keep]) + strings.TrimRight(truncationMarker, "\n")
+	}
+
+	// Binary search for the longest kept length that fits
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if te.EstimateTokens(build(mid)) <= limit {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return build(lo), true
+}
+
+// FitPrompt truncates prompt so that the prompt after EnhancePrompt for
+// contentType fits in limit tokens. It returns the shortened prompt, whether
+// it was truncated, and an error when even an empty prompt does not fit.
+func (te *TokenEstimator) FitPrompt(prompt, contentType string, limit int, mode TruncateMode) (string, bool, error) {
+	if te.EstimateTokens(EnhancePrompt(prompt, contentType)) <= limit {
+		return prompt, false, nil
+	}
+
+	// Reserve room for the instructions EnhancePrompt adds, then tighten the
+	// budget while the estimate of the enhanced prompt is still too large
+	overhead := te.EstimateTokens(EnhancePrompt(prompt, contentType)) - te.EstimateTokens(prompt)
+	budget := limit - overhead
+	for budget > 0 {
+		truncated, _ := te.TruncateToFit(prompt, budget, mode)
+		excess := te.EstimateTokens(EnhancePrompt(truncated, contentType)) - limit
+		if excess <= 0 {
+			return truncated, true, nil
+		}
+		budget -= excess
+	}
+	return "", false, fmt.Errorf("prompt instructions alone exceed %d tokens", limit)
+}
+
+// sentenceEnds end a sentence when followed by whitespace, so "3.14" is not
+// split; fullwidthSentenceEnds end one wherever they appear
+const (
+	sentenceEnds          = ".!?"
+	fullwidthSentenceEnds = "。！？"
+)
+
+// TruncateOutput shortens generated content to at most maxChars characters
+// (runes). With atSentence the cut moves back to the end of the last complete
+// sentence or line, if there is one. It reports whether the content was
+// shortened; maxChars of 0 or less disables the limit.
+func TruncateOutput(content string, maxChars int, atSentence bool) (string, bool) {
+	runes := []rune(content)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return content, false
+	}
+
+	cut := runes[:maxChars]
+	if atSentence {
+		for i := len(cut) - 1; i > 0; i-- {
+			if cut[i] == '\n' || strings.ContainsRune(fullwidthSentenceEnds, cut[i]) ||
+				strings.ContainsRune(sentenceEnds, cut[i]) && isSpaceRune(runes[i+1]) {
+				cut = cut[:i+1]
+				break
+			}
+		}
+	}
+	return strings.TrimRight(string(cut), " \t\r\n"), true
+}
+
+// isSpaceRune reports whether r separates a sentence end from what follows
+func isSpaceRune(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+}
@@ -0,0 +1,115 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateToFit(t *testing.T) {
+	estimator := NewTokenEstimator("gpt-3.5-turbo")
+	text := "START " + strings.Repeat("filler words here ", 500) + "END"
+
+	t.Run("fits unchanged", func(t *testing.T) {
+		got, truncated := estimator.TruncateToFit("short prompt", 100, TruncateTail)
+		if truncated || got != "short prompt" {
+			t.Errorf("TruncateToFit() = %q, %v; want unchanged", got, truncated)
+		}
+	})
+
+	for _, mode := range []TruncateMode{TruncateTail, TruncateMiddle} {
+		t.Run(string(mode), func(t *testing.T) {
+			got, truncated := estimator.TruncateToFit(text, 200, mode)
+			if !truncated {
+				t.Fatal("expected text to be truncated")
+			}
+			if tokens := estimator.EstimateTokens(got); tokens > 200 {
+				t.Errorf("truncated text has ~%d tokens, want <= 200", tokens)
+			}
+			if !strings.HasPrefix(got, "START") || !strings.Contains(got, "[... truncated ...]") {
+				t.Errorf("unexpected truncation result: %q", got)
+			}
+			if keepsEnd := strings.HasSuffix(got, "END"); keepsEnd != (mode == TruncateMiddle) {
+				t.Errorf("mode %s: keeps end = %v", mode, keepsEnd)
+			}
+		})
+	}
+}
+
+func TestFitPrompt(t *testing.T) {
+	estimator := NewTokenEstimator("gpt-3.5-turbo")
+	prompt := strings.Repeat("quarterly figures ", 1000)
+
+	fitted, truncated, err := estimator.FitPrompt(prompt, "report", 300, TruncateTail)
+	if err != nil {
+		t.Fatalf("FitPrompt() error = %v", err)
+	}
+	if !truncated {
+		t.Error("expected prompt to be truncated")
+	}
+	if tokens := estimator.EstimateTokens(EnhancePrompt(fitted, "report")); tokens > 300 {
+		t.Errorf("enhanced prompt has ~%d tokens, want <= 300", tokens)
+	}
+
+	if _, _, err := estimator.FitPrompt(prompt, "report", 5, TruncateTail); err == nil {
+		t.Error("expected error when instructions alone exceed the limit")
+	}
+}
+
+func TestParseTruncateMode(t *testing.T) {
+	if mode, err := ParseTruncateMode("Middle"); err != nil || mode != TruncateMiddle {
+		t.Errorf("ParseTruncateMode(Middle) = %v, %v", mode, err)
+	}
+	if _, err := ParseTruncateMode("head"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestPromptTokenLimit(t *testing.T) {
+	info := ModelInfo{ContextWindow: 4096}
+	if got := PromptTokenLimit(info, 1000); got != 3096 {
+		t.Errorf("PromptTokenLimit() = %d, want 3096", got)
+	}
+}
+
+func TestPromptLimitFor(t *testing.T) {
+	if limit, enforced := PromptLimitFor("gpt-4", 1000); limit != 7192 || !enforced {
+		t.Errorf("PromptLimitFor(gpt-4) = %d, %v; want 7192, true", limit, enforced)
+	}
+	// A guessed context window is not enforced
+	if _, enforced := PromptLimitFor("gpt-4o", 1000); enforced {
+		t.Error("PromptLimitFor(gpt-4o) should not be enforced for a model outside the catalog")
+	}
+	if limit, enforced := PromptLimitFor("gpt-4", 10000); limit > 0 || enforced {
+		t.Errorf("PromptLimitFor(gpt-4, 10000) = %d, %v; want no room and not enforced", limit, enforced)
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		maxChars      int
+		atSentence    bool
+		want          string
+		wantTruncated bool
+	}{
+		{"no limit", "Hello world.", 0, false, "Hello world.", false},
+		{"fits", "Hello world.", 12, false, "Hello world.", false},
+		{"character cut", "Hello world. Bye now.", 8, false, "Hello wo", true},
+		{"trailing space trimmed", "Hello world. Bye now.", 13, false, "Hello world.", true},
+		{"last sentence", "First one. Second one. Third", 25, true, "First one. Second one.", true},
+		{"decimal is not a sentence end", "Pi is 3.14 and more text", 12, true, "Pi is 3.14 a", true},
+		{"line break", "Title\nBody text continues", 12, true, "Title", true},
+		{"fullwidth end", "첫 문장입니다。둘째 문장", 10, true, "첫 문장입니다。", true},
+		{"counts runes", "안녕하세요 세계", 5, false, "안녕하세요", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := TruncateOutput(tt.content, tt.maxChars, tt.atSentence)
+			if got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("TruncateOutput() = %q, %v; want %q, %v", got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
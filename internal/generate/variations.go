@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Variation is one output of a prompt generated several times
+type Variation struct {
+	Index            int // 1-based
+	Content          string
+	Err              error
+	Duration         time.Duration
+	PromptTokens     int // Estimated
+	CompletionTokens int // Estimated from the returned content
+	Cost             float64
+	Currency         string
+}
+
+// GenerateVariations generates prompt n times in sequence and returns the
+// outputs in order. gen should not answer from a cache, so that every call
+// samples a new output at the requested temperature. A failed call is
+// reported in its variation's Err; an exhausted budget stops the remaining
+// calls. done, if not nil, is called after each variation.
+func GenerateVariations(gen ContentGenerator, prompt string, options GenerateOptions, n int, done func(Variation)) []Variation {
+	estimator := NewTokenEstimator(options.Model)
+	promptTokens := estimator.EstimateTokens(prompt)
+
+	variations := make([]Variation, 0, n)
+	for i := 1; i <= n; i++ {
+		started := time.Now()
+		content, err := gen.GenerateContent(prompt, options)
+		variation := Variation{
+			Index:    i,
+			Content:  content,
+			Err:      err,
+			Duration: time.Since(started),
+		}
+		// Failed calls are not counted
+		if err == nil {
+			variation.PromptTokens = promptTokens
+			variation.CompletionTokens = estimator.EstimateTokens(content)
+		}
+		variation.Cost, variation.Currency = estimator.EstimateCost(variation.PromptTokens, variation.CompletionTokens)
+		variations = append(variations, variation)
+
+		if done != nil {
+			done(variation)
+		}
+		if IsBudgetExceeded(err) {
+			break
+		}
+	}
+	return variations
+}
+
+// SumVariations returns the estimated tokens and cost of all variations
+func SumVariations(variations []Variation) (promptTokens, completionTokens int, cost float64) {
+	for _, v := range variations {
+		promptTokens += v.PromptTokens
+		completionTokens += v.CompletionTokens
+		cost += v.Cost
+	}
+	return promptTokens, completionTokens, cost
+}
+
+// VariationPath numbers an output path for one variation, before its
+// extension: "ideas.md" becomes "ideas_2.md" for the second variation
+func VariationPath(output string, index int) string {
+	ext := filepath.Ext(output)
+	return fmt.Sprintf("%s_%d%s", strings.TrimSuffix(output, ext), index, ext)
+}
+
+// FormatVariations renders successful variations as one Markdown document,
+// a "## Variation N" section each, separated by horizontal rules
+func FormatVariations(variations []Variation) string {
+	var sections []string
+	for _, v := range variations {
+		if v.Err != nil {
+			continue
+		}
+		sections = append(sections, fmt.Sprintf("## Variation %d\n\n%s\n", v.Index, strings.TrimSpace(v.Content)))
+	}
+	return strings.Join(sections, "\n---\n\n")
+}
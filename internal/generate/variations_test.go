@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// sequenceGenerator returns the next canned result on each call
+type sequenceGenerator struct {
+	contents []string
+	errs     []error
+	calls    int
+}
+
+func (s *sequenceGenerator) GenerateContent(prompt string, options GenerateOptions) (string, error) {
+	i := s.calls
+	s.calls++
+	return s.contents[i], s.errs[i]
+}
+
+func TestGenerateVariations(t *testing.T) {
+	gen := &sequenceGenerator{
+		contents: []string{"first idea", "", "third idea"},
+		errs:     []error{nil, errors.New("timeout"), nil},
+	}
+	var done []int
+	variations := GenerateVariations(gen, "Ideas for a launch post", GenerateOptions{Model: "gpt-4"}, 3, func(v Variation) {
+		done = append(done, v.Index)
+	})
+
+	if len(variations) != 3 || gen.calls != 3 || len(done) != 3 {
+		t.Fatalf("got %d variations, %d calls, %d callbacks; want 3 each", len(variations), gen.calls, len(done))
+	}
+	if variations[0].Index != 1 || variations[0].Content != "first idea" || variations[0].CompletionTokens == 0 {
+		t.Errorf("first variation = %+v", variations[0])
+	}
+	if variations[1].Err == nil || variations[1].PromptTokens != 0 || variations[1].Cost != 0 {
+		t.Errorf("failed variation should cost nothing: %+v", variations[1])
+	}
+
+	promptTokens, completionTokens, cost := SumVariations(variations)
+	if promptTokens != variations[0].PromptTokens*2 || completionTokens == 0 || cost <= 0 {
+		t.Errorf("SumVariations() = %d, %d, %v", promptTokens, completionTokens, cost)
+	}
+}
+
+func TestGenerateVariationsStopsAtBudget(t *testing.T) {
+	budgetErr := pkgErrors.NewBudgetExceededError(1, 0.5, 1)
+	gen := &sequenceGenerator{
+		contents: []string{"idea", "", "never"},
+		errs:     []error{nil, budgetErr, nil},
+	}
+	variations := GenerateVariations(gen, "prompt", GenerateOptions{Model: "gpt-4"}, 3, nil)
+	if len(variations) != 2 || gen.calls != 2 {
+		t.Errorf("got %d variations after %d calls, want 2 of each", len(variations), gen.calls)
+	}
+}
+
+func TestVariationPath(t *testing.T) {
+	tests := []struct {
+		output string
+		index  int
+		want   string
+	}{
+		{"ideas.md", 1, "ideas_1.md"},
+		{"out/post.draft.txt", 3, "out/post.draft_3.txt"},
+		{"notes", 2, "notes_2"},
+	}
+	for _, tt := range tests {
+		if got := VariationPath(tt.output, tt.index); got != tt.want {
+			t.Errorf("VariationPath(%q, %d) = %q, want %q", tt.output, tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestFormatVariations(t *testing.T) {
+	got := FormatVariations([]Variation{
+		{Index: 1, Content: "First\n"},
+		{Index: 2, Err: errors.New("failed")},
+		{Index: 3, Content: "Third"},
+	})
+	want := "## Variation 1\n\nFirst\n\n---\n\n## Variation 3\n\nThird\n"
+	if got != want {
+		t.Errorf("FormatVariations() = %q, want %q", got, want)
+	}
+	if strings.Contains(got, "Variation 2") {
+		t.Error("failed variations should be left out")
+	}
+}
@@ -0,0 +1,56 @@
+// Package httpclient provides HTTP clients that share one pooled transport,
+// so concurrent requests to the AI providers reuse connections instead of
+// opening a new socket for each request.
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connection pool limits of the shared transport
+const (
+	// MaxIdleConns is the number of idle connections kept across all hosts
+	MaxIdleConns = 100
+	// MaxIdleConnsPerHost is the number of idle connections kept per host.
+	// Go's default of 2 makes concurrent requests to one API open and close
+	// a connection for nearly every request.
+	MaxIdleConnsPerHost = 32
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout = 90 * time.Second
+)
+
+var (
+	sharedTransport     *http.Transport
+	sharedTransportOnce sync.Once
+)
+
+// SharedTransport returns the transport used by every client from New. It
+// keeps the proxy, dial and TLS settings of http.DefaultTransport with a
+// larger connection pool.
+func SharedTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewTransport()
+	})
+	return sharedTransport
+}
+
+// NewTransport returns a transport with the pool settings of
+// SharedTransport that is not shared with other clients
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = MaxIdleConns
+	transport.MaxIdleConnsPerHost = MaxIdleConnsPerHost
+	transport.IdleConnTimeout = IdleConnTimeout
+	return transport
+}
+
+// New returns an HTTP client with its own timeout that sends requests
+// through the shared transport
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: SharedTransport(),
+	}
+}
@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	a := New(30 * time.Second)
+	b := New(60 * time.Second)
+
+	if a.Timeout != 30*time.Second || b.Timeout != 60*time.Second {
+		t.Errorf("timeouts = %v, %v, want each client's own", a.Timeout, b.Timeout)
+	}
+	if a.Transport != b.Transport || a.Transport != SharedTransport() {
+		t.Error("clients should share one transport")
+	}
+
+	transport := SharedTransport()
+	if transport.MaxIdleConnsPerHost != MaxIdleConnsPerHost || transport.MaxIdleConns != MaxIdleConns || transport.IdleConnTimeout != IdleConnTimeout {
+		t.Errorf("unexpected pool settings: %d, %d, %v", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.Proxy == nil {
+		t.Error("shared transport should honor proxy environment variables")
+	}
+}
+
+func TestSharedTransportReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport()}
+	const workers, requests = 8, 10
+	runConcurrently(t, client, server.URL, workers, requests)
+
+	// Each worker keeps its connection between requests
+	if got := connections.Load(); got > workers {
+		t.Errorf("opened %d connections for %d concurrent workers", got, workers)
+	}
+}
+
+// runConcurrently sends requests from several goroutines, each reading the
+// whole response so its connection can be reused
+func runConcurrently(tb testing.TB, client *http.Client, url string, workers, requests int) {
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < requests; i++ {
+				resp, err := client.Get(url)
+				if err != nil {
+					tb.Error(err)
+					return
+				}
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkConcurrentRequests compares concurrent requests through the
+// shared transport with Go's default pool of two idle connections per host,
+// which closes and reopens connections under concurrency
+func BenchmarkConcurrentRequests(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	const workers = 16
+	defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+	defer defaultTransport.CloseIdleConnections()
+	pooled := NewTransport()
+	defer pooled.CloseIdleConnections()
+
+	for _, bench := range []struct {
+		name      string
+		transport *http.Transport
+	}{{"default", defaultTransport}, {"shared", pooled}} {
+		b.Run(bench.name, func(b *testing.B) {
+			client := &http.Client{Transport: bench.transport}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				runConcurrently(b, client, server.URL, workers, 4)
+			}
+		})
+	}
+}
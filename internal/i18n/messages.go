@@ -97,6 +97,8 @@ const (
 	MsgErrCodeAITimeout         = "error.code.ai_timeout"
 	MsgErrCodeAIInvalidResponse = "error.code.ai_invalid_response"
 	MsgErrCodeAIServiceDown     = "error.code.ai_service_down"
+	MsgErrCodeContextWindowExceeded = "error.code.context_window_exceeded"
+	MsgErrCodeBudgetExceeded    = "error.code.budget_exceeded"
 	MsgErrCodeInvalidInput      = "error.code.invalid_input"
 	MsgErrCodeMissingRequired   = "error.code.missing_required"
 	MsgErrCodeInvalidFormat     = "error.code.invalid_format"
@@ -118,4 +120,13 @@ const (
 	MsgSolutionUpgradeAPI       = "solution.upgrade_api"
 	MsgSolutionCheckFormat      = "solution.check_format"
 	MsgSolutionProvideRequired  = "solution.provide_required"
+	MsgSolutionRemovePassword   = "solution.remove_password"
+	MsgSolutionCheckAPIKey      = "solution.check_api_key"
+	MsgSolutionCheckConnection  = "solution.check_connection"
+	MsgSolutionTruncatePrompt   = "solution.truncate_prompt"
+	MsgSolutionRaiseBudget      = "solution.raise_budget"
+	MsgSolutionCheckInput       = "solution.check_input"
+	MsgSolutionConvertFormat    = "solution.convert_format"
+	MsgSolutionRepairDocument   = "solution.repair_document"
+	MsgSolutionReportIssue      = "solution.report_issue"
 )
\ No newline at end of file
@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"fmt"
+)
+
+// ConvertOutline converts a Markdown outline, such as AI-generated content,
+// to text-only slides: one per top-level section, titled by its heading.
+// Unlike Convert, the top level is the highest heading level used rather than
+// H1, so an outline of H2 sections still gets a slide per section. A single
+// heading that opens the document above the top level, as in "# Deck" followed
+// by "## Section" headings, becomes a title slide with the paragraph after it
+// as the subtitle. Within a slide, paragraphs, quotes, code and lower headings
+// become text and list items become bullets.
+func (p *PowerPointConverter) ConvertOutline(doc *Document) error {
+	blocks := doc.Blocks
+	top := topHeadingLevel(blocks)
+
+	// A lone heading above the sections titles the deck
+	if top > 0 && blocks[0].Type == BlockHeading && blocks[0].Level == top &&
+		countHeadings(blocks, top) == 1 && topHeadingLevel(blocks[1:]) > top {
+		title, rest := blocks[0].Content, blocks[1:]
+		subtitle := ""
+		if len(rest) > 0 && rest[0].Type == BlockParagraph {
+			subtitle, rest = rest[0].Content, rest[1:]
+		}
+		p.builder.AddTitleSlide(title, subtitle)
+		blocks = rest
+		top = topHeadingLevel(blocks)
+	}
+
+	// Content before the first section gets a slide without a title
+	var slide *Slide
+	for _, block := range blocks {
+		if block.Type == BlockHeading && block.Level == top {
+			p.builder.AddContentSlide(slide)
+			slide = &Slide{Title: block.Content}
+			continue
+		}
+		if slide == nil {
+			slide = &Slide{}
+		}
+		addOutlineBlock(slide, block)
+	}
+	p.builder.AddContentSlide(slide)
+
+	if len(p.builder.slides) == 0 {
+		return fmt.Errorf("no content to put on slides")
+	}
+	return nil
+}
+
+// addOutlineBlock adds a block to a slide as plain text or bullets
+func addOutlineBlock(slide *Slide, block Block) {
+	switch block.Type {
+	case BlockList:
+		slide.Bullets = append(slide.Bullets, block.Items...)
+	case BlockOrderedList:
+		for i, item := range block.Items {
+			slide.Bullets = append(slide.Bullets, fmt.Sprintf("%d. %s", i+1, item))
+		}
+	default:
+		if block.Content != "" {
+			slide.Content = append(slide.Content, block.Content)
+		}
+	}
+}
+
+// topHeadingLevel returns the highest (smallest) heading level among blocks,
+// or 0 when there are no headings
+func topHeadingLevel(blocks []Block) int {
+	top := 0
+	for _, block := range blocks {
+		if block.Type == BlockHeading && (top == 0 || block.Level < top) {
+			top = block.Level
+		}
+	}
+	return top
+}
+
+// countHeadings counts the headings of a level among blocks
+func countHeadings(blocks []Block, level int) int {
+	count := 0
+	for _, block := range blocks {
+		if block.Type == BlockHeading && block.Level == level {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,102 @@
+package markdown
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConvertOutline(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []Slide
+	}{
+		{
+			name: "sections without a deck title",
+			markdown: `## Goals
+
+- Ship the beta
+- Collect feedback
+
+## Risks
+
+Hiring is slow.
+
+1. Budget
+2. Schedule`,
+			want: []Slide{
+				{Title: "Goals", Bullets: []string{"Ship the beta", "Collect feedback"}},
+				{Title: "Risks", Content: []string{"Hiring is slow."}, Bullets: []string{"1. Budget", "2. Schedule"}},
+			},
+		},
+		{
+			name: "deck title with subtitle",
+			markdown: `# Quarterly Review
+
+Prepared for the board
+
+## Results
+
+### Revenue
+
+- Up 12%`,
+			want: []Slide{
+				{Title: "Quarterly Review", Content: []string{"Prepared for the board"}},
+				{Title: "Results", Content: []string{"Revenue"}, Bullets: []string{"Up 12%"}},
+			},
+		},
+		{
+			name: "content before the first section",
+			markdown: `Intro text
+
+# One
+
+- a
+
+# Two
+
+- b`,
+			want: []Slide{
+				{Content: []string{"Intro text"}},
+				{Title: "One", Bullets: []string{"a"}},
+				{Title: "Two", Bullets: []string{"b"}},
+			},
+		},
+		{
+			name:     "no headings",
+			markdown: "- only\n- bullets",
+			want:     []Slide{{Bullets: []string{"only", "bullets"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.markdown))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			converter := NewPowerPointConverter()
+			if err := converter.ConvertOutline(doc); err != nil {
+				t.Fatalf("ConvertOutline() error = %v", err)
+			}
+			if got := converter.builder.slides; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slides = %+v, want %+v", got, tt.want)
+			}
+
+			if err := converter.SaveAs(filepath.Join(t.TempDir(), "outline.pptx")); err != nil {
+				t.Errorf("SaveAs() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConvertOutlineEmpty(t *testing.T) {
+	doc, err := Parse([]byte("   \n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := NewPowerPointConverter().ConvertOutline(doc); err == nil {
+		t.Error("ConvertOutline() of an empty document should fail")
+	}
+}
@@ -11,11 +11,13 @@ import (
 	"time"
 
 	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/httpclient"
 	"github.com/pyhub/pyhub-docs/internal/retry"
 )
 
 const (
 	defaultAPIURL = "https://api.openai.com/v1/chat/completions"
+	modelsAPIURL  = "https://api.openai.com/v1/models"
 	defaultModel  = "gpt-3.5-turbo"
 )
 
@@ -23,8 +25,14 @@ const (
 type Client struct {
 	apiKey      string
 	apiURL      string
+	modelsURL   string
 	httpClient  *http.Client
 	retryConfig retry.Config
+
+	// lastInputTokens and lastOutputTokens are the usage the API reported
+	// for the last successful request
+	lastInputTokens  int
+	lastOutputTokens int
 }
 
 // NewClient creates a new OpenAI API client
@@ -42,10 +50,9 @@ func NewClient(apiKey string) (*Client, error) {
 
 	return &Client{
 		apiKey: apiKey,
-		apiURL: defaultAPIURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		apiURL:    defaultAPIURL,
+		modelsURL: modelsAPIURL,
+		httpClient: httpclient.New(30 * time.Second),
 		retryConfig: retryConfig,
 	}, nil
 }
@@ -62,6 +69,7 @@ type ChatCompletionRequest struct {
 	Messages    []Message `json:"messages"`
 	MaxTokens   int       `json:"max_tokens,omitempty"`
 	Temperature float64   `json:"temperature,omitempty"`
+	TopP        float64   `json:"top_p,omitempty"`
 }
 
 // ChatCompletionResponse represents the response from the API
@@ -111,6 +119,7 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 		},
 		MaxTokens:   options.MaxTokens,
 		Temperature: options.Temperature,
+		TopP:        options.TopP,
 	}
 
 	// Marshal the request
@@ -119,6 +128,8 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	c.lastInputTokens, c.lastOutputTokens = 0, 0
+
 	// Execute with retry logic
 	return retry.DoWithResult(ctx, c.retryConfig, func() (string, error) {
 		// Create HTTP request
@@ -177,12 +188,25 @@ func (c *Client) GenerateContentWithContext(ctx context.Context, prompt string,
 			return "", fmt.Errorf("no content generated")
 		}
 
+		c.lastInputTokens = chatResp.Usage.PromptTokens
+		c.lastOutputTokens = chatResp.Usage.CompletionTokens
 		return chatResp.Choices[0].Message.Content, nil
 	})
 }
 
+// LastUsage returns the input and output tokens the API reported for the
+// last successful request, or zeros if it reported none
+func (c *Client) LastUsage() (int, int) {
+	return c.lastInputTokens, c.lastOutputTokens
+}
+
 // buildSystemMessage creates appropriate system message based on content type
 func (c *Client) buildSystemMessage(contentType string) string {
+	return SystemMessage(contentType)
+}
+
+// SystemMessage returns the system message sent for a content type
+func SystemMessage(contentType string) string {
 	switch contentType {
 	case "blog":
 		return "You are a professional blog writer. Create engaging, well-structured blog posts with clear sections, compelling introductions, and actionable conclusions."
@@ -203,6 +227,7 @@ type GenerateOptions struct {
 	Model       string
 	MaxTokens   int
 	Temperature float64
+	TopP        float64 // Nucleus sampling; 0 leaves the provider default
 }
 
 // DefaultGenerateOptions returns default generation options
@@ -215,6 +240,69 @@ func DefaultGenerateOptions() GenerateOptions {
 	}
 }
 
+// ModelInfo provides information about OpenAI models
+type ModelInfo struct {
+	Name          string
+	Description   string
+	ContextWindow int     // Maximum input tokens
+	MaxTokens     int     // Maximum output tokens
+	Best4         string  // Best for what use case
+	InputPrice    float64 // USD per 1M input tokens
+	OutputPrice   float64 // USD per 1M output tokens
+}
+
+// GetModelInfo returns information about the supported models
+func GetModelInfo() []ModelInfo {
+	return []ModelInfo{
+		{
+			Name:          "gpt-4-turbo-preview",
+			Description:   "GPT-4 Turbo with a 128K context",
+			ContextWindow: 128000,
+			MaxTokens:     4096,
+			Best4:         "Long documents, complex analysis at lower cost than GPT-4",
+			InputPrice:    10.00,
+			OutputPrice:   30.00,
+		},
+		{
+			Name:          "gpt-4",
+			Description:   "Most capable original GPT-4 model",
+			ContextWindow: 8192,
+			MaxTokens:     4096,
+			Best4:         "Complex reasoning, careful technical writing",
+			InputPrice:    30.00,
+			OutputPrice:   60.00,
+		},
+		{
+			Name:          "gpt-3.5-turbo",
+			Description:   "Fast, low-cost model (default)",
+			ContextWindow: 4096,
+			MaxTokens:     4096,
+			Best4:         "Quick drafts, simple content, high volume",
+			InputPrice:    0.50,
+			OutputPrice:   1.50,
+		},
+		{
+			Name:          "gpt-3.5-turbo-16k",
+			Description:   "GPT-3.5 Turbo with a 16K context",
+			ContextWindow: 16384,
+			MaxTokens:     4096,
+			Best4:         "Summaries of longer documents on a budget",
+			InputPrice:    3.00,
+			OutputPrice:   4.00,
+		},
+	}
+}
+
+// AvailableModels returns a list of supported OpenAI models
+func AvailableModels() []string {
+	infos := GetModelInfo()
+	models := make([]string, len(infos))
+	for i, info := range infos {
+		models[i] = info.Name
+	}
+	return models
+}
+
 // OpenAIError represents an error from the OpenAI API with additional metadata
 type OpenAIError struct {
 	StatusCode int
@@ -262,4 +350,68 @@ func isRetryableOpenAIError(err error) bool {
 // SetRetryConfig allows customizing the retry configuration
 func (c *Client) SetRetryConfig(config retry.Config) {
 	c.retryConfig = config
+}
+
+// RetryConfig returns the current retry configuration
+func (c *Client) RetryConfig() retry.Config {
+	return c.retryConfig
+}
+
+// SetAPIURL sends generation requests to url instead of the provider's API,
+// such as a proxy or a test server
+func (c *Client) SetAPIURL(url string) {
+	c.apiURL = url
+}
+
+// ListModels returns the IDs of the models available to the API key. It is a
+// cheap request that does not consume tokens, useful for connectivity checks.
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	return retry.DoWithResult(ctx, c.retryConfig, func() ([]string, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "GET", c.modelsURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			var apiError struct {
+				Error APIError `json:"error"`
+			}
+			if err := json.Unmarshal(body, &apiError); err == nil && apiError.Error.Message != "" {
+				return nil, &OpenAIError{
+					StatusCode: resp.StatusCode,
+					Message:    apiError.Error.Message,
+					Type:       apiError.Error.Type,
+					Code:       apiError.Error.Code,
+				}
+			}
+			return nil, retry.NewHTTPError(resp.StatusCode, string(body))
+		}
+
+		var listResp struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &listResp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		models := make([]string, 0, len(listResp.Data))
+		for _, m := range listResp.Data {
+			models = append(models, m.ID)
+		}
+		return models, nil
+	})
 }
\ No newline at end of file
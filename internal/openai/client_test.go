@@ -510,4 +510,96 @@ func TestIsRetryableOpenAIError(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_GenerateContentSendsTopP(t *testing.T) {
+	var received ChatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"role": "assistant", "content": "ok"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-api-key")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	client.apiURL = server.URL
+
+	_, err = client.GenerateContent("prompt", GenerateOptions{
+		Model:       "gpt-4",
+		MaxTokens:   10,
+		Temperature: 1.5,
+		TopP:        0.9,
+	})
+	if err != nil {
+		t.Fatalf("GenerateContent() error = %v", err)
+	}
+	if received.TopP != 0.9 {
+		t.Errorf("request top_p = %v, want 0.9", received.TopP)
+	}
+	if received.Temperature != 1.5 {
+		t.Errorf("request temperature = %v, want 1.5", received.Temperature)
+	}
+}
+
+func TestGetModelInfo(t *testing.T) {
+	infos := GetModelInfo()
+	if len(infos) == 0 {
+		t.Fatal("GetModelInfo() returned empty slice")
+	}
+
+	for _, info := range infos {
+		if info.Name == "" || info.Description == "" || info.Best4 == "" {
+			t.Errorf("incomplete model info: %+v", info)
+		}
+		if info.ContextWindow <= 0 || info.MaxTokens <= 0 || info.MaxTokens > info.ContextWindow {
+			t.Errorf("%s: invalid limits %d/%d", info.Name, info.ContextWindow, info.MaxTokens)
+		}
+	}
+
+	if models := AvailableModels(); len(models) != len(infos) || models[0] != infos[0].Name {
+		t.Errorf("AvailableModels() = %v, want the names from GetModelInfo()", models)
+	}
+}
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("method = %s, want GET", r.Method)
+		}
+		if r.Header.Get("Authorization") != "Bearer valid-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"message": "Incorrect API key provided", "type": "invalid_request_error"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]string{{"id": "gpt-4"}, {"id": "gpt-3.5-turbo"}},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("valid-key")
+	client.modelsURL = server.URL
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 2 || models[0] != "gpt-4" {
+		t.Errorf("ListModels() = %v, want [gpt-4 gpt-3.5-turbo]", models)
+	}
+
+	client, _ = NewClient("wrong-key")
+	client.modelsURL = server.URL
+	_, err = client.ListModels(context.Background())
+	var openAIErr *OpenAIError
+	if !errors.As(err, &openAIErr) || openAIErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 OpenAIError, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package replace
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// checkAllPartsRules rejects rules that replacing in every XML part cannot
+// apply. Parts are streamed one at a time, so occurrences cannot be numbered
+// across the document.
+func checkAllPartsRules(rules []Rule) error {
+	for _, rule := range rules {
+		if rule.Occurrence != 0 {
+			return pkgErrors.NewValidationError("occurrence", fmt.Sprint(rule.Occurrence),
+				fmt.Sprintf("rule '%s' is limited to one occurrence, which cannot be combined with replacing in all parts", rule.Old))
+		}
+	}
+	return nil
+}
+
+// replaceInAllParts applies rules to the text runs of every XML part of a
+// Word or PowerPoint document (see ReplaceOptions.AllParts)
+func replaceInAllParts(docPath string, rules []Rule, opts ReplaceOptions) (int, error) {
+	if err := checkAllPartsRules(rules); err != nil {
+		return 0, err
+	}
+	if hasNormalizedRules(rules) {
+		ui.PrintWarning("Text normalization is not supported when replacing in all parts; normalized rules match exactly in %s", docPath)
+	}
+
+	info, err := os.Stat(docPath)
+	if err != nil {
+		return 0, pkgErrors.NewFileError(docPath, "opening document", err)
+	}
+	// Streaming rewrites the document while replacing, so it cannot be
+	// abandoned part way
+	if !opts.deadline.beginSave() {
+		return 0, ErrFileTimeout
+	}
+
+	var result *ReplaceResult
+	if document.IsPowerPointFile(docPath) {
+		result, err = processPowerPointDocumentStreaming(docPath, rules, info.Size(), nil, opts.Timings, true)
+	} else {
+		result, err = processWordDocumentStreaming(docPath, rules, info.Size(), opts.Timings, opts.IncludeHiddenText, nil, true)
+	}
+	replacements := 0
+	if result != nil {
+		replacements = result.Replacements
+	}
+	if err != nil {
+		return replacements, err
+	}
+
+	if opts.StripMetadata {
+		if err := document.StripFileMetadata(docPath); err != nil {
+			return replacements, fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	}
+	return replacements, nil
+}
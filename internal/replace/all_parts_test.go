@@ -0,0 +1,54 @@
+package replace
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestReplaceInDocumentAllParts(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+	count, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{AllParts: true})
+	if err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions() error = %v", err)
+	}
+	if count == 0 {
+		t.Error("expected replacements in all parts")
+	}
+	checkDocument(t, docPath, "Version 2.0")
+}
+
+func TestAllPartsRejectsOccurrenceRules(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0", Occurrence: 1}}
+	var validationErr *pkgErrors.ValidationError
+	if _, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{AllParts: true}); !errors.As(err, &validationErr) {
+		t.Errorf("ReplaceInDocumentWithOptions() error = %v, want validation error", err)
+	}
+	if _, err := ProcessLargeFile(docPath, rules, &LargeFileOptions{AllParts: true}); !errors.As(err, &validationErr) {
+		t.Errorf("ProcessLargeFile() error = %v, want validation error", err)
+	}
+}
+
+func TestProcessLargeFileAllPartsStreams(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+
+	// The file is far below the streaming threshold, but all parts always streams
+	opts := &LargeFileOptions{EnableStreaming: false, FileSizeThreshold: 10 * 1024 * 1024, AllParts: true}
+	result, err := ProcessLargeFile(docPath, []Rule{{Old: "Version 1.0", New: "Version 2.0"}}, opts)
+	if err != nil {
+		t.Fatalf("ProcessLargeFile() error = %v", err)
+	}
+	if result.Replacements == 0 {
+		t.Error("expected replacements in all parts")
+	}
+	checkDocument(t, docPath, "Version 2.0")
+}
@@ -0,0 +1,93 @@
+package replace
+
+import (
+	"fmt"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// Collision kinds
+const (
+	// CollisionDuplicate means two rules search for the same text; the later rule never matches
+	CollisionDuplicate = "duplicate"
+	// CollisionShadowed means an earlier rule's text is contained in a later rule's text,
+	// so the earlier rule rewrites it before the later rule can match
+	CollisionShadowed = "shadowed"
+)
+
+// RuleCollision describes two rules whose application order changes the result
+type RuleCollision struct {
+	Kind   string // CollisionDuplicate or CollisionShadowed
+	First  int    // index of the rule applied first
+	Second int    // index of the rule that is affected
+	Rules  [2]Rule
+}
+
+// String returns a human-readable description of the collision with ordering guidance
+func (c RuleCollision) String() string {
+	switch c.Kind {
+	case CollisionDuplicate:
+		return fmt.Sprintf("rules #%d and #%d both replace %q; rule #%d will never match (remove one of them)",
+			c.First+1, c.Second+1, c.Rules[0].Old, c.Second+1)
+	default:
+		return fmt.Sprintf("rule #%d (%q) is applied before rule #%d (%q) which contains it; rule #%d may never match (move it before rule #%d)",
+			c.First+1, c.Rules[0].Old, c.Second+1, c.Rules[1].Old, c.Second+1, c.First+1)
+	}
+}
+
+// DetectCollisions finds rules whose Old text is identical to, or contained in,
+// the Old text of a later rule. Rules are applied in order, so in both cases the
+// later rule may never see the text it is looking for. Disabled rules and rules
+// that never apply to the same document are ignored.
+func DetectCollisions(rules []Rule) []RuleCollision {
+	var collisions []RuleCollision
+
+	for i := 0; i < len(rules); i++ {
+		for j := i + 1; j < len(rules); j++ {
+			first, second := rules[i], rules[j]
+			if first.Old == "" || second.Old == "" || !first.IsEnabled() || !second.IsEnabled() {
+				continue
+			}
+			if !first.sharesFormat(second) || !first.sharesFiles(second) {
+				continue
+			}
+
+			switch {
+			case first.Old == second.Old:
+				collisions = append(collisions, RuleCollision{
+					Kind: CollisionDuplicate, First: i, Second: j, Rules: [2]Rule{first, second},
+				})
+			case strings.Contains(second.Old, first.Old):
+				collisions = append(collisions, RuleCollision{
+					Kind: CollisionShadowed, First: i, Second: j, Rules: [2]Rule{first, second},
+				})
+			}
+		}
+	}
+
+	return collisions
+}
+
+// CheckCollisions reports rule collisions as warnings, or as a validation error when strict is set
+func CheckCollisions(rules []Rule, strict bool) error {
+	collisions := DetectCollisions(rules)
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	if strict {
+		messages := make([]string, len(collisions))
+		for i, c := range collisions {
+			messages[i] = c.String()
+		}
+		return pkgErrors.NewValidationError("rules", len(rules),
+			fmt.Sprintf("conflicting replacement rules: %s", strings.Join(messages, "; ")))
+	}
+
+	for _, c := range collisions {
+		ui.PrintWarning("Rule conflict: %s", c.String())
+	}
+	return nil
+}
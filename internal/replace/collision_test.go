@@ -0,0 +1,159 @@
+package replace
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectCollisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []Rule
+		wantKinds []string
+	}{
+		{
+			name: "no overlap",
+			rules: []Rule{
+				{Old: "cat", New: "dog"},
+				{Old: "bird", New: "fish"},
+			},
+			wantKinds: nil,
+		},
+		{
+			name: "shorter rule before longer rule",
+			rules: []Rule{
+				{Old: "cat", New: "dog"},
+				{Old: "category", New: "section"},
+			},
+			wantKinds: []string{CollisionShadowed},
+		},
+		{
+			name: "longer rule first is safe",
+			rules: []Rule{
+				{Old: "category", New: "section"},
+				{Old: "cat", New: "dog"},
+			},
+			wantKinds: nil,
+		},
+		{
+			name: "identical old text",
+			rules: []Rule{
+				{Old: "cat", New: "dog"},
+				{Old: "cat", New: "lion"},
+			},
+			wantKinds: []string{CollisionDuplicate},
+		},
+		{
+			name: "rules for different formats",
+			rules: []Rule{
+				{Old: "cat", New: "dog", Format: "docx"},
+				{Old: "cat", New: "lion", Format: "pptx"},
+				{Old: "category", New: "section", Format: "pptx"},
+			},
+			wantKinds: []string{CollisionShadowed},
+		},
+		{
+			name: "rules for different files",
+			rules: []Rule{
+				{Old: "cat", New: "dog", Files: "contract_*"},
+				{Old: "cat", New: "lion", Files: "invoice_*"},
+				{Old: "category", New: "section", Files: "invoice_2024*"},
+			},
+			wantKinds: []string{CollisionShadowed},
+		},
+		{
+			name: "rules for overlapping files",
+			rules: []Rule{
+				{Old: "cat", New: "dog", Files: "contract_*"},
+				{Old: "cat", New: "lion", Files: "contract_2024*"},
+				{Old: "cat", New: "tiger"},
+			},
+			wantKinds: []string{CollisionDuplicate, CollisionDuplicate, CollisionDuplicate},
+		},
+		{
+			name: "multiple collisions",
+			rules: []Rule{
+				{Old: "cat", New: "dog"},
+				{Old: "cat", New: "lion"},
+				{Old: "category", New: "section"},
+			},
+			wantKinds: []string{CollisionDuplicate, CollisionShadowed, CollisionShadowed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collisions := DetectCollisions(tt.rules)
+			if len(collisions) != len(tt.wantKinds) {
+				t.Fatalf("DetectCollisions() returned %d collisions, want %d: %v", len(collisions), len(tt.wantKinds), collisions)
+			}
+			for i, c := range collisions {
+				if c.Kind != tt.wantKinds[i] {
+					t.Errorf("collision[%d].Kind = %s, want %s", i, c.Kind, tt.wantKinds[i])
+				}
+				if c.String() == "" {
+					t.Errorf("collision[%d] has empty description", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleCollision_StringGivesGuidance(t *testing.T) {
+	collisions := DetectCollisions([]Rule{
+		{Old: "cat", New: "dog"},
+		{Old: "category", New: "section"},
+	})
+	if len(collisions) != 1 {
+		t.Fatalf("expected 1 collision, got %d", len(collisions))
+	}
+	msg := collisions[0].String()
+	if !strings.Contains(msg, "move it before rule #1") {
+		t.Errorf("expected ordering guidance, got: %s", msg)
+	}
+}
+
+func TestReplaceInDocumentWithOptions_Strict(t *testing.T) {
+	rules := []Rule{
+		{Old: "Version", New: "Release"},
+		{Old: "Version 1.0", New: "Version 2.0"},
+	}
+
+	t.Run("strict mode errors", func(t *testing.T) {
+		docPath := filepath.Join(t.TempDir(), "strict.docx")
+		copyFile(t, "testdata/sample_document.docx", docPath)
+
+		_, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{Strict: true})
+		if err == nil {
+			t.Fatal("expected error for conflicting rules in strict mode")
+		}
+		if !strings.Contains(err.Error(), "conflicting replacement rules") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("default mode warns and continues", func(t *testing.T) {
+		docPath := filepath.Join(t.TempDir(), "warn.docx")
+		copyFile(t, "testdata/sample_document.docx", docPath)
+
+		if _, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{}); err != nil {
+			t.Fatalf("expected warning only, got error: %v", err)
+		}
+	})
+}
+
+func TestCheckCollisionsStrictIgnoresDisjointFiles(t *testing.T) {
+	rules := []Rule{
+		{Old: "Draft", New: "Final", Files: "contract_*"},
+		{Old: "Draft", New: "Approved", Files: "invoice_*"},
+	}
+	if err := CheckCollisions(rules, true); err != nil {
+		t.Errorf("CheckCollisions() error = %v, want none for rules on different files", err)
+	}
+
+	rules[1].Files = "contract_*.docx"
+	if err := CheckCollisions(rules, true); err == nil {
+		t.Error("CheckCollisions() error = nil, want an error for rules on overlapping files")
+	}
+}
@@ -16,6 +16,7 @@ type ConcurrentOptions struct {
 	MaxWorkers   int  // Maximum number of concurrent workers
 	ShowProgress bool // Whether to show progress
 	Verbose      bool // Whether to show verbose output
+	Replace      ReplaceOptions // Per-document replacement options
 }
 
 // DefaultConcurrentOptions returns default concurrent options
@@ -26,23 +27,45 @@ func DefaultConcurrentOptions() ConcurrentOptions {
 	}
 }
 
-// ReplaceInDirectoryConcurrent processes documents concurrently
+// ReplaceInDirectoryConcurrent processes documents concurrently. With
+// opts.Replace.FailFast no new files are started after the first failure;
+// files already running finish, and the results of every started file are
+// returned with a *FailFastError for the first failure.
 func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool, excludePattern string, opts ConcurrentOptions) ([]ReplaceResult, error) {
 	// Collect all files to process
 	var files []string
-	err := WalkDocumentFilesWithExclude(dirPath, recursive, excludePattern, func(path string) error {
+	err := WalkDocumentFilesWithSkips(dirPath, recursive, excludePattern, func(path string) error {
 		files = append(files, path)
 		return nil
-	})
+	}, opts.Replace.Skipped.recorder())
 	if err != nil {
 		return nil, err
 	}
 
+	// Skip files completed by an earlier run, then files unchanged since the
+	// last run with the same rules
+	if opts.Replace.State != nil || opts.Replace.Manifest != nil {
+		pending := files[:0]
+		for _, file := range files {
+			if opts.Replace.State != nil && opts.Replace.State.shouldSkip(file) {
+				opts.Replace.Skipped.Record(file, SkipCompleted)
+				continue
+			}
+			if opts.Replace.Manifest != nil && opts.Replace.Manifest.shouldSkip(file) {
+				opts.Replace.Skipped.Record(file, SkipUnchanged)
+				continue
+			}
+			pending = append(pending, file)
+		}
+		files = pending
+	}
+
 	if len(files) == 0 {
 		return []ReplaceResult{}, nil
 	}
 
 	// Validate all rules before processing
+	rules = EnabledRules(rules)
 	for i, rule := range rules {
 		if err := rule.Validate(); err != nil {
 			return nil, err
@@ -50,6 +73,12 @@ func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool,
 		_ = i // avoid unused variable warning
 	}
 
+	// Check rule collisions once for the whole directory
+	if err := CheckCollisions(rules, opts.Replace.Strict); err != nil {
+		return nil, err
+	}
+	opts.Replace.collisionsChecked = true
+
 	// Create worker pool
 	if opts.MaxWorkers <= 0 {
 		opts.MaxWorkers = 1
@@ -69,6 +98,10 @@ func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool,
 	results := make([]ReplaceResult, len(files))
 	var wg sync.WaitGroup
 	var processed int32
+	var stopOnce sync.Once
+	var stopErr *FailFastError
+	var stopped atomic.Bool
+	started := 0
 
 	// Process files concurrently
 	for i, file := range files {
@@ -78,9 +111,16 @@ func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool,
 			break
 		}
 		
-		wg.Add(1)
 		sem <- struct{}{} // Acquire semaphore
 		
+		// Start no new files once one has failed in fail-fast mode
+		if stopped.Load() {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		started++
+		
 		go func(idx int, path string) {
 			defer wg.Done()
 			defer func() { <-sem }() // Release semaphore
@@ -110,14 +150,22 @@ func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool,
 			}
 			
 			// Process the document
-			count, err := ReplaceInDocumentWithCount(path, rules)
+			count, err := replaceWithTimeout(path, rules, opts.Replace)
 			if err != nil {
 				result.Success = false
 				result.Error = err
+				if opts.Replace.FailFast {
+					stopOnce.Do(func() {
+						stopErr = &FailFastError{Path: path, Err: err}
+						stopped.Store(true)
+					})
+				}
 			} else {
 				result.Success = true
 				result.Replacements = count
+				recordCompleted(opts.Replace.State, path, count)
 			}
+			recordManifest(opts.Replace.Manifest, path, count, err)
 			
 			results[idx] = result
 			
@@ -144,6 +192,13 @@ func ReplaceInDirectoryConcurrent(dirPath string, rules []Rule, recursive bool,
 		}
 	}
 	
+	// Files not started because of cancellation or fail-fast have no result
+	results = results[:started]
+	SortResults(results)
+	saveManifest(opts.Replace.Manifest)
+	if stopErr != nil {
+		return results, stopErr
+	}
 	return results, nil
 }
 
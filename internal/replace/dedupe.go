@@ -0,0 +1,106 @@
+package replace
+
+import (
+	"fmt"
+	"strings"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// RuleConflict is a pair of rules that replace the same text with different
+// text. Rules apply in order, so only the first one ever matches.
+type RuleConflict struct {
+	First  SourcedRule
+	Second SourcedRule
+}
+
+// String describes the conflict and which rule wins
+func (c RuleConflict) String() string {
+	return fmt.Sprintf("%q is replaced with %q%s and with %q%s; only the first applies",
+		c.First.Old, c.First.New, ruleSourceSuffix(c.First), c.Second.New, ruleSourceSuffix(c.Second))
+}
+
+// ruleSourceSuffix names the rules file of a rule, if known
+func ruleSourceSuffix(rule SourcedRule) string {
+	if rule.Source == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", rule.Source)
+}
+
+// dedupeKey identifies rules that match the same text the same way
+type dedupeKey struct {
+	old        string
+	occurrence int
+	normalize  bool
+	format     string
+	files      string
+}
+
+// DedupeRules removes enabled rules that repeat an earlier enabled rule
+// exactly, as merged rule files often do, and returns the remaining rules in
+// order together with the conflicts among them: rules matching the same text
+// as an earlier rule but replacing it differently. Disabled rules are kept
+// as they are.
+func DedupeRules(rules []SourcedRule) ([]SourcedRule, []RuleConflict) {
+	first := make(map[dedupeKey]SourcedRule)
+	deduped := make([]SourcedRule, 0, len(rules))
+	var conflicts []RuleConflict
+
+	for _, rule := range rules {
+		if !rule.IsEnabled() {
+			deduped = append(deduped, rule)
+			continue
+		}
+		key := dedupeKey{old: rule.Old, occurrence: rule.Occurrence, normalize: rule.NormalizeText, format: rule.Format, files: rule.Files}
+		earlier, seen := first[key]
+		if !seen && rule.Format != "" {
+			// A format's rule may repeat a shared rule
+			shared := key
+			shared.format = ""
+			earlier, seen = first[shared]
+		}
+		if !seen && rule.Files != "" {
+			// A rule for some files may repeat a rule for every file
+			shared := key
+			shared.files = ""
+			if earlier, seen = first[shared]; !seen && rule.Format != "" {
+				shared.format = ""
+				earlier, seen = first[shared]
+			}
+		}
+		if seen {
+			if earlier.New == rule.New {
+				continue
+			}
+			conflicts = append(conflicts, RuleConflict{First: earlier, Second: rule})
+		} else {
+			first[key] = rule
+		}
+		deduped = append(deduped, rule)
+	}
+	return deduped, conflicts
+}
+
+// CheckRuleConflicts reports conflicting rules as warnings, or as a
+// validation error when strict is set
+func CheckRuleConflicts(conflicts []RuleConflict, strict bool) error {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if strict {
+		messages := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			messages[i] = c.String()
+		}
+		return pkgErrors.NewValidationError("rules", len(conflicts),
+			fmt.Sprintf("contradictory replacement rules: %s", strings.Join(messages, "; ")))
+	}
+
+	for _, c := range conflicts {
+		ui.PrintWarning("Contradictory rules: %s", c.String())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package replace
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestDedupeRules(t *testing.T) {
+	disabled := false
+	rules := []SourcedRule{
+		{Rule: Rule{Old: "colour", New: "color"}, Source: "a.yml"},
+		{Rule: Rule{Old: "Acme", New: "ACME"}, Source: "a.yml"},
+		{Rule: Rule{Old: "colour", New: "color", Description: "US spelling"}, Source: "b.yml"},
+		{Rule: Rule{Old: "Acme", New: "Acme Corp."}, Source: "b.yml"},
+		{Rule: Rule{Old: "Acme", New: "ACME", Occurrence: 1}, Source: "b.yml"},
+		{Rule: Rule{Old: "colour", New: "color", Enabled: &disabled}, Source: "b.yml"},
+	}
+
+	deduped, conflicts := DedupeRules(rules)
+
+	want := []SourcedRule{rules[0], rules[1], rules[3], rules[4], rules[5]}
+	if !reflect.DeepEqual(deduped, want) {
+		t.Errorf("DedupeRules() rules =\n%+v\nwant\n%+v", deduped, want)
+	}
+	if len(conflicts) != 1 || conflicts[0].First.New != "ACME" || conflicts[0].Second.New != "Acme Corp." {
+		t.Fatalf("DedupeRules() conflicts = %+v", conflicts)
+	}
+	wantMessage := `"Acme" is replaced with "ACME" (a.yml) and with "Acme Corp." (b.yml); only the first applies`
+	if got := conflicts[0].String(); got != wantMessage {
+		t.Errorf("String() = %q, want %q", got, wantMessage)
+	}
+}
+
+func TestDedupeRulesFormats(t *testing.T) {
+	rules := []SourcedRule{
+		{Rule: Rule{Old: "colour", New: "color"}},
+		{Rule: Rule{Old: "colour", New: "color", Format: "docx"}},
+		{Rule: Rule{Old: "Slide", New: "Page", Format: "docx"}},
+		{Rule: Rule{Old: "Slide", New: "Frame", Format: "pptx"}},
+		{Rule: Rule{Old: "colour", New: "hue", Format: "pptx"}},
+	}
+
+	deduped, conflicts := DedupeRules(rules)
+
+	want := []SourcedRule{rules[0], rules[2], rules[3], rules[4]}
+	if !reflect.DeepEqual(deduped, want) {
+		t.Errorf("DedupeRules() rules =\n%+v\nwant\n%+v", deduped, want)
+	}
+	// Only the pptx rule contradicts a rule that also applies to pptx files
+	if len(conflicts) != 1 || conflicts[0].First.New != "color" || conflicts[0].Second.New != "hue" {
+		t.Errorf("DedupeRules() conflicts = %+v", conflicts)
+	}
+}
+
+func TestDedupeRulesFiles(t *testing.T) {
+	rules := []SourcedRule{
+		{Rule: Rule{Old: "Draft", New: "Final", Files: "contract_*"}},
+		{Rule: Rule{Old: "Draft", New: "Approved", Files: "invoice_*"}},
+		{Rule: Rule{Old: "Draft", New: "Final", Files: "contract_*"}},
+		{Rule: Rule{Old: "Ltd", New: "Limited"}},
+		{Rule: Rule{Old: "Ltd", New: "Ltd.", Files: "invoice_*"}},
+	}
+
+	deduped, conflicts := DedupeRules(rules)
+
+	want := []SourcedRule{rules[0], rules[1], rules[3], rules[4]}
+	if !reflect.DeepEqual(deduped, want) {
+		t.Errorf("DedupeRules() rules =\n%+v\nwant\n%+v", deduped, want)
+	}
+	// Only the invoice rule contradicts a rule that also applies to invoices
+	if len(conflicts) != 1 || conflicts[0].First.New != "Limited" || conflicts[0].Second.New != "Ltd." {
+		t.Errorf("DedupeRules() conflicts = %+v", conflicts)
+	}
+}
+
+func TestCheckRuleConflicts(t *testing.T) {
+	if err := CheckRuleConflicts(nil, true); err != nil {
+		t.Errorf("no conflicts should pass, got %v", err)
+	}
+
+	conflicts := []RuleConflict{{
+		First:  SourcedRule{Rule: Rule{Old: "a", New: "b"}},
+		Second: SourcedRule{Rule: Rule{Old: "a", New: "c"}},
+	}}
+	if err := CheckRuleConflicts(conflicts, false); err != nil {
+		t.Errorf("conflicts should only warn without strict, got %v", err)
+	}
+
+	err := CheckRuleConflicts(conflicts, true)
+	var validationErr *pkgErrors.ValidationError
+	if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), `"a" is replaced with "b" and with "c"`) {
+		t.Errorf("expected validation error naming the conflict, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package replace
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// dumpPartPattern matches the XML parts that hold replaceable text: the Word
+// body, headers, footers and notes, and PowerPoint slides
+var dumpPartPattern = regexp.MustCompile(`^(word/(document|header\d*|footer\d*|footnotes|endnotes)\.xml|ppt/slides/slide\d+\.xml)$`)
+
+// dumpDirName turns a document path into a single directory name so documents
+// with the same name in different folders do not overwrite each other
+func dumpDirName(docPath string) string {
+	name := filepath.ToSlash(filepath.Clean(docPath))
+	name = strings.TrimLeft(name, "./")
+	return strings.NewReplacer("/", "_", ":", "_").Replace(name)
+}
+
+// DumpXML copies the text-bearing XML parts of a Word or PowerPoint document
+// to dir/<document>/<stage>/, keeping their paths inside the package, e.g.
+// dumps/report.docx/before/word/document.xml. It is a debugging aid for
+// replacements that do not match; other formats are skipped.
+func DumpXML(docPath, dir, stage string) error {
+	reader, err := zip.OpenReader(docPath)
+	if err != nil {
+		// RTF and other non-package formats have no XML parts
+		if !document.IsWordFile(docPath) && !document.IsPowerPointFile(docPath) {
+			return nil
+		}
+		return fmt.Errorf("failed to open %s: %w", docPath, err)
+	}
+	defer reader.Close()
+
+	target := filepath.Join(dir, dumpDirName(docPath), stage)
+	for _, file := range reader.File {
+		if !dumpPartPattern.MatchString(file.Name) {
+			continue
+		}
+		if err := dumpPart(file, filepath.Join(target, filepath.FromSlash(path.Clean(file.Name)))); err != nil {
+			return fmt.Errorf("failed to dump %s from %s: %w", file.Name, docPath, err)
+		}
+	}
+	return nil
+}
+
+// dumpPart writes one zip entry to dest
+func dumpPart(file *zip.File, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// dumpXMLStage dumps a document's XML for the given stage, warning instead of
+// failing the replacement when it cannot be written
+func dumpXMLStage(docPath, dir, stage string) {
+	if dir == "" {
+		return
+	}
+	if err := DumpXML(docPath, dir, stage); err != nil {
+		ui.PrintWarning("--dump-xml: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package replace
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+func TestReplaceDumpsXMLBeforeAndAfter(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+	dumpDir := t.TempDir()
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+	if _, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{DumpXMLDir: dumpDir}); err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions() error = %v", err)
+	}
+
+	partDir := filepath.Join(dumpDir, dumpDirName(docPath))
+	before, err := os.ReadFile(filepath.Join(partDir, "before", "word", "document.xml"))
+	if err != nil {
+		t.Fatalf("before dump missing: %v", err)
+	}
+	after, err := os.ReadFile(filepath.Join(partDir, "after", "word", "document.xml"))
+	if err != nil {
+		t.Fatalf("after dump missing: %v", err)
+	}
+	if !strings.Contains(string(before), "Version 1.0") || !strings.Contains(string(after), "Version 2.0") {
+		t.Error("dumps should hold the XML before and after replacement")
+	}
+
+	// Only text-bearing parts are dumped
+	if _, err := os.Stat(filepath.Join(partDir, "before", "[Content_Types].xml")); err == nil {
+		t.Error("package metadata should not be dumped")
+	}
+}
+
+func TestDumpXMLPresentation(t *testing.T) {
+	dumpDir := t.TempDir()
+	if err := DumpXML("testdata/sample_presentation.pptx", dumpDir, "before"); err != nil {
+		t.Fatalf("DumpXML() error = %v", err)
+	}
+	slides, _ := filepath.Glob(filepath.Join(dumpDir, "testdata_sample_presentation.pptx", "before", "ppt", "slides", "slide*.xml"))
+	if len(slides) == 0 {
+		t.Error("slide XML was not dumped")
+	}
+}
+
+func TestDumpDirName(t *testing.T) {
+	tests := map[string]string{
+		"report.docx":           "report.docx",
+		"./docs/a/report.docx":  "docs_a_report.docx",
+		"docs/b/report.docx":    "docs_b_report.docx",
+		"../shared/report.docx": "shared_report.docx",
+	}
+	for input, want := range tests {
+		if got := dumpDirName(input); got != want {
+			t.Errorf("dumpDirName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestReplaceStrictXML(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+
+	// Add a malformed part that replacement itself never reads
+	reader, err := zip.OpenReader(docPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range reader.File {
+		rc, _ := file.Open()
+		w, _ := writer.Create(file.Name)
+		io.Copy(w, rc)
+		rc.Close()
+	}
+	w, _ := writer.Create("word/settings.xml")
+	w.Write([]byte("<w:settings><w:zoom></w:settings>"))
+	writer.Close()
+	reader.Close()
+	if err := os.WriteFile(docPath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+	if _, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{StrictXML: true}); !errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
+		t.Fatalf("strict replacement error = %v, want ErrDocumentCorrupted", err)
+	}
+	if _, err := ProcessLargeFile(docPath, rules, &LargeFileOptions{StrictXML: true}); !errors.Is(err, pkgErrors.ErrDocumentCorrupted) {
+		t.Fatalf("strict large file error = %v, want ErrDocumentCorrupted", err)
+	}
+
+	// Lenient by default
+	if count, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{}); err != nil || count == 0 {
+		t.Errorf("lenient replacement = %d, %v", count, err)
+	}
+}
@@ -0,0 +1,59 @@
+package replace
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Match is one occurrence of a search query in a document's text
+type Match struct {
+	// Line is the 1-based line of the extracted text the match starts on
+	Line int `json:"line"`
+	// Text is the matched text
+	Text string `json:"text"`
+	// Context is the match with the text around it on the same line
+	Context string `json:"context"`
+}
+
+// FindOptions controls how a query matches document text
+type FindOptions struct {
+	// Regex treats the query as a regular expression instead of literal text
+	Regex bool
+	// CaseInsensitive ignores letter case
+	CaseInsensitive bool
+}
+
+// CompileQuery turns a search query into a pattern. Literal queries are
+// quoted so regular expression characters match themselves.
+func CompileQuery(query string, opts FindOptions) (*regexp.Regexp, error) {
+	expr := query
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if opts.CaseInsensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+// FindMatches returns every non-empty match of pattern in text, in order,
+// with the surrounding text shown the same way as in change previews
+func FindMatches(text string, pattern *regexp.Regexp) []Match {
+	var matches []Match
+	line, counted := 1, 0
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		line += strings.Count(text[counted:loc[0]], "\n")
+		counted = loc[0]
+
+		matched := text[loc[0]:loc[1]]
+		matches = append(matches, Match{
+			Line:    line,
+			Text:    matched,
+			Context: snippetAround(text, loc[0], matched, matched).Before,
+		})
+	}
+	return matches
+}
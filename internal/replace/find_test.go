@@ -0,0 +1,57 @@
+package replace
+
+import "testing"
+
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		opts  FindOptions
+		text  string
+		want  bool
+	}{
+		{"v1.0", FindOptions{}, "v1.0", true},
+		{"v1.0", FindOptions{}, "v1x0", false}, // literal dot
+		{"v1.0", FindOptions{Regex: true}, "v1x0", true},
+		{"draft", FindOptions{}, "Draft", false},
+		{"draft", FindOptions{CaseInsensitive: true}, "Draft", true},
+		{`v\d+`, FindOptions{Regex: true, CaseInsensitive: true}, "V2", true},
+	}
+	for _, tt := range tests {
+		pattern, err := CompileQuery(tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("CompileQuery(%q) error = %v", tt.query, err)
+		}
+		if got := pattern.MatchString(tt.text); got != tt.want {
+			t.Errorf("CompileQuery(%q, %+v) matches %q = %v, want %v", tt.query, tt.opts, tt.text, got, tt.want)
+		}
+	}
+
+	if _, err := CompileQuery("(", FindOptions{Regex: true}); err == nil {
+		t.Error("expected error for invalid regular expression")
+	}
+}
+
+func TestFindMatches(t *testing.T) {
+	text := "Version 1.0 released.\nNothing here.\nUpgrade from Version 1.0 and version 1.0 now."
+	pattern, _ := CompileQuery("version 1.0", FindOptions{CaseInsensitive: true})
+
+	matches := FindMatches(text, pattern)
+	if len(matches) != 3 {
+		t.Fatalf("got %d matches, want 3: %+v", len(matches), matches)
+	}
+	if matches[0].Line != 1 || matches[1].Line != 3 || matches[2].Line != 3 {
+		t.Errorf("lines = %d, %d, %d, want 1, 3, 3", matches[0].Line, matches[1].Line, matches[2].Line)
+	}
+	if matches[2].Text != "version 1.0" {
+		t.Errorf("text = %q, want the matched spelling", matches[2].Text)
+	}
+	if matches[1].Context != "Upgrade from Version 1.0 and version 1.0 now." {
+		t.Errorf("context = %q", matches[1].Context)
+	}
+
+	// Patterns that can match empty text do not report empty matches
+	empty, _ := CompileQuery("x*", FindOptions{Regex: true})
+	if got := FindMatches("abc", empty); len(got) != 0 {
+		t.Errorf("got %+v, want no matches", got)
+	}
+}
@@ -0,0 +1,118 @@
+package replace
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ForbiddenTerm is text that documents must not contain, such as an old
+// product name or an internal codename
+type ForbiddenTerm struct {
+	// Term is the text, or regular expression, to look for
+	Term string `json:"term"`
+	// Regex treats Term as a regular expression
+	Regex bool `json:"regex,omitempty"`
+	// CaseInsensitive ignores letter case
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+	// Description explains why the term is forbidden or what to use instead
+	Description string `json:"description,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// Violation is a forbidden term found in a document's text
+type Violation struct {
+	Term        string  `json:"term"`
+	Description string  `json:"description,omitempty"`
+	Matches     []Match `json:"matches"`
+}
+
+// ParseForbiddenTerms parses a YAML list of forbidden terms. Each entry is
+// either a plain string or a map with 'term' and optional 'regex',
+// 'case_insensitive' and 'description' fields. Patterns are compiled, so an
+// invalid regular expression is reported here.
+func ParseForbiddenTerms(data []byte) ([]ForbiddenTerm, error) {
+	var rawTerms []interface{}
+	if err := yaml.Unmarshal(data, &rawTerms); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	terms := make([]ForbiddenTerm, 0, len(rawTerms))
+	for i, rawTerm := range rawTerms {
+		var term ForbiddenTerm
+		switch raw := rawTerm.(type) {
+		case string:
+			term.Term = raw
+		case map[string]interface{}:
+			value, ok := raw["term"]
+			if !ok || value == nil {
+				return nil, fmt.Errorf("term at index %d: missing required field 'term'", i)
+			}
+			term.Term = fmt.Sprintf("%v", value)
+			for _, flag := range []struct {
+				field  string
+				target *bool
+			}{{"regex", &term.Regex}, {"case_insensitive", &term.CaseInsensitive}} {
+				if rawFlag, ok := raw[flag.field]; ok {
+					value, isBool := rawFlag.(bool)
+					if !isBool {
+						return nil, fmt.Errorf("term at index %d: '%s' must be true or false", i, flag.field)
+					}
+					*flag.target = value
+				}
+			}
+			if rawDescription, ok := raw["description"]; ok && rawDescription != nil {
+				term.Description = fmt.Sprintf("%v", rawDescription)
+			}
+		default:
+			return nil, fmt.Errorf("term at index %d: expected a string or a map with 'term'", i)
+		}
+
+		if term.Term == "" {
+			return nil, fmt.Errorf("term at index %d: term cannot be empty", i)
+		}
+		pattern, err := CompileQuery(term.Term, FindOptions{Regex: term.Regex, CaseInsensitive: term.CaseInsensitive})
+		if err != nil {
+			return nil, fmt.Errorf("term at index %d: invalid regular expression: %w", i, err)
+		}
+		term.pattern = pattern
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// LoadForbiddenTerms loads forbidden terms from a YAML file
+func LoadForbiddenTerms(filename string) ([]ForbiddenTerm, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+	terms, err := ParseForbiddenTerms(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse forbidden terms from %s: %w", filename, err)
+	}
+	return terms, nil
+}
+
+// CheckForbidden returns a violation for each term found in text, in the
+// order of terms. Terms not loaded by ParseForbiddenTerms are compiled here,
+// and never match if their pattern is invalid.
+func CheckForbidden(text string, terms []ForbiddenTerm) []Violation {
+	var violations []Violation
+	for _, term := range terms {
+		pattern := term.pattern
+		if pattern == nil {
+			var err error
+			if pattern, err = CompileQuery(term.Term, FindOptions{Regex: term.Regex, CaseInsensitive: term.CaseInsensitive}); err != nil {
+				continue
+			}
+		}
+		if matches := FindMatches(text, pattern); len(matches) > 0 {
+			violations = append(violations, Violation{Term: term.Term, Description: term.Description, Matches: matches})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,90 @@
+package replace
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseForbiddenTerms(t *testing.T) {
+	terms, err := ParseForbiddenTerms([]byte(`
+- Acme Classic
+- term: "Project (Falcon|Osprey)"
+  regex: true
+  description: Internal codename
+- term: confidential draft
+  case_insensitive: true
+`))
+	if err != nil {
+		t.Fatalf("ParseForbiddenTerms() error = %v", err)
+	}
+	if len(terms) != 3 {
+		t.Fatalf("got %d terms, want 3: %+v", len(terms), terms)
+	}
+	if terms[0].Term != "Acme Classic" || terms[0].Regex || terms[0].CaseInsensitive {
+		t.Errorf("terms[0] = %+v", terms[0])
+	}
+	if !terms[1].Regex || terms[1].Description != "Internal codename" {
+		t.Errorf("terms[1] = %+v", terms[1])
+	}
+	if !terms[2].CaseInsensitive {
+		t.Errorf("terms[2] = %+v", terms[2])
+	}
+
+	invalid := map[string]string{
+		"invalid regex":   "- term: \"(\"\n  regex: true",
+		"missing term":    "- description: no term",
+		"empty term":      "- \"\"",
+		"non-bool flag":   "- term: x\n  regex: yes please",
+		"unexpected type": "- [a, b]",
+		"not a list":      "term: x",
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseForbiddenTerms([]byte(data)); err == nil {
+				t.Errorf("expected an error for %q", data)
+			}
+		})
+	}
+
+	_, err = ParseForbiddenTerms([]byte("- ok\n- term: \"[\"\n  regex: true"))
+	if err == nil || !strings.Contains(err.Error(), "index 1") {
+		t.Errorf("error should name the term index, got %v", err)
+	}
+}
+
+func TestCheckForbidden(t *testing.T) {
+	terms, err := ParseForbiddenTerms([]byte(`
+- Acme Classic
+- term: "Project (Falcon|Osprey)"
+  regex: true
+- term: draft
+  case_insensitive: true
+- absent
+`))
+	if err != nil {
+		t.Fatalf("ParseForbiddenTerms() error = %v", err)
+	}
+
+	text := "Acme Classic is retired.\nProject Falcon and Project Osprey ship in Q3.\nDRAFT"
+	violations := CheckForbidden(text, terms)
+	if len(violations) != 3 {
+		t.Fatalf("got %d violations, want 3: %+v", len(violations), violations)
+	}
+	if violations[0].Term != "Acme Classic" || len(violations[0].Matches) != 1 {
+		t.Errorf("violations[0] = %+v", violations[0])
+	}
+	if len(violations[1].Matches) != 2 || violations[1].Matches[1].Text != "Project Osprey" {
+		t.Errorf("violations[1] = %+v", violations[1])
+	}
+	if violations[2].Matches[0].Line != 3 {
+		t.Errorf("violations[2] = %+v", violations[2])
+	}
+
+	// Terms built in code are compiled on demand
+	if got := CheckForbidden("v1.0", []ForbiddenTerm{{Term: "V1.0", CaseInsensitive: true}}); len(got) != 1 {
+		t.Errorf("got %+v, want one violation", got)
+	}
+	if got := CheckForbidden("clean text", terms); len(got) != 0 {
+		t.Errorf("got %+v, want no violations", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pyhub/pyhub-docs/internal/document"
 	"github.com/pyhub/pyhub-docs/internal/ui"
@@ -20,6 +21,29 @@ type LargeFileOptions struct {
 	ShowMemoryUsage bool
 	// EnableMemoryMonitor enables memory monitoring
 	EnableMemoryMonitor bool
+	// Slides limits PowerPoint replacement to these slide numbers (nil means all slides)
+	Slides map[int]bool
+	// Strict turns rule collision warnings into errors
+	Strict bool
+	// StripMetadata blanks author, company and other document properties after processing
+	StripMetadata bool
+	// Timings records how long opening, replacing and saving take (nil disables)
+	Timings *Timings
+	// IncludeHiddenText also replaces text in Word runs marked hidden
+	IncludeHiddenText bool
+	// Parts limits Word replacement to these parts (nil means all parts)
+	Parts map[string]bool
+	// StrictXML fails the document if any XML part is not well-formed
+	StrictXML bool
+	// DumpXMLDir, for debugging, receives the document's XML parts before
+	// and after replacement (see DumpXML); empty disables
+	DumpXMLDir string
+	// AllParts replaces the text runs of every XML part of Word and
+	// PowerPoint documents, which always streams (see ReplaceOptions.AllParts)
+	AllParts bool
+	// MaxRuleReplacements warns about rules that would replace more than this
+	// many times in the file, or with Strict leaves it unchanged (0 disables)
+	MaxRuleReplacements int
 }
 
 // DefaultLargeFileOptions returns default options for large file processing
@@ -37,6 +61,7 @@ func ProcessLargeFile(filePath string, rules []Rule, opts *LargeFileOptions) (*R
 	if opts == nil {
 		opts = DefaultLargeFileOptions()
 	}
+	rules = EnabledRules(rules)
 	
 	// Get file info
 	fileInfo, err := os.Stat(filePath)
@@ -55,6 +80,11 @@ func ProcessLargeFile(filePath string, rules []Rule, opts *LargeFileOptions) (*R
 	
 	// Determine if we should use streaming
 	useStreaming := opts.EnableStreaming && fileSize > opts.FileSizeThreshold
+	allParts := opts.AllParts && (document.IsWordFile(filePath) || document.IsPowerPointFile(filePath))
+	if allParts {
+		// Replacing in every part is only implemented by streaming
+		useStreaming = true
+	}
 	
 	if opts.ShowMemoryUsage {
 		ui.PrintInfo("Processing %s (size: %s)", filePath, document.FormatBytes(uint64(fileSize)))
@@ -88,24 +118,86 @@ func ProcessLargeFile(filePath string, rules []Rule, opts *LargeFileOptions) (*R
 		Replacements: 0,
 	}
 	
+	// Detect rules whose order changes the result
+	if err := CheckCollisions(rules, opts.Strict); err != nil {
+		return nil, err
+	}
+	
+	// Leave the file untouched when every rule is scoped to other files
+	rules = RulesForFile(rules, filePath)
+	if len(rules) == 0 {
+		result.Success = true
+		return result, nil
+	}
+	
+	if opts.StrictXML {
+		if err := document.ValidateXML(filePath); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Streaming replaces text part by part without the whole document text,
+	// so normalized rules fall back to exact matching
+	if useStreaming && hasNormalizedRules(rules) {
+		ui.PrintWarning("Text normalization is not supported in streaming mode; normalized rules match exactly in %s", filePath)
+	}
+	
+	if opts.DumpXMLDir != "" {
+		dumpXMLStage(filePath, opts.DumpXMLDir, "before")
+		defer dumpXMLStage(filePath, opts.DumpXMLDir, "after")
+	}
+	
+	if allParts {
+		if err := checkAllPartsRules(rules); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Streaming replaces text part by part and cannot number occurrences
+	// across the document, so such rules use standard processing
+	if useStreaming && hasOccurrenceRules(rules) {
+		ui.PrintWarning("Rules limited to one occurrence are not supported in streaming mode; processing %s without streaming", filePath)
+		useStreaming = false
+	}
+	
+	// Catch rules, such as a single space or letter, that match far more than intended
+	if document.IsWordFile(filePath) || document.IsPowerPointFile(filePath) {
+		if err := checkLargeFileLimit(filePath, rules, fileSize, opts, useStreaming); err != nil {
+			return nil, err
+		}
+	}
+	
 	// Process based on file type and size
-	switch ext {
-	case ".docx":
+	switch {
+	case document.IsWordFile(filePath):
 		if useStreaming {
-			result, err = processWordDocumentStreaming(filePath, rules, fileSize)
+			result, err = processWordDocumentStreaming(filePath, rules, fileSize, opts.Timings, opts.IncludeHiddenText, opts.Parts, allParts)
 		} else {
-			result, err = processWordDocumentStandard(filePath, rules)
+			result, err = processWordDocumentStandard(filePath, rules, opts.Timings, opts.IncludeHiddenText, opts.Parts)
 		}
 		
-	case ".pptx":
+	case document.IsPowerPointFile(filePath):
 		if useStreaming {
-			result, err = processPowerPointDocumentStreaming(filePath, rules, fileSize)
+			result, err = processPowerPointDocumentStreaming(filePath, rules, fileSize, opts.Slides, opts.Timings, allParts)
 		} else {
-			result, err = processPowerPointDocumentStandard(filePath, rules)
+			result, err = processPowerPointDocumentStandard(filePath, rules, opts.Slides, opts.Timings)
 		}
 		
 	default:
-		return nil, fmt.Errorf("unsupported file type: %s", ext)
+		if !document.IsSupportedFormat(filePath) {
+			return nil, fmt.Errorf("unsupported file type: %s", ext)
+		}
+		// Other registered formats have no streaming implementation
+		result, err = processRegisteredDocument(filePath, rules, opts)
+	}
+	
+	// Strip document properties from the saved file
+	if err == nil && opts.StripMetadata {
+		if stripErr := document.StripFileMetadata(filePath); stripErr != nil {
+			result.Success = false
+			result.Error = stripErr
+			err = fmt.Errorf("failed to strip metadata: %w", stripErr)
+		}
 	}
 	
 	// Show final memory stats if monitoring
@@ -120,16 +212,22 @@ func ProcessLargeFile(filePath string, rules []Rule, opts *LargeFileOptions) (*R
 }
 
 // processWordDocumentStreaming processes a Word document using streaming
-func processWordDocumentStreaming(filePath string, rules []Rule, fileSize int64) (*ReplaceResult, error) {
+func processWordDocumentStreaming(filePath string, rules []Rule, fileSize int64, timings *Timings, includeHidden bool, parts map[string]bool, allParts bool) (*ReplaceResult, error) {
 	// Get adaptive options based on file size
 	streamOpts := document.AdaptiveStreamingOptions(fileSize)
 	
 	// Open document in streaming mode
+	opened := time.Now()
 	doc, err := document.OpenWordDocumentStreaming(filePath, streamOpts)
+	timings.Record(PhaseOpen, time.Since(opened))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open document for streaming: %w", err)
 	}
 	defer doc.Close()
+	doc.SetIncludeHiddenText(includeHidden)
+	doc.SetParts(parts)
+	doc.SetAllParts(allParts)
+	timings.AddFile()
 	
 	result := &ReplaceResult{
 		FilePath:     filePath,
@@ -138,6 +236,7 @@ func processWordDocumentStreaming(filePath string, rules []Rule, fileSize int64)
 	}
 	
 	// Apply each rule using streaming
+	replacing := time.Now()
 	for _, rule := range rules {
 		count, err := doc.ReplaceTextStreaming(rule.Old, rule.New)
 		if err != nil {
@@ -148,17 +247,23 @@ func processWordDocumentStreaming(filePath string, rules []Rule, fileSize int64)
 		result.Replacements += count
 	}
 	
+	timings.Record(PhaseReplace, time.Since(replacing))
 	return result, nil
 }
 
 // processWordDocumentStandard processes a Word document using standard method
-func processWordDocumentStandard(filePath string, rules []Rule) (*ReplaceResult, error) {
+func processWordDocumentStandard(filePath string, rules []Rule, timings *Timings, includeHidden bool, parts map[string]bool) (*ReplaceResult, error) {
 	// Use the existing standard processing
+	opened := time.Now()
 	doc, err := document.OpenWordDocument(filePath)
+	timings.Record(PhaseOpen, time.Since(opened))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open document: %w", err)
 	}
 	defer doc.Close()
+	doc.SetIncludeHiddenText(includeHidden)
+	doc.SetParts(parts)
+	timings.AddFile()
 	
 	result := &ReplaceResult{
 		FilePath:     filePath,
@@ -167,21 +272,39 @@ func processWordDocumentStandard(filePath string, rules []Rule) (*ReplaceResult,
 	}
 	
 	// Apply each rule
+	replacing := time.Now()
 	for _, rule := range rules {
-		err := doc.ReplaceText(rule.Old, rule.New)
+		var err error
+		if rule.Occurrence != 0 {
+			var count int
+			count, err = replaceOccurrence(filePath, doc, rule, nil)
+			result.Replacements += count
+		} else {
+			var targets []string
+			targets, err = ruleTargets(rule, doc.SelectedText)
+			for _, target := range targets {
+				var count int
+				if count, err = doc.ReplaceTextCount(target, rule.New); err != nil {
+					break
+				}
+				result.Replacements += count
+			}
+		}
 		if err != nil {
 			result.Success = false
 			result.Error = err
 			return result, err
 		}
-		// Note: The standard ReplaceText doesn't return count
-		// We increment by 1 for each successful rule application
-		result.Replacements++
 	}
 	
+	timings.Record(PhaseReplace, time.Since(replacing))
+	
 	// Save document
 	if result.Replacements > 0 {
-		if err := doc.Save(); err != nil {
+		saving := time.Now()
+		err := doc.Save()
+		timings.Record(PhaseSave, time.Since(saving))
+		if err != nil {
 			result.Success = false
 			result.Error = err
 			return result, err
@@ -192,16 +315,20 @@ func processWordDocumentStandard(filePath string, rules []Rule) (*ReplaceResult,
 }
 
 // processPowerPointDocumentStreaming processes a PowerPoint document using streaming
-func processPowerPointDocumentStreaming(filePath string, rules []Rule, fileSize int64) (*ReplaceResult, error) {
+func processPowerPointDocumentStreaming(filePath string, rules []Rule, fileSize int64, slides map[int]bool, timings *Timings, allParts bool) (*ReplaceResult, error) {
 	// Get adaptive options based on file size
 	streamOpts := document.AdaptiveStreamingOptions(fileSize)
 	
 	// Open document in streaming mode
+	opened := time.Now()
 	doc, err := document.OpenPowerPointDocumentStreaming(filePath, streamOpts)
+	timings.Record(PhaseOpen, time.Since(opened))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open presentation for streaming: %w", err)
 	}
 	defer doc.Close()
+	doc.SetAllParts(allParts)
+	timings.AddFile()
 	
 	result := &ReplaceResult{
 		FilePath:     filePath,
@@ -209,9 +336,14 @@ func processPowerPointDocumentStreaming(filePath string, rules []Rule, fileSize
 		Replacements: 0,
 	}
 	
+	if slides != nil {
+		warnMissingSlides(filePath, slides, doc.GetSlideNumbers())
+	}
+	
 	// Apply each rule using streaming
+	replacing := time.Now()
 	for _, rule := range rules {
-		count, err := doc.ReplaceTextInSlidesStreaming(rule.Old, rule.New)
+		count, err := doc.ReplaceTextInSelectedSlidesStreaming(rule.Old, rule.New, slides)
 		if err != nil {
 			result.Success = false
 			result.Error = err
@@ -220,17 +352,21 @@ func processPowerPointDocumentStreaming(filePath string, rules []Rule, fileSize
 		result.Replacements += count
 	}
 	
+	timings.Record(PhaseReplace, time.Since(replacing))
 	return result, nil
 }
 
 // processPowerPointDocumentStandard processes a PowerPoint document using standard method
-func processPowerPointDocumentStandard(filePath string, rules []Rule) (*ReplaceResult, error) {
+func processPowerPointDocumentStandard(filePath string, rules []Rule, slides map[int]bool, timings *Timings) (*ReplaceResult, error) {
 	// Use the existing standard processing
+	opened := time.Now()
 	doc, err := document.OpenPowerPointDocument(filePath)
+	timings.Record(PhaseOpen, time.Since(opened))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open presentation: %w", err)
 	}
 	defer doc.Close()
+	timings.AddFile()
 	
 	result := &ReplaceResult{
 		FilePath:     filePath,
@@ -238,22 +374,44 @@ func processPowerPointDocumentStandard(filePath string, rules []Rule) (*ReplaceR
 		Replacements: 0,
 	}
 	
+	if slides != nil {
+		warnMissingSlides(filePath, slides, doc.SlideNumbers())
+	}
+	
 	// Apply each rule
+	replacing := time.Now()
 	for _, rule := range rules {
-		err := doc.ReplaceText(rule.Old, rule.New)
+		var err error
+		if rule.Occurrence != 0 {
+			var count int
+			count, err = replaceOccurrence(filePath, doc, rule, slides)
+			result.Replacements += count
+		} else {
+			var targets []string
+			targets, err = ruleTargets(rule, doc.GetText)
+			for _, target := range targets {
+				var count int
+				if count, err = doc.ReplaceTextInSlidesCount(target, rule.New, slides); err != nil {
+					break
+				}
+				result.Replacements += count
+			}
+		}
 		if err != nil {
 			result.Success = false
 			result.Error = err
 			return result, err
 		}
-		// Note: The standard ReplaceText doesn't return count
-		// We increment by 1 for each successful rule application
-		result.Replacements++
 	}
 	
+	timings.Record(PhaseReplace, time.Since(replacing))
+	
 	// Save document
 	if result.Replacements > 0 {
-		if err := doc.Save(); err != nil {
+		saving := time.Now()
+		err := doc.Save()
+		timings.Record(PhaseSave, time.Since(saving))
+		if err != nil {
 			result.Success = false
 			result.Error = err
 			return result, err
@@ -263,6 +421,27 @@ func processPowerPointDocumentStandard(filePath string, rules []Rule) (*ReplaceR
 	return result, nil
 }
 
+// processRegisteredDocument processes a document of a format registered with
+// document.RegisterFormat using the standard replacement
+func processRegisteredDocument(filePath string, rules []Rule, opts *LargeFileOptions) (*ReplaceResult, error) {
+	count, err := ReplaceInDocumentWithOptions(filePath, rules, ReplaceOptions{
+		Slides:              opts.Slides,
+		Timings:             opts.Timings,
+		IncludeHiddenText:   opts.IncludeHiddenText,
+		Parts:               opts.Parts,
+		Strict:              opts.Strict,
+		MaxRuleReplacements: opts.MaxRuleReplacements,
+		collisionsChecked:   true,
+	})
+	result := &ReplaceResult{
+		FilePath:     filePath,
+		Success:      err == nil,
+		Replacements: count,
+		Error:        err,
+	}
+	return result, err
+}
+
 // EstimateMemoryUsage estimates memory usage for processing a file
 func EstimateMemoryUsage(filePath string) (uint64, error) {
 	fileInfo, err := os.Stat(filePath)
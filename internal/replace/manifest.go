@@ -0,0 +1,154 @@
+package replace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ManifestEntry records the content of a file after it was processed
+type ManifestEntry struct {
+	Hash         string    `json:"hash"`
+	Replacements int       `json:"replacements"`
+	ProcessedAt  time.Time `json:"processed_at"`
+}
+
+// Manifest records the content hash of every processed file together with
+// the hash of the rules applied and the options they were applied with, so a repeated directory run can skip files
+// that have not changed since. Unlike ProcessingState it is keyed on content:
+// a file edited since the last run is processed again.
+type Manifest struct {
+	RulesHash string                   `json:"rules_hash"`
+	Files     map[string]ManifestEntry `json:"files"`
+
+	path    string
+	skipped int
+	mu      sync.Mutex
+}
+
+// LoadManifest loads the manifest at path, or starts a new one if it does not
+// exist yet. A manifest written for different rules, or for options that
+// change what they write (see HashRun), is discarded, so every file is
+// processed again.
+func LoadManifest(path string, rules []Rule, opts ReplaceOptions) (*Manifest, error) {
+	manifest := &Manifest{
+		RulesHash: HashRun(rules, opts),
+		Files:     make(map[string]ManifestEntry),
+		path:      path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return manifest, nil
+		}
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var stored Manifest
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if stored.RulesHash == manifest.RulesHash && stored.Files != nil {
+		manifest.Files = stored.Files
+	}
+	return manifest, nil
+}
+
+// hashFile returns the SHA-256 of a file's content
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// IsUnchanged reports whether the file's content matches the manifest, that
+// is, whether it was processed with the same rules and not modified since
+func (m *Manifest) IsUnchanged(path string) bool {
+	m.mu.Lock()
+	entry, ok := m.Files[stateKey(path)]
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	hash, err := hashFile(path)
+	return err == nil && hash == entry.Hash
+}
+
+// shouldSkip reports whether the file is unchanged and counts it as skipped
+func (m *Manifest) shouldSkip(path string) bool {
+	if !m.IsUnchanged(path) {
+		return false
+	}
+	m.mu.Lock()
+	m.skipped++
+	m.mu.Unlock()
+	return true
+}
+
+// Skipped returns the number of files skipped because they were unchanged
+func (m *Manifest) Skipped() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.skipped
+}
+
+// Record stores the hash of a file's content after it was processed
+func (m *Manifest) Record(path string, replacements int) error {
+	hash, err := hashFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Files[stateKey(path)] = ManifestEntry{
+		Hash:         hash,
+		Replacements: replacements,
+		ProcessedAt:  time.Now(),
+	}
+	return nil
+}
+
+// Forget removes a file from the manifest so it is processed on the next run
+func (m *Manifest) Forget(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.Files, stateKey(path))
+}
+
+// Save writes the manifest atomically
+func (m *Manifest) Save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	tmpPath := m.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmpPath, m.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return nil
+}
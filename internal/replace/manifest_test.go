@@ -0,0 +1,162 @@
+package replace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+	docPath := filepath.Join(dir, "doc.docx")
+	os.WriteFile(docPath, []byte("content"), 0644)
+	rules := []Rule{{Old: "a", New: "b"}}
+
+	manifest, err := LoadManifest(manifestPath, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadManifest on missing file failed: %v", err)
+	}
+	if manifest.IsUnchanged(docPath) {
+		t.Error("a new manifest should not report files as unchanged")
+	}
+	if err := manifest.Record(docPath, 1); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := manifest.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := LoadManifest(manifestPath, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if !reloaded.IsUnchanged(docPath) {
+		t.Error("recorded file should be unchanged")
+	}
+
+	// Editing the file invalidates its entry
+	os.WriteFile(docPath, []byte("edited"), 0644)
+	if reloaded.IsUnchanged(docPath) {
+		t.Error("edited file should not be unchanged")
+	}
+	os.WriteFile(docPath, []byte("content"), 0644)
+
+	// Changing the rules invalidates the whole manifest
+	changed, err := LoadManifest(manifestPath, []Rule{{Old: "a", New: "c"}}, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadManifest with changed rules failed: %v", err)
+	}
+	if changed.IsUnchanged(docPath) || len(changed.Files) != 0 {
+		t.Error("a manifest written for other rules should be discarded")
+	}
+}
+
+func TestLoadManifestOptionsChanged(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+	docPath := filepath.Join(dir, "deck.pptx")
+	os.WriteFile(docPath, []byte("content"), 0644)
+	rules := []Rule{{Old: "a", New: "b"}}
+	opts := ReplaceOptions{Slides: map[int]bool{1: true}}
+
+	manifest, err := LoadManifest(manifestPath, rules, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.Record(docPath, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Options that do not change what is written keep the manifest
+	same, err := LoadManifest(manifestPath, rules, ReplaceOptions{Slides: map[int]bool{1: true}, Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !same.IsUnchanged(docPath) {
+		t.Error("a manifest written with the same slides should be kept")
+	}
+
+	for _, changed := range []ReplaceOptions{
+		{Slides: map[int]bool{2: true}},
+		{},
+		{Slides: map[int]bool{1: true}, StripMetadata: true},
+		{AllParts: true},
+	} {
+		reloaded, err := LoadManifest(manifestPath, rules, changed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloaded.IsUnchanged(docPath) || len(reloaded.Files) != 0 {
+			t.Errorf("a manifest written with other options should be discarded for %+v", changed)
+		}
+	}
+}
+
+func TestLoadManifestInvalidFile(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	os.WriteFile(manifestPath, []byte("not json"), 0644)
+
+	if _, err := LoadManifest(manifestPath, []Rule{{Old: "a", New: "b"}}, ReplaceOptions{}); err == nil {
+		t.Error("expected error for invalid manifest")
+	}
+}
+
+func TestReplaceInDirectorySkipsUnchangedFiles(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		dir := t.TempDir()
+		for i := 1; i <= 3; i++ {
+			copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, fmt.Sprintf("doc%d.docx", i)))
+		}
+		manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+		rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+
+		run := func(rules []Rule) ([]ReplaceResult, *Manifest) {
+			t.Helper()
+			manifest, err := LoadManifest(manifestPath, rules, ReplaceOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := ReplaceOptions{Manifest: manifest}
+
+			var results []ReplaceResult
+			if concurrent {
+				copts := DefaultConcurrentOptions()
+				copts.Replace = opts
+				results, err = ReplaceInDirectoryConcurrent(dir, rules, false, "", copts)
+			} else {
+				results, err = ReplaceInDirectoryWithOptions(dir, rules, false, "", opts)
+			}
+			if err != nil {
+				t.Fatalf("replace failed (concurrent=%v): %v", concurrent, err)
+			}
+			return results, manifest
+		}
+
+		if results, _ := run(rules); len(results) != 3 {
+			t.Fatalf("first run (concurrent=%v) processed %d files, want 3", concurrent, len(results))
+		}
+
+		// Nothing changed, so nothing is reopened
+		if results, manifest := run(rules); len(results) != 0 || manifest.Skipped() != 3 {
+			t.Errorf("second run (concurrent=%v): got %d results and %d skipped, want 0 and 3", concurrent, len(results), manifest.Skipped())
+		}
+
+		// An edited file is processed again
+		copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, "doc2.docx"))
+		results, manifest := run(rules)
+		if len(results) != 1 || results[0].FilePath != filepath.Join(dir, "doc2.docx") || manifest.Skipped() != 2 {
+			t.Errorf("after edit (concurrent=%v): got %v and %d skipped, want only doc2.docx", concurrent, results, manifest.Skipped())
+		}
+		checkDocument(t, filepath.Join(dir, "doc2.docx"), "Version 2.0")
+
+		// Changed rules process every file again
+		if results, _ := run([]Rule{{Old: "Version 2.0", New: "Version 3.0"}}); len(results) != 3 {
+			t.Errorf("after rules change (concurrent=%v) processed %d files, want 3", concurrent, len(results))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package replace
+
+// SourcedRule is a replacement rule together with the rules file it came from
+type SourcedRule struct {
+	Rule
+	// Source is the rules file the rule was loaded from
+	Source string
+	// Overrides is the file of an earlier rule with the same Old text that
+	// this rule replaced, or empty
+	Overrides string
+}
+
+// mergeKey identifies the rules a later rules file overrides
+type mergeKey struct {
+	old    string
+	format string
+	files  string
+}
+
+// MergeRules adds the rules loaded from source to merged. A rule whose Old
+// text, format and file pattern match a rule from an earlier file replaces that rule in its
+// position, so later files override earlier ones; other rules are appended
+// in order. Rules repeated within one file are kept as they are and reported
+// by collision detection.
+func MergeRules(merged []SourcedRule, source string, rules []Rule) []SourcedRule {
+	earlier := make(map[mergeKey]int, len(merged))
+	for i, rule := range merged {
+		if rule.Source != source {
+			key := mergeKey{old: rule.Old, format: rule.Format, files: rule.Files}
+			if _, seen := earlier[key]; !seen {
+				earlier[key] = i
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		sourced := SourcedRule{Rule: rule, Source: source}
+		key := mergeKey{old: rule.Old, format: rule.Format, files: rule.Files}
+		if i, ok := earlier[key]; ok {
+			sourced.Overrides = merged[i].Source
+			merged[i] = sourced
+			delete(earlier, key)
+			continue
+		}
+		merged = append(merged, sourced)
+	}
+	return merged
+}
+
+// RulesOf returns the rules of sourced rules, keeping their order
+func RulesOf(sourced []SourcedRule) []Rule {
+	rules := make([]Rule, len(sourced))
+	for i, rule := range sourced {
+		rules[i] = rule.Rule
+	}
+	return rules
+}
@@ -0,0 +1,84 @@
+package replace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeRules(t *testing.T) {
+	var merged []SourcedRule
+	merged = MergeRules(merged, "terms.yml", []Rule{
+		{Old: "colour", New: "color"},
+		{Old: "Acme", New: "ACME"},
+	})
+	merged = MergeRules(merged, "brand.yml", []Rule{
+		{Old: "Acme", New: "Acme Corp."},
+		{Old: "Widget", New: "Widget Pro"},
+	})
+
+	want := []SourcedRule{
+		{Rule: Rule{Old: "colour", New: "color"}, Source: "terms.yml"},
+		{Rule: Rule{Old: "Acme", New: "Acme Corp."}, Source: "brand.yml", Overrides: "terms.yml"},
+		{Rule: Rule{Old: "Widget", New: "Widget Pro"}, Source: "brand.yml"},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeRules() =\n%+v\nwant\n%+v", merged, want)
+	}
+
+	wantRules := []Rule{want[0].Rule, want[1].Rule, want[2].Rule}
+	if got := RulesOf(merged); !reflect.DeepEqual(got, wantRules) {
+		t.Errorf("RulesOf() = %+v, want %+v", got, wantRules)
+	}
+}
+
+func TestMergeRulesKeepsDuplicatesWithinAFile(t *testing.T) {
+	merged := MergeRules(nil, "rules.yml", []Rule{
+		{Old: "a", New: "b"},
+		{Old: "a", New: "c"},
+	})
+	if len(merged) != 2 {
+		t.Fatalf("duplicates within one file should be kept for collision detection, got %d rules", len(merged))
+	}
+
+	// A later file overrides only the first of them and appends its own repeat
+	merged = MergeRules(merged, "later.yml", []Rule{
+		{Old: "a", New: "d"},
+		{Old: "a", New: "e"},
+	})
+	if len(merged) != 3 || merged[0].New != "d" || merged[1].New != "c" || merged[2].New != "e" {
+		t.Errorf("MergeRules() = %+v", merged)
+	}
+}
+
+func TestMergeRulesKeepsFormatsApart(t *testing.T) {
+	merged := MergeRules(nil, "base.yml", []Rule{{Old: "Draft", New: "Final"}})
+	merged = MergeRules(merged, "slides.yml", []Rule{{Old: "Draft", New: "Done", Format: "pptx"}})
+
+	if len(merged) != 2 || merged[0].New != "Final" || merged[1].New != "Done" || merged[1].Overrides != "" {
+		t.Errorf("MergeRules() = %+v, want the pptx rule added beside the shared rule", merged)
+	}
+}
+
+func TestMergeRulesKeepsFilesApart(t *testing.T) {
+	merged := MergeRules(nil, "base.yml", []Rule{
+		{Old: "Draft", New: "Final"},
+		{Old: "Ltd", New: "Limited", Files: "contract_*"},
+	})
+	merged = MergeRules(merged, "invoices.yml", []Rule{
+		{Old: "Draft", New: "Paid", Files: "invoice_*"},
+		{Old: "Ltd", New: "Ltd.", Files: "contract_*"},
+	})
+
+	if len(merged) != 3 {
+		t.Fatalf("MergeRules() = %+v, want 3 rules", merged)
+	}
+	if merged[0].New != "Final" || merged[0].Overrides != "" {
+		t.Errorf("merged[0] = %+v, want the shared rule kept", merged[0])
+	}
+	if merged[1].New != "Ltd." || merged[1].Overrides != "base.yml" {
+		t.Errorf("merged[1] = %+v, want the contract rule overridden", merged[1])
+	}
+	if merged[2].New != "Paid" || merged[2].Overrides != "" {
+		t.Errorf("merged[2] = %+v, want the invoice rule added", merged[2])
+	}
+}
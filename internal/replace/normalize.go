@@ -0,0 +1,127 @@
+package replace
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// typographicFolds maps characters that word processors substitute while
+// typing to the plain characters users write in rule files. Ligatures and
+// space characters use their Unicode compatibility (NFKC) decomposition;
+// curly quotes and primes, which have no compatibility decomposition, fold
+// to the ASCII apostrophe or quotation mark.
+var typographicFolds = map[rune]string{
+	// Single quotes, apostrophes and prime
+	'\u2018': "'", '\u2019': "'", '\u201A': "'", '\u201B': "'", '\u2032': "'",
+	// Double quotes and double prime
+	'\u201C': `"`, '\u201D': `"`, '\u201E': `"`, '\u201F': `"`, '\u2033': `"`,
+	// No-break, figure, narrow no-break, thin, en and em spaces
+	'\u00A0': " ", '\u2007': " ", '\u202F': " ", '\u2009': " ", '\u2002': " ", '\u2003': " ",
+	// Latin ligatures
+	'\uFB00': "ff", '\uFB01': "fi", '\uFB02': "fl", '\uFB03': "ffi", '\uFB04': "ffl", '\uFB05': "st", '\uFB06': "st",
+}
+
+// NormalizeText folds smart quotes, non-breaking spaces and ligatures to
+// their plain equivalents. Only the characters listed in typographicFolds
+// change; the text is not otherwise converted to a Unicode normalization
+// form, so composed and decomposed accents still compare as different.
+func NormalizeText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if fold, ok := typographicFolds[r]; ok {
+			b.WriteString(fold)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizedVariants returns the distinct spellings in text that equal old
+// once both are normalized, in order of first appearance. Replacing each
+// variant changes the original characters at the matching positions.
+func normalizedVariants(text, old string) []string {
+	target := NormalizeText(old)
+	if target == "" {
+		return nil
+	}
+
+	// Normalize text, remembering the original byte range of every
+	// normalized byte so matches can be mapped back
+	var normalized strings.Builder
+	var starts, ends []int
+	var boundary []bool
+	for i, r := range text {
+		fold, ok := typographicFolds[r]
+		if !ok {
+			fold = string(r)
+		}
+		end := i + utf8.RuneLen(r)
+		for j := 0; j < len(fold); j++ {
+			starts = append(starts, i)
+			ends = append(ends, end)
+			boundary = append(boundary, j == 0)
+		}
+		normalized.WriteString(fold)
+	}
+	norm := normalized.String()
+
+	var variants []string
+	seen := make(map[string]bool)
+	for offset := 0; offset < len(norm); {
+		idx := strings.Index(norm[offset:], target)
+		if idx < 0 {
+			break
+		}
+		start := offset + idx
+		end := start + len(target)
+
+		// Skip matches that begin or end inside an expanded ligature
+		if boundary[start] && (end == len(norm) || boundary[end]) {
+			variant := text[starts[start]:ends[end-1]]
+			if !seen[variant] {
+				seen[variant] = true
+				variants = append(variants, variant)
+			}
+			offset = end
+		} else {
+			offset = start + 1
+		}
+	}
+	return variants
+}
+
+// ruleTargets returns the exact strings to replace for a rule. Without
+// normalization that is the rule's own old text; with it, every spelling
+// of that text found in the document, read lazily through text.
+func ruleTargets(rule Rule, text func() (string, error)) ([]string, error) {
+	if !rule.NormalizeText {
+		return []string{rule.Old}, nil
+	}
+	content, err := text()
+	if err != nil {
+		return nil, err
+	}
+	return normalizedVariants(content, rule.Old), nil
+}
+
+// hasNormalizedRules reports whether any rule matches normalized text
+func hasNormalizedRules(rules []Rule) bool {
+	for _, rule := range rules {
+		if rule.NormalizeText {
+			return true
+		}
+	}
+	return false
+}
+
+// WithNormalization returns a copy of rules with NormalizeText enabled on every rule
+func WithNormalization(rules []Rule) []Rule {
+	normalized := make([]Rule, len(rules))
+	for i, rule := range rules {
+		rule.NormalizeText = true
+		normalized[i] = rule
+	}
+	return normalized
+}
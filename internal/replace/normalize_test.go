@@ -0,0 +1,95 @@
+package replace
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"“v1.0”", `"v1.0"`},
+		{"it’s", "it's"},
+		{"10 kg", "10 kg"},
+		{"ﬁnal oﬃce", "final office"},
+		{"plain text", "plain text"},
+		{"café", "café"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeText(tt.input); got != tt.want {
+			t.Errorf("NormalizeText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizedVariants(t *testing.T) {
+	text := "Use “v1.0” now. Also \"v1.0\" and “v1.0” again."
+	got := normalizedVariants(text, `"v1.0"`)
+	want := []string{"“v1.0”", `"v1.0"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("variants = %q, want %q", got, want)
+	}
+
+	// Matches inside an expanded ligature cannot be mapped back and are skipped
+	if got := normalizedVariants("ofﬁce", "ice"); len(got) != 0 {
+		t.Errorf("partial ligature match should be skipped, got %q", got)
+	}
+	if got := normalizedVariants("ofﬁce", "office"); !reflect.DeepEqual(got, []string{"ofﬁce"}) {
+		t.Errorf("whole ligature match = %q", got)
+	}
+}
+
+func TestPreviewChangesNormalized(t *testing.T) {
+	text := "Release “v1.0” today"
+	changes := PreviewChanges(text, []Rule{{Old: `"v1.0" today`, New: `"v2.0" today`, NormalizeText: true}})
+	if len(changes) != 1 || changes[0].Count != 1 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if got := changes[0].Snippets[0].After; got != `Release "v2.0" today` {
+		t.Errorf("after = %q", got)
+	}
+
+	if changes := PreviewChanges(text, []Rule{{Old: `"v1.0"`, New: `"v2.0"`}}); len(changes) != 0 {
+		t.Errorf("exact rule should not match smart quotes, got %+v", changes)
+	}
+}
+
+func TestReplaceInDocumentNormalized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotes.docx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	fw, _ := w.Create("word/document.xml")
+	fw.Write([]byte(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		"<w:p><w:r><w:t>Version “v1.0” released</w:t></w:r></w:p>" +
+		`<w:p><w:r><w:t>Plain "v1.0" stays plain</w:t></w:r></w:p></w:body></w:document>`))
+	w.Close()
+	f.Close()
+
+	rules := []Rule{{Old: `"v1.0"`, New: `"v2.0"`, NormalizeText: true}}
+	if _, err := ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{}); err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions failed: %v", err)
+	}
+
+	doc, err := document.OpenWordDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	got := doc.GetTextParagraphs()
+	want := []string{"Version \"v2.0\" released", `Plain "v2.0" stays plain`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paragraphs = %q, want %q", got, want)
+	}
+}
@@ -3,44 +3,137 @@ package replace
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// ParseYAMLRules parses YAML data into a slice of Rules
+// RuleSections lists the sections of a rules file written as a mapping, in
+// the order their rules are applied: the shared rules, then the rules for
+// each format
+var RuleSections = []string{"shared", "docx", "pptx"}
+
+// ParseYAMLRules parses YAML data into a slice of Rules. The data is either a
+// list of rules, which apply to every document, or a mapping with the
+// sections in RuleSections. Rules in a format section apply only to documents
+// of that format and follow the shared rules (see Rule.Format).
 func ParseYAMLRules(data []byte) ([]Rule, error) {
 	// Handle empty data
 	if len(data) == 0 {
 		return []Rule{}, nil
 	}
 
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.MappingNode {
+		return parseRuleSections(root.Content[0])
+	}
+
 	// First, parse as generic interface to check structure
 	var rawRules []map[string]interface{}
 	err := yaml.Unmarshal(data, &rawRules)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
-	
+	return parseRuleList(rawRules, "", "rule")
+}
+
+// parseRuleSections parses a rules file written as a mapping of sections
+func parseRuleSections(mapping *yaml.Node) ([]Rule, error) {
+	sections := make(map[string][]map[string]interface{})
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		name := mapping.Content[i].Value
+		known := false
+		for _, section := range RuleSections {
+			if name == section {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown section %q (expected a list of rules or the sections %s)", name, strings.Join(RuleSections, ", "))
+		}
+		var rawRules []map[string]interface{}
+		if err := mapping.Content[i+1].Decode(&rawRules); err != nil {
+			return nil, fmt.Errorf("section %q: failed to parse YAML: %w", name, err)
+		}
+		sections[name] = rawRules
+	}
+
+	rules := []Rule{}
+	for _, section := range RuleSections {
+		format := section
+		if section == "shared" {
+			format = ""
+		}
+		sectionRules, err := parseRuleList(sections[section], format, section+" rule")
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, sectionRules...)
+	}
+	return rules, nil
+}
+
+// parseRuleList converts raw rules to Rules for format ("" for all formats);
+// label names a rule in error messages
+func parseRuleList(rawRules []map[string]interface{}, format string, label string) ([]Rule, error) {
 	// Validate and convert each rule
 	rules := make([]Rule, 0, len(rawRules))
 	for i, rawRule := range rawRules {
 		// Check for required fields
 		if _, hasOld := rawRule["old"]; !hasOld {
-			return nil, fmt.Errorf("rule at index %d: missing required field 'old'", i)
+			return nil, fmt.Errorf("%s at index %d: missing required field 'old'", label, i)
 		}
 		if _, hasNew := rawRule["new"]; !hasNew {
-			return nil, fmt.Errorf("rule at index %d: missing required field 'new'", i)
+			return nil, fmt.Errorf("%s at index %d: missing required field 'new'", label, i)
 		}
 		
 		// Convert to Rule struct
 		rule := Rule{
-			Old: fmt.Sprintf("%v", rawRule["old"]),
-			New: fmt.Sprintf("%v", rawRule["new"]),
+			Old:    fmt.Sprintf("%v", rawRule["old"]),
+			New:    fmt.Sprintf("%v", rawRule["new"]),
+			Format: format,
+		}
+		
+		// Optional metadata
+		if rawEnabled, ok := rawRule["enabled"]; ok {
+			enabled, isBool := rawEnabled.(bool)
+			if !isBool {
+				return nil, fmt.Errorf("%s at index %d: 'enabled' must be true or false", label, i)
+			}
+			rule.Enabled = &enabled
+		}
+		if rawDescription, ok := rawRule["description"]; ok && rawDescription != nil {
+			rule.Description = fmt.Sprintf("%v", rawDescription)
+		}
+		if rawNormalize, ok := rawRule["normalize"]; ok {
+			normalize, isBool := rawNormalize.(bool)
+			if !isBool {
+				return nil, fmt.Errorf("%s at index %d: 'normalize' must be true or false", label, i)
+			}
+			rule.NormalizeText = normalize
+		}
+		if rawOccurrence, ok := rawRule["occurrence"]; ok {
+			occurrence, isInt := rawOccurrence.(int)
+			if !isInt {
+				return nil, fmt.Errorf("%s at index %d: 'occurrence' must be a whole number (1 = first, -1 = last, 0 = all)", label, i)
+			}
+			rule.Occurrence = occurrence
+		}
+		if rawFiles, ok := rawRule["files"]; ok && rawFiles != nil {
+			files, isString := rawFiles.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s at index %d: 'files' must be a file name pattern such as \"contract_*\"", label, i)
+			}
+			rule.Files = files
 		}
 		
 		// Use the Validate method for additional validation
 		if err := rule.Validate(); err != nil {
-			return nil, fmt.Errorf("rule at index %d: %w", i, err)
+			return nil, fmt.Errorf("%s at index %d: %w", label, i, err)
 		}
 		
 		rules = append(rules, rule)
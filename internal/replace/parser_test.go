@@ -1,6 +1,7 @@
 package replace
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -156,6 +157,11 @@ func TestRule_Validate(t *testing.T) {
 			rule:    Rule{Old: "same", New: "same"},
 			wantErr: true,
 		},
+		{
+			name:    "occurrence with normalize",
+			rule:    Rule{Old: "old", New: "new", Occurrence: 1, NormalizeText: true},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,4 +209,119 @@ func TestLoadRulesFromFile(t *testing.T) {
 			t.Errorf("LoadRulesFromFile() returned non-nil rules for non-existent file")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestParseYAMLRulesMetadata(t *testing.T) {
+	input := `- old: "2023"
+  new: "2024"
+  description: "Yearly update"
+- old: "ACME Corp"
+  new: "ACME Inc."
+  enabled: false
+- old: "draft"
+  new: "final"
+  enabled: true`
+
+	rules, err := ParseYAMLRules([]byte(input))
+	if err != nil {
+		t.Fatalf("ParseYAMLRules() error = %v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+
+	if rules[0].Description != "Yearly update" || !rules[0].IsEnabled() || rules[0].Enabled != nil {
+		t.Errorf("rule 0 = %+v, want description and default enabled", rules[0])
+	}
+	if rules[1].IsEnabled() {
+		t.Error("rule 1 should be disabled")
+	}
+	if !rules[2].IsEnabled() {
+		t.Error("rule 2 should be enabled")
+	}
+
+	enabled := EnabledRules(rules)
+	if len(enabled) != 2 || enabled[0].Old != "2023" || enabled[1].Old != "draft" {
+		t.Errorf("EnabledRules() = %+v", enabled)
+	}
+
+	if _, err := ParseYAMLRules([]byte(`- old: "a"
+  new: "b"
+  enabled: "no"`)); err == nil {
+		t.Error("expected error for non-boolean enabled")
+	}
+
+	rules, err = ParseYAMLRules([]byte(`- old: '"v1.0"'
+  new: '"v2.0"'
+  normalize: true`))
+	if err != nil || len(rules) != 1 || !rules[0].NormalizeText {
+		t.Errorf("normalize flag not parsed: %+v, %v", rules, err)
+	}
+	if _, err := ParseYAMLRules([]byte(`- old: "a"
+  new: "b"
+  normalize: "yes"`)); err == nil {
+		t.Error("expected error for non-boolean normalize")
+	}
+
+	rules, err = ParseYAMLRules([]byte(`- old: "Draft"
+  new: "Final"
+  occurrence: -1`))
+	if err != nil || len(rules) != 1 || rules[0].Occurrence != -1 {
+		t.Errorf("occurrence not parsed: %+v, %v", rules, err)
+	}
+	if _, err := ParseYAMLRules([]byte(`- old: "a"
+  new: "b"
+  occurrence: first`)); err == nil {
+		t.Error("expected error for non-numeric occurrence")
+	}
+
+	rules, err = ParseYAMLRules([]byte(`- old: "Client"
+  new: "Customer"
+  files: "contract_*"`))
+	if err != nil || len(rules) != 1 || rules[0].Files != "contract_*" {
+		t.Errorf("files pattern not parsed: %+v, %v", rules, err)
+	}
+	if _, err := ParseYAMLRules([]byte(`- old: "a"
+  new: "b"
+  files: "[draft"`)); err == nil {
+		t.Error("expected error for malformed files pattern")
+	}
+}
+
+func TestParseYAMLRulesSections(t *testing.T) {
+	rules, err := ParseYAMLRules([]byte(`pptx:
+  - old: "Slide"
+    new: "Page"
+shared:
+  - old: "2023"
+    new: "2024"
+docx:
+  - old: "Draft"
+    new: "Final"`))
+	if err != nil {
+		t.Fatalf("ParseYAMLRules() error = %v", err)
+	}
+	want := []Rule{
+		{Old: "2023", New: "2024"},
+		{Old: "Draft", New: "Final", Format: "docx"},
+		{Old: "Slide", New: "Page", Format: "pptx"},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(rules), len(want), rules)
+	}
+	for i := range want {
+		if rules[i].Old != want[i].Old || rules[i].New != want[i].New || rules[i].Format != want[i].Format {
+			t.Errorf("rule %d = %+v, want %+v", i, rules[i], want[i])
+		}
+	}
+
+	if _, err := ParseYAMLRules([]byte(`xlsx:
+  - old: "a"
+    new: "b"`)); err == nil {
+		t.Error("expected error for an unknown section")
+	}
+	if _, err := ParseYAMLRules([]byte(`docx:
+  - new: "b"`)); err == nil || !strings.Contains(err.Error(), "docx rule at index 0") {
+		t.Errorf("error = %v, want it to name the docx rule", err)
+	}
+}
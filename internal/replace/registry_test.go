@@ -0,0 +1,91 @@
+package replace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+// fakeDocument is a plain-text document format used to test the format registry
+type fakeDocument struct {
+	path string
+	text string
+}
+
+func openFakeDocument(path string) (document.Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeDocument{path: path, text: string(data)}, nil
+}
+
+func (d *fakeDocument) GetText() (string, error)      { return d.text, nil }
+func (d *fakeDocument) GetPlainText() (string, error) { return d.text, nil }
+func (d *fakeDocument) Save() error                   { return d.SaveAs(d.path) }
+func (d *fakeDocument) Close() error                  { return nil }
+
+func (d *fakeDocument) ReplaceText(old, new string) error {
+	d.text = strings.ReplaceAll(d.text, old, new)
+	return nil
+}
+
+func (d *fakeDocument) SaveAs(path string) error {
+	return os.WriteFile(path, []byte(d.text), 0644)
+}
+
+func TestRegisteredFormatIsWalkedAndProcessed(t *testing.T) {
+	document.RegisterFormat(".fake", openFakeDocument)
+	defer document.UnregisterFormat(".fake")
+
+	dir := t.TempDir()
+	subDir := filepath.Join(dir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{filepath.Join(dir, "a.fake"), filepath.Join(subDir, "b.FAKE")}
+	for _, path := range files {
+		if err := os.WriteFile(path, []byte("Version 1.0"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ignored := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(ignored, []byte("Version 1.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	if err := WalkDocumentFiles(dir, true, func(path string) error {
+		walked = append(walked, path)
+		return nil
+	}); err != nil {
+		t.Fatalf("WalkDocumentFiles failed: %v", err)
+	}
+	if len(walked) != 2 {
+		t.Fatalf("walked %v, want the two .fake files", walked)
+	}
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+	if err := ReplaceInDirectory(dir, rules, true); err != nil {
+		t.Fatalf("ReplaceInDirectory failed: %v", err)
+	}
+
+	for _, path := range files {
+		data, _ := os.ReadFile(path)
+		if string(data) != "Version 2.0" {
+			t.Errorf("%s = %q, want replaced text", path, data)
+		}
+	}
+	if data, _ := os.ReadFile(ignored); string(data) != "Version 1.0" {
+		t.Errorf("unregistered file was modified: %q", data)
+	}
+
+	// Unregistered formats are rejected
+	document.UnregisterFormat(".fake")
+	if _, err := ReplaceInDocumentWithCount(files[0], rules); err == nil {
+		t.Error("expected an error for an unregistered format")
+	}
+}
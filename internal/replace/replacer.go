@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pyhub/pyhub-docs/internal/document"
 	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+	"github.com/pyhub/pyhub-docs/internal/ui"
 )
 
 // ReplaceInDocument applies replacement rules to a single Word or PowerPoint document
@@ -17,8 +20,63 @@ func ReplaceInDocument(docPath string, rules []Rule) error {
 	return err
 }
 
+// ReplaceOptions contains per-document options for replacement
+type ReplaceOptions struct {
+	// Slides limits PowerPoint replacement to these slide numbers (nil means all slides)
+	Slides map[int]bool
+	// Strict turns rule collision warnings into errors
+	Strict bool
+	// StripMetadata blanks author, company and other document properties on save
+	StripMetadata bool
+	// State records completed files of a directory run so it can be resumed (nil disables)
+	State *ProcessingState
+	// Manifest skips files of a directory run whose content and rules are
+	// unchanged since the previous run (nil disables)
+	Manifest *Manifest
+	// Timings records how long opening, replacing and saving take (nil disables)
+	Timings *Timings
+	// IncludeHiddenText also replaces text in Word runs marked hidden
+	IncludeHiddenText bool
+	// Parts limits Word replacement to these parts (see
+	// document.ParseWordParts; nil means all parts)
+	Parts map[string]bool
+	// FailFast stops a directory run at the first file that fails instead of
+	// recording the failure and continuing
+	FailFast bool
+	// DumpXMLDir, for debugging, receives each document's XML parts before
+	// and after replacement (see DumpXML); empty disables
+	DumpXMLDir string
+	// Skipped records the files a directory run leaves out and why (nil disables)
+	Skipped *SkipLog
+	// StrictXML fails a document whose XML parts are not all well-formed
+	// before anything is replaced (see document.ValidateXML)
+	StrictXML bool
+	// MaxRuleReplacements warns about rules that would replace more than this
+	// many occurrences in a document, and with Strict fails the document
+	// before anything is replaced; 0 disables the check
+	MaxRuleReplacements int
+	// AllParts replaces the text runs of every XML part of Word and
+	// PowerPoint documents instead of the body, header, footer, note and
+	// slide parts; Parts and Slides are ignored. It streams each part, so
+	// rules limited to one occurrence are rejected.
+	AllParts bool
+	// FileTimeout fails a document of a directory run that takes longer than
+	// this, leaving it unchanged, and goes on with the next one; 0 disables
+	FileTimeout time.Duration
+
+	// collisionsChecked is set by directory operations that already checked the rules once
+	collisionsChecked bool
+	// deadline is set while a document is processed under FileTimeout
+	deadline *fileDeadline
+}
+
 // ReplaceInDocumentWithCount applies replacement rules and returns the count of replacements
 func ReplaceInDocumentWithCount(docPath string, rules []Rule) (int, error) {
+	return ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{})
+}
+
+// ReplaceInDocumentWithOptions applies replacement rules with options and returns the count of replacements
+func ReplaceInDocumentWithOptions(docPath string, rules []Rule, opts ReplaceOptions) (int, error) {
 	// Validate input
 	if docPath == "" {
 		return 0, pkgErrors.NewValidationError("path", docPath, "document path cannot be empty")
@@ -36,6 +94,7 @@ func ReplaceInDocumentWithCount(docPath string, rules []Rule) (int, error) {
 	}
 
 	// Skip if no rules to apply
+	rules = EnabledRules(rules)
 	if len(rules) == 0 {
 		return 0, nil
 	}
@@ -47,20 +106,41 @@ func ReplaceInDocumentWithCount(docPath string, rules []Rule) (int, error) {
 		}
 	}
 
-	// Determine document type and open accordingly
-	lowerPath := strings.ToLower(docPath)
-	var doc document.Document
-	var err error
-	
-	if strings.HasSuffix(lowerPath, ".docx") {
-		doc, err = document.OpenWordDocument(docPath)
-	} else if strings.HasSuffix(lowerPath, ".pptx") {
-		doc, err = document.OpenPowerPointDocument(docPath)
-	} else {
+	// Leave the document untouched when every rule is scoped to other files
+	rules = RulesForFile(rules, docPath)
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	// Detect rules whose order changes the result
+	if !opts.collisionsChecked {
+		if err := CheckCollisions(rules, opts.Strict); err != nil {
+			return 0, err
+		}
+	}
+
+	// Open the document with the format registered for its extension
+	if !document.IsSupportedFormat(docPath) {
 		ext := filepath.Ext(docPath)
-		return 0, pkgErrors.NewDocumentError(docPath, ext, "unsupported format (only .docx and .pptx)", pkgErrors.ErrUnsupportedFormat)
+		return 0, pkgErrors.NewDocumentError(docPath, ext,
+			fmt.Sprintf("unsupported format (supported: %s)", strings.Join(document.SupportedExtensions(), ", ")), pkgErrors.ErrUnsupportedFormat)
 	}
-	
+	if opts.StrictXML {
+		if err := document.ValidateXML(docPath); err != nil {
+			return 0, err
+		}
+	}
+	if opts.DumpXMLDir != "" {
+		dumpXMLStage(docPath, opts.DumpXMLDir, "before")
+		defer dumpXMLStage(docPath, opts.DumpXMLDir, "after")
+	}
+	if opts.AllParts && (document.IsWordFile(docPath) || document.IsPowerPointFile(docPath)) {
+		return replaceInAllParts(docPath, rules, opts)
+	}
+
+	opened := time.Now()
+	doc, err := document.Open(docPath)
+	opts.Timings.Record(PhaseOpen, time.Since(opened))
 	if err != nil {
 		// Check if document is corrupted
 		if strings.Contains(err.Error(), "corrupted") || strings.Contains(err.Error(), "invalid") {
@@ -68,49 +148,231 @@ func ReplaceInDocumentWithCount(docPath string, rules []Rule) (int, error) {
 		}
 		return 0, pkgErrors.NewDocumentError(docPath, filepath.Ext(docPath), "failed to open document", err)
 	}
+	pptDoc, _ := doc.(*document.PowerPointDocument)
 	defer doc.Close()
+	opts.Timings.AddFile()
+
+	if pptDoc != nil && opts.Slides != nil {
+		warnMissingSlides(docPath, opts.Slides, pptDoc.SlideNumbers())
+	}
+	// Regular expression rules find their targets in the text they replace
+	textOf := doc.GetText
+	if pptDoc != nil {
+		textOf = func() (string, error) { return pptDoc.SelectedSlidesText(opts.Slides) }
+	}
+	if wordDoc, ok := doc.(*document.WordDocument); ok {
+		wordDoc.SetIncludeHiddenText(opts.IncludeHiddenText)
+		wordDoc.SetParts(opts.Parts)
+		textOf = wordDoc.SelectedText
+		warnTrackedChanges(docPath, wordDoc.TrackedChanges())
+	}
+
+	// Catch rules, such as a single space or letter, that match far more than intended
+	if opts.MaxRuleReplacements > 0 {
+		text, err := textOf()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read text: %w", err)
+		}
+		if err := CheckReplacementLimit(docPath, PreviewChanges(text, rules), opts.MaxRuleReplacements, opts.Strict); err != nil {
+			return 0, err
+		}
+	}
 
 	// Track total replacements
 	totalReplacements := 0
+	replacing := time.Now()
 
 	// Apply each replacement rule
 	for _, rule := range rules {
-		if err := doc.ReplaceText(rule.Old, rule.New); err != nil {
-			return totalReplacements, fmt.Errorf("failed to replace '%s' with '%s': %w", rule.Old, rule.New, err)
+		if rule.Occurrence != 0 {
+			count, err := replaceOccurrence(docPath, doc, rule, opts.Slides)
+			if err != nil {
+				return totalReplacements, fmt.Errorf("failed to replace '%s' with '%s': %w", rule.Old, rule.New, err)
+			}
+			totalReplacements += count
+			continue
+		}
+		targets, err := ruleTargets(rule, textOf)
+		if err != nil {
+			return totalReplacements, fmt.Errorf("failed to read text for '%s': %w", rule.Old, err)
+		}
+		for _, target := range targets {
+			count, err := replaceTextCount(doc, target, rule.New, opts.Slides)
+			if err != nil {
+				return totalReplacements, fmt.Errorf("failed to replace '%s' with '%s': %w", target, rule.New, err)
+			}
+			totalReplacements += count
 		}
-		// Note: Currently we don't have a way to get the count from ReplaceText
-		// This would require modifying the document package to return counts
-		// For now, we'll increment by 1 if replacement succeeded
-		totalReplacements++
+	}
+
+	if opts.StripMetadata {
+		if err := stripDocumentMetadata(doc); err != nil {
+			return totalReplacements, fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	}
+
+	opts.Timings.Record(PhaseReplace, time.Since(replacing))
+
+	// A document given up on by a directory run's FileTimeout stays unchanged
+	if !opts.deadline.beginSave() {
+		return totalReplacements, ErrFileTimeout
 	}
 
 	// Save the modified document
-	if err := doc.Save(); err != nil {
+	saving := time.Now()
+	err = doc.Save()
+	opts.Timings.Record(PhaseSave, time.Since(saving))
+	if err != nil {
 		return totalReplacements, fmt.Errorf("failed to save document: %w", err)
 	}
 
 	return totalReplacements, nil
 }
 
-// WalkDocumentFiles walks through .docx and .pptx files in a directory and calls the callback for each file
+// replaceTextCount replaces every occurrence of target, on the selected
+// slides of a presentation, and returns how many were replaced. Formats that
+// do not count replacements (see document.CountingReplacer) are counted in
+// their text beforehand.
+func replaceTextCount(doc document.Document, target, new string, slides map[int]bool) (int, error) {
+	switch d := doc.(type) {
+	case *document.PowerPointDocument:
+		return d.ReplaceTextInSlidesCount(target, new, slides)
+	case document.CountingReplacer:
+		return d.ReplaceTextCount(target, new)
+	}
+	text, err := doc.GetText()
+	if err != nil {
+		return 0, err
+	}
+	return strings.Count(text, target), doc.ReplaceText(target, new)
+}
+
+// replaceOccurrence applies a rule limited to one occurrence (see
+// Rule.Occurrence) and returns the number of replacements, warning when the
+// document has no such occurrence; only Word and PowerPoint documents support it
+func replaceOccurrence(docPath string, doc document.Document, rule Rule, slides map[int]bool) (int, error) {
+	var replaced bool
+	var err error
+	switch d := doc.(type) {
+	case *document.WordDocument:
+		replaced, err = d.ReplaceTextOccurrence(rule.Old, rule.New, rule.Occurrence)
+	case *document.PowerPointDocument:
+		replaced, err = d.ReplaceTextOccurrenceInSlides(rule.Old, rule.New, rule.Occurrence, slides)
+	default:
+		err = errors.New("occurrence is only supported in Word and PowerPoint documents")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !replaced {
+		ui.PrintWarning("%s: rule %q has no occurrence %d; nothing replaced", docPath, rule.Old, rule.Occurrence)
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// hasOccurrenceRules reports whether any rule is limited to one occurrence
+func hasOccurrenceRules(rules []Rule) bool {
+	for _, rule := range rules {
+		if rule.Occurrence != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCompleted saves a completed file to the state, warning if the state cannot be written
+func recordCompleted(state *ProcessingState, path string, replacements int) {
+	if state == nil {
+		return
+	}
+	if err := state.MarkCompleted(path, replacements); err != nil {
+		ui.PrintWarning("Failed to update state file: %v", err)
+	}
+}
+
+// recordManifest records a processed file in the manifest, or forgets a failed
+// one so it is retried, warning if the file cannot be hashed
+func recordManifest(manifest *Manifest, path string, replacements int, err error) {
+	if manifest == nil {
+		return
+	}
+	if err != nil {
+		manifest.Forget(path)
+		return
+	}
+	if err := manifest.Record(path, replacements); err != nil {
+		ui.PrintWarning("Failed to update manifest: %v", err)
+	}
+}
+
+// saveManifest writes the manifest at the end of a directory run
+func saveManifest(manifest *Manifest) {
+	if manifest == nil {
+		return
+	}
+	if err := manifest.Save(); err != nil {
+		ui.PrintWarning("Failed to save manifest: %v", err)
+	}
+}
+
+// stripDocumentMetadata clears document properties on documents that support it
+func stripDocumentMetadata(doc document.Document) error {
+	stripper, ok := doc.(interface{ StripMetadata() error })
+	if !ok {
+		return nil
+	}
+	return stripper.StripMetadata()
+}
+
+// warnMissingSlides warns about selected slide numbers that the presentation does not have
+func warnMissingSlides(docPath string, selected map[int]bool, existing []int) {
+	if missing := document.MissingSlides(selected, existing); len(missing) > 0 {
+		ui.PrintWarning("%s: slide(s) %v not found (presentation has %d slides)", docPath, missing, len(existing))
+	}
+}
+
+// warnTrackedChanges warns that rules also match text in pending insertions
+// and never match deleted text
+func warnTrackedChanges(docPath string, count int) {
+	if count > 0 {
+		ui.PrintWarning("%s: document has %d tracked change(s); run 'dox changes --accept' or '--reject' first for predictable results", docPath, count)
+	}
+}
+
+// WalkDocumentFiles walks through documents of every registered format in a directory and calls the callback for each file
 func WalkDocumentFiles(dirPath string, recursive bool, callback func(string) error) error {
 	// Keep WalkDocxFiles for backward compatibility
 	return WalkDocumentFilesWithExclude(dirPath, recursive, "", callback)
 }
 
-// WalkDocumentFilesWithExclude walks through .docx and .pptx files with exclude pattern support
+// WalkDocumentFilesWithExclude walks through documents of every registered
+// format (see document.RegisterFormat) with exclude pattern support
 func WalkDocumentFilesWithExclude(dirPath string, recursive bool, excludePattern string, callback func(string) error) error {
-	return walkDocumentFiles(dirPath, recursive, excludePattern, callback, ".docx", ".pptx")
+	return walkDocumentFiles(dirPath, recursive, excludePattern, callback, nil, document.SupportedExtensions()...)
 }
 
 // WalkDocxFiles walks through .docx files in a directory and calls the callback for each file
 // Deprecated: Use WalkDocumentFiles instead
 func WalkDocxFiles(dirPath string, recursive bool, callback func(string) error) error {
-	return walkDocumentFiles(dirPath, recursive, "", callback, ".docx")
+	return walkDocumentFiles(dirPath, recursive, "", callback, nil, ".docx")
 }
 
-// walkDocumentFiles is the internal implementation that accepts multiple extensions
-func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, callback func(string) error, extensions ...string) error {
+// WalkDocumentFilesWithSkips is WalkDocumentFilesWithExclude that also calls
+// onSkip, when not nil, for every file it leaves out and the reason
+func WalkDocumentFilesWithSkips(dirPath string, recursive bool, excludePattern string, callback func(string) error, onSkip func(path, reason string)) error {
+	return walkDocumentFiles(dirPath, recursive, excludePattern, callback, onSkip, document.SupportedExtensions()...)
+}
+
+// walkDocumentFiles is the internal implementation that accepts multiple
+// extensions. onSkip, when not nil, is told about every file left out.
+func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, callback func(string) error, onSkip func(path, reason string), extensions ...string) error {
+	skip := func(path, reason string) {
+		if onSkip != nil {
+			onSkip(path, reason)
+		}
+	}
+
 	if recursive {
 		return filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
@@ -126,6 +388,7 @@ func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, ca
 			if excludePattern != "" {
 				matched, err := filepath.Match(excludePattern, filepath.Base(path))
 				if err == nil && matched {
+					skip(path, skipExcluded(excludePattern))
 					return nil // Skip excluded files
 				}
 			}
@@ -138,6 +401,7 @@ func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, ca
 				}
 			}
 
+			skip(path, SkipUnsupported)
 			return nil
 		})
 	} else {
@@ -148,8 +412,11 @@ func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, ca
 		}
 
 		for _, entry := range entries {
+			path := filepath.Join(dirPath, entry.Name())
+
 			// Skip directories
 			if entry.IsDir() {
+				skip(path, SkipSubdirectory)
 				continue
 			}
 
@@ -157,21 +424,26 @@ func walkDocumentFiles(dirPath string, recursive bool, excludePattern string, ca
 			if excludePattern != "" {
 				matched, err := filepath.Match(excludePattern, entry.Name())
 				if err == nil && matched {
+					skip(path, skipExcluded(excludePattern))
 					continue // Skip excluded files
 				}
 			}
 
 			// Process files with specified extensions
 			lowerName := strings.ToLower(entry.Name())
+			supported := false
 			for _, ext := range extensions {
 				if strings.HasSuffix(lowerName, ext) {
-					path := filepath.Join(dirPath, entry.Name())
+					supported = true
 					if err := callback(path); err != nil {
 						return err
 					}
 					break
 				}
 			}
+			if !supported {
+				skip(path, SkipUnsupported)
+			}
 		}
 	}
 	return nil
@@ -194,6 +466,7 @@ func ReplaceInDirectory(dirPath string, rules []Rule, recursive bool) error {
 	}
 
 	// Skip if no rules to apply
+	rules = EnabledRules(rules)
 	if len(rules) == 0 {
 		return nil
 	}
@@ -244,6 +517,28 @@ type ReplaceResult struct {
 	Replacements int
 }
 
+// SortResults orders results by file path so directory output is the same
+// between runs and with or without concurrent processing
+func SortResults(results []ReplaceResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].FilePath < results[j].FilePath
+	})
+}
+
+// FailFastError reports the file whose failure stopped a fail-fast directory run
+type FailFastError struct {
+	Path string
+	Err  error
+}
+
+func (e *FailFastError) Error() string {
+	return fmt.Sprintf("stopped after %s failed: %v", e.Path, e.Err)
+}
+
+func (e *FailFastError) Unwrap() error {
+	return e.Err
+}
+
 // ReplaceInDirectoryWithResults applies replacement rules and returns detailed results
 func ReplaceInDirectoryWithResults(dirPath string, rules []Rule, recursive bool) ([]ReplaceResult, error) {
 	return ReplaceInDirectoryWithResultsAndExclude(dirPath, rules, recursive, "")
@@ -251,6 +546,13 @@ func ReplaceInDirectoryWithResults(dirPath string, rules []Rule, recursive bool)
 
 // ReplaceInDirectoryWithResultsAndExclude applies replacement rules with exclude pattern support
 func ReplaceInDirectoryWithResultsAndExclude(dirPath string, rules []Rule, recursive bool, excludePattern string) ([]ReplaceResult, error) {
+	return ReplaceInDirectoryWithOptions(dirPath, rules, recursive, excludePattern, ReplaceOptions{})
+}
+
+// ReplaceInDirectoryWithOptions applies replacement rules with exclude pattern support and per-document options.
+// With opts.FailFast the walk stops at the first failed file, returning the
+// results so far and a *FailFastError.
+func ReplaceInDirectoryWithOptions(dirPath string, rules []Rule, recursive bool, excludePattern string, opts ReplaceOptions) ([]ReplaceResult, error) {
 	var results []ReplaceResult
 
 	// Validate input
@@ -268,6 +570,7 @@ func ReplaceInDirectoryWithResultsAndExclude(dirPath string, rules []Rule, recur
 	}
 
 	// Skip if no rules to apply
+	rules = EnabledRules(rules)
 	if len(rules) == 0 {
 		return results, nil
 	}
@@ -279,13 +582,31 @@ func ReplaceInDirectoryWithResultsAndExclude(dirPath string, rules []Rule, recur
 		}
 	}
 
+	// Check rule collisions once for the whole directory
+	if err := CheckCollisions(rules, opts.Strict); err != nil {
+		return nil, err
+	}
+	opts.collisionsChecked = true
+
 	// Process documents in the directory
-	err = WalkDocumentFilesWithExclude(dirPath, recursive, excludePattern, func(path string) error {
+	var stopErr *FailFastError
+	err = WalkDocumentFilesWithSkips(dirPath, recursive, excludePattern, func(path string) error {
+		// Skip files completed by an earlier run
+		if opts.State != nil && opts.State.shouldSkip(path) {
+			opts.Skipped.Record(path, SkipCompleted)
+			return nil
+		}
+		// Skip files unchanged since the last run with the same rules
+		if opts.Manifest != nil && opts.Manifest.shouldSkip(path) {
+			opts.Skipped.Record(path, SkipUnchanged)
+			return nil
+		}
+
 		result := ReplaceResult{
 			FilePath: path,
 		}
 
-		count, err := ReplaceInDocumentWithCount(path, rules)
+		count, err := replaceWithTimeout(path, rules, opts)
 		if err != nil {
 			result.Success = false
 			result.Error = err
@@ -293,12 +614,23 @@ func ReplaceInDirectoryWithResultsAndExclude(dirPath string, rules []Rule, recur
 		} else {
 			result.Success = true
 			result.Replacements = count
+			recordCompleted(opts.State, path, count)
 		}
+		recordManifest(opts.Manifest, path, count, err)
 
 		results = append(results, result)
+		if err != nil && opts.FailFast {
+			stopErr = &FailFastError{Path: path, Err: err}
+			return stopErr
+		}
 		return nil // Continue processing other files
-	})
+	}, opts.Skipped.recorder())
 
+	SortResults(results)
+	saveManifest(opts.Manifest)
+	if stopErr != nil {
+		return results, stopErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error walking directory: %w", err)
 	}
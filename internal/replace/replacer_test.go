@@ -1,9 +1,13 @@
 package replace
 
 import (
+	"archive/zip"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -313,6 +317,33 @@ func joinStrings(strs []string, sep string) string {
 	return result
 }
 
+// readPackagePart returns the content of one part of an Office package
+func readPackagePart(t *testing.T, path, name string) string {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.Name == name {
+			rc, err := file.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rc.Close()
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return string(data)
+		}
+	}
+	t.Fatalf("%s has no part %s", path, name)
+	return ""
+}
+
 func checkDocument(t *testing.T, path string, expectedText string) {
 	t.Helper()
 	
@@ -329,4 +360,307 @@ func checkDocument(t *testing.T, path string, expectedText string) {
 	if !contains(allText, expectedText) {
 		t.Errorf("Expected text '%s' not found in %s", expectedText, path)
 	}
-}
\ No newline at end of file
+}
+
+func TestReplaceInDocumentSkipsDisabledRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.docx")
+	copyFile(t, "testdata/sample_document.docx", path)
+
+	disabled := false
+	rules := []Rule{
+		{Old: "Version 1.0", New: "Version 2.0", Description: "Release bump"},
+		{Old: "Draft", New: "Final", Enabled: &disabled},
+		// A disabled duplicate must not trip strict collision checking
+		{Old: "Version 1.0", New: "Version 3.0", Enabled: &disabled},
+	}
+
+	count, err := ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{Strict: true})
+	if err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1 (only the enabled rule)", count)
+	}
+
+	checkDocument(t, path, "Version 2.0")
+	checkDocument(t, path, "Status: Draft")
+}
+
+func TestReplaceInWordTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "letter.dotx")
+	copyFile(t, "../document/testdata/sample.dotx", path)
+	rules := []Rule{{Old: "{{name}}", New: "Jane"}}
+
+	count, err := ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{})
+	if err != nil || count != 1 {
+		t.Fatalf("ReplaceInDocumentWithOptions(.dotx) = %d, %v; want 1 replacement", count, err)
+	}
+	checkDocument(t, path, "Dear Jane,")
+
+	// The file stays a template
+	contentTypes := readPackagePart(t, path, "[Content_Types].xml")
+	if !strings.Contains(contentTypes, "wordprocessingml.template.main+xml") {
+		t.Errorf("template content type lost: %s", contentTypes)
+	}
+
+	// Large-file processing, streaming or not, handles templates as well
+	for _, streaming := range []bool{false, true} {
+		copyFile(t, "../document/testdata/sample.dotx", path)
+		result, err := ProcessLargeFile(path, rules, &LargeFileOptions{EnableStreaming: streaming})
+		if err != nil || result.Replacements != 1 {
+			t.Errorf("ProcessLargeFile(.dotx, streaming %v) = %+v, %v", streaming, result, err)
+		}
+	}
+}
+
+func TestReplaceInDocumentFilesPattern(t *testing.T) {
+	dir := t.TempDir()
+	contract := filepath.Join(dir, "contract_acme.docx")
+	memo := filepath.Join(dir, "memo.docx")
+	copyFile(t, "testdata/sample_document.docx", contract)
+	copyFile(t, "testdata/sample_document.docx", memo)
+
+	rules := []Rule{
+		{Old: "Version 1.0", New: "Version 2.0"},
+		{Old: "Draft", New: "Signed", Files: "contract_*"},
+	}
+	if _, err := ReplaceInDocumentWithCount(contract, rules); err != nil {
+		t.Fatalf("ReplaceInDocumentWithCount(contract) failed: %v", err)
+	}
+	count, err := ReplaceInDocumentWithCount(memo, rules)
+	if err != nil {
+		t.Fatalf("ReplaceInDocumentWithCount(memo) failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("memo count = %d, want 1 (the unscoped rule)", count)
+	}
+
+	checkDocument(t, contract, "Status: Signed")
+	checkDocument(t, memo, "Status: Draft")
+	checkDocument(t, memo, "Version 2.0")
+
+	// A document no rule applies to is not opened or saved
+	scoped := []Rule{{Old: "Draft", New: "Signed", Files: "contract_*"}}
+	if count, err := ReplaceInDocumentWithCount(memo, scoped); err != nil || count != 0 {
+		t.Errorf("ReplaceInDocumentWithCount(memo) = %d, %v; want 0, nil", count, err)
+	}
+}
+
+func TestReplaceInDocumentFormatRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", path)
+
+	rules, err := ParseYAMLRules([]byte(`shared:
+  - old: "Version 1.0"
+    new: "Version 2.0"
+docx:
+  - old: "Draft"
+    new: "Signed"
+pptx:
+  - old: "Draft"
+    new: "Final"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := ReplaceInDocumentWithCount(path, rules)
+	if err != nil {
+		t.Fatalf("ReplaceInDocumentWithCount() failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2 (the shared and the docx rule)", count)
+	}
+	checkDocument(t, path, "Version 2.0")
+	checkDocument(t, path, "Status: Signed")
+
+	pptxRules := RulesForFile(rules, "deck.PPTX")
+	if len(pptxRules) != 2 || pptxRules[0].Old != "Version 1.0" || pptxRules[1].New != "Final" {
+		t.Errorf("RulesForFile(deck.PPTX) = %+v, want the shared rule then the pptx rule", pptxRules)
+	}
+	if formats := RuleFormats(rules); len(formats) != 2 || formats[0] != "docx" || formats[1] != "pptx" {
+		t.Errorf("RuleFormats() = %v, want [docx pptx]", formats)
+	}
+}
+
+func TestRuleAppliesTo(t *testing.T) {
+	tests := []struct {
+		files string
+		path  string
+		want  bool
+	}{
+		{"", "any/report.docx", true},
+		{"contract_*", "legal/contract_2024.docx", true},
+		{"contract_*.pptx", "legal/contract_2024.docx", false},
+		{"contract_*", "contracts/memo.docx", false},
+		{"[", "contract.docx", false},
+	}
+	for _, tt := range tests {
+		if got := (Rule{Old: "a", Files: tt.files}).AppliesTo(tt.path); got != tt.want {
+			t.Errorf("AppliesTo(%q) with files %q = %v, want %v", tt.path, tt.files, got, tt.want)
+		}
+	}
+
+	if err := (Rule{Old: "a", New: "b", Files: "["}).Validate(); err == nil {
+		t.Error("Validate() should reject a malformed files pattern")
+	}
+}
+
+func TestReplaceInDocumentOccurrence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.docx")
+	copyFile(t, "testdata/sample_document.docx", path)
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0", Occurrence: -1}}
+	count, err := ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+	checkDocument(t, path, "Version 2.0")
+
+	// An occurrence the document does not have is not counted
+	rules = []Rule{{Old: "2023", New: "2024", Occurrence: 3}}
+	count, err = ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{})
+	if err != nil || count != 0 {
+		t.Errorf("ReplaceInDocumentWithOptions(occurrence 3 of 2) = %d, %v; want 0, nil", count, err)
+	}
+	checkDocument(t, path, "Year: 2023")
+}
+
+func TestReplaceInDocumentHiddenText(t *testing.T) {
+	for _, includeHidden := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "hidden.docx")
+		copyFile(t, "../document/testdata/hidden.docx", path)
+
+		rules := []Rule{{Old: "Hidden Draft", New: "Hidden Final"}}
+		if _, err := ReplaceInDocumentWithOptions(path, rules, ReplaceOptions{IncludeHiddenText: includeHidden}); err != nil {
+			t.Fatalf("ReplaceInDocumentWithOptions failed: %v", err)
+		}
+
+		doc, err := document.OpenWordDocument(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc.SetIncludeHiddenText(true)
+		text, _ := doc.GetText()
+		doc.Close()
+
+		if replaced := contains(text, "Hidden Final paragraph"); replaced != includeHidden {
+			t.Errorf("includeHidden=%v: hidden paragraph replaced = %v", includeHidden, replaced)
+		}
+	}
+}
+
+func TestReplaceInDirectoryFailFast(t *testing.T) {
+	setup := func(t *testing.T) string {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "a_broken.docx"), []byte("not a zip"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, "b_good.docx"))
+		return dir
+	}
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+
+	run := map[string]func(dir string, opts ReplaceOptions) ([]ReplaceResult, error){
+		"sequential": func(dir string, opts ReplaceOptions) ([]ReplaceResult, error) {
+			return ReplaceInDirectoryWithOptions(dir, rules, false, "", opts)
+		},
+		"concurrent": func(dir string, opts ReplaceOptions) ([]ReplaceResult, error) {
+			return ReplaceInDirectoryConcurrent(dir, rules, false, "", ConcurrentOptions{MaxWorkers: 1, Replace: opts})
+		},
+	}
+
+	for name, replaceDir := range run {
+		t.Run(name+" continues by default", func(t *testing.T) {
+			dir := setup(t)
+			results, err := replaceDir(dir, ReplaceOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != 2 || results[0].Success || !results[1].Success {
+				t.Errorf("results = %+v, want the broken file failed and the good one processed", results)
+			}
+		})
+
+		t.Run(name+" stops at first failure", func(t *testing.T) {
+			dir := setup(t)
+			results, err := replaceDir(dir, ReplaceOptions{FailFast: true})
+
+			var stopped *FailFastError
+			if !errors.As(err, &stopped) {
+				t.Fatalf("error = %v, want *FailFastError", err)
+			}
+			if filepath.Base(stopped.Path) != "a_broken.docx" {
+				t.Errorf("stopped at %s, want a_broken.docx", stopped.Path)
+			}
+			if len(results) != 1 {
+				t.Errorf("got %d results, want only the failed file", len(results))
+			}
+			checkDocument(t, filepath.Join(dir, "b_good.docx"), "Version 1.0")
+		})
+	}
+}
+
+func TestDirectoryResultsAreSortedByPath(t *testing.T) {
+	dir := t.TempDir()
+	// Walk order visits a/ before a.docx; path order puts a.docx first
+	for _, name := range []string{"c.docx", "a.docx", "a/z.docx", "a/b.docx", "B.docx"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		copyFile(t, "testdata/sample_document.docx", path)
+	}
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+
+	var want []string
+	for _, name := range []string{"B.docx", "a.docx", "a/b.docx", "a/z.docx", "c.docx"} {
+		want = append(want, filepath.Join(dir, name))
+	}
+	paths := func(results []ReplaceResult) []string {
+		var got []string
+		for _, result := range results {
+			got = append(got, result.FilePath)
+		}
+		return got
+	}
+
+	sequential, err := ReplaceInDirectoryWithOptions(dir, rules, true, "", ReplaceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := paths(sequential); !reflect.DeepEqual(got, want) {
+		t.Errorf("sequential order = %v, want %v", got, want)
+	}
+
+	for i := 0; i < 3; i++ {
+		concurrentResults, err := ReplaceInDirectoryConcurrent(dir, rules, true, "", ConcurrentOptions{MaxWorkers: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := paths(concurrentResults); !reflect.DeepEqual(got, want) {
+			t.Errorf("concurrent run %d order = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestReplaceInDocumentCountsMatches(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+
+	// A rule that matches nothing adds nothing to the count
+	rules := []Rule{{Old: "not in the document", New: "x"}}
+	count, err := ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{})
+	if err != nil || count != 0 {
+		t.Fatalf("ReplaceInDocumentWithOptions() = %d, %v; want 0 replacements", count, err)
+	}
+
+	// "2023" appears in the year line and the copyright line
+	rules = append(rules, Rule{Old: "2023", New: "2024"})
+	count, err = ReplaceInDocumentWithOptions(docPath, rules, ReplaceOptions{})
+	if err != nil || count != 2 {
+		t.Fatalf("ReplaceInDocumentWithOptions() = %d, %v; want 2 replacements", count, err)
+	}
+	checkDocument(t, docPath, "Copyright 2024")
+}
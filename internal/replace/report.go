@@ -0,0 +1,246 @@
+package replace
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSnippetsPerRule limits how many before/after examples are kept per rule and file
+const maxSnippetsPerRule = 5
+
+// snippetRadius is the number of characters kept on each side of a match
+const snippetRadius = 40
+
+// Snippet is a short excerpt of text before and after a replacement
+type Snippet struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// RuleChange describes the proposed changes of one rule in one file
+type RuleChange struct {
+	Rule     Rule      `json:"rule"`
+	Count    int       `json:"count"`
+	Snippets []Snippet `json:"snippets,omitempty"`
+}
+
+// FileChanges describes all proposed changes for one file
+type FileChanges struct {
+	Path  string       `json:"path"`
+	Rules []RuleChange `json:"rules,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+// Total returns the number of replacements proposed for the file
+func (f FileChanges) Total() int {
+	total := 0
+	for _, rc := range f.Rules {
+		total += rc.Count
+	}
+	return total
+}
+
+// PreviewChanges computes the changes the rules would make to text. Rules are
+// applied in order, as during a real replacement, so later rules see the
+// output of earlier ones. Disabled rules are skipped. Normalized rules count
+// every spelling of their old text.
+func PreviewChanges(text string, rules []Rule) []RuleChange {
+	var changes []RuleChange
+
+	for _, rule := range rules {
+		if rule.Old == "" || !rule.IsEnabled() {
+			continue
+		}
+		targets := []string{rule.Old}
+		if rule.NormalizeText {
+			targets = normalizedVariants(text, rule.Old)
+		}
+
+		if rule.Occurrence != 0 {
+			if change, replaced, ok := previewOccurrence(text, rule); ok {
+				changes = append(changes, change)
+				text = replaced
+			}
+			continue
+		}
+
+		change := RuleChange{Rule: rule}
+		for _, target := range targets {
+			change.Count += strings.Count(text, target)
+
+			offset := 0
+			for len(change.Snippets) < maxSnippetsPerRule {
+				idx := strings.Index(text[offset:], target)
+				if idx < 0 {
+					break
+				}
+				start := offset + idx
+				change.Snippets = append(change.Snippets, snippetAround(text, start, target, rule.New))
+				offset = start + len(target)
+			}
+		}
+		if change.Count == 0 {
+			continue
+		}
+
+		changes = append(changes, change)
+		for _, target := range targets {
+			text = strings.ReplaceAll(text, target, rule.New)
+		}
+	}
+
+	return changes
+}
+
+// previewOccurrence previews a rule limited to one occurrence, returning its
+// change and the text with that occurrence replaced. It reports false when
+// the text has no such occurrence.
+func previewOccurrence(text string, rule Rule) (RuleChange, string, bool) {
+	var starts []int
+	for offset := 0; ; {
+		idx := strings.Index(text[offset:], rule.Old)
+		if idx < 0 {
+			break
+		}
+		starts = append(starts, offset+idx)
+		offset += idx + len(rule.Old)
+	}
+
+	index := rule.Occurrence - 1
+	if rule.Occurrence < 0 {
+		index = len(starts) + rule.Occurrence
+	}
+	if index < 0 || index >= len(starts) {
+		return RuleChange{}, text, false
+	}
+
+	start := starts[index]
+	change := RuleChange{
+		Rule:     rule,
+		Count:    1,
+		Snippets: []Snippet{snippetAround(text, start, rule.Old, rule.New)},
+	}
+	return change, text[:start] + rule.New + text[start+len(rule.Old):], true
+}
+
+// snippetAround returns the text surrounding a match of old on its line,
+// before and after replacement with new
+func snippetAround(text string, start int, old, new string) Snippet {
+	end := start + len(old)
+
+	from := start - snippetRadius
+	if lineStart := strings.LastIndex(text[:start], "\n") + 1; from < lineStart {
+		from = lineStart
+	}
+	to := end + snippetRadius
+	if lineEnd := strings.Index(text[end:], "\n"); lineEnd >= 0 && end+lineEnd < to {
+		to = end + lineEnd
+	}
+	if to > len(text) {
+		to = len(text)
+	}
+	// Avoid cutting multi-byte characters in half
+	for from > 0 && from < len(text) && !isRuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !isRuneStart(text[to]) {
+		to++
+	}
+
+	prefix, suffix := text[from:start], text[end:to]
+	if from > 0 && text[from-1] != '\n' {
+		prefix = "…" + prefix
+	}
+	if to < len(text) && text[to] != '\n' {
+		suffix += "…"
+	}
+
+	return Snippet{
+		Before: prefix + old + suffix,
+		After:  prefix + new + suffix,
+	}
+}
+
+// isRuneStart reports whether b is the first byte of a UTF-8 sequence
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// FormatMarkdownReport renders proposed changes as a Markdown review document
+// with a summary table followed by one section per file
+func FormatMarkdownReport(files []FileChanges, rules []Rule) string {
+	var b strings.Builder
+
+	b.WriteString("# Proposed Replacements\n\n")
+
+	totalReplacements := 0
+	filesWithChanges := 0
+	for _, f := range files {
+		if f.Total() > 0 {
+			filesWithChanges++
+			totalReplacements += f.Total()
+		}
+	}
+	b.WriteString(fmt.Sprintf("%d replacement(s) in %d of %d file(s) using %d rule(s).\n\n",
+		totalReplacements, filesWithChanges, len(files), len(EnabledRules(rules))))
+
+	// Summary table
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| File | Replacements | Rules matched |\n")
+	b.WriteString("| --- | ---: | ---: |\n")
+	for _, f := range files {
+		if f.Error != "" {
+			b.WriteString(fmt.Sprintf("| %s | error | - |\n", markdownCell(f.Path)))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("| %s | %d | %d |\n", markdownCell(f.Path), f.Total(), len(f.Rules)))
+	}
+	b.WriteString("\n")
+
+	// Per-file sections
+	for _, f := range files {
+		if f.Error == "" && f.Total() == 0 {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("## %s\n\n", f.Path))
+		if f.Error != "" {
+			b.WriteString(fmt.Sprintf("Could not read file: %s\n\n", f.Error))
+			continue
+		}
+
+		for _, rc := range f.Rules {
+			b.WriteString(fmt.Sprintf("### `%s` → `%s` (%d)\n\n", markdownCode(rc.Rule.Old), markdownCode(rc.Rule.New), rc.Count))
+			if rc.Rule.Description != "" {
+				b.WriteString(singleLine(rc.Rule.Description) + "\n\n")
+			}
+			b.WriteString("```diff\n")
+			for _, s := range rc.Snippets {
+				b.WriteString("- " + singleLine(s.Before) + "\n")
+				b.WriteString("+ " + singleLine(s.After) + "\n")
+			}
+			b.WriteString("```\n")
+			if rc.Count > len(rc.Snippets) {
+				b.WriteString(fmt.Sprintf("\n_%d more occurrence(s) not shown._\n", rc.Count-len(rc.Snippets)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// markdownCell escapes text for use in a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(singleLine(s), "|", "\\|")
+}
+
+// markdownCode makes text safe inside an inline code span
+func markdownCode(s string) string {
+	return strings.ReplaceAll(singleLine(s), "`", "'")
+}
+
+// singleLine collapses line breaks so a value fits on one Markdown line
+func singleLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
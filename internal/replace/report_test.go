@@ -0,0 +1,120 @@
+package replace
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreviewChanges(t *testing.T) {
+	text := "Version 1.0 released.\nUpgrade from Version 1.0 now.\nContact: old@example.com"
+	rules := []Rule{
+		{Old: "Version 1.0", New: "Version 2.0"},
+		{Old: "old@example.com", New: "new@example.com"},
+		{Old: "missing", New: "unused"},
+	}
+
+	changes := PreviewChanges(text, rules)
+	if len(changes) != 2 {
+		t.Fatalf("got %d rule changes, want 2", len(changes))
+	}
+
+	if changes[0].Count != 2 || len(changes[0].Snippets) != 2 {
+		t.Errorf("first rule: count=%d snippets=%d, want 2 and 2", changes[0].Count, len(changes[0].Snippets))
+	}
+	if got := changes[0].Snippets[1]; got.Before != "Upgrade from Version 1.0 now." || got.After != "Upgrade from Version 2.0 now." {
+		t.Errorf("unexpected snippet: %+v", got)
+	}
+	if changes[1].Snippets[0].After != "Contact: new@example.com" {
+		t.Errorf("unexpected snippet: %+v", changes[1].Snippets[0])
+	}
+}
+
+func TestPreviewChangesAppliesRulesInOrder(t *testing.T) {
+	changes := PreviewChanges("alpha", []Rule{
+		{Old: "alpha", New: "beta"},
+		{Old: "beta", New: "gamma"},
+	})
+
+	if len(changes) != 2 || changes[1].Count != 1 {
+		t.Fatalf("later rule should see the output of earlier rules, got %+v", changes)
+	}
+}
+
+func TestPreviewChangesSkipsDisabledRules(t *testing.T) {
+	disabled := false
+	changes := PreviewChanges("alpha beta", []Rule{
+		{Old: "alpha", New: "one", Enabled: &disabled},
+		{Old: "beta", New: "two", Description: "Rename beta"},
+	})
+
+	if len(changes) != 1 || changes[0].Rule.Old != "beta" {
+		t.Fatalf("disabled rule should be skipped, got %+v", changes)
+	}
+
+	report := FormatMarkdownReport([]FileChanges{{Path: "a.docx", Rules: changes}}, []Rule{{Old: "beta", New: "two", Description: "Rename beta"}})
+	if !strings.Contains(report, "Rename beta") {
+		t.Errorf("report should include the rule description:\n%s", report)
+	}
+}
+
+func TestPreviewChangesOccurrence(t *testing.T) {
+	text := "Draft one\nDraft two\nDraft three"
+
+	changes := PreviewChanges(text, []Rule{{Old: "Draft", New: "Final", Occurrence: -1}})
+	if len(changes) != 1 || changes[0].Count != 1 {
+		t.Fatalf("got %+v, want one change", changes)
+	}
+	if got := changes[0].Snippets[0]; got.Before != "Draft three" || got.After != "Final three" {
+		t.Errorf("unexpected snippet: %+v", got)
+	}
+
+	if changes := PreviewChanges(text, []Rule{{Old: "Draft", New: "Final", Occurrence: 4}}); len(changes) != 0 {
+		t.Errorf("missing occurrence should not be previewed, got %+v", changes)
+	}
+}
+
+func TestPreviewChangesTruncatesLongLines(t *testing.T) {
+	text := strings.Repeat("x", 100) + "target" + strings.Repeat("y", 100)
+	changes := PreviewChanges(text, []Rule{{Old: "target", New: "done"}})
+
+	snippet := changes[0].Snippets[0]
+	if !strings.HasPrefix(snippet.Before, "…") || !strings.HasSuffix(snippet.Before, "…") {
+		t.Errorf("long line should be elided on both sides: %q", snippet.Before)
+	}
+	if len(snippet.Before) > 2*snippetRadius+len("target")+2*len("…") {
+		t.Errorf("snippet too long: %d bytes", len(snippet.Before))
+	}
+}
+
+func TestFormatMarkdownReport(t *testing.T) {
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+	files := []FileChanges{
+		{Path: "docs/a.docx", Rules: PreviewChanges("Version 1.0 is out", rules)},
+		{Path: "docs/b.docx"},
+		{Path: "docs/c.pptx", Error: "failed to open"},
+	}
+
+	report := FormatMarkdownReport(files, rules)
+
+	for _, expected := range []string{
+		"# Proposed Replacements",
+		"1 replacement(s) in 1 of 3 file(s) using 1 rule(s).",
+		"| docs/a.docx | 1 | 1 |",
+		"| docs/b.docx | 0 | 0 |",
+		"| docs/c.pptx | error | - |",
+		"## docs/a.docx",
+		"### `Version 1.0` → `Version 2.0` (1)",
+		"- Version 1.0 is out",
+		"+ Version 2.0 is out",
+		"## docs/c.pptx",
+		"Could not read file: failed to open",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("report missing %q:\n%s", expected, report)
+		}
+	}
+
+	if strings.Contains(report, "## docs/b.docx") {
+		t.Error("files without changes should only appear in the summary")
+	}
+}
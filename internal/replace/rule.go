@@ -2,6 +2,7 @@ package replace
 
 import (
 	"errors"
+	"path/filepath"
 	"strings"
 )
 
@@ -9,6 +10,124 @@ import (
 type Rule struct {
 	Old string `yaml:"old" json:"old"`
 	New string `yaml:"new" json:"new"`
+	// Enabled turns the rule off when set to false; nil means enabled
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// Description is free text shown in previews and reports
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// NormalizeText matches Old ignoring smart quotes, non-breaking spaces and
+	// ligatures (see NormalizeText); only the matched text is replaced
+	NormalizeText bool `yaml:"normalize,omitempty" json:"normalize,omitempty"`
+	// Occurrence limits a Word or PowerPoint rule to one occurrence of Old,
+	// counted across the document's text: 1 is the first, -1 the last and
+	// 0 (the default) replaces every occurrence
+	Occurrence int `yaml:"occurrence,omitempty" json:"occurrence,omitempty"`
+	// Files limits the rule to documents whose base name matches this glob,
+	// e.g. "contract_*"; empty applies the rule to every document
+	Files string `yaml:"files,omitempty" json:"files,omitempty"`
+	// Format limits the rule to documents with this extension, without the
+	// dot ("docx" or "pptx"); it is set for rules in a format section of a
+	// rules file and empty applies the rule to every format
+	Format string `yaml:"-" json:"format,omitempty"`
+}
+
+// IsEnabled reports whether the rule should be applied
+func (r Rule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// EnabledRules returns the rules that are not disabled, keeping their order
+func EnabledRules(rules []Rule) []Rule {
+	enabled := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.IsEnabled() {
+			enabled = append(enabled, rule)
+		}
+	}
+	return enabled
+}
+
+// AppliesTo reports whether the rule applies to the document at path (see
+// Rule.Format and Rule.Files)
+func (r Rule) AppliesTo(path string) bool {
+	if r.Format != "" && !strings.EqualFold(strings.TrimPrefix(filepath.Ext(path), "."), r.Format) {
+		return false
+	}
+	if r.Files == "" {
+		return true
+	}
+	matched, err := filepath.Match(r.Files, filepath.Base(path))
+	return err == nil && matched
+}
+
+// sharesFormat reports whether two rules can apply to the same document
+// format, which rules for different formats never do
+func (r Rule) sharesFormat(other Rule) bool {
+	return r.Format == "" || other.Format == "" || strings.EqualFold(r.Format, other.Format)
+}
+
+// sharesFiles reports whether two rules can apply to the same document by
+// name. Patterns whose literal prefixes or suffixes differ, such as
+// "contract_*" and "invoice_*", never match the same name; other patterns
+// are assumed to overlap.
+func (r Rule) sharesFiles(other Rule) bool {
+	if r.Files == "" || other.Files == "" || r.Files == other.Files {
+		return true
+	}
+	prefix, suffix := globLiterals(r.Files)
+	otherPrefix, otherSuffix := globLiterals(other.Files)
+	if !strings.HasPrefix(prefix, otherPrefix) && !strings.HasPrefix(otherPrefix, prefix) {
+		return false
+	}
+	return strings.HasSuffix(suffix, otherSuffix) || strings.HasSuffix(otherSuffix, suffix)
+}
+
+// globLiterals returns the literal text before the first and after the last
+// wildcard of a file name pattern; a pattern without wildcards is all literal
+func globLiterals(pattern string) (prefix, suffix string) {
+	first := strings.IndexAny(pattern, `*?[\`)
+	if first < 0 {
+		return pattern, pattern
+	}
+	last := strings.LastIndexAny(pattern, `*?]\`)
+	return pattern[:first], pattern[last+1:]
+}
+
+// RuleFormats returns the formats that have rules of their own, in the
+// order they first appear
+func RuleFormats(rules []Rule) []string {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Format != "" && !seen[rule.Format] {
+			seen[rule.Format] = true
+			formats = append(formats, rule.Format)
+		}
+	}
+	return formats
+}
+
+// RulesForFormat returns the shared rules and the rules of format, keeping
+// their order
+func RulesForFormat(rules []Rule, format string) []Rule {
+	applicable := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Format == "" || strings.EqualFold(rule.Format, format) {
+			applicable = append(applicable, rule)
+		}
+	}
+	return applicable
+}
+
+// RulesForFile returns the rules that apply to the document at path, keeping
+// their order
+func RulesForFile(rules []Rule, path string) []Rule {
+	applicable := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.AppliesTo(path) {
+			applicable = append(applicable, rule)
+		}
+	}
+	return applicable
 }
 
 // Validate checks if the rule is valid
@@ -22,6 +141,18 @@ func (r Rule) Validate() error {
 	if r.Old == r.New {
 		return errors.New("old and new values cannot be the same")
 	}
+
+	// Normalized matching may find several spellings, so there is no single
+	// numbering of occurrences
+	if r.Occurrence != 0 && r.NormalizeText {
+		return errors.New("occurrence cannot be combined with normalize")
+	}
+
+	if r.Files != "" {
+		if _, err := filepath.Match(r.Files, ""); err != nil {
+			return errors.New("files is not a valid file name pattern")
+		}
+	}
 	
 	return nil
 }
\ No newline at end of file
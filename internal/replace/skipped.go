@@ -0,0 +1,70 @@
+package replace
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Reasons a directory run leaves a file out
+const (
+	SkipUnsupported  = "unsupported file type"
+	SkipSubdirectory = "subdirectory (not recursive)"
+	SkipCompleted    = "already completed according to the state file"
+	SkipUnchanged    = "unchanged since the last run according to the manifest"
+)
+
+// skipExcluded is the reason for a file matching the exclude pattern
+func skipExcluded(pattern string) string {
+	return fmt.Sprintf("matches exclude pattern %q", pattern)
+}
+
+// SkippedFile is a file a directory run left out, with the reason
+type SkippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// SkipLog collects the files a directory run leaves out. It is safe for
+// concurrent use. A nil *SkipLog records nothing.
+type SkipLog struct {
+	mu    sync.Mutex
+	files []SkippedFile
+}
+
+// NewSkipLog creates an empty SkipLog
+func NewSkipLog() *SkipLog {
+	return &SkipLog{}
+}
+
+// Record adds a skipped file
+func (l *SkipLog) Record(path, reason string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.files = append(l.files, SkippedFile{Path: path, Reason: reason})
+}
+
+// Files returns the skipped files sorted by path; it is never nil for a
+// non-nil log, so an empty list encodes as a JSON array
+func (l *SkipLog) Files() []SkippedFile {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	files := append([]SkippedFile{}, l.files...)
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// recorder returns the log's Record method for walkDocumentFiles, or nil
+func (l *SkipLog) recorder() func(path, reason string) {
+	if l == nil {
+		return nil
+	}
+	return l.Record
+}
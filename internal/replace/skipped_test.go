@@ -0,0 +1,92 @@
+package replace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalkDocumentFilesWithSkips(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.docx"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(dir, "draft.docx"), []byte{}, 0644)
+	os.Mkdir(filepath.Join(dir, "sub"), 0755)
+	os.WriteFile(filepath.Join(dir, "sub", "b.pptx"), []byte{}, 0644)
+
+	walk := func(recursive bool) ([]string, []SkippedFile) {
+		var visited []string
+		log := NewSkipLog()
+		err := WalkDocumentFilesWithSkips(dir, recursive, "draft*", func(path string) error {
+			visited = append(visited, filepath.Base(path))
+			return nil
+		}, log.Record)
+		if err != nil {
+			t.Fatalf("WalkDocumentFilesWithSkips failed: %v", err)
+		}
+		return visited, log.Files()
+	}
+
+	visited, skipped := walk(true)
+	if len(visited) != 2 {
+		t.Errorf("recursive walk visited %v, want a.docx and b.pptx", visited)
+	}
+	want := []SkippedFile{
+		{Path: filepath.Join(dir, "draft.docx"), Reason: `matches exclude pattern "draft*"`},
+		{Path: filepath.Join(dir, "notes.txt"), Reason: SkipUnsupported},
+	}
+	if len(skipped) != len(want) || skipped[0] != want[0] || skipped[1] != want[1] {
+		t.Errorf("recursive skipped = %+v, want %+v", skipped, want)
+	}
+
+	visited, skipped = walk(false)
+	if len(visited) != 1 || visited[0] != "a.docx" {
+		t.Errorf("non-recursive walk visited %v, want a.docx", visited)
+	}
+	if len(skipped) != 3 || skipped[2] != (SkippedFile{Path: filepath.Join(dir, "sub"), Reason: SkipSubdirectory}) {
+		t.Errorf("non-recursive skipped = %+v, want the subdirectory listed", skipped)
+	}
+}
+
+func TestSkipLogNil(t *testing.T) {
+	var log *SkipLog
+	log.Record("a.docx", SkipUnsupported)
+	if files := log.Files(); files != nil {
+		t.Errorf("nil log returned %v", files)
+	}
+	if files := NewSkipLog().Files(); files == nil || len(files) != 0 {
+		t.Errorf("empty log returned %#v, want an empty slice", files)
+	}
+}
+
+func TestReplaceInDirectoryRecordsSkipped(t *testing.T) {
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "doc.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+
+	manifest, err := LoadManifest(filepath.Join(t.TempDir(), "manifest.json"), rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manifest.Record(docPath, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, concurrent := range []bool{false, true} {
+		opts := ReplaceOptions{Manifest: manifest, Skipped: NewSkipLog()}
+		if concurrent {
+			_, err = ReplaceInDirectoryConcurrent(dir, rules, true, "", ConcurrentOptions{MaxWorkers: 2, Replace: opts})
+		} else {
+			_, err = ReplaceInDirectoryWithOptions(dir, rules, true, "", opts)
+		}
+		if err != nil {
+			t.Fatalf("concurrent=%v: %v", concurrent, err)
+		}
+
+		skipped := opts.Skipped.Files()
+		if len(skipped) != 1 || skipped[0] != (SkippedFile{Path: docPath, Reason: SkipUnchanged}) {
+			t.Errorf("concurrent=%v: skipped = %+v, want the unchanged document", concurrent, skipped)
+		}
+	}
+}
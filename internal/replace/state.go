@@ -0,0 +1,177 @@
+package replace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// CompletedFile records a file that was processed successfully
+type CompletedFile struct {
+	Replacements int       `json:"replacements"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+// ProcessingState tracks which files of a directory run have completed so an
+// interrupted run can be resumed. It is saved to disk after every file.
+type ProcessingState struct {
+	RulesHash string                   `json:"rules_hash"`
+	Completed map[string]CompletedFile `json:"completed"`
+
+	path    string
+	skipped int
+	mu      sync.Mutex
+}
+
+// HashRules returns a stable hash of the replacement rules
+func HashRules(rules []Rule) string {
+	data, _ := json.Marshal(rules)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// runOptions are the replace options that change what the rules write into a
+// document, hashed with the rules by HashRun
+type runOptions struct {
+	Slides            map[int]bool    `json:"slides,omitempty"`
+	Parts             map[string]bool `json:"parts,omitempty"`
+	AllParts          bool            `json:"all_parts,omitempty"`
+	IncludeHiddenText bool            `json:"include_hidden_text,omitempty"`
+	StripMetadata     bool            `json:"strip_metadata,omitempty"`
+}
+
+// HashRun returns a stable hash of the replacement rules together with the
+// options that change what they write into a document, such as the selected
+// slides or parts. Without such options it is HashRules of the rules.
+func HashRun(rules []Rule, opts ReplaceOptions) string {
+	options := runOptions{
+		Slides:            opts.Slides,
+		Parts:             opts.Parts,
+		AllParts:          opts.AllParts,
+		IncludeHiddenText: opts.IncludeHiddenText,
+		StripMetadata:     opts.StripMetadata,
+	}
+	if options.AllParts {
+		// Every part is replaced whatever was selected
+		options.Slides, options.Parts = nil, nil
+	}
+	if len(options.Slides) == 0 && len(options.Parts) == 0 && !options.AllParts && !options.IncludeHiddenText && !options.StripMetadata {
+		return HashRules(rules)
+	}
+
+	data, _ := json.Marshal(struct {
+		Rules   []Rule     `json:"rules"`
+		Options runOptions `json:"options"`
+	}{rules, options})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadState loads the state file at path, or starts a new state if it does not
+// exist yet. If the rules or the options they are applied with (see HashRun)
+// changed since the state was written, a warning is printed and the new hash
+// is recorded from then on.
+func LoadState(path string, rules []Rule, opts ReplaceOptions) (*ProcessingState, error) {
+	state := &ProcessingState{
+		Completed: make(map[string]CompletedFile),
+		path:      path,
+	}
+	hash := HashRun(rules, opts)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			state.RulesHash = hash
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read state file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Completed == nil {
+		state.Completed = make(map[string]CompletedFile)
+	}
+
+	if state.RulesHash != "" && state.RulesHash != hash {
+		ui.PrintWarning("Rules or options have changed since %s was written; %d previously completed file(s) will still be skipped (delete the state file to reprocess them)",
+			path, len(state.Completed))
+	}
+	state.RulesHash = hash
+
+	return state, nil
+}
+
+// stateKey normalizes a file path so relative and absolute spellings match
+func stateKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// IsCompleted reports whether the file was completed in an earlier run
+func (s *ProcessingState) IsCompleted(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, done := s.Completed[stateKey(path)]
+	return done
+}
+
+// shouldSkip reports whether the file is already completed and counts it as skipped
+func (s *ProcessingState) shouldSkip(path string) bool {
+	if !s.IsCompleted(path) {
+		return false
+	}
+	s.mu.Lock()
+	s.skipped++
+	s.mu.Unlock()
+	return true
+}
+
+// Skipped returns the number of files skipped because they were already completed
+func (s *ProcessingState) Skipped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.skipped
+}
+
+// MarkCompleted records a successfully processed file and saves the state
+func (s *ProcessingState) MarkCompleted(path string, replacements int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Completed[stateKey(path)] = CompletedFile{
+		Replacements: replacements,
+		CompletedAt:  time.Now(),
+	}
+	return s.save()
+}
+
+// save writes the state atomically; the caller must hold the lock
+func (s *ProcessingState) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save state file: %w", err)
+	}
+	return nil
+}
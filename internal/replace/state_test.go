@@ -0,0 +1,138 @@
+package replace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "progress.json")
+	rules := []Rule{{Old: "a", New: "b"}}
+
+	state, err := LoadState(statePath, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadState on missing file failed: %v", err)
+	}
+	if len(state.Completed) != 0 {
+		t.Fatalf("expected empty state, got %d entries", len(state.Completed))
+	}
+
+	if err := state.MarkCompleted("doc.docx", 3); err != nil {
+		t.Fatalf("MarkCompleted failed: %v", err)
+	}
+	if _, err := os.Stat(statePath); err != nil {
+		t.Fatalf("state file should be written after MarkCompleted: %v", err)
+	}
+
+	reloaded, err := LoadState(statePath, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if !reloaded.IsCompleted("doc.docx") {
+		t.Error("reloaded state should contain the completed file")
+	}
+	if reloaded.IsCompleted("other.docx") {
+		t.Error("unexpected completed file")
+	}
+
+	// Changed rules only warn; completed files are kept and the new hash is recorded
+	changed := []Rule{{Old: "a", New: "c"}}
+	reloaded, err = LoadState(statePath, changed, ReplaceOptions{})
+	if err != nil {
+		t.Fatalf("LoadState with changed rules failed: %v", err)
+	}
+	if !reloaded.IsCompleted("doc.docx") {
+		t.Error("completed files should be kept when rules change")
+	}
+	if reloaded.RulesHash != HashRules(changed) {
+		t.Error("state should record the current rules hash")
+	}
+}
+
+func TestLoadStateInvalidFile(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "progress.json")
+	os.WriteFile(statePath, []byte("not json"), 0644)
+
+	if _, err := LoadState(statePath, []Rule{{Old: "a", New: "b"}}, ReplaceOptions{}); err == nil {
+		t.Error("expected error for invalid state file")
+	}
+}
+
+func TestReplaceInDirectoryResumesFromState(t *testing.T) {
+	dir := t.TempDir()
+	for i := 1; i <= 3; i++ {
+		copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, fmt.Sprintf("doc%d.docx", i)))
+	}
+	statePath := filepath.Join(t.TempDir(), "progress.json")
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}}
+
+	// Simulate an interrupted run that finished doc1
+	state, err := LoadState(statePath, rules, ReplaceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := state.MarkCompleted(filepath.Join(dir, "doc1.docx"), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, concurrent := range []bool{false, true} {
+		state, err := LoadState(statePath, rules, ReplaceOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := ReplaceOptions{State: state}
+
+		var results []ReplaceResult
+		if concurrent {
+			copts := DefaultConcurrentOptions()
+			copts.Replace = opts
+			results, err = ReplaceInDirectoryConcurrent(dir, rules, false, "", copts)
+		} else {
+			results, err = ReplaceInDirectoryWithOptions(dir, rules, false, "", opts)
+		}
+		if err != nil {
+			t.Fatalf("replace failed (concurrent=%v): %v", concurrent, err)
+		}
+
+		if concurrent {
+			// Everything was completed by the sequential run
+			if len(results) != 0 || state.Skipped() != 3 {
+				t.Errorf("concurrent re-run: got %d results and %d skipped, want 0 and 3", len(results), state.Skipped())
+			}
+			continue
+		}
+
+		if len(results) != 2 || state.Skipped() != 1 {
+			t.Errorf("got %d results and %d skipped, want 2 and 1", len(results), state.Skipped())
+		}
+		for _, name := range []string{"doc1.docx", "doc2.docx", "doc3.docx"} {
+			if !state.IsCompleted(filepath.Join(dir, name)) {
+				t.Errorf("%s should be recorded as completed", name)
+			}
+		}
+	}
+
+	// doc1 was skipped, so it still has the original text
+	checkDocument(t, filepath.Join(dir, "doc1.docx"), "Version 1.0")
+	checkDocument(t, filepath.Join(dir, "doc2.docx"), "Version 2.0")
+}
+
+func TestHashRun(t *testing.T) {
+	rules := []Rule{{Old: "a", New: "b"}}
+	if got := HashRun(rules, ReplaceOptions{Strict: true, FailFast: true}); got != HashRules(rules) {
+		t.Error("options that do not change the output should not change the hash")
+	}
+	hidden := HashRun(rules, ReplaceOptions{IncludeHiddenText: true})
+	if hidden == HashRules(rules) {
+		t.Error("including hidden text should change the hash")
+	}
+	parts := HashRun(rules, ReplaceOptions{Parts: map[string]bool{"body": true}})
+	if parts == HashRules(rules) || parts == hidden {
+		t.Error("selected parts should change the hash")
+	}
+	if HashRun(rules, ReplaceOptions{AllParts: true, Slides: map[int]bool{1: true}}) != HashRun(rules, ReplaceOptions{AllParts: true}) {
+		t.Error("slides are ignored with all parts and should not change the hash")
+	}
+}
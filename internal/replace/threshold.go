@@ -0,0 +1,142 @@
+package replace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+	"github.com/pyhub/pyhub-docs/internal/ui"
+)
+
+// DefaultMaxRuleReplacements is how many replacements one rule may make in a
+// file before it is reported as suspiciously broad
+const DefaultMaxRuleReplacements = 1000
+
+// ErrTooManyReplacements is returned in strict mode when a rule would make
+// more replacements in a file than the limit allows
+var ErrTooManyReplacements = errors.New("rule exceeds the replacement limit")
+
+// CheckReplacementLimit reports the rules whose changes exceed limit, such as
+// a rule replacing a single space or letter. It warns about each one, or with
+// strict returns ErrTooManyReplacements for the first so the file can be left
+// unchanged. A limit of 0 disables the check.
+func CheckReplacementLimit(docPath string, changes []RuleChange, limit int, strict bool) error {
+	if limit <= 0 {
+		return nil
+	}
+	for _, change := range changes {
+		if change.Count <= limit {
+			continue
+		}
+		if strict {
+			return fmt.Errorf("%w: %q would be replaced %d times in %s (limit %d)",
+				ErrTooManyReplacements, change.Rule.Old, change.Count, docPath, limit)
+		}
+		ui.PrintWarning("%s: rule %q makes %d replacements (over %d); check that it is not too broad",
+			docPath, change.Rule.Old, change.Count, limit)
+	}
+	return nil
+}
+
+// checkLargeFileLimit applies CheckReplacementLimit to a file processed by
+// ProcessLargeFile, before anything is written. Streamed files are counted
+// chunk by chunk so they are never loaded whole.
+func checkLargeFileLimit(filePath string, rules []Rule, fileSize int64, opts *LargeFileOptions, streaming bool) error {
+	if opts.MaxRuleReplacements <= 0 {
+		return nil
+	}
+
+	var changes []RuleChange
+	var err error
+	if streaming {
+		changes, err = streamingRuleChanges(filePath, rules, fileSize, opts.Slides)
+	} else {
+		changes, err = documentRuleChanges(filePath, rules, opts.IncludeHiddenText, opts.Parts, opts.Slides)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to count replacements: %w", err)
+	}
+	return CheckReplacementLimit(filePath, changes, opts.MaxRuleReplacements, opts.Strict)
+}
+
+// documentRuleChanges previews the rules on the text of a document opened
+// whole, limited to the selected Word parts or PowerPoint slides
+func documentRuleChanges(filePath string, rules []Rule, includeHidden bool, parts map[string]bool, slides map[int]bool) ([]RuleChange, error) {
+	doc, err := document.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer doc.Close()
+
+	textOf := doc.GetText
+	if wordDoc, ok := doc.(*document.WordDocument); ok {
+		wordDoc.SetIncludeHiddenText(includeHidden)
+		wordDoc.SetParts(parts)
+		textOf = wordDoc.SelectedText
+	}
+	if pptDoc, ok := doc.(*document.PowerPointDocument); ok {
+		textOf = func() (string, error) { return pptDoc.SelectedSlidesText(slides) }
+	}
+	text, err := textOf()
+	if err != nil {
+		return nil, err
+	}
+	return PreviewChanges(text, rules), nil
+}
+
+// streamingRuleChanges counts the replacements each rule would make in the
+// text of a streamed Word or PowerPoint document. A match lying entirely in
+// the text a chunk repeats from the previous one was counted with that chunk
+// and is subtracted again.
+func streamingRuleChanges(filePath string, rules []Rule, fileSize int64, slides map[int]bool) ([]RuleChange, error) {
+	patterns := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		patterns = append(patterns, rule.Old)
+	}
+	counts := make(map[string]int)
+	count := func(chunk string, carried int) error {
+		for _, change := range PreviewChanges(chunk, rules) {
+			counts[change.Rule.Old] += change.Count
+		}
+		for _, change := range PreviewChanges(chunk[:carried], rules) {
+			counts[change.Rule.Old] -= change.Count
+		}
+		return nil
+	}
+
+	streamOpts := document.AdaptiveStreamingOptions(fileSize)
+	if document.IsPowerPointFile(filePath) {
+		doc, err := document.OpenPowerPointDocumentStreaming(filePath, streamOpts)
+		if err != nil {
+			return nil, err
+		}
+		defer doc.Close()
+		err = doc.ProcessSlidesChunkedOverlapped(patterns, func(slideNum int, chunk string, carried int) error {
+			if slides != nil && !slides[slideNum] {
+				return nil
+			}
+			return count(chunk, carried)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		doc, err := document.OpenWordDocumentStreaming(filePath, streamOpts)
+		if err != nil {
+			return nil, err
+		}
+		defer doc.Close()
+		if err := doc.ProcessTextChunkedOverlapped(patterns, count); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []RuleChange
+	for _, rule := range rules {
+		if counts[rule.Old] > 0 {
+			changes = append(changes, RuleChange{Rule: rule, Count: counts[rule.Old]})
+			delete(counts, rule.Old)
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,106 @@
+package replace
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckReplacementLimit(t *testing.T) {
+	changes := []RuleChange{
+		{Rule: Rule{Old: "ACME", New: "Acme"}, Count: 3},
+		{Rule: Rule{Old: " ", New: "_"}, Count: 1500},
+	}
+
+	if err := CheckReplacementLimit("a.docx", changes, 1000, false); err != nil {
+		t.Errorf("non-strict check error = %v, want a warning only", err)
+	}
+	if err := CheckReplacementLimit("a.docx", changes, 1000, true); !errors.Is(err, ErrTooManyReplacements) {
+		t.Errorf("strict check error = %v, want ErrTooManyReplacements", err)
+	}
+	if err := CheckReplacementLimit("a.docx", changes, 0, true); err != nil {
+		t.Errorf("disabled check error = %v", err)
+	}
+	if err := CheckReplacementLimit("a.docx", changes, 1500, true); err != nil {
+		t.Errorf("check at the limit error = %v", err)
+	}
+}
+
+func TestReplaceStrictReplacementLimit(t *testing.T) {
+	docPath := filepath.Join(t.TempDir(), "report.docx")
+	copyFile(t, "testdata/sample_document.docx", docPath)
+	original, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []Rule{{Old: "Version 1.0", New: "Version 2.0"}, {Old: "e", New: "E"}}
+	opts := ReplaceOptions{MaxRuleReplacements: 1, Strict: true}
+	if _, err := ReplaceInDocumentWithOptions(docPath, rules, opts); !errors.Is(err, ErrTooManyReplacements) {
+		t.Fatalf("ReplaceInDocumentWithOptions() error = %v, want ErrTooManyReplacements", err)
+	}
+	if after, _ := os.ReadFile(docPath); !bytes.Equal(after, original) {
+		t.Error("document was changed although a rule exceeded the limit")
+	}
+
+	// Without --strict the rules are applied after the warning
+	opts.Strict = false
+	if _, err := ReplaceInDocumentWithOptions(docPath, rules, opts); err != nil {
+		t.Fatalf("ReplaceInDocumentWithOptions() error = %v", err)
+	}
+	if after, _ := os.ReadFile(docPath); bytes.Equal(after, original) {
+		t.Error("document was not changed without --strict")
+	}
+}
+
+func TestProcessLargeFileReplacementLimit(t *testing.T) {
+	rules := []Rule{{Old: "e", New: "E"}}
+	for _, streaming := range []bool{false, true} {
+		docPath := filepath.Join(t.TempDir(), "report.docx")
+		copyFile(t, "testdata/sample_document.docx", docPath)
+		original, err := os.ReadFile(docPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opts := &LargeFileOptions{EnableStreaming: streaming, MaxRuleReplacements: 1, Strict: true}
+		if _, err := ProcessLargeFile(docPath, rules, opts); !errors.Is(err, ErrTooManyReplacements) {
+			t.Errorf("ProcessLargeFile(streaming %v) error = %v, want ErrTooManyReplacements", streaming, err)
+		}
+		if after, _ := os.ReadFile(docPath); !bytes.Equal(after, original) {
+			t.Errorf("streaming %v: document was changed although a rule exceeded the limit", streaming)
+		}
+
+		opts.MaxRuleReplacements = 0
+		if _, err := ProcessLargeFile(docPath, rules, opts); err != nil {
+			t.Errorf("ProcessLargeFile(streaming %v) without a limit error = %v", streaming, err)
+		}
+	}
+}
+
+func TestReplacementLimitCountsSelectedSlides(t *testing.T) {
+	// "2023" occurs twice, both times on slide 2
+	rules := []Rule{{Old: "2023", New: "2024"}}
+	for _, tt := range []struct {
+		slides  map[int]bool
+		wantErr bool
+	}{
+		{slides: map[int]bool{1: true}, wantErr: false},
+		{slides: map[int]bool{2: true}, wantErr: true},
+	} {
+		docPath := filepath.Join(t.TempDir(), "deck.pptx")
+		copyFile(t, "testdata/sample_presentation.pptx", docPath)
+
+		opts := ReplaceOptions{MaxRuleReplacements: 1, Strict: true, Slides: tt.slides}
+		if _, err := ReplaceInDocumentWithOptions(docPath, rules, opts); errors.Is(err, ErrTooManyReplacements) != tt.wantErr {
+			t.Errorf("ReplaceInDocumentWithOptions(slides %v) error = %v, want limit error %v", tt.slides, err, tt.wantErr)
+		}
+
+		largeOpts := &LargeFileOptions{MaxRuleReplacements: 1, Strict: true, Slides: tt.slides}
+		if _, err := ProcessLargeFile(docPath, rules, largeOpts); errors.Is(err, ErrTooManyReplacements) != tt.wantErr {
+			t.Errorf("ProcessLargeFile(slides %v) error = %v, want limit error %v", tt.slides, err, tt.wantErr)
+		}
+	}
+}
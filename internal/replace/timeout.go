@@ -0,0 +1,109 @@
+package replace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	pkgErrors "github.com/pyhub/pyhub-docs/internal/errors"
+)
+
+// ErrFileTimeout is the cause of the error recorded for a document that takes
+// longer than ReplaceOptions.FileTimeout
+var ErrFileTimeout = errors.New("processing timed out")
+
+// maxAbandonedFiles limits how many timed-out documents may still be
+// processed in the background. Beyond it, a timed-out document is waited for
+// before its worker moves on, so slow documents cannot pile up.
+const maxAbandonedFiles = 4
+
+// abandonedFiles holds a slot for each timed-out document still being
+// processed in the background
+var abandonedFiles = make(chan struct{}, maxAbandonedFiles)
+
+// fileDeadline decides between a document that finished in time and one that
+// was given up on. Once a document is being saved it is no longer given up
+// on, and once given up on it is never saved, so a timed-out document is
+// always left unchanged. A nil *fileDeadline never expires.
+type fileDeadline struct {
+	mu      sync.Mutex
+	expired bool
+	saving  bool
+}
+
+// beginSave reports whether the document may still be saved; from then on
+// the deadline cannot expire
+func (d *fileDeadline) beginSave() bool {
+	if d == nil {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.expired {
+		return false
+	}
+	d.saving = true
+	return true
+}
+
+// expire gives up on the document, unless it is already being saved
+func (d *fileDeadline) expire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.saving {
+		return false
+	}
+	d.expired = true
+	return true
+}
+
+// replaceWithTimeout applies the rules to one document of a directory run
+// under opts.FileTimeout. A document that runs out of time is left unchanged
+// and reported with ErrFileTimeout; its processing is abandoned in the
+// background, as documents cannot be interrupted while they are parsed, up
+// to maxAbandonedFiles at a time.
+func replaceWithTimeout(docPath string, rules []Rule, opts ReplaceOptions) (int, error) {
+	if opts.FileTimeout <= 0 {
+		return ReplaceInDocumentWithOptions(docPath, rules, opts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.FileTimeout)
+	defer cancel()
+
+	type outcome struct {
+		count int
+		err   error
+	}
+	deadline := &fileDeadline{}
+	opts.deadline = deadline
+	done := make(chan outcome, 1)
+	go func() {
+		count, err := ReplaceInDocumentWithOptions(docPath, rules, opts)
+		done <- outcome{count, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.count, out.err
+	case <-ctx.Done():
+		if !deadline.expire() {
+			// Saving has started; a half-written document is worse than a late one
+			out := <-done
+			return out.count, out.err
+		}
+		select {
+		case abandonedFiles <- struct{}{}:
+			go func() {
+				<-done
+				<-abandonedFiles
+			}()
+		default:
+			// Too many documents are abandoned already
+			<-done
+		}
+		return 0, pkgErrors.NewDocumentError(docPath, filepath.Ext(docPath),
+			fmt.Sprintf("processing took longer than %s; the document was left unchanged", opts.FileTimeout), ErrFileTimeout)
+	}
+}
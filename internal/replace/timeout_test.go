@@ -0,0 +1,86 @@
+package replace
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileDeadline(t *testing.T) {
+	var none *fileDeadline
+	if !none.beginSave() {
+		t.Error("a nil deadline should never stop a save")
+	}
+
+	saving := &fileDeadline{}
+	if !saving.beginSave() || saving.expire() {
+		t.Error("a document being saved should not expire")
+	}
+
+	expired := &fileDeadline{}
+	if !expired.expire() || expired.beginSave() {
+		t.Error("an expired document should not be saved")
+	}
+}
+
+func TestReplaceInDirectoryFileTimeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slow.docx")
+	copyFile(t, "testdata/sample_document.docx", path)
+	rules := []Rule{{Old: "Draft", New: "Final"}}
+
+	results, err := ReplaceInDirectoryWithOptions(dir, rules, false, "", ReplaceOptions{FileTimeout: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("ReplaceInDirectoryWithOptions() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Success || !errors.Is(results[0].Error, ErrFileTimeout) {
+		t.Fatalf("results = %+v, want one failure with ErrFileTimeout", results)
+	}
+	checkDocument(t, path, "Status: Draft")
+
+	// A generous timeout changes nothing
+	results, err = ReplaceInDirectoryWithOptions(dir, rules, false, "", ReplaceOptions{FileTimeout: time.Minute})
+	if err != nil || len(results) != 1 || !results[0].Success {
+		t.Fatalf("results = %+v, %v; want one success", results, err)
+	}
+	checkDocument(t, path, "Status: Final")
+}
+
+// waitForAbandoned waits until no timed-out document is processed in the background
+func waitForAbandoned(t *testing.T) {
+	t.Helper()
+
+	for start := time.Now(); len(abandonedFiles) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("%d abandoned documents still running", len(abandonedFiles))
+		}
+	}
+}
+
+func TestReplaceWithTimeoutLimitsAbandoned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow.docx")
+	copyFile(t, "testdata/sample_document.docx", path)
+	rules := []Rule{{Old: "Draft", New: "Final"}}
+	opts := ReplaceOptions{FileTimeout: time.Nanosecond}
+	waitForAbandoned(t)
+
+	// A timed-out document holds a slot until its processing ends
+	if _, err := replaceWithTimeout(path, rules, opts); !errors.Is(err, ErrFileTimeout) {
+		t.Fatalf("replaceWithTimeout() error = %v, want ErrFileTimeout", err)
+	}
+	waitForAbandoned(t)
+
+	// With every slot taken, the timed-out document is waited for instead
+	for i := 0; i < maxAbandonedFiles; i++ {
+		abandonedFiles <- struct{}{}
+	}
+	_, err := replaceWithTimeout(path, rules, opts)
+	for i := 0; i < maxAbandonedFiles; i++ {
+		<-abandonedFiles
+	}
+	if !errors.Is(err, ErrFileTimeout) {
+		t.Fatalf("replaceWithTimeout() error = %v, want ErrFileTimeout", err)
+	}
+	checkDocument(t, path, "Status: Draft")
+}
@@ -0,0 +1,123 @@
+package replace
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Processing phases recorded by Timings. Streaming mode writes while it
+// replaces, so its save time is part of the replace phase.
+const (
+	PhaseOpen    = "open"
+	PhaseReplace = "replace"
+	PhaseSave    = "save"
+)
+
+// phaseOrder lists the known phases in processing order
+var phaseOrder = map[string]int{PhaseOpen: 0, PhaseReplace: 1, PhaseSave: 2}
+
+// PhaseTiming is the time spent in one processing phase across documents
+type PhaseTiming struct {
+	Phase string
+	Total time.Duration
+	Max   time.Duration
+	Count int
+}
+
+// Average returns the mean duration of the phase
+func (p PhaseTiming) Average() time.Duration {
+	if p.Count == 0 {
+		return 0
+	}
+	return p.Total / time.Duration(p.Count)
+}
+
+// Timings accumulates per-phase durations of document processing. It is safe
+// for concurrent use, so a single Timings can aggregate a whole directory
+// run. A nil *Timings records nothing.
+type Timings struct {
+	mu     sync.Mutex
+	phases map[string]*PhaseTiming
+	files  int
+}
+
+// NewTimings creates an empty Timings
+func NewTimings() *Timings {
+	return &Timings{phases: make(map[string]*PhaseTiming)}
+}
+
+// Record adds the duration of one run of a phase
+func (t *Timings) Record(phase string, elapsed time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.phases[phase]
+	if !ok {
+		p = &PhaseTiming{Phase: phase}
+		t.phases[phase] = p
+	}
+	p.Total += elapsed
+	p.Count++
+	if elapsed > p.Max {
+		p.Max = elapsed
+	}
+}
+
+// AddFile counts a processed document
+func (t *Timings) AddFile() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.files++
+	t.mu.Unlock()
+}
+
+// Files returns the number of documents processed
+func (t *Timings) Files() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.files
+}
+
+// Phases returns the recorded phases in processing order
+func (t *Timings) Phases() []PhaseTiming {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	phases := make([]PhaseTiming, 0, len(t.phases))
+	for _, p := range t.phases {
+		phases = append(phases, *p)
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		oi, iKnown := phaseOrder[phases[i].Phase]
+		oj, jKnown := phaseOrder[phases[j].Phase]
+		if iKnown != jKnown {
+			return iKnown
+		}
+		if iKnown && oi != oj {
+			return oi < oj
+		}
+		return phases[i].Phase < phases[j].Phase
+	})
+	return phases
+}
+
+// Total returns the time spent in all phases
+func (t *Timings) Total() time.Duration {
+	var total time.Duration
+	for _, p := range t.Phases() {
+		total += p.Total
+	}
+	return total
+}
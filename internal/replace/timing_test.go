@@ -0,0 +1,67 @@
+package replace
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimings(t *testing.T) {
+	timings := NewTimings()
+	timings.Record("custom", time.Millisecond)
+	timings.Record(PhaseSave, 4*time.Millisecond)
+	timings.Record(PhaseOpen, 2*time.Millisecond)
+	timings.Record(PhaseOpen, 6*time.Millisecond)
+	timings.AddFile()
+	timings.AddFile()
+
+	phases := timings.Phases()
+	var names []string
+	for _, p := range phases {
+		names = append(names, p.Phase)
+	}
+	if len(names) != 3 || names[0] != PhaseOpen || names[1] != PhaseSave || names[2] != "custom" {
+		t.Fatalf("phase order = %v, want [open save custom]", names)
+	}
+
+	open := phases[0]
+	if open.Count != 2 || open.Total != 8*time.Millisecond || open.Max != 6*time.Millisecond || open.Average() != 4*time.Millisecond {
+		t.Errorf("open phase = %+v, average %v", open, open.Average())
+	}
+	if timings.Files() != 2 || timings.Total() != 13*time.Millisecond {
+		t.Errorf("files = %d, total = %v", timings.Files(), timings.Total())
+	}
+}
+
+func TestTimings_Nil(t *testing.T) {
+	var timings *Timings
+	timings.Record(PhaseOpen, time.Second)
+	timings.AddFile()
+	if timings.Files() != 0 || timings.Phases() != nil || timings.Total() != 0 {
+		t.Error("nil Timings should record nothing")
+	}
+}
+
+func TestReplaceInDirectoryWithOptions_Timings(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, "a.docx"))
+	copyFile(t, "testdata/sample_document.docx", filepath.Join(dir, "b.docx"))
+
+	timings := NewTimings()
+	rules := []Rule{{Old: "Hello", New: "Hi"}}
+	if _, err := ReplaceInDirectoryWithOptions(dir, rules, false, "", ReplaceOptions{Timings: timings}); err != nil {
+		t.Fatal(err)
+	}
+
+	if timings.Files() != 2 {
+		t.Errorf("Files() = %d, want 2", timings.Files())
+	}
+	for _, p := range timings.Phases() {
+		if p.Count != 2 {
+			t.Errorf("phase %s recorded %d times, want 2", p.Phase, p.Count)
+		}
+	}
+	if len(timings.Phases()) != 3 {
+		t.Errorf("phases = %+v, want open, replace and save", timings.Phases())
+	}
+}
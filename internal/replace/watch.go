@@ -0,0 +1,234 @@
+package replace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+// DefaultWatchDebounce is how long a file must stay unchanged before it is
+// processed. Editors often save through temporary files and several writes.
+const DefaultWatchDebounce = 500 * time.Millisecond
+
+// WatchOptions controls which files a Watcher reports and when
+type WatchOptions struct {
+	Recursive bool          // Watch subdirectories, including ones created later
+	Exclude   string        // Glob matched against the file name
+	Include   []string      // Globs matched against the file name; empty matches every supported format
+	Debounce  time.Duration // Quiet period before a changed file is reported
+}
+
+// Matches reports whether a changed file should be processed. Only supported
+// document formats are matched; Office lock files (~$name.docx) and hidden
+// files are always ignored.
+func (o WatchOptions) Matches(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, "~$") || strings.HasPrefix(name, ".") {
+		return false
+	}
+	if !document.IsSupportedFormat(path) {
+		return false
+	}
+	if o.Exclude != "" {
+		if matched, err := filepath.Match(o.Exclude, name); err == nil && matched {
+			return false
+		}
+	}
+	if len(o.Include) == 0 {
+		return true
+	}
+	for _, pattern := range o.Include {
+		if matched, err := filepath.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher reports documents under a file or directory once they stop changing
+type Watcher struct {
+	fs   *fsnotify.Watcher
+	file string // set when watching a single file
+	opts WatchOptions
+
+	mu      sync.Mutex
+	timers  map[string]*time.Timer
+	ready   chan string
+	done    chan struct{}
+	handled map[string]time.Time // modification time after the last run
+}
+
+// NewWatcher starts watching root, which may be a document or a directory.
+// A single document is watched through its directory so that editors which
+// save by replacing the file are still noticed.
+func NewWatcher(root string, opts WatchOptions) (*Watcher, error) {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultWatchDebounce
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	w := &Watcher{
+		fs:      fsw,
+		opts:    opts,
+		timers:  make(map[string]*time.Timer),
+		ready:   make(chan string),
+		done:    make(chan struct{}),
+		handled: make(map[string]time.Time),
+	}
+
+	if info.IsDir() {
+		err = w.addDir(root)
+	} else {
+		w.file = filepath.Clean(root)
+		err = fsw.Add(filepath.Dir(w.file))
+	}
+	if err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", root, err)
+	}
+	return w, nil
+}
+
+// addDir watches dir and, when recursive, its non-hidden subdirectories
+func (w *Watcher) addDir(dir string) error {
+	if !w.opts.Recursive {
+		return w.fs.Add(dir)
+	}
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		return w.fs.Add(path)
+	})
+}
+
+// Run calls handle for each changed document until stop is closed or the
+// watcher fails. Files are handled one at a time on the calling goroutine.
+// Changes made by handle itself do not trigger another call.
+func (w *Watcher) Run(stop <-chan struct{}, handle func(path string)) error {
+	defer w.stopTimers()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(event)
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("file watcher failed: %w", err)
+
+		case path := <-w.ready:
+			info, err := os.Stat(path)
+			if err != nil || info.IsDir() {
+				continue // Removed or renamed away before it settled
+			}
+			if last, ok := w.handled[path]; ok && info.ModTime().Equal(last) {
+				continue // Our own save, or a write that changed nothing
+			}
+			handle(path)
+			if info, err := os.Stat(path); err == nil {
+				w.handled[path] = info.ModTime()
+			}
+		}
+	}
+}
+
+// handleEvent schedules matching files and starts watching new directories
+func (w *Watcher) handleEvent(event fsnotify.Event) {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+		return
+	}
+	path := filepath.Clean(event.Name)
+
+	if w.file != "" {
+		if path == w.file {
+			w.schedule(path)
+		}
+		return
+	}
+
+	if event.Has(fsnotify.Create) && w.opts.Recursive {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			if !strings.HasPrefix(info.Name(), ".") {
+				w.addDir(path)
+			}
+			return
+		}
+	}
+
+	if w.opts.Matches(path) {
+		w.schedule(path)
+	}
+}
+
+// schedule (re)starts the debounce timer for path
+func (w *Watcher) schedule(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if timer, ok := w.timers[path]; ok {
+		timer.Reset(w.opts.Debounce)
+		return
+	}
+	w.timers[path] = time.AfterFunc(w.opts.Debounce, func() {
+		w.mu.Lock()
+		delete(w.timers, path)
+		w.mu.Unlock()
+
+		select {
+		case w.ready <- path:
+		case <-w.done:
+		}
+	})
+}
+
+// stopTimers cancels pending timers and releases timers already firing
+func (w *Watcher) stopTimers() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for path, timer := range w.timers {
+		timer.Stop()
+		delete(w.timers, path)
+	}
+	select {
+	case <-w.done:
+	default:
+		close(w.done)
+	}
+}
+
+// Close stops watching
+func (w *Watcher) Close() error {
+	w.stopTimers()
+	return w.fs.Close()
+}
@@ -0,0 +1,127 @@
+package replace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchOptions_Matches(t *testing.T) {
+	tests := []struct {
+		name string
+		opts WatchOptions
+		path string
+		want bool
+	}{
+		{"supported format", WatchOptions{}, "docs/report.docx", true},
+		{"unsupported format", WatchOptions{}, "docs/notes.txt", false},
+		{"editor temp file", WatchOptions{}, "docs/report.docx.tmp", false},
+		{"office lock file", WatchOptions{}, "docs/~$report.docx", false},
+		{"hidden file", WatchOptions{}, "docs/.report.docx", false},
+		{"excluded", WatchOptions{Exclude: "draft_*"}, "docs/draft_report.docx", false},
+		{"included", WatchOptions{Include: []string{"*.pptx", "*.docx"}}, "docs/report.docx", true},
+		{"not included", WatchOptions{Include: []string{"*.pptx"}}, "docs/report.docx", false},
+		{"exclude wins over include", WatchOptions{Include: []string{"*.docx"}, Exclude: "draft_*"}, "draft_a.docx", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Matches(tt.path); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(dir, WatchOptions{Recursive: true, Debounce: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+	defer w.Close()
+
+	handled := make(chan string, 10)
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(stop, func(path string) {
+			// Saving from the handler must not trigger another run
+			os.WriteFile(path, []byte("processed"), 0644)
+			handled <- path
+		})
+	}()
+
+	// Several quick writes are handled once
+	doc := filepath.Join(sub, "report.docx")
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(doc, []byte("draft"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644)
+
+	select {
+	case path := <-handled:
+		if path != doc {
+			t.Errorf("handled %q, want %q", path, doc)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("changed document was not handled")
+	}
+
+	select {
+	case path := <-handled:
+		t.Errorf("unexpected second run for %q", path)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	close(stop)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after stop")
+	}
+}
+
+func TestWatcher_SingleFile(t *testing.T) {
+	dir := t.TempDir()
+	doc := filepath.Join(dir, "report.docx")
+	if err := os.WriteFile(doc, []byte("draft"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(doc, WatchOptions{Debounce: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	handled := make(chan string, 10)
+	stop := make(chan struct{})
+	defer close(stop)
+	go w.Run(stop, func(path string) { handled <- path })
+
+	// Other documents in the same directory are not reported
+	os.WriteFile(filepath.Join(dir, "other.docx"), []byte("x"), 0644)
+	os.WriteFile(doc, []byte("edited"), 0644)
+
+	select {
+	case path := <-handled:
+		if path != doc {
+			t.Errorf("handled %q, want %q", path, doc)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watched file was not handled")
+	}
+}
@@ -0,0 +1,66 @@
+package retry
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Named retry presets. Each maps to a complete Config:
+//
+//	none:         no retries
+//	conservative: 2 retries, 2s initial delay, 30s max delay, x2 backoff, jitter
+//	aggressive:   6 retries, 500ms initial delay, 20s max delay, x1.5 backoff, jitter
+//
+// Conservative waits longer between fewer attempts, which suits shared API
+// quotas; aggressive retries quickly and often, which suits interactive use
+// with transient network errors.
+const (
+	PresetNone         = "none"
+	PresetConservative = "conservative"
+	PresetAggressive   = "aggressive"
+)
+
+var presets = map[string]Config{
+	PresetNone: {
+		MaxRetries:   0,
+		InitialDelay: 0,
+		MaxDelay:     0,
+		Multiplier:   1.0,
+		Jitter:       false,
+	},
+	PresetConservative: {
+		MaxRetries:   2,
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2.0,
+		Jitter:       true,
+	},
+	PresetAggressive: {
+		MaxRetries:   6,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     20 * time.Second,
+		Multiplier:   1.5,
+		Jitter:       true,
+	},
+}
+
+// Preset returns the configuration of a named preset. The name is matched
+// case-insensitively; ok is false for unknown names.
+func Preset(name string) (config Config, ok bool) {
+	config, ok = presets[strings.ToLower(strings.TrimSpace(name))]
+	if ok {
+		config.RetryableCheck = DefaultRetryableCheck
+	}
+	return config, ok
+}
+
+// PresetNames returns the names of all presets in sorted order
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,52 @@
+package retry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPreset(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   int
+		initialDelay time.Duration
+		maxDelay     time.Duration
+		multiplier   float64
+		jitter       bool
+	}{
+		{PresetNone, 0, 0, 0, 1.0, false},
+		{PresetConservative, 2, 2 * time.Second, 30 * time.Second, 2.0, true},
+		{PresetAggressive, 6, 500 * time.Millisecond, 20 * time.Second, 1.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, ok := Preset(tt.name)
+			if !ok {
+				t.Fatalf("Preset(%q) not found", tt.name)
+			}
+			if config.MaxRetries != tt.maxRetries || config.InitialDelay != tt.initialDelay ||
+				config.MaxDelay != tt.maxDelay || config.Multiplier != tt.multiplier || config.Jitter != tt.jitter {
+				t.Errorf("Preset(%q) = %+v", tt.name, config)
+			}
+			if config.RetryableCheck == nil {
+				t.Error("preset should use the default retryable check")
+			}
+		})
+	}
+
+	if _, ok := Preset(" Aggressive "); !ok {
+		t.Error("preset names should be matched case-insensitively")
+	}
+	if _, ok := Preset("reckless"); ok {
+		t.Error("unknown preset should not be found")
+	}
+}
+
+func TestPresetNames(t *testing.T) {
+	want := []string{PresetAggressive, PresetConservative, PresetNone}
+	if got := PresetNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PresetNames() = %v, want %v", got, want)
+	}
+}
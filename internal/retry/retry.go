@@ -18,6 +18,7 @@ type Config struct {
 	Multiplier     float64       // Exponential backoff multiplier
 	Jitter         bool          // Add random jitter to delays
 	RetryableCheck func(error) bool // Custom function to determine if error is retryable
+	OnRetry        func(attempt int, err error, delay time.Duration) // Called before waiting to retry; attempt is the failed attempt, starting at 1
 }
 
 // DefaultConfig returns default retry configuration
@@ -131,6 +132,9 @@ func Do(ctx context.Context, config Config, fn func() error) error {
 		
 		// Calculate delay
 		delay := calculateDelay(attempt, config)
+		if config.OnRetry != nil {
+			config.OnRetry(attempt+1, err, delay)
+		}
 		
 		// Wait before retry
 		select {
@@ -173,6 +177,9 @@ func DoWithResult[T any](ctx context.Context, config Config, fn func() (T, error
 		
 		// Calculate delay
 		delay := calculateDelay(attempt, config)
+		if config.OnRetry != nil {
+			config.OnRetry(attempt+1, err, delay)
+		}
 		
 		// Wait before retry
 		select {
@@ -202,6 +202,47 @@ func TestDoWithResult(t *testing.T) {
 	})
 }
 
+func TestOnRetry(t *testing.T) {
+	var attempts []int
+	var waited time.Duration
+	config := Config{
+		MaxRetries:     3,
+		InitialDelay:   1 * time.Millisecond,
+		MaxDelay:       10 * time.Millisecond,
+		Multiplier:     2.0,
+		RetryableCheck: DefaultRetryableCheck,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			attempts = append(attempts, attempt)
+			waited += delay
+		},
+	}
+
+	calls := 0
+	_, err := DoWithResult(context.Background(), config, func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("connection refused")
+		}
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("DoWithResult() unexpected error: %v", err)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", attempts)
+	}
+	if waited != 3*time.Millisecond {
+		t.Errorf("OnRetry delays sum to %v, want 3ms", waited)
+	}
+
+	// A non-retryable error is returned without calling the hook
+	attempts = nil
+	Do(context.Background(), config, func() error { return errors.New("invalid request") })
+	if len(attempts) != 0 {
+		t.Errorf("OnRetry called %d times for a non-retryable error", len(attempts))
+	}
+}
+
 func TestDoWithContext(t *testing.T) {
 	config := Config{
 		MaxRetries:   5,
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ReadAPIKeyFile reads an API key from a file, trimming surrounding whitespace.
+// A leading "~/" is expanded to the home directory. The key itself never
+// appears in returned errors.
+func ReadAPIKeyFile(path string) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read API key file %s: %w", path, err)
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+	if strings.ContainsAny(key, "\r\n") {
+		return "", fmt.Errorf("API key file %q must contain a single key on one line", path)
+	}
+
+	// Key files readable by other users defeat the purpose of keeping keys off the command line
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0044 != 0 {
+			Warnf("API key file %s is readable by other users (%v), consider chmod 600", path, info.Mode().Perm())
+		}
+	}
+
+	return key, nil
+}
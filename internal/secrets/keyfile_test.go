@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadAPIKeyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "openai.key")
+	os.WriteFile(valid, []byte("  sk-test1234567890abcdef\n"), 0600)
+
+	key, err := ReadAPIKeyFile(valid)
+	if err != nil {
+		t.Fatalf("ReadAPIKeyFile failed: %v", err)
+	}
+	if key != "sk-test1234567890abcdef" {
+		t.Errorf("key = %q, want trimmed key", key)
+	}
+
+	empty := filepath.Join(dir, "empty.key")
+	os.WriteFile(empty, []byte(" \n"), 0600)
+	if _, err := ReadAPIKeyFile(empty); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Errorf("expected empty file error, got %v", err)
+	}
+
+	multi := filepath.Join(dir, "multi.key")
+	os.WriteFile(multi, []byte("sk-first\nsk-second-secret\n"), 0600)
+	_, err = ReadAPIKeyFile(multi)
+	if err == nil {
+		t.Fatal("expected error for multi-line key file")
+	}
+	if strings.Contains(err.Error(), "sk-second-secret") {
+		t.Error("error message must not contain the key")
+	}
+
+	if _, err := ReadAPIKeyFile(filepath.Join(dir, "missing.key")); err == nil || !strings.Contains(err.Error(), "cannot read API key file") {
+		t.Errorf("expected unreadable file error, got %v", err)
+	}
+}
@@ -230,4 +230,8 @@ func Warnf(format string, v ...interface{}) {
 // Errorf logs an error message after sanitizing
 func Errorf(format string, v ...interface{}) {
 	DefaultSecureLogger.Printf("[ERROR] "+format, v...)
-}
\ No newline at end of file
+}
+// Sanitize masks API keys, tokens and other secrets found in message
+func Sanitize(message string) string {
+	return DefaultSecureLogger.sanitize(message)
+}
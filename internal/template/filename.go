@@ -0,0 +1,106 @@
+package template
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FilenameTemplate builds output file names from a pattern such as
+// "letter_{{name}}_{{date}}.docx". Placeholder values come from each item,
+// plus the built-in values {{index}} (1-based item number) and {{date}}
+// (today as YYYY-MM-DD), which item values override.
+//
+// Only substituted values are sanitized; directories written in the pattern
+// itself are kept. Names repeated within one FilenameTemplate get a numeric
+// suffix: report.docx, report_2.docx, report_3.docx.
+type FilenameTemplate struct {
+	pattern string
+	parser  *Parser
+	used    map[string]bool
+}
+
+// filenameNow returns the time used for {{date}}; replaced in tests
+var filenameNow = time.Now
+
+// NewFilenameTemplate creates a filename template from pattern
+func NewFilenameTemplate(pattern string) (*FilenameTemplate, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, fmt.Errorf("output template cannot be empty")
+	}
+	return &FilenameTemplate{
+		pattern: pattern,
+		parser:  NewParser(),
+		used:    make(map[string]bool),
+	}, nil
+}
+
+// Resolve returns the file name for the item with the given 1-based index
+func (f *FilenameTemplate) Resolve(index int, values map[string]interface{}) (string, error) {
+	builtins := map[string]interface{}{
+		"index": index,
+		"date":  filenameNow().Format("2006-01-02"),
+	}
+
+	name := f.pattern
+	placeholders := f.parser.FindPlaceholders(f.pattern)
+	for i := len(placeholders) - 1; i >= 0; i-- {
+		placeholder := placeholders[i]
+		value, ok := lookupValue(placeholder.Name, values)
+		if !ok {
+			value, ok = builtins[placeholder.Name]
+		}
+		if !ok {
+			return "", fmt.Errorf("no value for %s in output template %q", placeholder.Expression, f.pattern)
+		}
+		safe := SanitizeFilename(f.parser.formatValue(value))
+		name = name[:placeholder.Position] + safe + name[placeholder.Position+len(placeholder.Expression):]
+	}
+
+	return f.unique(filepath.Clean(name)), nil
+}
+
+// unique appends _2, _3, ... before the extension of a name already returned.
+// Names are compared case-insensitively, as on Windows and macOS.
+func (f *FilenameTemplate) unique(name string) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for n := 2; f.used[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s_%d%s", base, n, ext)
+	}
+	f.used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// reservedFilenames cannot be used as file names on Windows
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SanitizeFilename makes a value safe to use within a file name: path
+// separators, characters not allowed on Windows and control characters
+// become "_", and leading or trailing spaces and dots are removed
+func SanitizeFilename(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			b.WriteRune('_')
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	name := strings.Trim(b.String(), " .")
+	if name == "" {
+		return "_"
+	}
+	if reservedFilenames[strings.ToUpper(name)] {
+		return "_" + name
+	}
+	return name
+}
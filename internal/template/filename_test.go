@@ -0,0 +1,79 @@
+package template
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilenameTemplate_Resolve(t *testing.T) {
+	filenameNow = func() time.Time { return time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC) }
+	defer func() { filenameNow = time.Now }()
+
+	names, err := NewFilenameTemplate("out/letter_{{name}}_{{date}}.docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		values map[string]interface{}
+		want   string
+	}{
+		{map[string]interface{}{"name": "Kim"}, "out/letter_Kim_2024-05-01.docx"},
+		{map[string]interface{}{"name": "../../etc/passwd"}, "out/letter__.._etc_passwd_2024-05-01.docx"},
+		{map[string]interface{}{"name": "Kim"}, "out/letter_Kim_2024-05-01_2.docx"},
+		{map[string]interface{}{"name": "KIM"}, "out/letter_KIM_2024-05-01_3.docx"},
+		{map[string]interface{}{"name": "Lee", "date": "Q2"}, "out/letter_Lee_Q2.docx"},
+	}
+	for i, tt := range tests {
+		got, err := names.Resolve(i+1, tt.values)
+		if err != nil {
+			t.Fatalf("Resolve(%v) error = %v", tt.values, err)
+		}
+		if got != filepath.FromSlash(tt.want) {
+			t.Errorf("Resolve(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestFilenameTemplate_ResolveBuiltinsAndErrors(t *testing.T) {
+	names, _ := NewFilenameTemplate("{{index}}-{{customer.name}}.pptx")
+
+	got, err := names.Resolve(3, map[string]interface{}{
+		"customer": map[string]interface{}{"name": "ACME"},
+	})
+	if err != nil || got != "3-ACME.pptx" {
+		t.Errorf("Resolve() = %q, %v, want 3-ACME.pptx", got, err)
+	}
+
+	if _, err := names.Resolve(4, nil); err == nil || !strings.Contains(err.Error(), "{{customer.name}}") {
+		t.Errorf("Resolve() error = %v, want missing placeholder", err)
+	}
+
+	if _, err := NewFilenameTemplate("  "); err == nil {
+		t.Error("expected error for empty template")
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Jane Doe", "Jane Doe"},
+		{`a/b\\c`, "a_b__c"},
+		{`what? "now" <x>|y*:z`, "what_ _now_ _x__y__z"},
+		{"tab\there", "tab_here"},
+		{" ..hidden. ", "hidden"},
+		{"...", "_"},
+		{"", "_"},
+		{"con", "_con"},
+		{"한글 이름", "한글 이름"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeFilename(tt.value); got != tt.want {
+			t.Errorf("SanitizeFilename(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+// Template engines
+const (
+	// EngineSimple replaces {{name}} placeholders in place, keeping the
+	// formatting of every run
+	EngineSimple = "simple"
+	// EngineGoTemplate runs the document text through Go's text/template,
+	// allowing conditionals, ranges and functions. Paragraphs whose text
+	// changes keep only the formatting of their first run.
+	EngineGoTemplate = "gotemplate"
+)
+
+// Engines lists the supported template engines
+var Engines = []string{EngineSimple, EngineGoTemplate}
+
+// ValidateEngine checks that name is a supported template engine
+func ValidateEngine(name string) error {
+	for _, engine := range Engines {
+		if name == engine {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown template engine %q (supported: %s)", name, strings.Join(Engines, ", "))
+}
+
+// Paragraph markers. Each paragraph's text is prefixed with
+// paragraphStart, its index and paragraphIndexEnd before the document is
+// executed, so paragraphs repeated by a range or removed by an if can be
+// traced back to the paragraph they came from.
+const (
+	paragraphStart    = "\x1e"
+	paragraphIndexEnd = "\x1f"
+)
+
+// controlActionRegex matches actions that only control flow, such as
+// {{range .items}}, {{else}}, {{end}} or comments
+var controlActionRegex = regexp.MustCompile(`\{\{-?\s*(?:(?:if|else|end|range|with|break|continue)\b|/\*)[^}]*\}\}`)
+
+// paragraphMarkerRegex matches the marker placed before each paragraph
+var paragraphMarkerRegex = regexp.MustCompile(paragraphStart + `\d+` + paragraphIndexEnd)
+
+// isControlParagraph reports whether a paragraph holds nothing but control
+// actions. Such paragraphs are dropped when they render empty, so a range
+// written as {{range}}, body and {{end}} paragraphs leaves no blank lines.
+func isControlParagraph(text string) bool {
+	stripped := controlActionRegex.ReplaceAllString(text, "")
+	return stripped != text && strings.TrimSpace(stripped) == ""
+}
+
+// renderGoTemplate executes the paragraph texts of a document part as one Go
+// template and returns the resulting paragraphs. Actions may span several
+// paragraphs, e.g. a range repeats every paragraph between {{range}} and
+// {{end}}, but a single {{...}} action must sit within one paragraph.
+// Referencing a value that is not defined is an error.
+func renderGoTemplate(name string, texts []string, values map[string]interface{}) ([]document.RenderedParagraph, error) {
+	var source strings.Builder
+	for i, text := range texts {
+		source.WriteString(paragraphStart + strconv.Itoa(i) + paragraphIndexEnd + text)
+	}
+
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(source.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", cleanMarkers(err))
+	}
+	var output strings.Builder
+	if err := tmpl.Execute(&output, values); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", cleanMarkers(err))
+	}
+
+	var rendered []document.RenderedParagraph
+	for _, part := range strings.Split(output.String(), paragraphStart) {
+		indexText, text, found := strings.Cut(part, paragraphIndexEnd)
+		if !found {
+			// Only output before the first paragraph, which is always empty
+			continue
+		}
+		index, err := strconv.Atoi(indexText)
+		if err != nil || index < 0 || index >= len(texts) {
+			return nil, errors.New("template output lost its paragraph structure")
+		}
+		if text == "" && isControlParagraph(texts[index]) {
+			continue
+		}
+		rendered = append(rendered, document.RenderedParagraph{Source: index, Text: text})
+	}
+	return rendered, nil
+}
+
+// cleanMarkers makes paragraph markers in template errors readable
+func cleanMarkers(err error) error {
+	return errors.New(paragraphMarkerRegex.ReplaceAllString(err.Error(), "¶"))
+}
+
+// processWordGoTemplate renders the body of a Word document with the Go
+// template engine
+func processWordGoTemplate(doc *document.WordDocument, values map[string]interface{}) error {
+	texts, err := doc.ParagraphTexts()
+	if err != nil {
+		return err
+	}
+	rendered, err := renderGoTemplate("document", texts, values)
+	if err != nil {
+		return err
+	}
+	return doc.RewriteParagraphs(rendered)
+}
+
+// processPowerPointGoTemplate renders every slide of a presentation with the
+// Go template engine. Each slide is a separate template, so actions cannot
+// span slides.
+func processPowerPointGoTemplate(doc *document.PowerPointDocument, values map[string]interface{}) error {
+	for _, number := range doc.SlideNumbers() {
+		texts, err := doc.SlideParagraphTexts(number)
+		if err != nil {
+			return err
+		}
+		rendered, err := renderGoTemplate(fmt.Sprintf("slide %d", number), texts, values)
+		if err != nil {
+			return fmt.Errorf("slide %d: %w", number, err)
+		}
+		if err := doc.RewriteSlideParagraphs(number, rendered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestRenderGoTemplate(t *testing.T) {
+	values := map[string]interface{}{
+		"title": "Q4 Report",
+		"draft": false,
+		"items": []interface{}{"Revenue", "Costs"},
+	}
+
+	t.Run("range over a slice repeats paragraphs", func(t *testing.T) {
+		texts := []string{"{{.title}}", "{{range .items}}", "- {{.}}", "{{end}}", "Done"}
+		rendered, err := renderGoTemplate("document", texts, values)
+		if err != nil {
+			t.Fatalf("renderGoTemplate() error = %v", err)
+		}
+		want := []document.RenderedParagraph{
+			{Source: 0, Text: "Q4 Report"},
+			{Source: 2, Text: "- Revenue"},
+			{Source: 2, Text: "- Costs"},
+			{Source: 4, Text: "Done"},
+		}
+		if !reflect.DeepEqual(rendered, want) {
+			t.Errorf("renderGoTemplate() = %+v, want %+v", rendered, want)
+		}
+	})
+
+	t.Run("range within one paragraph", func(t *testing.T) {
+		rendered, err := renderGoTemplate("document", []string{"Topics: {{range $i, $item := .items}}{{if $i}}, {{end}}{{$item}}{{end}}"}, values)
+		if err != nil {
+			t.Fatalf("renderGoTemplate() error = %v", err)
+		}
+		if len(rendered) != 1 || rendered[0].Text != "Topics: Revenue, Costs" {
+			t.Errorf("renderGoTemplate() = %+v", rendered)
+		}
+	})
+
+	t.Run("false condition removes paragraphs", func(t *testing.T) {
+		texts := []string{"{{if .draft}}", "DRAFT", "{{end}}", "", "Body"}
+		rendered, err := renderGoTemplate("document", texts, values)
+		if err != nil {
+			t.Fatalf("renderGoTemplate() error = %v", err)
+		}
+		// Empty paragraphs that are not control paragraphs are kept
+		want := []document.RenderedParagraph{{Source: 3, Text: ""}, {Source: 4, Text: "Body"}}
+		if !reflect.DeepEqual(rendered, want) {
+			t.Errorf("renderGoTemplate() = %+v, want %+v", rendered, want)
+		}
+	})
+
+	t.Run("undefined value is an error", func(t *testing.T) {
+		_, err := renderGoTemplate("document", []string{"Dear {{.name}}"}, values)
+		if err == nil || !strings.Contains(err.Error(), "name") {
+			t.Errorf("expected an error naming the missing value, got %v", err)
+		}
+	})
+
+	t.Run("parse errors hide paragraph markers", func(t *testing.T) {
+		_, err := renderGoTemplate("document", []string{"{{range .items}}", "{{.}}"}, values)
+		if err == nil {
+			t.Fatal("expected an error for an unclosed range")
+		}
+		if strings.ContainsAny(err.Error(), paragraphStart+paragraphIndexEnd) {
+			t.Errorf("error contains paragraph markers: %q", err)
+		}
+	})
+}
+
+func TestIsControlParagraph(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"{{range .items}}", true},
+		{" {{- end -}} ", true},
+		{"{{else}}{{/* note */}}", true},
+		{"{{if .a}}x{{end}}", false},
+		{"{{.title}}", false},
+		{"{{ending}}", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isControlParagraph(tt.text); got != tt.want {
+			t.Errorf("isControlParagraph(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestValidateEngine(t *testing.T) {
+	for _, engine := range Engines {
+		if err := ValidateEngine(engine); err != nil {
+			t.Errorf("ValidateEngine(%q) error = %v", engine, err)
+		}
+	}
+	if err := ValidateEngine("jinja"); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
@@ -0,0 +1,83 @@
+package template
+
+import "fmt"
+
+// List styles for list-valued placeholders
+const (
+	ListStyleBullet   = "bullet"
+	ListStyleNumbered = "numbered"
+)
+
+// bulletPrefix is prepended to each item of a bulleted list
+const bulletPrefix = "• "
+
+// ListValue is a placeholder value rendered as one paragraph per item.
+// In a values file it is either a plain list or a map with "items" and an
+// optional "style" of "bullet" (default) or "numbered":
+//
+//	features:
+//	  style: numbered
+//	  items: ["Fast", "Simple"]
+type ListValue struct {
+	Items []string
+	Style string
+}
+
+// Lines returns the items with their bullet or number prefix
+func (l ListValue) Lines() []string {
+	lines := make([]string, len(l.Items))
+	for i, item := range l.Items {
+		if l.Style == ListStyleNumbered {
+			lines[i] = fmt.Sprintf("%d. %s", i+1, item)
+		} else {
+			lines[i] = bulletPrefix + item
+		}
+	}
+	return lines
+}
+
+// ListValueFor returns the list value for a placeholder, if its value is a list
+func (p *Parser) ListValueFor(name string, values map[string]interface{}) (ListValue, bool) {
+	val, ok := lookupValue(name, values)
+	if !ok {
+		return ListValue{}, false
+	}
+
+	switch v := val.(type) {
+	case []interface{}, []string:
+		items, _ := listItems(v, p)
+		return ListValue{Items: items, Style: ListStyleBullet}, true
+	case map[string]interface{}:
+		return toListValue(v, p)
+	}
+	return ListValue{}, false
+}
+
+// toListValue converts a map with an "items" list and optional "style" into a list value
+func toListValue(m map[string]interface{}, p *Parser) (ListValue, bool) {
+	items, ok := listItems(m["items"], p)
+	if !ok {
+		return ListValue{}, false
+	}
+
+	style := ListStyleBullet
+	if s, ok := m["style"].(string); ok && (s == ListStyleNumbered || s == "ordered") {
+		style = ListStyleNumbered
+	}
+	return ListValue{Items: items, Style: style}, true
+}
+
+// listItems formats the elements of a slice value as strings
+func listItems(value interface{}, p *Parser) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = p.formatValue(item)
+		}
+		return items, true
+	}
+	return nil, false
+}
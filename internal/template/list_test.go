@@ -0,0 +1,115 @@
+package template
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pyhub/pyhub-docs/internal/document"
+)
+
+func TestListValueFor(t *testing.T) {
+	parser := NewParser()
+	values := map[string]interface{}{
+		"items": []interface{}{"apple", "banana"},
+		"steps": map[string]interface{}{
+			"style": "numbered",
+			"items": []interface{}{"Plan", "Build", 3},
+		},
+		"author": map[string]interface{}{"name": "Jane"},
+		"title":  "Report",
+	}
+
+	list, ok := parser.ListValueFor("items", values)
+	if !ok || !reflect.DeepEqual(list.Lines(), []string{"• apple", "• banana"}) {
+		t.Errorf("items: got %v (ok=%v)", list.Lines(), ok)
+	}
+
+	list, ok = parser.ListValueFor("steps", values)
+	if !ok || !reflect.DeepEqual(list.Lines(), []string{"1. Plan", "2. Build", "3. 3"}) {
+		t.Errorf("steps: got %v (ok=%v)", list.Lines(), ok)
+	}
+
+	for _, name := range []string{"author", "title", "missing"} {
+		if _, ok := parser.ListValueFor(name, values); ok {
+			t.Errorf("%s should not be a list value", name)
+		}
+	}
+
+	// List metadata renders inline like a plain array
+	if got := parser.ReplacePlaceholders("Steps: {{steps}}", values); got != "Steps: Plan, Build, 3" {
+		t.Errorf("inline list = %q", got)
+	}
+
+	// List values are never reported as missing
+	if missing := parser.ValidatePlaceholders("{{items}} {{steps}}", values); len(missing) != 0 {
+		t.Errorf("unexpected missing placeholders: %v", missing)
+	}
+}
+
+// writeTemplateDocx creates a minimal .docx whose body is the given paragraphs
+func writeTemplateDocx(t *testing.T, path string, paragraphs ...string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	parts := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="xml" ContentType="application/xml"/></Types>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8"?><w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			strings.Join(paragraphs, "") + `</w:body></w:document>`,
+	}
+	for name, content := range parts {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWordProcessorRendersLists(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "template.docx")
+	outputPath := filepath.Join(dir, "output.docx")
+
+	writeTemplateDocx(t, templatePath,
+		`<w:p><w:r><w:t>Features:</w:t></w:r></w:p>`,
+		`<w:p><w:pPr><w:pStyle w:val="ListParagraph"/></w:pPr><w:r><w:t>{{features}}</w:t></w:r></w:p>`,
+		`<w:p><w:r><w:t>Inline: {{features}}</w:t></w:r></w:p>`,
+	)
+
+	values := map[string]interface{}{
+		"features": map[string]interface{}{
+			"style": "numbered",
+			"items": []interface{}{"Fast", "Simple"},
+		},
+	}
+
+	processor := NewWordProcessor()
+	if err := processor.ProcessTemplate(templatePath, values, outputPath); err != nil {
+		t.Fatalf("ProcessTemplate failed: %v", err)
+	}
+
+	doc, err := document.OpenWordDocument(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	defer doc.Close()
+
+	paragraphs := doc.GetTextParagraphs()
+	expected := []string{"Features:", "1. Fast", "2. Simple", "Inline: Fast, Simple"}
+	if !reflect.DeepEqual(paragraphs, expected) {
+		t.Errorf("paragraphs = %q, want %q", paragraphs, expected)
+	}
+}
@@ -12,6 +12,9 @@ type Placeholder struct {
 	Name       string
 	Expression string // Full expression including {{ }}
 	Position   int    // Position in text
+	// ContentType is the optional hint after a colon, as in {{intro:blog}},
+	// naming the kind of content to generate for the placeholder
+	ContentType string
 }
 
 // Parser handles template parsing and placeholder extraction
@@ -21,9 +24,10 @@ type Parser struct {
 
 // NewParser creates a new template parser
 func NewParser() *Parser {
-	// Pattern to match {{placeholder_name}} format
-	// Supports alphanumeric, underscore, dash, and dot
-	pattern := regexp.MustCompile(`\{\{([a-zA-Z0-9_\-\.]+)\}\}`)
+	// Pattern to match {{placeholder_name}} format, optionally followed by a
+	// content-type hint: {{placeholder_name:type}}
+	// Names support alphanumeric, underscore, dash, and dot
+	pattern := regexp.MustCompile(`\{\{([a-zA-Z0-9_\-\.]+)(?::([a-zA-Z]+))?\}\}`)
 	return &Parser{
 		placeholderPattern: pattern,
 	}
@@ -39,17 +43,41 @@ func (p *Parser) FindPlaceholders(text string) []Placeholder {
 		// match[2] and match[3] are the start and end of the first capturing group
 		fullMatch := text[match[0]:match[1]]
 		placeholderName := text[match[2]:match[3]]
+		// match[4] and match[5] are the content-type hint, -1 when absent
+		contentType := ""
+		if match[4] >= 0 {
+			contentType = strings.ToLower(text[match[4]:match[5]])
+		}
 		
 		placeholders = append(placeholders, Placeholder{
-			Name:       placeholderName,
-			Expression: fullMatch,
-			Position:   match[0],
+			Name:        placeholderName,
+			Expression:  fullMatch,
+			Position:    match[0],
+			ContentType: contentType,
 		})
 	}
 	
 	return placeholders
 }
 
+// UniquePlaceholders returns the first occurrence of each placeholder name.
+// A name used both with and without a content-type hint keeps the first
+// hint given.
+func UniquePlaceholders(placeholders []Placeholder) []Placeholder {
+	index := make(map[string]int)
+	unique := make([]Placeholder, 0)
+	for _, p := range placeholders {
+		i, seen := index[p.Name]
+		if !seen {
+			index[p.Name] = len(unique)
+			unique = append(unique, p)
+		} else if unique[i].ContentType == "" {
+			unique[i].ContentType = p.ContentType
+		}
+	}
+	return unique
+}
+
 // ReplacePlaceholders replaces placeholders in text with provided values
 func (p *Parser) ReplacePlaceholders(text string, values map[string]interface{}) string {
 	result := text
@@ -62,7 +90,7 @@ func (p *Parser) ReplacePlaceholders(text string, values map[string]interface{})
 		placeholder := placeholders[i]
 		
 		// Get value for placeholder
-		value := p.getValueForPlaceholder(placeholder.Name, values)
+		value := p.valueFor(placeholder, values)
 		
 		// Replace placeholder with value
 		result = strings.Replace(result, placeholder.Expression, value, 1)
@@ -73,6 +101,25 @@ func (p *Parser) ReplacePlaceholders(text string, values map[string]interface{})
 
 // getValueForPlaceholder retrieves the value for a placeholder name
 func (p *Parser) getValueForPlaceholder(name string, values map[string]interface{}) string {
+	if val, ok := lookupValue(name, values); ok {
+		return p.formatValue(val)
+	}
+	
+	// Return placeholder unchanged if value not found
+	return fmt.Sprintf("{{%s}}", name)
+}
+
+// valueFor retrieves the value for a placeholder. A placeholder without a
+// value is returned unchanged, content-type hint included.
+func (p *Parser) valueFor(placeholder Placeholder, values map[string]interface{}) string {
+	if val, ok := lookupValue(placeholder.Name, values); ok {
+		return p.formatValue(val)
+	}
+	return placeholder.Expression
+}
+
+// lookupValue finds the raw value for a placeholder name
+func lookupValue(name string, values map[string]interface{}) (interface{}, bool) {
 	// Handle nested values (e.g., "author.name")
 	parts := strings.Split(name, ".")
 	current := values
@@ -80,21 +127,18 @@ func (p *Parser) getValueForPlaceholder(name string, values map[string]interface
 	for i, part := range parts {
 		if i == len(parts)-1 {
 			// Last part - get the actual value
-			if val, ok := current[part]; ok {
-				return p.formatValue(val)
-			}
-		} else {
-			// Navigate nested maps
-			if nested, ok := current[part].(map[string]interface{}); ok {
-				current = nested
-			} else {
-				break
-			}
+			val, ok := current[part]
+			return val, ok
+		}
+		// Navigate nested maps
+		nested, ok := current[part].(map[string]interface{})
+		if !ok {
+			break
 		}
+		current = nested
 	}
 	
-	// Return placeholder unchanged if value not found
-	return fmt.Sprintf("{{%s}}", name)
+	return nil, false
 }
 
 // formatValue formats a value as a string
@@ -113,6 +157,14 @@ func (p *Parser) formatValue(value interface{}) string {
 		return "false"
 	case time.Time:
 		return v.Format("2006-01-02")
+	case []string:
+		return strings.Join(v, ", ")
+	case map[string]interface{}:
+		// List values with metadata render inline like plain arrays
+		if list, ok := toListValue(v, p); ok {
+			return strings.Join(list.Items, ", ")
+		}
+		return fmt.Sprintf("%v", v)
 	case []interface{}:
 		// For arrays, join with comma
 		items := make([]string, len(v))
@@ -131,6 +183,11 @@ func (p *Parser) ValidatePlaceholders(text string, values map[string]interface{}
 	missing := make([]string, 0)
 	
 	for _, placeholder := range placeholders {
+		// List values are rendered as paragraphs, not checked as text
+		if _, isList := p.ListValueFor(placeholder.Name, values); isList {
+			continue
+		}
+		
 		value := p.getValueForPlaceholder(placeholder.Name, values)
 		// If the value is still a placeholder, it means it wasn't found
 		if strings.HasPrefix(value, "{{") && strings.HasSuffix(value, "}}") {
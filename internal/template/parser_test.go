@@ -5,6 +5,34 @@ import (
 	"testing"
 )
 
+func TestPlaceholderContentTypeHints(t *testing.T) {
+	parser := NewParser()
+	
+	placeholders := parser.FindPlaceholders("{{intro:blog}} {{body}} {{body:Report}} {{intro:email}}")
+	var hints []string
+	for _, placeholder := range placeholders {
+		hints = append(hints, placeholder.ContentType)
+	}
+	if want := []string{"blog", "", "report", "email"}; !reflect.DeepEqual(hints, want) {
+		t.Errorf("content types = %v, want %v", hints, want)
+	}
+	
+	unique := UniquePlaceholders(placeholders)
+	if len(unique) != 2 || unique[0].Name != "intro" || unique[0].ContentType != "blog" ||
+		unique[1].Name != "body" || unique[1].ContentType != "report" {
+		t.Errorf("unique placeholders = %+v, want intro:blog and body:report", unique)
+	}
+	
+	// Values are keyed by the plain name; missing values keep the hint
+	result := parser.ReplacePlaceholders("{{intro:blog}} / {{outro:summary}}", map[string]interface{}{"intro": "Hello"})
+	if result != "Hello / {{outro:summary}}" {
+		t.Errorf("ReplacePlaceholders = %q", result)
+	}
+	if missing := parser.ValidatePlaceholders("{{intro:blog}} {{outro:summary}}", map[string]interface{}{"intro": "Hello"}); !reflect.DeepEqual(missing, []string{"outro"}) {
+		t.Errorf("missing = %v, want [outro]", missing)
+	}
+}
+
 func TestFindPlaceholders(t *testing.T) {
 	parser := NewParser()
 	
@@ -38,6 +66,11 @@ func TestFindPlaceholders(t *testing.T) {
 			text:     "{{first_name}} {{last-name}} {{user.full_name}}",
 			expected: []string{"first_name", "last-name", "user.full_name"},
 		},
+		{
+			name:     "placeholders with content-type hints",
+			text:     "{{intro:blog}} {{summary:Summary}} {{title}}",
+			expected: []string{"intro", "summary", "title"},
+		},
 	}
 	
 	for _, tt := range tests {
@@ -8,6 +8,11 @@ import (
 // PowerPointProcessor handles template processing for PowerPoint documents
 type PowerPointProcessor struct {
 	parser *Parser
+	// StripMetadata blanks author, company and other document properties in the output
+	StripMetadata bool
+	// Engine selects how placeholders are substituted: EngineSimple (the
+	// default when empty) or EngineGoTemplate
+	Engine string
 }
 
 // NewPowerPointProcessor creates a new PowerPoint template processor
@@ -26,6 +31,30 @@ func (p *PowerPointProcessor) ProcessTemplate(templatePath string, values map[st
 	}
 	defer doc.Close()
 	
+	if p.Engine == EngineGoTemplate {
+		if err := processPowerPointGoTemplate(doc, values); err != nil {
+			return err
+		}
+	} else if err := p.replacePlaceholders(doc, values); err != nil {
+		return err
+	}
+	
+	if p.StripMetadata {
+		if err := doc.StripMetadata(); err != nil {
+			return fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	}
+	
+	// Save the processed document
+	if err := doc.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("failed to save processed document: %w", err)
+	}
+	
+	return nil
+}
+
+// replacePlaceholders substitutes {{name}} placeholders with the simple engine
+func (p *PowerPointProcessor) replacePlaceholders(doc *document.PowerPointDocument, values map[string]interface{}) error {
 	// Get document text
 	text, err := doc.GetText()
 	if err != nil {
@@ -37,22 +66,27 @@ func (p *PowerPointProcessor) ProcessTemplate(templatePath string, values map[st
 	
 	// Replace placeholders
 	for _, placeholder := range placeholders {
-		value := p.getPlaceholderValue(placeholder.Name, values)
+		// List values become one paragraph per item where the placeholder
+		// stands alone in its paragraph
+		if list, ok := p.parser.ListValueFor(placeholder.Name, values); ok {
+			if _, err := doc.ReplaceWithList(placeholder.Expression, list.Lines()); err != nil {
+				return fmt.Errorf("failed to render list %s: %w", placeholder.Name, err)
+			}
+		}
+		
+		value := p.getPlaceholderValue(placeholder, values)
 		err = doc.ReplaceText(placeholder.Expression, value)
 		if err != nil {
 			return fmt.Errorf("failed to replace placeholder %s: %w", placeholder.Name, err)
 		}
 	}
 	
-	// Save the processed document
-	if err := doc.SaveAs(outputPath); err != nil {
-		return fmt.Errorf("failed to save processed document: %w", err)
-	}
-	
 	return nil
 }
 
-// ValidateTemplate checks if all placeholders in the template have values
+// ValidateTemplate checks if all placeholders in the template have values.
+// With the Go template engine it instead returns any error rendering the
+// template with values.
 func (p *PowerPointProcessor) ValidateTemplate(templatePath string, values map[string]interface{}) ([]string, error) {
 	// Open template document
 	doc, err := document.OpenPowerPointDocument(templatePath)
@@ -61,6 +95,15 @@ func (p *PowerPointProcessor) ValidateTemplate(templatePath string, values map[s
 	}
 	defer doc.Close()
 	
+	// The Go template engine reports undefined values as errors, so the
+	// template is rendered, without saving, to check it
+	if p.Engine == EngineGoTemplate {
+		if err := processPowerPointGoTemplate(doc, values); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	
 	// Get document text
 	text, err := doc.GetText()
 	if err != nil {
@@ -72,8 +115,9 @@ func (p *PowerPointProcessor) ValidateTemplate(templatePath string, values map[s
 	return missing, nil
 }
 
-// ExtractPlaceholders extracts all placeholders from the template
-func (p *PowerPointProcessor) ExtractPlaceholders(templatePath string) ([]string, error) {
+// ExtractPlaceholderDetails extracts the unique placeholders of the template
+// in order of appearance, with their content-type hints
+func (p *PowerPointProcessor) ExtractPlaceholderDetails(templatePath string) ([]Placeholder, error) {
 	// Open template document
 	doc, err := document.OpenPowerPointDocument(templatePath)
 	if err != nil {
@@ -90,20 +134,26 @@ func (p *PowerPointProcessor) ExtractPlaceholders(templatePath string) ([]string
 	// Find all placeholders
 	placeholders := p.parser.FindPlaceholders(text)
 	
-	// Extract unique placeholder names
-	seen := make(map[string]bool)
-	names := make([]string, 0)
+	return UniquePlaceholders(placeholders), nil
+}
+
+// ExtractPlaceholders extracts all placeholders from the template
+func (p *PowerPointProcessor) ExtractPlaceholders(templatePath string) ([]string, error) {
+	placeholders, err := p.ExtractPlaceholderDetails(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	names := make([]string, 0, len(placeholders))
 	for _, placeholder := range placeholders {
-		if !seen[placeholder.Name] {
-			seen[placeholder.Name] = true
-			names = append(names, placeholder.Name)
-		}
+		names = append(names, placeholder.Name)
 	}
 	
 	return names, nil
 }
 
-// getPlaceholderValue gets the value for a placeholder
-func (p *PowerPointProcessor) getPlaceholderValue(name string, values map[string]interface{}) string {
-	return p.parser.getValueForPlaceholder(name, values)
+// getPlaceholderValue gets the value for a placeholder, leaving placeholders
+// without a value in place
+func (p *PowerPointProcessor) getPlaceholderValue(placeholder Placeholder, values map[string]interface{}) string {
+	return p.parser.valueFor(placeholder, values)
 }
\ No newline at end of file
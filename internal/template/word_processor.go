@@ -8,6 +8,11 @@ import (
 // WordProcessor handles template processing for Word documents
 type WordProcessor struct {
 	parser *Parser
+	// StripMetadata blanks author, company and other document properties in the output
+	StripMetadata bool
+	// Engine selects how placeholders are substituted: EngineSimple (the
+	// default when empty) or EngineGoTemplate
+	Engine string
 }
 
 // NewWordProcessor creates a new Word template processor
@@ -26,6 +31,30 @@ func (w *WordProcessor) ProcessTemplate(templatePath string, values map[string]i
 	}
 	defer doc.Close()
 	
+	if w.Engine == EngineGoTemplate {
+		if err := processWordGoTemplate(doc, values); err != nil {
+			return err
+		}
+	} else if err := w.replacePlaceholders(doc, values); err != nil {
+		return err
+	}
+	
+	if w.StripMetadata {
+		if err := doc.StripMetadata(); err != nil {
+			return fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	}
+	
+	// Save the processed document
+	if err := doc.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("failed to save processed document: %w", err)
+	}
+	
+	return nil
+}
+
+// replacePlaceholders substitutes {{name}} placeholders with the simple engine
+func (w *WordProcessor) replacePlaceholders(doc *document.WordDocument, values map[string]interface{}) error {
 	// Get document text
 	text, err := doc.GetText()
 	if err != nil {
@@ -37,22 +66,27 @@ func (w *WordProcessor) ProcessTemplate(templatePath string, values map[string]i
 	
 	// Replace placeholders
 	for _, placeholder := range placeholders {
-		value := w.getPlaceholderValue(placeholder.Name, values)
+		// List values become one paragraph per item where the placeholder
+		// stands alone in its paragraph
+		if list, ok := w.parser.ListValueFor(placeholder.Name, values); ok {
+			if _, err := doc.ReplaceWithList(placeholder.Expression, list.Lines()); err != nil {
+				return fmt.Errorf("failed to render list %s: %w", placeholder.Name, err)
+			}
+		}
+		
+		value := w.getPlaceholderValue(placeholder, values)
 		err = doc.ReplaceText(placeholder.Expression, value)
 		if err != nil {
 			return fmt.Errorf("failed to replace placeholder %s: %w", placeholder.Name, err)
 		}
 	}
 	
-	// Save the processed document
-	if err := doc.SaveAs(outputPath); err != nil {
-		return fmt.Errorf("failed to save processed document: %w", err)
-	}
-	
 	return nil
 }
 
-// ValidateTemplate checks if all placeholders in the template have values
+// ValidateTemplate checks if all placeholders in the template have values.
+// With the Go template engine it instead returns any error rendering the
+// template with values.
 func (w *WordProcessor) ValidateTemplate(templatePath string, values map[string]interface{}) ([]string, error) {
 	// Open template document
 	doc, err := document.OpenWordDocument(templatePath)
@@ -61,6 +95,15 @@ func (w *WordProcessor) ValidateTemplate(templatePath string, values map[string]
 	}
 	defer doc.Close()
 	
+	// The Go template engine reports undefined values as errors, so the
+	// template is rendered, without saving, to check it
+	if w.Engine == EngineGoTemplate {
+		if err := processWordGoTemplate(doc, values); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	
 	// Get document text
 	text, err := doc.GetText()
 	if err != nil {
@@ -72,8 +115,9 @@ func (w *WordProcessor) ValidateTemplate(templatePath string, values map[string]
 	return missing, nil
 }
 
-// ExtractPlaceholders extracts all placeholders from the template
-func (w *WordProcessor) ExtractPlaceholders(templatePath string) ([]string, error) {
+// ExtractPlaceholderDetails extracts the unique placeholders of the template
+// in order of appearance, with their content-type hints
+func (w *WordProcessor) ExtractPlaceholderDetails(templatePath string) ([]Placeholder, error) {
 	// Open template document
 	doc, err := document.OpenWordDocument(templatePath)
 	if err != nil {
@@ -90,20 +134,26 @@ func (w *WordProcessor) ExtractPlaceholders(templatePath string) ([]string, erro
 	// Find all placeholders
 	placeholders := w.parser.FindPlaceholders(text)
 	
-	// Extract unique placeholder names
-	seen := make(map[string]bool)
-	names := make([]string, 0)
-	for _, p := range placeholders {
-		if !seen[p.Name] {
-			seen[p.Name] = true
-			names = append(names, p.Name)
-		}
+	return UniquePlaceholders(placeholders), nil
+}
+
+// ExtractPlaceholders extracts all placeholders from the template
+func (w *WordProcessor) ExtractPlaceholders(templatePath string) ([]string, error) {
+	placeholders, err := w.ExtractPlaceholderDetails(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	
+	names := make([]string, 0, len(placeholders))
+	for _, placeholder := range placeholders {
+		names = append(names, placeholder.Name)
 	}
 	
 	return names, nil
 }
 
-// getPlaceholderValue gets the value for a placeholder
-func (w *WordProcessor) getPlaceholderValue(name string, values map[string]interface{}) string {
-	return w.parser.getValueForPlaceholder(name, values)
+// getPlaceholderValue gets the value for a placeholder, leaving placeholders
+// without a value in place
+func (w *WordProcessor) getPlaceholderValue(placeholder Placeholder, values map[string]interface{}) string {
+	return w.parser.valueFor(placeholder, values)
 }
\ No newline at end of file
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// countdownTick is how often a countdown updates; tests shorten it
+var countdownTick = time.Second
+
+// Countdown shows the time left of d after label in the description, e.g.
+// "Retrying in 4s...", updating it every second, and restores the description
+// when d has passed. A new countdown replaces the running one; Finish and
+// Clear stop it.
+func (p *ProgressBar) Countdown(label string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopCountdownLocked()
+
+	stop := make(chan struct{})
+	p.countdown = stop
+	p.bar.Describe(countdownDescription(label, d))
+	go p.runCountdown(label, time.Now().Add(d), countdownTick, stop)
+}
+
+// runCountdown updates the countdown every tick until the deadline or until
+// stop is closed
+func (p *ProgressBar) runCountdown(label string, deadline time.Time, tick time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		if p.countdown != stop {
+			// Stopped while waiting for the lock
+			p.mu.Unlock()
+			return
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			p.countdown = nil
+			p.bar.Describe(p.description)
+			p.mu.Unlock()
+			return
+		}
+		p.bar.Describe(countdownDescription(label, remaining))
+		p.mu.Unlock()
+	}
+}
+
+// stopCountdownLocked ends the running countdown, if any, and restores the
+// description. p.mu must be held.
+func (p *ProgressBar) stopCountdownLocked() {
+	if p.countdown == nil {
+		return
+	}
+	close(p.countdown)
+	p.countdown = nil
+	p.bar.Describe(p.description)
+}
+
+// countdownDescription formats the time left in whole seconds, rounded up
+// so the countdown never shows 0s while still waiting
+func countdownDescription(label string, remaining time.Duration) string {
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return fmt.Sprintf("%s %ds...", label, seconds)
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountdownDescription(t *testing.T) {
+	tests := []struct {
+		remaining time.Duration
+		want      string
+	}{
+		{4 * time.Second, "Retrying in 4s..."},
+		{3200 * time.Millisecond, "Retrying in 4s..."},
+		{10 * time.Millisecond, "Retrying in 1s..."},
+	}
+	for _, tt := range tests {
+		if got := countdownDescription("Retrying in", tt.remaining); got != tt.want {
+			t.Errorf("countdownDescription(%v) = %q, want %q", tt.remaining, got, tt.want)
+		}
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	original := countdownTick
+	countdownTick = 5 * time.Millisecond
+	defer func() { countdownTick = original }()
+
+	t.Run("ends after the delay", func(t *testing.T) {
+		spinner := NewSpinner("Generating...")
+		defer spinner.Finish()
+
+		spinner.Countdown("Retrying in", 20*time.Millisecond)
+		spinner.SetDescription("Still generating...")
+		time.Sleep(100 * time.Millisecond)
+
+		spinner.mu.Lock()
+		defer spinner.mu.Unlock()
+		if spinner.countdown != nil {
+			t.Error("countdown should have ended")
+		}
+		if spinner.description != "Still generating..." {
+			t.Errorf("description = %q", spinner.description)
+		}
+	})
+
+	t.Run("stops on finish", func(t *testing.T) {
+		spinner := NewSpinner("Generating...")
+		spinner.Countdown("Retrying in", time.Hour)
+		spinner.Countdown("Retrying in", time.Hour)
+		spinner.Finish()
+
+		spinner.mu.Lock()
+		defer spinner.mu.Unlock()
+		if spinner.countdown != nil {
+			t.Error("Finish should stop the countdown")
+		}
+	})
+}
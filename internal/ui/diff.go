@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	
 	"github.com/fatih/color"
@@ -14,6 +15,14 @@ type DiffLine struct {
 	LineNum int
 }
 
+// Replacement is one text replacement of a preview. Replacements are applied
+// in order, like the rules they come from, so chained rules such as A to B
+// and B to C preview what a real run produces.
+type Replacement struct {
+	Old string
+	New string
+}
+
 // DiffFormatter formats text differences in a diff-like format
 type DiffFormatter struct {
 	contextLines int
@@ -49,48 +58,201 @@ func (df *DiffFormatter) FormatTextDiff(oldText, newText string, filename string
 		maxLines = len(newLines)
 	}
 	
+	changed := make([]bool, maxLines)
 	for i := 0; i < maxLines; i++ {
-		var oldLine, newLine string
-		
-		if i < len(oldLines) {
-			oldLine = oldLines[i]
-		}
-		if i < len(newLines) {
-			newLine = newLines[i]
+		changed[i] = lineAt(oldLines, i) != lineAt(newLines, i)
+	}
+	
+	for w, window := range ContextWindows(changed, df.contextLines) {
+		if w > 0 {
+			df.writeSeparator(&result)
 		}
-		
-		if oldLine != newLine {
-			if oldLine != "" {
-				df.writeDiffLine(&result, "-", oldLine, i+1)
+		for i := window.Start; i <= window.End; i++ {
+			oldLine := lineAt(oldLines, i)
+			newLine := lineAt(newLines, i)
+			
+			if oldLine != newLine {
+				if oldLine != "" {
+					df.writeDiffLine(&result, "-", oldLine, i+1)
+				}
+				if newLine != "" {
+					df.writeDiffLine(&result, "+", newLine, i+1)
+				}
+			} else if oldLine != "" {
+				// Context line
+				df.writeDiffLine(&result, " ", oldLine, i+1)
 			}
-			if newLine != "" {
-				df.writeDiffLine(&result, "+", newLine, i+1)
+		}
+	}
+	
+	return result.String()
+}
+
+// FormatReplacementContext shows each line affected by the replacements together
+// with the surrounding context lines, similar to `diff -U`
+func (df *DiffFormatter) FormatReplacementContext(text string, replacements []Replacement) string {
+	var result strings.Builder
+	
+	lines, modified, changed := replaceLines(text, replacements)
+	
+	for w, window := range ContextWindows(changed, df.contextLines) {
+		if w > 0 {
+			df.writeSeparator(&result)
+		}
+		for i := window.Start; i <= window.End; i++ {
+			if changed[i] {
+				df.writeDiffLine(&result, "-", lines[i], i+1)
+				df.writeDiffLine(&result, "+", modified[i], i+1)
+			} else {
+				df.writeDiffLine(&result, " ", lines[i], i+1)
 			}
-		} else if oldLine != "" {
-			// Context line
-			df.writeDiffLine(&result, " ", oldLine, i+1)
 		}
 	}
 	
 	return result.String()
 }
 
-// FormatReplacementDiff shows the replacements that will be made
-func (df *DiffFormatter) FormatReplacementDiff(text string, replacements map[string]string) string {
+// replaceLines applies the replacements in order to each line of text and
+// reports which lines changed
+func replaceLines(text string, replacements []Replacement) (lines, modified []string, changed []bool) {
+	lines = strings.Split(text, "\n")
+	modified = make([]string, len(lines))
+	changed = make([]bool, len(lines))
+	for i, line := range lines {
+		newLine := line
+		for _, r := range replacements {
+			if r.Old != "" {
+				newLine = strings.ReplaceAll(newLine, r.Old, r.New)
+			}
+		}
+		modified[i] = newLine
+		changed[i] = newLine != line
+	}
+	return lines, modified, changed
+}
+
+// FormatUnifiedDiff returns the replacements applied to the lines of text as
+// a unified diff (---/+++ headers and @@ hunks, no color) for tools such as
+// patch viewers or delta. path names the file in the headers. The result is
+// empty when no line changes; a negative contextLines puts every line in one
+// hunk.
+func FormatUnifiedDiff(text string, replacements []Replacement, path string, contextLines int) string {
+	lines, modified, changed := replaceLines(text, replacements)
+	windows := ContextWindows(changed, contextLines)
+	hasChanges := false
+	for _, isChanged := range changed {
+		hasChanges = hasChanges || isChanged
+	}
+	if !hasChanges {
+		return ""
+	}
+	
 	var result strings.Builder
+	name := strings.TrimPrefix(filepath.ToSlash(path), "/")
+	fmt.Fprintf(&result, "--- a/%s\n+++ b/%s\n", name, name)
+	for _, window := range windows {
+		// Replacements never add or remove lines, so both sides of a hunk
+		// cover the same range
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", hunkRange(window), hunkRange(window))
+		for i := window.Start; i <= window.End; {
+			if !changed[i] {
+				result.WriteString(" " + lines[i] + "\n")
+				i++
+				continue
+			}
+			// Removed lines of a change block come before the added ones
+			end := i
+			for end <= window.End && changed[end] {
+				end++
+			}
+			for j := i; j < end; j++ {
+				result.WriteString("-" + lines[j] + "\n")
+			}
+			for j := i; j < end; j++ {
+				result.WriteString("+" + modified[j] + "\n")
+			}
+			i = end
+		}
+	}
+	return result.String()
+}
+
+// hunkRange formats a line range for a hunk header: the one-based first line
+// and, unless it is one, the number of lines
+func hunkRange(window LineRange) string {
+	count := window.End - window.Start + 1
+	if count == 1 {
+		return fmt.Sprintf("%d", window.Start+1)
+	}
+	return fmt.Sprintf("%d,%d", window.Start+1, count)
+}
+
+// LineRange is an inclusive range of zero-based line indexes
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// ContextWindows groups changed lines into ranges padded with up to context
+// unchanged lines on each side. Ranges that overlap or touch are merged.
+// A negative context returns a single range covering every line.
+func ContextWindows(changed []bool, context int) []LineRange {
+	if len(changed) == 0 {
+		return nil
+	}
+	if context < 0 {
+		return []LineRange{{Start: 0, End: len(changed) - 1}}
+	}
 	
-	// Apply replacements and show diff
-	modifiedText := text
-	for old, new := range replacements {
-		modifiedText = strings.ReplaceAll(modifiedText, old, new)
+	var windows []LineRange
+	for i, isChanged := range changed {
+		if !isChanged {
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i + context
+		if end > len(changed)-1 {
+			end = len(changed) - 1
+		}
+		
+		if n := len(windows); n > 0 && start <= windows[n-1].End+1 {
+			if end > windows[n-1].End {
+				windows[n-1].End = end
+			}
+			continue
+		}
+		windows = append(windows, LineRange{Start: start, End: end})
 	}
 	
-	// Show replacements summary
+	return windows
+}
+
+// lineAt returns the line at index i, or an empty string when out of range
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// FormatReplacementDiff shows the replacements that will be made
+func (df *DiffFormatter) FormatReplacementDiff(text string, replacements []Replacement) string {
+	var result strings.Builder
+	
+	// Show replacements summary, counting each replacement in the text left
+	// by the ones before it
 	result.WriteString("\nReplacements to be made:\n")
-	for old, new := range replacements {
-		count := strings.Count(text, old)
-		if count > 0 {
-			df.writeReplacementLine(&result, old, new, count)
+	modifiedText := text
+	for _, r := range replacements {
+		if r.Old == "" {
+			continue
+		}
+		if count := strings.Count(modifiedText, r.Old); count > 0 {
+			df.writeReplacementLine(&result, r.Old, r.New, count)
+			modifiedText = strings.ReplaceAll(modifiedText, r.Old, r.New)
 		}
 	}
 	
@@ -117,6 +279,15 @@ func (df *DiffFormatter) writeDiffLine(sb *strings.Builder, prefix string, conte
 	}
 }
 
+// writeSeparator writes the marker placed between non-adjacent context windows
+func (df *DiffFormatter) writeSeparator(sb *strings.Builder) {
+	if df.colorEnabled {
+		sb.WriteString(Info.Sprint("  ...") + "\n")
+	} else {
+		sb.WriteString("  ...\n")
+	}
+}
+
 // writeReplacementLine writes a replacement summary line
 func (df *DiffFormatter) writeReplacementLine(sb *strings.Builder, old, new string, count int) {
 	if df.colorEnabled {
@@ -145,7 +316,7 @@ func ShowSimpleDiff(oldText, newText, filename string) {
 }
 
 // ShowReplacementPreview shows what replacements will be made
-func ShowReplacementPreview(text string, replacements map[string]string, filename string) {
+func ShowReplacementPreview(text string, replacements []Replacement, filename string) {
 	formatter := NewDiffFormatter(3)
 	
 	if !color.NoColor {
@@ -155,4 +326,21 @@ func ShowReplacementPreview(text string, replacements map[string]string, filenam
 	}
 	
 	fmt.Print(formatter.FormatReplacementDiff(text, replacements))
-}
\ No newline at end of file
+}
+
+// ShowReplacementPreviewWithContext shows the replacement summary followed by
+// the affected lines, each with contextLines lines of surrounding text.
+// A negative contextLines shows the whole document.
+func ShowReplacementPreviewWithContext(text string, replacements []Replacement, filename string, contextLines int) {
+	formatter := NewDiffFormatter(contextLines)
+	
+	if !color.NoColor {
+		fmt.Printf("\n%s\n", Warning.Sprintf("=== %s ===", filename))
+	} else {
+		fmt.Printf("\n=== %s ===\n", filename)
+	}
+	
+	fmt.Print(formatter.FormatReplacementDiff(text, replacements))
+	fmt.Println()
+	fmt.Print(formatter.FormatReplacementContext(text, replacements))
+}
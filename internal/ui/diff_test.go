@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContextWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		changed []bool
+		context int
+		want    []LineRange
+	}{
+		{
+			name:    "no lines",
+			changed: nil,
+			context: 3,
+			want:    nil,
+		},
+		{
+			name:    "no changes",
+			changed: []bool{false, false, false},
+			context: 1,
+			want:    nil,
+		},
+		{
+			name:    "single change clamped to bounds",
+			changed: []bool{true, false, false, false},
+			context: 2,
+			want:    []LineRange{{Start: 0, End: 2}},
+		},
+		{
+			name:    "distant changes stay separate",
+			changed: []bool{true, false, false, false, false, false, true},
+			context: 1,
+			want:    []LineRange{{Start: 0, End: 1}, {Start: 5, End: 6}},
+		},
+		{
+			name:    "touching windows merge",
+			changed: []bool{true, false, false, true},
+			context: 1,
+			want:    []LineRange{{Start: 0, End: 3}},
+		},
+		{
+			name:    "zero context shows only changed lines",
+			changed: []bool{false, true, false, true},
+			context: 0,
+			want:    []LineRange{{Start: 1, End: 1}, {Start: 3, End: 3}},
+		},
+		{
+			name:    "negative context shows everything",
+			changed: []bool{false, true, false},
+			context: -1,
+			want:    []LineRange{{Start: 0, End: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContextWindows(tt.changed, tt.context)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ContextWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatReplacementContext(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	lines[49] = "the old value"
+	text := strings.Join(lines, "\n")
+
+	formatter := NewDiffFormatter(2)
+	formatter.colorEnabled = false
+
+	output := formatter.FormatReplacementContext(text, []Replacement{{Old: "old", New: "new"}})
+
+	for _, expected := range []string{"line 48", "line 49", "- ", "the old value", "+ ", "the new value", "line 51", "line 52"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("output missing %q:\n%s", expected, output)
+		}
+	}
+	for _, unexpected := range []string{"line 47\n", "line 53\n", "line 1\n"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("output should not contain %q:\n%s", unexpected, output)
+		}
+	}
+}
+
+func TestFormatTextDiffUsesContext(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng"
+	newText := "a\nb\nc\nD\ne\nf\ng"
+
+	formatter := NewDiffFormatter(1)
+	formatter.colorEnabled = false
+
+	output := formatter.FormatTextDiff(oldText, newText, "test.txt")
+
+	for _, expected := range []string{"c\n", "- ", "d\n", "+ ", "D\n", "e\n"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("output missing %q:\n%s", expected, output)
+		}
+	}
+	for _, unexpected := range []string{": a\n", ": b\n", ": f\n", ": g\n"} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("output should not contain %q:\n%s", unexpected, output)
+		}
+	}
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	text := "Title\nACME Corp report\nline 3\nline 4\nline 5\nline 6\nACME Corp and ACME Corp\nACME Corp\nend"
+	replacements := []Replacement{{Old: "ACME Corp", New: "ACME Inc."}}
+
+	want := "--- a/docs/report.docx\n" +
+		"+++ b/docs/report.docx\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" Title\n" +
+		"-ACME Corp report\n" +
+		"+ACME Inc. report\n" +
+		" line 3\n" +
+		"@@ -6,4 +6,4 @@\n" +
+		" line 6\n" +
+		"-ACME Corp and ACME Corp\n" +
+		"-ACME Corp\n" +
+		"+ACME Inc. and ACME Inc.\n" +
+		"+ACME Inc.\n" +
+		" end\n"
+	if got := FormatUnifiedDiff(text, replacements, "docs/report.docx", 1); got != want {
+		t.Errorf("FormatUnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := FormatUnifiedDiff("one\nACME Corp", replacements, "/tmp/a.docx", 0); !strings.HasPrefix(got, "--- a/tmp/a.docx\n+++ b/tmp/a.docx\n@@ -2 +2 @@\n-ACME Corp\n+ACME Inc.\n") {
+		t.Errorf("single-line hunk = %q", got)
+	}
+	if strings.Contains(FormatUnifiedDiff(text, replacements, "x.docx", -1), "\x1b[") {
+		t.Error("unified diff should not contain color codes")
+	}
+	if got := FormatUnifiedDiff("nothing here", replacements, "x.docx", -1); got != "" {
+		t.Errorf("FormatUnifiedDiff() without changes = %q, want empty", got)
+	}
+}
+
+func TestReplaceLinesInRuleOrder(t *testing.T) {
+	// Sorted by old text, "a" would be replaced first and its result
+	// replaced again by the "b" rule
+	replacements := []Replacement{{Old: "b", New: "c"}, {Old: "a", New: "b"}}
+	_, modified, changed := replaceLines("a b\nnone", replacements)
+	if modified[0] != "b c" || !changed[0] || changed[1] {
+		t.Errorf("replaceLines() = %q, %v; want [\"b c\" \"none\"], [true false]", modified, changed)
+	}
+
+	// The second rule counts the text the first one produced
+	plain := &DiffFormatter{}
+	summary := plain.FormatReplacementDiff("a", []Replacement{{Old: "a", New: "b"}, {Old: "b", New: "c"}})
+	if !strings.Contains(summary, "'a' → 'b' (1 occurrence)") || !strings.Contains(summary, "'b' → 'c' (1 occurrence)") {
+		t.Errorf("chained rules missing from summary:\n%s", summary)
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pyhub/pyhub-docs/internal/secrets"
+)
+
+// DefaultPromptPreviewLength is the number of characters of a prompt shown in logs
+const DefaultPromptPreviewLength = 60
+
+// RedactSecrets masks API keys and other key-like strings in s
+func RedactSecrets(s string) string {
+	return secrets.Sanitize(s)
+}
+
+// TruncatePrompt shortens s to at most max characters, adding an ellipsis and
+// the original length when anything was cut. Line breaks are collapsed so the
+// result fits on one log line.
+func TruncatePrompt(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	length := utf8.RuneCountInString(s)
+	if max <= 0 || length <= max {
+		return s
+	}
+
+	runes := []rune(s)
+	return fmt.Sprintf("%s… (%d chars)", string(runes[:max]), length)
+}
+
+// RedactPrompt returns a short, secret-free preview of a prompt for verbose output
+func RedactPrompt(prompt string) string {
+	return TruncatePrompt(RedactSecrets(prompt), DefaultPromptPreviewLength)
+}
+
+// RedactError wraps err so that its message has secrets masked. errors.Is and
+// errors.As still see the original error.
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return RedactSecrets(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const sampleKey = "sk-proj1234567890abcdefghijklmnopqrstuvwxyz"
+
+// captureOutput returns everything fn writes to stdout and stderr
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+
+	oldOut, oldErr := os.Stdout, os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout, os.Stderr = w, w
+
+	fn()
+
+	w.Close()
+	os.Stdout, os.Stderr = oldOut, oldErr
+
+	out, _ := io.ReadAll(r)
+	return string(out)
+}
+
+func TestRedactSecrets(t *testing.T) {
+	for _, input := range []string{
+		sampleKey,
+		"request failed: invalid api key " + sampleKey,
+		"Authorization: Bearer " + sampleKey,
+		"api_key=" + sampleKey,
+	} {
+		got := RedactSecrets(input)
+		if strings.Contains(got, sampleKey) {
+			t.Errorf("RedactSecrets(%q) leaked the key: %q", input, got)
+		}
+	}
+
+	if got := RedactSecrets("plain message"); got != "plain message" {
+		t.Errorf("RedactSecrets changed a message without secrets: %q", got)
+	}
+}
+
+func TestTruncatePrompt(t *testing.T) {
+	tests := []struct {
+		input string
+		max   int
+		want  string
+	}{
+		{"short prompt", 20, "short prompt"},
+		{"line one\n\nline   two", 50, "line one line two"},
+		{"abcdefghij", 4, "abcd… (10 chars)"},
+		{"한국어 프롬프트", 3, "한국어… (8 chars)"},
+		{"unlimited", 0, "unlimited"},
+	}
+
+	for _, tt := range tests {
+		if got := TruncatePrompt(tt.input, tt.max); got != tt.want {
+			t.Errorf("TruncatePrompt(%q, %d) = %q, want %q", tt.input, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestRedactPromptNeverPrintsKey(t *testing.T) {
+	prompt := "Summarize the config below.\nOPENAI_API_KEY=" + sampleKey + "\n" + strings.Repeat("details ", 50)
+
+	output := captureOutput(t, func() {
+		PrintInfo("Prompt: %s", RedactPrompt(prompt))
+	})
+
+	if strings.Contains(output, sampleKey) {
+		t.Errorf("output contains the API key: %q", output)
+	}
+	if !strings.Contains(output, "Summarize the config") {
+		t.Errorf("output should contain the start of the prompt: %q", output)
+	}
+	if strings.Contains(output, strings.Repeat("details ", 10)) {
+		t.Errorf("prompt should be truncated: %q", output)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	sentinel := errors.New("401 unauthorized")
+	err := fmt.Errorf("invalid key %s: %w", sampleKey, sentinel)
+
+	redacted := RedactError(err)
+	if !errors.Is(redacted, sentinel) {
+		t.Error("redacted error should still unwrap to the original error")
+	}
+
+	output := captureOutput(t, func() {
+		PrintError("Failed: %v", fmt.Errorf("wrapped: %w", redacted))
+	})
+	if strings.Contains(output, sampleKey) {
+		t.Errorf("output contains the API key: %q", output)
+	}
+	if !strings.Contains(output, "401 unauthorized") {
+		t.Errorf("output should keep the error details: %q", output)
+	}
+
+	if RedactError(nil) != nil {
+		t.Error("RedactError(nil) should be nil")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/schollz/progressbar/v3"
 )
 
@@ -50,28 +51,58 @@ func init() {
 		iconProcess = ">"
 		iconDot     = "*"
 	}
+
+	DetectColor()
+}
+
+// stdoutIsTerminal reports whether standard output is a terminal; tests replace it
+var stdoutIsTerminal = func() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// DetectColor turns color output off when the NO_COLOR environment variable
+// is set (see https://no-color.org) or standard output is not a terminal,
+// and on otherwise. EnableColor still forces color afterwards.
+func DetectColor() {
+	color.NoColor = colorDisabledByEnvironment(os.Getenv, stdoutIsTerminal())
+}
+
+// colorDisabledByEnvironment decides the default color setting
+func colorDisabledByEnvironment(getenv func(string) string, isTerminal bool) bool {
+	return getenv("NO_COLOR") != "" || !isTerminal
 }
 
-// PrintSuccess prints a success message with green color
+// PrintSuccess prints a success message with green color, unless quiet
 func PrintSuccess(format string, args ...interface{}) {
+	if IsQuiet() {
+		return
+	}
 	Success.Printf("%s ", iconSuccess)
 	fmt.Printf(format+"\n", args...)
 }
 
-// PrintError prints an error message with red color
+// PrintError prints an error message with red color to stderr
 func PrintError(format string, args ...interface{}) {
-	Error.Printf("%s ", iconError)
+	Error.Fprintf(os.Stderr, "%s ", iconError)
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
 }
 
-// PrintWarning prints a warning message with yellow color
+// PrintWarning prints a warning message with yellow color to stderr, unless
+// only errors are shown
 func PrintWarning(format string, args ...interface{}) {
-	Warning.Printf("%s ", iconWarning)
+	if GetVerbosity() < VerbosityErrors {
+		return
+	}
+	Warning.Fprintf(os.Stderr, "%s ", iconWarning)
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
 }
 
-// PrintInfo prints an info message with cyan color
+// PrintInfo prints an info message with cyan color, unless quiet
 func PrintInfo(format string, args ...interface{}) {
+	if IsQuiet() {
+		return
+	}
 	Info.Printf("%s ", iconInfo)
 	fmt.Printf(format+"\n", args...)
 }
@@ -109,9 +140,11 @@ func PrintFileOperation(operation, filePath, fileType string) {
 
 // ProgressBar represents a progress bar for operations
 type ProgressBar struct {
-	bar   *progressbar.ProgressBar
-	total int
-	mu    sync.Mutex
+	bar         *progressbar.ProgressBar
+	total       int
+	description string
+	countdown   chan struct{} // Closed to stop the running Countdown
+	mu          sync.Mutex
 }
 
 // NewProgressBar creates a new progress bar
@@ -136,8 +169,9 @@ func NewProgressBar(total int, description string) *ProgressBar {
 	)
 	
 	return &ProgressBar{
-		bar:   bar,
-		total: total,
+		bar:         bar,
+		total:       total,
+		description: description,
 	}
 }
 
@@ -157,8 +191,9 @@ func NewSpinner(description string) *ProgressBar {
 	)
 	
 	return &ProgressBar{
-		bar:   bar,
-		total: -1,
+		bar:         bar,
+		total:       -1,
+		description: description,
 	}
 }
 
@@ -176,17 +211,22 @@ func (p *ProgressBar) IncrementBy(n int) {
 	p.bar.Add(n)
 }
 
-// SetDescription updates the progress bar description
+// SetDescription updates the progress bar description. During a Countdown
+// the new description is shown once the countdown ends.
 func (p *ProgressBar) SetDescription(description string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.bar.Describe(description)
+	p.description = description
+	if p.countdown == nil {
+		p.bar.Describe(description)
+	}
 }
 
 // Finish completes the progress bar
 func (p *ProgressBar) Finish() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	p.stopCountdownLocked()
 	p.bar.Finish()
 }
 
@@ -194,6 +234,7 @@ func (p *ProgressBar) Finish() {
 func (p *ProgressBar) Clear() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	p.stopCountdownLocked()
 	p.bar.Clear()
 }
 
@@ -248,8 +289,11 @@ func Confirmation(prompt string) bool {
 	return response == "y" || response == "Y" || response == "yes" || response == "Yes"
 }
 
-// PrintSummary prints a summary with statistics
+// PrintSummary prints a summary with statistics, unless quiet
 func PrintSummary(title string, stats map[string]interface{}) {
+	if IsQuiet() {
+		return
+	}
 	PrintHeader(title)
 	
 	for key, value := range stats {
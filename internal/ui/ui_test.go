@@ -409,4 +409,75 @@ func TestHelperFunctions(t *testing.T) {
 		// Restore original state
 		EnableColor()
 	})
-}
\ No newline at end of file
+}
+
+func TestColorDisabledByEnvironment(t *testing.T) {
+	tests := []struct {
+		name       string
+		noColor    string
+		isTerminal bool
+		want       bool
+	}{
+		{"terminal without NO_COLOR", "", true, false},
+		{"NO_COLOR set", "1", true, true},
+		{"NO_COLOR set to any value", "false", true, true},
+		{"not a terminal", "", false, true},
+		{"NO_COLOR and not a terminal", "1", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if key == "NO_COLOR" {
+					return tt.noColor
+				}
+				return ""
+			}
+			if got := colorDisabledByEnvironment(getenv, tt.isTerminal); got != tt.want {
+				t.Errorf("colorDisabledByEnvironment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectColor(t *testing.T) {
+	originalTerminal := stdoutIsTerminal
+	originalNoColor := color.NoColor
+	defer func() {
+		stdoutIsTerminal = originalTerminal
+		color.NoColor = originalNoColor
+	}()
+
+	t.Run("terminal", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		stdoutIsTerminal = func() bool { return true }
+		DetectColor()
+		if !IsColorEnabled() {
+			t.Error("color should be enabled on a terminal")
+		}
+	})
+
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		stdoutIsTerminal = func() bool { return true }
+		DetectColor()
+		if IsColorEnabled() {
+			t.Error("NO_COLOR should disable color")
+		}
+
+		// An explicit request still forces color on
+		EnableColor()
+		if !IsColorEnabled() {
+			t.Error("EnableColor should override NO_COLOR")
+		}
+	})
+
+	t.Run("redirected output", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		stdoutIsTerminal = func() bool { return false }
+		DetectColor()
+		if IsColorEnabled() {
+			t.Error("color should be disabled when stdout is not a terminal")
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package ui
+
+import "sync"
+
+// Verbosity controls which messages the Print functions show. Command
+// output such as generated content and JSON is not affected.
+type Verbosity int
+
+const (
+	// VerbosityQuiet shows errors only
+	VerbosityQuiet Verbosity = iota
+	// VerbosityErrors shows warnings and errors but no success or progress
+	// messages, so scheduled jobs stay silent unless something breaks
+	VerbosityErrors
+	// VerbosityNormal shows every message
+	VerbosityNormal
+	// VerbosityVerbose also asks commands for extra detail
+	VerbosityVerbose
+)
+
+var (
+	currentVerbosity = VerbosityNormal
+	verbosityMu      sync.RWMutex
+)
+
+// SetVerbosity sets the global verbosity
+func SetVerbosity(v Verbosity) {
+	verbosityMu.Lock()
+	defer verbosityMu.Unlock()
+	currentVerbosity = v
+}
+
+// GetVerbosity returns the global verbosity
+func GetVerbosity() Verbosity {
+	verbosityMu.RLock()
+	defer verbosityMu.RUnlock()
+	return currentVerbosity
+}
+
+// IsQuiet reports whether success and progress messages are suppressed
+func IsQuiet() bool {
+	return GetVerbosity() < VerbosityNormal
+}
+
+// IsVerbose reports whether commands should print extra detail
+func IsVerbose() bool {
+	return GetVerbosity() >= VerbosityVerbose
+}
+
+// ResolveVerbosity combines the --quiet, --quiet-errors and --verbose flags;
+// the quietest one given wins
+func ResolveVerbosity(quiet, quietErrors, verbose bool) Verbosity {
+	switch {
+	case quiet:
+		return VerbosityQuiet
+	case quietErrors:
+		return VerbosityErrors
+	case verbose:
+		return VerbosityVerbose
+	default:
+		return VerbosityNormal
+	}
+}
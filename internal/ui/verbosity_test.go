@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStreams returns what fn writes to stdout and to stderr separately
+func captureStreams(t *testing.T, fn func()) (string, string) {
+	t.Helper()
+	oldOut, oldErr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+
+	fn()
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	stdout, _ := io.ReadAll(outR)
+	stderr, _ := io.ReadAll(errR)
+	return string(stdout), string(stderr)
+}
+
+func TestVerbosity(t *testing.T) {
+	defer SetVerbosity(VerbosityNormal)
+
+	tests := []struct {
+		verbosity   Verbosity
+		wantInfo    bool
+		wantWarning bool
+	}{
+		{VerbosityQuiet, false, false},
+		{VerbosityErrors, false, true},
+		{VerbosityNormal, true, true},
+		{VerbosityVerbose, true, true},
+	}
+
+	for _, tt := range tests {
+		SetVerbosity(tt.verbosity)
+		stdout, stderr := captureStreams(t, func() {
+			PrintSuccess("saved")
+			PrintInfo("processing")
+			PrintWarning("careful")
+			PrintError("failed")
+		})
+
+		if got := strings.Contains(stdout, "saved") && strings.Contains(stdout, "processing"); got != tt.wantInfo {
+			t.Errorf("verbosity %d: success and info shown = %v, want %v (stdout %q)", tt.verbosity, got, tt.wantInfo, stdout)
+		}
+		if got := strings.Contains(stderr, "careful"); got != tt.wantWarning {
+			t.Errorf("verbosity %d: warning shown = %v, want %v", tt.verbosity, got, tt.wantWarning)
+		}
+		if !strings.Contains(stderr, "failed") {
+			t.Errorf("verbosity %d: errors must always be shown", tt.verbosity)
+		}
+		if strings.Contains(stdout, "careful") || strings.Contains(stdout, "failed") {
+			t.Errorf("verbosity %d: warnings and errors must not go to stdout: %q", tt.verbosity, stdout)
+		}
+	}
+}
+
+func TestResolveVerbosity(t *testing.T) {
+	tests := []struct {
+		quiet, quietErrors, verbose bool
+		want                        Verbosity
+	}{
+		{false, false, false, VerbosityNormal},
+		{false, false, true, VerbosityVerbose},
+		{false, true, true, VerbosityErrors},
+		{true, true, true, VerbosityQuiet},
+	}
+	for _, tt := range tests {
+		if got := ResolveVerbosity(tt.quiet, tt.quietErrors, tt.verbose); got != tt.want {
+			t.Errorf("ResolveVerbosity(%v, %v, %v) = %d, want %d", tt.quiet, tt.quietErrors, tt.verbose, got, tt.want)
+		}
+	}
+}